@@ -2,15 +2,25 @@ package activities
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/PeerDB-io/peer-flow/connectors"
+	connpostgres "github.com/PeerDB-io/peer-flow/connectors/postgres"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/model"
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// cdcRecordStreamBufferSize and qRepRecordStreamBufferSize are how many records can be pulled
+	// ahead of the destination, they bound the records held in memory while syncing.
+	cdcRecordStreamBufferSize  = 1 << 12
+	qRepRecordStreamBufferSize = 1 << 12
+)
+
 // CheckConnectionResult is the result of a CheckConnection call.
 type CheckConnectionResult struct {
 	// True of metadata tables need to be set up.
@@ -154,11 +164,60 @@ func (a *FlowableActivity) SetupReplication(
 	return nil
 }
 
+// AlterPublication changes the tables replicated by a running flow, only Postgres sources have a publication.
+func (a *FlowableActivity) AlterPublication(
+	ctx context.Context,
+	config *protos.AlterPublicationInput,
+) error {
+	conn, err := connectors.GetConnector(ctx, config.PeerConnectionConfig)
+	defer connectors.CloseConnector(conn)
+	if err != nil {
+		return fmt.Errorf("failed to get connector: %w", err)
+	}
+
+	pgConn, ok := conn.(*connpostgres.PostgresConnector)
+	if !ok {
+		return fmt.Errorf("altering the replicated tables is only supported for postgres sources")
+	}
+
+	err = pgConn.AlterPublication(config)
+	if err != nil {
+		return fmt.Errorf("failed to alter publication: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateAlterPublication checks that the tables replicated by a running flow can be changed, before
+// any of the change is applied.
+func (a *FlowableActivity) ValidateAlterPublication(
+	ctx context.Context,
+	config *protos.AlterPublicationInput,
+) error {
+	conn, err := connectors.GetConnector(ctx, config.PeerConnectionConfig)
+	defer connectors.CloseConnector(conn)
+	if err != nil {
+		return fmt.Errorf("failed to get connector: %w", err)
+	}
+
+	pgConn, ok := conn.(*connpostgres.PostgresConnector)
+	if !ok {
+		return fmt.Errorf("altering the replicated tables is only supported for postgres sources")
+	}
+
+	return pgConn.ValidateAlterPublication(config)
+}
+
 // CreateRawTable creates a raw table in the destination flowable.
 func (a *FlowableActivity) CreateRawTable(
 	ctx context.Context,
 	config *protos.CreateRawTableInput,
 ) (*protos.CreateRawTableOutput, error) {
+	if config.CdcSyncMode == protos.CDCSyncMode_CDC_SYNC_MODE_DIRECT_APPLY &&
+		config.PeerConnectionConfig.Type != protos.DBType_POSTGRES {
+		return nil, fmt.Errorf("direct apply is not supported for %s destinations", config.PeerConnectionConfig.Type)
+	}
+
 	conn, err := connectors.GetConnector(ctx, config.PeerConnectionConfig)
 	defer connectors.CloseConnector(conn)
 
@@ -196,9 +255,41 @@ func (a *FlowableActivity) CreateNormalizedTable(
 		return nil, fmt.Errorf("failed to get connector: %w", err)
 	}
 
+	if len(config.Transforms.GetTransforms()) > 0 {
+		transformer, err := model.NewRecordTransformer(config.Transforms)
+		if err != nil {
+			return nil, err
+		}
+		config.SourceTableSchema, err = transformer.TransformTableSchema(config.SourceTableSchema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform schema of table %s: %w", config.TableIdentifier, err)
+		}
+	}
+
 	return conn.SetupNormalizedTable(config)
 }
 
+// TruncateDestinationTables removes the rows of the destination tables of a mirror, so that an initial
+// copy that is started over doesn't add the rows copied by an earlier attempt again.
+func (a *FlowableActivity) TruncateDestinationTables(
+	ctx context.Context,
+	config *protos.FlowConnectionConfigs,
+) error {
+	conn, err := connectors.GetConnector(ctx, config.Destination)
+	defer connectors.CloseConnector(conn)
+
+	if err != nil {
+		return fmt.Errorf("failed to get connector: %w", err)
+	}
+
+	tableIdentifiers := make([]string, 0, len(config.TableNameMapping))
+	for _, dstTableName := range config.TableNameMapping {
+		tableIdentifiers = append(tableIdentifiers, dstTableName)
+	}
+	sort.Strings(tableIdentifiers)
+	return conn.TruncateTables(tableIdentifiers)
+}
+
 // StartFlow implements IFlowable.StartFlow.
 func (a *FlowableActivity) StartFlow(ctx context.Context, input *protos.StartFlowInput) (*model.SyncResponse, error) {
 	conn := input.FlowConnectionConfigs
@@ -215,51 +306,131 @@ func (a *FlowableActivity) StartFlow(ctx context.Context, input *protos.StartFlo
 		return nil, fmt.Errorf("failed to get destination connector: %w", err)
 	}
 
-	log.Info("initializing table schema...")
-	err = dest.InitializeTableSchema(input.FlowConnectionConfigs.TableNameSchemaMapping)
+	transformers, err := model.NewRecordTransformers(input.FlowConnectionConfigs.Transforms)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize table schema: %w", err)
+		return nil, err
 	}
 
-	log.Info("pulling records...")
-
-	records, err := src.PullRecords(&model.PullRecordsRequest{
-		FlowJobName:            input.FlowConnectionConfigs.FlowJobName,
-		SrcTableIDNameMapping:  input.FlowConnectionConfigs.SrcTableIdNameMapping,
-		TableNameMapping:       input.FlowConnectionConfigs.TableNameMapping,
-		LastSyncState:          input.LastSyncState,
-		MaxBatchSize:           uint32(input.SyncFlowOptions.BatchSize),
-		IdleTimeout:            10 * time.Second,
-		TableNameSchemaMapping: input.FlowConnectionConfigs.TableNameSchemaMapping,
-	})
+	log.Info("initializing table schema...")
+	dstTableNameSchemaMapping, err := model.TransformTableSchemaMapping(input.FlowConnectionConfigs.TableNameSchemaMapping,
+		input.FlowConnectionConfigs.TableNameMapping, transformers)
 	if err != nil {
-		return nil, fmt.Errorf("failed to pull records: %w", err)
+		return nil, err
+	}
+	err = dest.InitializeTableSchema(dstTableNameSchemaMapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize table schema: %w", err)
 	}
 
-	// log the number of records
-	numRecords := len(records.Records)
-	log.Printf("pulled %d records", numRecords)
+	log.Info("pulling records...")
 
-	if numRecords == 0 {
+	idleTimeout := 10 * time.Second
+	if input.SyncFlowOptions.IdleTimeoutSeconds > 0 {
+		idleTimeout = time.Duration(input.SyncFlowOptions.IdleTimeoutSeconds) * time.Second
+	}
+
+	// records are synced while they're pulled, the stream bounds how many are held in memory.
+	// records are transformed as they're sent, so that every destination gets the same transformed rows.
+	stream := model.NewCDCRecordStream(cdcRecordStreamBufferSize, transformers)
+	pullErrChan := make(chan error, 1)
+	go func() {
+		err := src.PullRecords(&model.PullRecordsRequest{
+			FlowJobName:            input.FlowConnectionConfigs.FlowJobName,
+			SrcTableIDNameMapping:  input.FlowConnectionConfigs.SrcTableIdNameMapping,
+			TableNameMapping:       input.FlowConnectionConfigs.TableNameMapping,
+			LastSyncState:          input.LastSyncState,
+			MaxBatchSize:           uint32(input.SyncFlowOptions.BatchSize),
+			IdleTimeout:            idleTimeout,
+			TableNameSchemaMapping: input.FlowConnectionConfigs.TableNameSchemaMapping,
+			RelationMessageMapping: input.SyncFlowOptions.RelationMessageMapping,
+			ColumnFilters:          input.FlowConnectionConfigs.ColumnFilters,
+			RowFilters:             input.FlowConnectionConfigs.RowFilters,
+			TransactionalBatches: input.FlowConnectionConfigs.CdcSyncMode ==
+				protos.CDCSyncMode_CDC_SYNC_MODE_DIRECT_APPLY,
+			RecordStream: stream,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to pull records: %w", err)
+		}
+		stream.Close(err)
+		pullErrChan <- err
+	}()
+
+	if stream.WaitAndCheckEmpty() {
+		if err := <-pullErrChan; err != nil {
+			return nil, err
+		}
 		log.Info("no records to push")
+
+		err = a.replayTableSchemaDeltas(input.FlowConnectionConfigs.FlowJobName, dest,
+			model.TransformTableSchemaDeltas(stream.TableSchemaDeltas(), transformers))
+		if err != nil {
+			return nil, err
+		}
+		if len(stream.TableSchemaDeltas()) > 0 {
+			return &model.SyncResponse{
+				TableSchemaDeltas:      stream.TableSchemaDeltas(),
+				RelationMessageMapping: stream.RelationMessageMapping(),
+			}, nil
+		}
 		return nil, nil
 	}
 
 	res, err := dest.SyncRecords(&model.SyncRecordsRequest{
-		Records:     records,
-		FlowJobName: input.FlowConnectionConfigs.FlowJobName,
+		Records:            stream,
+		FlowJobName:        input.FlowConnectionConfigs.FlowJobName,
+		SyncMode:           input.FlowConnectionConfigs.CdcSyncMode,
+		ObjectFormat:       input.FlowConnectionConfigs.CdcObjectFormat,
+		ParquetCompression: input.FlowConnectionConfigs.ParquetCompression,
 	})
-
-	log.Info("pushed records")
-
+	// destinations stop reading the stream on errors, the pull is stopped then.
+	// errors of the pull reach the destination through the stream otherwise.
+	stream.Abandon()
+	if pullErr := <-pullErrChan; pullErr != nil && !errors.Is(pullErr, model.ErrRecordStreamAbandoned) {
+		log.Warnf("pull of records ended with: %v", pullErr)
+	}
 	if err != nil {
 		log.Warnf("failed to push records: %v", err)
 		return nil, fmt.Errorf("failed to push records: %w", err)
 	}
+	log.Printf("pushed %d records", res.NumRecordsSynced)
+
+	// schema changes are only known once the batch is pulled, they are applied before the next normalize.
+	// destinations encoding records with the schema of their table already applied them while syncing,
+	// replaying them again leaves their schemas as they are.
+	err = a.replayTableSchemaDeltas(input.FlowConnectionConfigs.FlowJobName, dest,
+		model.TransformTableSchemaDeltas(stream.TableSchemaDeltas(), transformers))
+	if err != nil {
+		return nil, err
+	}
+	res.TableSchemaDeltas = stream.TableSchemaDeltas()
+	res.RelationMessageMapping = stream.RelationMessageMapping()
 
 	return res, nil
 }
 
+// replayTableSchemaDeltas applies the schema changes of the source tables to the destination tables.
+func (a *FlowableActivity) replayTableSchemaDeltas(
+	flowJobName string,
+	dest connectors.Connector,
+	schemaDeltas []*protos.TableSchemaDelta,
+) error {
+	for _, schemaDelta := range schemaDeltas {
+		// destinations only add columns, dropped columns are left in place and fill up with NULLs.
+		if len(schemaDelta.DroppedColumns) > 0 || len(schemaDelta.RetypedColumns) > 0 {
+			log.Warnf("[%s] columns dropped %v or retyped %v on source table %s are not changed on %s",
+				flowJobName, schemaDelta.DroppedColumns, schemaDelta.RetypedColumns,
+				schemaDelta.SrcTableName, schemaDelta.DstTableName)
+		}
+
+		err := dest.ReplayTableSchemaDelta(flowJobName, schemaDelta)
+		if err != nil {
+			return fmt.Errorf("failed to replay schema change for table %s: %w", schemaDelta.DstTableName, err)
+		}
+	}
+	return nil
+}
+
 func (a *FlowableActivity) StartNormalize(ctx context.Context, input *protos.StartNormalizeInput) (*model.NormalizeResponse, error) {
 	conn := input.FlowConnectionConfigs
 
@@ -275,8 +446,18 @@ func (a *FlowableActivity) StartNormalize(ctx context.Context, input *protos.Sta
 		return nil, fmt.Errorf("failed to get destination connector: %w", err)
 	}
 
+	transformers, err := model.NewRecordTransformers(input.FlowConnectionConfigs.Transforms)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Info("initializing table schema...")
-	err = dest.InitializeTableSchema(input.FlowConnectionConfigs.TableNameSchemaMapping)
+	dstTableNameSchemaMapping, err := model.TransformTableSchemaMapping(input.FlowConnectionConfigs.TableNameSchemaMapping,
+		input.FlowConnectionConfigs.TableNameMapping, transformers)
+	if err != nil {
+		return nil, err
+	}
+	err = dest.InitializeTableSchema(dstTableNameSchemaMapping)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize table schema: %w", err)
 	}
@@ -347,14 +528,41 @@ func (a *FlowableActivity) ReplicateQRepPartition(ctx context.Context,
 
 	log.Printf("replicating partition %s\n", partition.PartitionId)
 
-	recordBatch, err := srcConn.PullQRepRecords(config, partition)
-	if err != nil {
-		return fmt.Errorf("failed to pull records: %w", err)
+	var transformer *model.RecordTransformer
+	if len(config.Transforms.GetTransforms()) > 0 {
+		transformer, err = model.NewRecordTransformer(config.Transforms)
+		if err != nil {
+			return err
+		}
+	}
+
+	// records are synced while they're pulled, the stream bounds how many are held in memory.
+	stream := model.NewQRecordStream(qRepRecordStreamBufferSize)
+	pullErrChan := make(chan error, 1)
+	go func() {
+		numRecords, err := srcConn.PullQRepRecords(config, partition, stream)
+		if err != nil {
+			err = fmt.Errorf("failed to pull records: %w", err)
+		} else {
+			log.Printf("pulled %d records\n", numRecords)
+		}
+		stream.Close(err)
+		pullErrChan <- err
+	}()
+
+	syncStream := stream
+	if transformer != nil {
+		syncStream = transformer.TransformQRecordStream(stream, qRepRecordStreamBufferSize)
+	}
+
+	res, err := destConn.SyncQRepRecords(config, partition, syncStream)
+	// destinations can return before reading every record, like for partitions synced earlier.
+	// errors of the pull reach the destination through the stream otherwise.
+	syncStream.Abandon()
+	stream.Abandon()
+	if pullErr := <-pullErrChan; pullErr != nil && !errors.Is(pullErr, model.ErrRecordStreamAbandoned) {
+		log.Warnf("pull of partition %s ended with: %v", partition.PartitionId, pullErr)
 	}
-
-	log.Printf("pulled %d records\n", len(recordBatch.Records))
-
-	res, err := destConn.SyncQRepRecords(config, partition, recordBatch)
 	if err != nil {
 		return fmt.Errorf("failed to sync records: %w", err)
 	}
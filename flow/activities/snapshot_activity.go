@@ -0,0 +1,96 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	connpostgres "github.com/PeerDB-io/peer-flow/connectors/postgres"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	log "github.com/sirupsen/logrus"
+)
+
+// SlotSnapshotSignal holds the connection keeping an exported slot snapshot alive.
+type SlotSnapshotSignal struct {
+	signal    *connpostgres.SlotSignal
+	connector *connpostgres.PostgresConnector
+}
+
+// SnapshotActivity creates replication slots with exported snapshots for the initial copy.
+// The snapshot is only valid while the connection that created the slot is open, so these
+// activities have to run in a session on the worker that holds the connection.
+type SnapshotActivity struct {
+	SnapshotConnections map[string]*SlotSnapshotSignal
+	lock                sync.Mutex
+}
+
+// ExportSlotSnapshot sets up replication on the source and returns the slot and the snapshot
+// exported with it. The connection is kept open until CloseSlotKeepAlive is called.
+func (a *SnapshotActivity) ExportSlotSnapshot(
+	ctx context.Context,
+	input *protos.SetupReplicationInput,
+) (*protos.SetupReplicationOutput, error) {
+	pgConfig := input.PeerConnectionConfig.GetPostgresConfig()
+	if pgConfig == nil {
+		return nil, fmt.Errorf("initial snapshot is only supported for postgres sources")
+	}
+
+	// the connector outlives this activity, it is closed by CloseSlotKeepAlive.
+	conn, err := connpostgres.NewPostgresConnector(context.Background(), pgConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connector: %w", err)
+	}
+
+	signal := connpostgres.NewSlotSignal()
+	replicationErr := make(chan error, 1)
+	go func() {
+		replicationErr <- conn.SetupReplicationWithSnapshot(signal, input)
+	}()
+
+	var slot *connpostgres.SlotCreationResult
+	select {
+	case slot = <-signal.SlotCreated:
+	case err = <-replicationErr:
+		conn.Close()
+		if err == nil {
+			err = fmt.Errorf("replication was set up without reporting a slot")
+		}
+		return nil, fmt.Errorf("failed to setup replication: %w", err)
+	case <-ctx.Done():
+		conn.Close()
+		return nil, ctx.Err()
+	}
+	log.Infof("slot %s created for flow job %s with snapshot %s", slot.SlotName, input.FlowJobName, slot.SnapshotName)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.SnapshotConnections[input.FlowJobName] = &SlotSnapshotSignal{
+		signal:    signal,
+		connector: conn,
+	}
+
+	return &protos.SetupReplicationOutput{
+		SlotName:     slot.SlotName,
+		SnapshotName: slot.SnapshotName,
+	}, nil
+}
+
+// CloseSlotKeepAlive releases the snapshot exported for the flow job and closes its connection.
+func (a *SnapshotActivity) CloseSlotKeepAlive(ctx context.Context, flowJobName string) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	s, ok := a.SnapshotConnections[flowJobName]
+	if !ok {
+		log.Warnf("no snapshot connection found for flow job %s", flowJobName)
+		return nil
+	}
+
+	close(s.signal.CloneComplete)
+	if err := s.connector.Close(); err != nil {
+		log.Errorf("failed to close snapshot connection for flow job %s: %v", flowJobName, err)
+	}
+	delete(a.SnapshotConnections, flowJobName)
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/PeerDB-io/peer-flow/connectors"
+	connbigquery "github.com/PeerDB-io/peer-flow/connectors/bigquery"
+	connmysql "github.com/PeerDB-io/peer-flow/connectors/mysql"
+	connsnowflake "github.com/PeerDB-io/peer-flow/connectors/snowflake"
+	connsqlserver "github.com/PeerDB-io/peer-flow/connectors/sqlserver"
+	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// ValidateQRepPartition compares the rows of a partition between the source and the destination of
+// a mirror. Row counts are computed by the peers, the destination rows are counted over the range of
+// the watermark column as the transforms of the table name it. With compareChecksums, the partition
+// is also pulled from the source with the query of the config and read back from the destination table
+// over the same range, selecting the columns the source records have once transformed, to compare a
+// checksum of the rows. Checksums read every row of both sides through the worker, as the peers don't
+// hash values alike and the transforms are applied by the worker.
+func (a *FlowableActivity) ValidateQRepPartition(ctx context.Context,
+	config *protos.QRepConfig,
+	partition *protos.QRepPartition,
+	compareChecksums bool,
+) (*protos.PartitionValidation, error) {
+	srcConn, err := connectors.GetConnector(ctx, config.SourcePeer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source connector: %w", err)
+	}
+	defer connectors.CloseConnector(srcConn)
+
+	destConn, err := connectors.GetConnector(ctx, config.DestinationPeer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination connector: %w", err)
+	}
+	defer connectors.CloseConnector(destConn)
+
+	quote, err := destinationQuoteFunc(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var transformer *model.RecordTransformer
+	watermarkColumn := config.WatermarkColumn
+	if len(config.Transforms.GetTransforms()) > 0 {
+		transformer, err = model.NewRecordTransformer(config.Transforms)
+		if err != nil {
+			return nil, err
+		}
+		watermarkColumn, err = transformer.DestinationColumn(config.WatermarkColumn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find watermark column in destination table: %w", err)
+		}
+	}
+
+	if !compareChecksums {
+		srcConfig := proto.Clone(config).(*protos.QRepConfig)
+		srcConfig.Query = fmt.Sprintf("SELECT COUNT(*) FROM (%s) peerdb_validate_src",
+			strings.TrimSuffix(strings.TrimSpace(config.Query), ";"))
+		srcCount, err := countQRepPartition(srcConn, srcConfig, partition)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count partition rows on source: %w", err)
+		}
+
+		destConfig := proto.Clone(config).(*protos.QRepConfig)
+		destConfig.SnapshotName = ""
+		destConfig.Query = fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s BETWEEN {{.start}} AND {{.end}}",
+			config.DestinationTableIdentifier, quote(watermarkColumn))
+		destCount, err := countQRepPartition(destConn, destConfig, partition)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count partition rows on destination: %w", err)
+		}
+
+		log.Printf("validated partition %s: %d source rows, %d destination rows\n",
+			partition.PartitionId, srcCount, destCount)
+		return &protos.PartitionValidation{
+			Partition:           partition,
+			SourceRowCount:      srcCount,
+			DestinationRowCount: destCount,
+		}, nil
+	}
+
+	srcChecksum, schema, err := checksumQRepPartition(srcConn, config, partition, transformer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to checksum partition on source: %w", err)
+	}
+
+	destConfig := proto.Clone(config).(*protos.QRepConfig)
+	destConfig.SnapshotName = ""
+	destConfig.Query = destinationPartitionQuery(config, schema, watermarkColumn, quote)
+	destChecksum, _, err := checksumQRepPartition(destConn, destConfig, partition, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to checksum partition on destination: %w", err)
+	}
+
+	log.Printf("validated partition %s: %d source rows, %d destination rows\n",
+		partition.PartitionId, srcChecksum.NumRecords, destChecksum.NumRecords)
+	return &protos.PartitionValidation{
+		Partition:           partition,
+		SourceRowCount:      srcChecksum.NumRecords,
+		DestinationRowCount: destChecksum.NumRecords,
+		SourceChecksum:      srcChecksum.String(),
+		DestinationChecksum: destChecksum.String(),
+	}, nil
+}
+
+// countQRepPartition pulls the single row of a query counting the rows of a partition and returns the count.
+func countQRepPartition(
+	conn connectors.Connector,
+	config *protos.QRepConfig,
+	partition *protos.QRepPartition,
+) (int64, error) {
+	stream := model.NewQRecordStream(1)
+	go func() {
+		_, err := conn.PullQRepRecords(config, partition, stream)
+		stream.Close(err)
+	}()
+	defer stream.Abandon()
+
+	batch, err := stream.ToQRecordBatch()
+	if err != nil {
+		return 0, err
+	}
+	if len(batch.Records) != 1 || len(batch.Records[0].Entries) != 1 {
+		return 0, fmt.Errorf("expected a single count, got %d rows", len(batch.Records))
+	}
+	return countValue(batch.Records[0].Entries[0].Value)
+}
+
+// countValue converts a count to an int64, peers return counts as different types.
+func countValue(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int32:
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case *big.Rat:
+		if !v.IsInt() {
+			return 0, fmt.Errorf("count %s isn't an integer", v.String())
+		}
+		return v.Num().Int64(), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected count %v of type %T", value, value)
+	}
+}
+
+// checksumQRepPartition pulls the records of a partition and returns their checksum and schema.
+func checksumQRepPartition(
+	conn connectors.Connector,
+	config *protos.QRepConfig,
+	partition *protos.QRepPartition,
+	transformer *model.RecordTransformer,
+) (*model.QRecordChecksum, *model.QRecordSchema, error) {
+	stream := model.NewQRecordStream(qRepRecordStreamBufferSize)
+	go func() {
+		_, err := conn.PullQRepRecords(config, partition, stream)
+		stream.Close(err)
+	}()
+
+	checksumStream := stream
+	if transformer != nil {
+		checksumStream = transformer.TransformQRecordStream(stream, qRepRecordStreamBufferSize)
+	}
+	// the source stops pulling if the checksum fails.
+	defer stream.Abandon()
+	defer checksumStream.Abandon()
+
+	schema, err := checksumStream.Schema()
+	if err != nil {
+		return nil, nil, err
+	}
+	checksum, err := model.ChecksumQRecordStream(checksumStream)
+	if err != nil {
+		return nil, nil, err
+	}
+	return checksum, schema, nil
+}
+
+// destinationQuoteFunc returns the function quoting identifiers for the destination of a config.
+func destinationQuoteFunc(config *protos.QRepConfig) (func(string) string, error) {
+	switch config.DestinationPeer.Config.(type) {
+	case *protos.Peer_PostgresConfig:
+		return utils.QuoteIdentifier, nil
+	case *protos.Peer_SnowflakeConfig:
+		return connsnowflake.QuoteIdentifier, nil
+	case *protos.Peer_BigqueryConfig:
+		return connbigquery.QuoteIdentifier, nil
+	case *protos.Peer_MysqlConfig:
+		return connmysql.QuoteIdentifier, nil
+	case *protos.Peer_SqlserverConfig:
+		return connsqlserver.QuoteIdentifier, nil
+	default:
+		return nil, fmt.Errorf("validation isn't supported for destination peer %s",
+			config.DestinationPeer.Name)
+	}
+}
+
+// destinationPartitionQuery returns the query reading the columns of a schema from the destination
+// table of a config, over a range of the watermark column of the destination table.
+func destinationPartitionQuery(config *protos.QRepConfig, schema *model.QRecordSchema, watermarkColumn string,
+	quote func(string) string) string {
+	columnNames := make([]string, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		columnNames = append(columnNames, quote(field.Name))
+	}
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s BETWEEN {{.start}} AND {{.end}}",
+		strings.Join(columnNames, ","), config.DestinationTableIdentifier,
+		quote(watermarkColumn))
+}
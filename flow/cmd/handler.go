@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	parquet "github.com/PeerDB-io/peer-flow/connectors/utils/parquet"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/shared"
 	peerflow "github.com/PeerDB-io/peer-flow/workflows"
@@ -11,6 +12,9 @@ import (
 	"go.temporal.io/sdk/client"
 )
 
+// number of recent sync flow statuses and errors returned by GetFlowStatus.
+const maxRecentFlowStatuses = 10
+
 // grpc server implementation
 type FlowRequestHandler struct {
 	temporalClient client.Client
@@ -26,6 +30,12 @@ func NewFlowRequestHandler(temporalClient client.Client) *FlowRequestHandler {
 func (h *FlowRequestHandler) CreatePeerFlow(
 	ctx context.Context, req *protos.CreatePeerFlowRequest) (*protos.CreatePeerFlowResponse, error) {
 	cfg := req.ConnectionConfigs
+	if err := validateCDCObjectFormat(cfg); err != nil {
+		return nil, err
+	}
+	if err := validateInitialCopy(cfg); err != nil {
+		return nil, err
+	}
 	workflowID := fmt.Sprintf("%s-peerflow-%s", cfg.FlowJobName, uuid.New())
 	workflowOptions := client.StartWorkflowOptions{
 		ID:        workflowID,
@@ -62,6 +72,36 @@ func (h *FlowRequestHandler) CreatePeerFlow(
 	}, nil
 }
 
+// validateCDCObjectFormat checks the format of the objects of a mirror, it only applies to S3 destinations.
+func validateCDCObjectFormat(cfg *protos.FlowConnectionConfigs) error {
+	if cfg.CdcObjectFormat == "" && cfg.ParquetCompression == "" {
+		return nil
+	}
+	if cfg.Destination.GetType() != protos.DBType_S3 {
+		return fmt.Errorf("cdc_object_format and parquet_compression are only supported for S3 destinations")
+	}
+	switch cfg.CdcObjectFormat {
+	case "", SyncDataFormatAvro:
+		if cfg.ParquetCompression != "" {
+			return fmt.Errorf("parquet_compression needs cdc_object_format %s", SyncDataFormatParquet)
+		}
+	case SyncDataFormatParquet:
+		return parquet.ValidateCompression(cfg.ParquetCompression)
+	default:
+		return &UnsupportedOptionError{"cdc_object_format", cfg.CdcObjectFormat}
+	}
+	return nil
+}
+
+// validateInitialCopy checks that the initial copy of a mirror can be taken, the rows are copied
+// under a snapshot exported along with the replication slot, which only Postgres sources have.
+func validateInitialCopy(cfg *protos.FlowConnectionConfigs) error {
+	if cfg.DoInitialCopy && cfg.Source.GetType() != protos.DBType_POSTGRES {
+		return fmt.Errorf("do_initial_copy is only supported for Postgres sources")
+	}
+	return nil
+}
+
 func (h *FlowRequestHandler) CreateQRepFlow(
 	ctx context.Context, req *protos.CreateQRepFlowRequest) (*protos.CreateQRepFlowResponse, error) {
 	lastPartition := &protos.QRepPartition{
@@ -70,6 +110,9 @@ func (h *FlowRequestHandler) CreateQRepFlow(
 	}
 
 	cfg := req.QrepConfig
+	if err := validateParquetConfig(cfg); err != nil {
+		return nil, err
+	}
 	workflowID := fmt.Sprintf("%s-qrepflow-%s", cfg.FlowJobName, uuid.New())
 	workflowOptions := client.StartWorkflowOptions{
 		ID:        workflowID,
@@ -144,3 +187,150 @@ func (h *FlowRequestHandler) ShutdownFlow(
 		Ok: true,
 	}, nil
 }
+
+// PauseFlow pauses the peer flow before its next sync flow, the replication slot and raw tables are kept.
+func (h *FlowRequestHandler) PauseFlow(
+	ctx context.Context, req *protos.PauseRequest) (*protos.PauseResponse, error) {
+	err := h.temporalClient.SignalWorkflow(
+		ctx,
+		req.WorkflowId,
+		"",
+		shared.PeerFlowSignalName,
+		shared.PauseSignal,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to signal PeerFlow workflow: %w", err)
+	}
+
+	return &protos.PauseResponse{
+		Ok: true,
+	}, nil
+}
+
+// ResumeFlow resumes a paused peer flow.
+func (h *FlowRequestHandler) ResumeFlow(
+	ctx context.Context, req *protos.ResumeRequest) (*protos.ResumeResponse, error) {
+	err := h.temporalClient.SignalWorkflow(
+		ctx,
+		req.WorkflowId,
+		"",
+		shared.PeerFlowSignalName,
+		shared.ResumeSignal,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to signal PeerFlow workflow: %w", err)
+	}
+
+	return &protos.ResumeResponse{
+		Ok: true,
+	}, nil
+}
+
+// GetFlowStatus returns the state of a peer flow, as reported by its status query.
+func (h *FlowRequestHandler) GetFlowStatus(
+	ctx context.Context, req *protos.FlowStatusRequest) (*protos.FlowStatusResponse, error) {
+	res, err := h.temporalClient.QueryWorkflow(ctx, req.WorkflowId, "", peerflow.PeerFlowStatusQuery, req.FlowJobName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query PeerFlow workflow: %w", err)
+	}
+
+	var state peerflow.PeerFlowState
+	if err := res.Get(&state); err != nil {
+		return nil, fmt.Errorf("unable to decode PeerFlow state: %w", err)
+	}
+
+	syncFlowStatuses := make([]*protos.SyncFlowStatus, 0, maxRecentFlowStatuses)
+	var lastSyncedCheckpointID int64
+	// statuses are nil for sync flows that had nothing to sync.
+	for i := len(state.SyncFlowStatuses) - 1; i >= 0 && len(syncFlowStatuses) < maxRecentFlowStatuses; i-- {
+		syncRes := state.SyncFlowStatuses[i]
+		if syncRes == nil || syncRes.NumRecordsSynced == 0 {
+			continue
+		}
+		if lastSyncedCheckpointID == 0 {
+			lastSyncedCheckpointID = syncRes.LastSyncedCheckPointID
+		}
+		syncFlowStatuses = append(syncFlowStatuses, &protos.SyncFlowStatus{
+			FirstSyncedCheckpointId: syncRes.FirstSyncedCheckPointID,
+			LastSyncedCheckpointId:  syncRes.LastSyncedCheckPointID,
+			NumRecordsSynced:        syncRes.NumRecordsSynced,
+			SyncBatchId:             syncRes.CurrentSyncBatchID,
+		})
+	}
+	// oldest first.
+	for i, j := 0, len(syncFlowStatuses)-1; i < j; i, j = i+1, j-1 {
+		syncFlowStatuses[i], syncFlowStatuses[j] = syncFlowStatuses[j], syncFlowStatuses[i]
+	}
+
+	return &protos.FlowStatusResponse{
+		SetupComplete:          state.SetupComplete,
+		ActiveSignal:           state.ActiveSignal.String(),
+		LastSyncedCheckpointId: lastSyncedCheckpointID,
+		LastSyncedBatchId:      state.LastSyncedBatchID,
+		LastNormalizedBatchId:  state.LastNormalizedBatchID,
+		RecentSyncFlowStatuses: syncFlowStatuses,
+		SyncFlowErrors:         lastN(state.SyncFlowErrorMessages, maxRecentFlowStatuses),
+		NormalizeFlowErrors:    lastN(state.NormalizeFlowErrorMessages, maxRecentFlowStatuses),
+		NumSyncFlowErrors:      uint64(state.NumSyncFlowErrors),
+		NumNormalizeFlowErrors: uint64(state.NumNormalizeFlowErrors),
+	}, nil
+}
+
+// GetQRepFlowStatus returns the partitions done and remaining of a QRep flow.
+func (h *FlowRequestHandler) GetQRepFlowStatus(
+	ctx context.Context, req *protos.QRepFlowStatusRequest) (*protos.QRepFlowStatusResponse, error) {
+	res, err := h.temporalClient.QueryWorkflow(ctx, req.WorkflowId, "", peerflow.QRepFlowStatusQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query QRepFlow workflow: %w", err)
+	}
+
+	var status peerflow.QRepFlowStatus
+	if err := res.Get(&status); err != nil {
+		return nil, fmt.Errorf("unable to decode QRepFlow status: %w", err)
+	}
+
+	return &protos.QRepFlowStatusResponse{
+		NumPartitionsProcessed: uint64(status.NumPartitionsProcessed),
+		NumPartitionsRemaining: uint64(status.NumPartitionsRemaining),
+		LastPartitionId:        status.LastPartitionID,
+	}, nil
+}
+
+// ValidateFlow compares the tables of a CDC or QRep mirror between its source and destination,
+// waiting for the ValidateFlow workflow to report the partitions that differ.
+func (h *FlowRequestHandler) ValidateFlow(
+	ctx context.Context, req *protos.ValidateFlowRequest) (*protos.ValidateFlowResponse, error) {
+	if (req.ConnectionConfigs == nil) == (req.QrepConfig == nil) {
+		return nil, fmt.Errorf("exactly one of connection_configs and qrep_config must be set")
+	}
+
+	workflowID := fmt.Sprintf("%s-validateflow-%s", req.FlowJobName, uuid.New())
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        workflowID,
+		TaskQueue: shared.PeerFlowTaskQueue,
+	}
+	validateFlowHandle, err := h.temporalClient.ExecuteWorkflow(
+		ctx,                           // context
+		workflowOptions,               // workflow start options
+		peerflow.ValidateFlowWorkflow, // workflow function
+		req,                           // workflow input
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start ValidateFlow workflow: %w", err)
+	}
+
+	res := &protos.ValidateFlowResponse{}
+	if err = validateFlowHandle.Get(ctx, &res); err != nil {
+		return nil, fmt.Errorf("ValidateFlow workflow did not execute successfully: %w", err)
+	}
+
+	res.WorkflowId = workflowID
+	return res, nil
+}
+
+func lastN(items []string, n int) []string {
+	if len(items) > n {
+		return items[len(items)-n:]
+	}
+	return items
+}
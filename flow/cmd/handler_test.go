@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/shared"
+	peerflow "github.com/PeerDB-io/peer-flow/workflows"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/mocks"
+)
+
+// newQueryResult returns the result of a query that decodes to value.
+func newQueryResult(value interface{}) *mocks.Value {
+	res := &mocks.Value{}
+	res.On("Get", mock.Anything).Run(func(args mock.Arguments) {
+		switch ptr := args.Get(0).(type) {
+		case *peerflow.PeerFlowState:
+			*ptr = value.(peerflow.PeerFlowState)
+		case *peerflow.QRepFlowStatus:
+			*ptr = value.(peerflow.QRepFlowStatus)
+		}
+	}).Return(nil)
+	return res
+}
+
+func TestGetFlowStatus(t *testing.T) {
+	state := peerflow.PeerFlowState{
+		SetupComplete:         true,
+		LastSyncedBatchID:     12,
+		LastNormalizedBatchID: 11,
+		NumSyncFlowErrors:     40,
+	}
+	for i := int64(1); i <= 12; i++ {
+		state.SyncFlowStatuses = append(state.SyncFlowStatuses, &model.SyncResponse{
+			FirstSyncedCheckPointID: i * 10,
+			LastSyncedCheckPointID:  i*10 + 9,
+			NumRecordsSynced:        i % 2,
+			CurrentSyncBatchID:      i,
+		}, nil)
+		state.SyncFlowErrorMessages = append(state.SyncFlowErrorMessages, "sync failed")
+	}
+
+	temporalClient := &mocks.Client{}
+	temporalClient.On("QueryWorkflow", mock.Anything, "test_flow-peerflow", "",
+		peerflow.PeerFlowStatusQuery, "test_flow").Return(newQueryResult(state), nil)
+	h := NewFlowRequestHandler(temporalClient)
+
+	res, err := h.GetFlowStatus(context.Background(), &protos.FlowStatusRequest{
+		WorkflowId:  "test_flow-peerflow",
+		FlowJobName: "test_flow",
+	})
+	require.NoError(t, err)
+	require.True(t, res.SetupComplete)
+	require.EqualValues(t, 12, res.LastSyncedBatchId)
+	require.EqualValues(t, 11, res.LastNormalizedBatchId)
+	// only syncs that synced records are reported, oldest first.
+	require.Len(t, res.RecentSyncFlowStatuses, 6)
+	require.EqualValues(t, 1, res.RecentSyncFlowStatuses[0].SyncBatchId)
+	require.EqualValues(t, 11, res.RecentSyncFlowStatuses[5].SyncBatchId)
+	require.EqualValues(t, 119, res.LastSyncedCheckpointId)
+	require.Len(t, res.SyncFlowErrors, maxRecentFlowStatuses)
+	// the count covers errors whose messages were dropped from the state.
+	require.EqualValues(t, 40, res.NumSyncFlowErrors)
+	temporalClient.AssertExpectations(t)
+}
+
+func TestGetQRepFlowStatus(t *testing.T) {
+	status := peerflow.QRepFlowStatus{
+		NumPartitionsProcessed: 7,
+		NumPartitionsRemaining: 3,
+		LastPartitionID:        "partition-7",
+	}
+
+	temporalClient := &mocks.Client{}
+	temporalClient.On("QueryWorkflow", mock.Anything, "test_qrep-qrepflow", "",
+		peerflow.QRepFlowStatusQuery).Return(newQueryResult(status), nil)
+	h := NewFlowRequestHandler(temporalClient)
+
+	res, err := h.GetQRepFlowStatus(context.Background(), &protos.QRepFlowStatusRequest{
+		WorkflowId: "test_qrep-qrepflow",
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 7, res.NumPartitionsProcessed)
+	require.EqualValues(t, 3, res.NumPartitionsRemaining)
+	require.Equal(t, "partition-7", res.LastPartitionId)
+	temporalClient.AssertExpectations(t)
+}
+
+func TestPauseAndResumeFlow(t *testing.T) {
+	temporalClient := &mocks.Client{}
+	temporalClient.On("SignalWorkflow", mock.Anything, "test_flow-peerflow", "",
+		shared.PeerFlowSignalName, shared.PauseSignal).Return(nil)
+	temporalClient.On("SignalWorkflow", mock.Anything, "test_flow-peerflow", "",
+		shared.PeerFlowSignalName, shared.ResumeSignal).Return(errors.New("workflow not found"))
+	h := NewFlowRequestHandler(temporalClient)
+
+	pauseRes, err := h.PauseFlow(context.Background(), &protos.PauseRequest{WorkflowId: "test_flow-peerflow"})
+	require.NoError(t, err)
+	require.True(t, pauseRes.Ok)
+
+	resumeRes, err := h.ResumeFlow(context.Background(), &protos.ResumeRequest{WorkflowId: "test_flow-peerflow"})
+	require.ErrorContains(t, err, "workflow not found")
+	require.Nil(t, resumeRes)
+	temporalClient.AssertExpectations(t)
+}
+
+func TestCreatePeerFlowValidatesObjectFormat(t *testing.T) {
+	temporalClient := &mocks.Client{}
+	h := NewFlowRequestHandler(temporalClient)
+	s3Peer := &protos.Peer{Type: protos.DBType_S3}
+	pgPeer := &protos.Peer{Type: protos.DBType_POSTGRES}
+
+	for _, tc := range []struct {
+		cfg *protos.FlowConnectionConfigs
+		err string
+	}{
+		{&protos.FlowConnectionConfigs{Destination: pgPeer, CdcObjectFormat: "parquet"},
+			"only supported for S3 destinations"},
+		{&protos.FlowConnectionConfigs{Destination: s3Peer, CdcObjectFormat: "orc"},
+			"unsupported cdc_object_format: orc"},
+		{&protos.FlowConnectionConfigs{Destination: s3Peer, CdcObjectFormat: "parquet", ParquetCompression: "lzma"},
+			"unsupported Parquet compression: lzma"},
+		{&protos.FlowConnectionConfigs{Destination: s3Peer, ParquetCompression: "zstd"},
+			"parquet_compression needs cdc_object_format parquet"},
+	} {
+		res, err := h.CreatePeerFlow(context.Background(), &protos.CreatePeerFlowRequest{ConnectionConfigs: tc.cfg})
+		require.ErrorContains(t, err, tc.err)
+		require.Nil(t, res)
+	}
+	require.NoError(t, validateCDCObjectFormat(&protos.FlowConnectionConfigs{
+		Destination: s3Peer, CdcObjectFormat: "parquet", ParquetCompression: "zstd"}))
+	// no workflow is started for invalid configs.
+	temporalClient.AssertExpectations(t)
+}
+
+func TestCreatePeerFlowValidatesInitialCopy(t *testing.T) {
+	temporalClient := &mocks.Client{}
+	h := NewFlowRequestHandler(temporalClient)
+
+	for _, sourceType := range []protos.DBType{protos.DBType_MYSQL, protos.DBType_SQLSERVER} {
+		res, err := h.CreatePeerFlow(context.Background(), &protos.CreatePeerFlowRequest{
+			ConnectionConfigs: &protos.FlowConnectionConfigs{
+				Source:        &protos.Peer{Type: sourceType},
+				DoInitialCopy: true,
+			},
+		})
+		require.ErrorContains(t, err, "do_initial_copy is only supported for Postgres sources")
+		require.Nil(t, res)
+	}
+	require.NoError(t, validateInitialCopy(&protos.FlowConnectionConfigs{
+		Source: &protos.Peer{Type: protos.DBType_POSTGRES}, DoInitialCopy: true}))
+	require.NoError(t, validateInitialCopy(&protos.FlowConnectionConfigs{
+		Source: &protos.Peer{Type: protos.DBType_MYSQL}}))
+	// no workflow is started for invalid configs.
+	temporalClient.AssertExpectations(t)
+}
+
+func TestCreateQRepFlowValidatesParquetConfig(t *testing.T) {
+	temporalClient := &mocks.Client{}
+	h := NewFlowRequestHandler(temporalClient)
+	s3Peer := &protos.Peer{Type: protos.DBType_S3}
+	sfPeer := &protos.Peer{Type: protos.DBType_SNOWFLAKE}
+	parquetMode := protos.QRepSyncMode_QREP_SYNC_MODE_STORAGE_PARQUET
+
+	for _, tc := range []struct {
+		cfg *protos.QRepConfig
+		err string
+	}{
+		{&protos.QRepConfig{DestinationPeer: sfPeer, SyncMode: parquetMode},
+			"sync_data_format parquet is only supported for S3 destinations"},
+		{&protos.QRepConfig{DestinationPeer: s3Peer, SyncMode: parquetMode, ParquetCompression: "lzo"},
+			"unsupported Parquet compression: lzo"},
+		{&protos.QRepConfig{DestinationPeer: s3Peer, ParquetRowGroupSizeMb: 128},
+			"parquet_compression and parquet_row_group_size_mb need sync_data_format parquet"},
+	} {
+		res, err := h.CreateQRepFlow(context.Background(), &protos.CreateQRepFlowRequest{QrepConfig: tc.cfg})
+		require.ErrorContains(t, err, tc.err)
+		require.Nil(t, res)
+	}
+	require.NoError(t, validateParquetConfig(&protos.QRepConfig{
+		DestinationPeer: s3Peer, SyncMode: parquetMode, ParquetCompression: "zstd", ParquetRowGroupSizeMb: 128}))
+	// no workflow is started for invalid configs.
+	temporalClient.AssertExpectations(t)
+}
+
+func TestValidateFlowReturnsNoResponseWithError(t *testing.T) {
+	run := &mocks.WorkflowRun{}
+	run.On("Get", mock.Anything, mock.Anything).Return(errors.New("activity failed"))
+	temporalClient := &mocks.Client{}
+	temporalClient.On("ExecuteWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(run, nil)
+	h := NewFlowRequestHandler(temporalClient)
+
+	res, err := h.ValidateFlow(context.Background(), &protos.ValidateFlowRequest{
+		FlowJobName: "job",
+		QrepConfig:  &protos.QRepConfig{FlowJobName: "job"},
+	})
+	require.ErrorContains(t, err, "activity failed")
+	require.Nil(t, res)
+}
@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 
+	parquet "github.com/PeerDB-io/peer-flow/connectors/utils/parquet"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 )
 
 const (
 	SyncDataFormatAvro    = "avro"
+	SyncDataFormatParquet = "parquet"
 	SyncDataFormatDefault = "default"
 	WriteModeAppend       = "append"
 	WriteModeUpsert       = "upsert"
@@ -62,6 +64,14 @@ func genConfigForQRepFlow(
 		} else {
 			config.StagingPath = ""
 		}
+	case SyncDataFormatParquet:
+		config.SyncMode = protos.QRepSyncMode_QREP_SYNC_MODE_STORAGE_PARQUET
+		if compression, ok := flowOptions["parquet_compression"].(string); ok {
+			config.ParquetCompression = compression
+		}
+		if rowGroupSizeMB, ok := flowOptions["parquet_row_group_size_mb"].(float64); ok {
+			config.ParquetRowGroupSizeMb = uint32(rowGroupSizeMB)
+		}
 	case SyncDataFormatDefault:
 		config.SyncMode = protos.QRepSyncMode_QREP_SYNC_MODE_MULTI_INSERT
 	default:
@@ -85,5 +95,20 @@ func genConfigForQRepFlow(
 	default:
 		return &UnsupportedOptionError{"mode", mode}
 	}
-	return nil
+	return validateParquetConfig(config)
+}
+
+// validateParquetConfig checks the Parquet options of a QRep flow, only S3 destinations write Parquet files.
+func validateParquetConfig(config *protos.QRepConfig) error {
+	if config.SyncMode != protos.QRepSyncMode_QREP_SYNC_MODE_STORAGE_PARQUET {
+		if config.ParquetCompression != "" || config.ParquetRowGroupSizeMb != 0 {
+			return fmt.Errorf("parquet_compression and parquet_row_group_size_mb need sync_data_format %s",
+				SyncDataFormatParquet)
+		}
+		return nil
+	}
+	if config.DestinationPeer.GetType() != protos.DBType_S3 {
+		return fmt.Errorf("sync_data_format %s is only supported for S3 destinations", SyncDataFormatParquet)
+	}
+	return parquet.ValidateCompression(config.ParquetCompression)
 }
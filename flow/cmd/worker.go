@@ -49,7 +49,9 @@ func WorkerMain(opts *WorkerOptions) error {
 	}
 	defer c.Close()
 
-	w := worker.New(c, shared.PeerFlowTaskQueue, worker.Options{})
+	w := worker.New(c, shared.PeerFlowTaskQueue, worker.Options{
+		EnableSessionWorker: true,
+	})
 	w.RegisterWorkflow(peerflow.PeerFlowWorkflow)
 	w.RegisterWorkflow(peerflow.PeerFlowWorkflowWithConfig)
 	w.RegisterWorkflow(peerflow.SyncFlowWorkflow)
@@ -58,8 +60,13 @@ func WorkerMain(opts *WorkerOptions) error {
 	w.RegisterWorkflow(peerflow.QRepFlowWorkflow)
 	w.RegisterWorkflow(peerflow.QRepPartitionWorkflow)
 	w.RegisterWorkflow(peerflow.DropFlowWorkflow)
+	w.RegisterWorkflow(peerflow.SnapshotFlowWorkflow)
+	w.RegisterWorkflow(peerflow.ValidateFlowWorkflow)
 	w.RegisterActivity(&activities.FetchConfigActivity{})
 	w.RegisterActivity(&activities.FlowableActivity{})
+	w.RegisterActivity(&activities.SnapshotActivity{
+		SnapshotConnections: make(map[string]*activities.SlotSnapshotSignal),
+	})
 
 	err = w.Run(worker.InterruptCh())
 	if err != nil {
@@ -191,6 +191,12 @@ func (c *BigQueryConnector) ConnectionActive() bool {
 	return c.client != nil
 }
 
+// QuoteIdentifier quotes an identifier with backticks, escaping the backticks and backslashes it contains.
+func QuoteIdentifier(identifier string) string {
+	identifier = strings.ReplaceAll(identifier, `\`, `\\`)
+	return "`" + strings.ReplaceAll(identifier, "`", "\\`") + "`"
+}
+
 // NeedsSetupMetadataTables returns true if the metadata tables need to be set up.
 func (c *BigQueryConnector) NeedsSetupMetadataTables() bool {
 	_, err := c.client.Dataset(c.datasetID).Table(MirrorJobsTable).Metadata(c.ctx)
@@ -380,8 +386,45 @@ func (c *BigQueryConnector) getTableNametoUnchangedCols(flowJobName string, sync
 	return resultMap, nil
 }
 
+// getTableNameToLastTruncate returns the position of the last truncate of each table
+// in the batch range, tables that weren't truncated are not part of the map.
+func (c *BigQueryConnector) getTableNameToLastTruncate(flowJobName string, syncBatchID int64,
+	normalizeBatchID int64) (map[string]model.RawRecordPosition, error) {
+	rawTableName := c.getRawTableName(flowJobName)
+
+	query := fmt.Sprintf(`SELECT _peerdb_destination_table_name, _peerdb_batch_id, _peerdb_timestamp_nanos
+	 FROM %s.%s WHERE _peerdb_batch_id > %d and _peerdb_batch_id <= %d and _peerdb_record_type = 3
+	 QUALIFY ROW_NUMBER() OVER (PARTITION BY _peerdb_destination_table_name
+	 ORDER BY _peerdb_batch_id DESC, _peerdb_timestamp_nanos DESC) = 1`,
+		c.datasetID, rawTableName, normalizeBatchID, syncBatchID)
+	q := c.client.Query(query)
+	it, err := q.Read(c.ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to run query %s on BigQuery:\n %w", query, err)
+		return nil, err
+	}
+	resultMap := make(map[string]model.RawRecordPosition)
+
+	var row struct {
+		Tablename      string `bigquery:"_peerdb_destination_table_name"`
+		BatchID        int64  `bigquery:"_peerdb_batch_id"`
+		TimestampNanos int64  `bigquery:"_peerdb_timestamp_nanos"`
+	}
+	for {
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read last truncate of tables: %w", err)
+		}
+		resultMap[row.Tablename] = model.RawRecordPosition{BatchID: row.BatchID, Timestamp: row.TimestampNanos}
+	}
+	return resultMap, nil
+}
+
 // PullRecords pulls records from the source.
-func (c *BigQueryConnector) PullRecords(req *model.PullRecordsRequest) (*model.RecordBatch, error) {
+func (c *BigQueryConnector) PullRecords(req *model.PullRecordsRequest) error {
 	panic("not implemented")
 }
 
@@ -407,7 +450,7 @@ func (r StagingBQRecord) Save() (map[string]bigquery.Value, string, error) {
 func (c *BigQueryConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.SyncResponse, error) {
 	rawTableName := c.getRawTableName(req.FlowJobName)
 
-	log.Printf("pushing %d records to %s.%s", len(req.Records.Records), c.datasetID, rawTableName)
+	log.Printf("pushing records to %s.%s", c.datasetID, rawTableName)
 
 	stagingTableName := c.getStagingTableName(req.FlowJobName)
 	stagingTable := c.client.Dataset(c.datasetID).Table(stagingTableName)
@@ -429,14 +472,31 @@ func (c *BigQueryConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 	}
 	syncBatchID = syncBatchID + 1
 
-	records := make([]StagingBQRecord, 0)
+	// insert the records into the staging table in batches of size syncRecordsBatchSize
+	// as they're read from the stream.
+	stagingInserter := stagingTable.Inserter()
+	stagingInserter.IgnoreUnknownValues = true
+	records := make([]StagingBQRecord, 0, SyncRecordsBatchSize)
+	flushRecords := func() error {
+		if len(records) == 0 {
+			return nil
+		}
+		err := stagingInserter.Put(c.ctx, records)
+		if err != nil {
+			return fmt.Errorf("failed to insert chunked rows into staging table: %v", err)
+		}
+		records = records[:0]
+		return nil
+	}
 
-	first := true
+	numRecords := 0
 	var firstCP int64 = 0
-	lastCP := req.Records.LastCheckPointID
+	timestamps := model.NewRawRecordTimestamps()
 
 	// loop over req.Records
-	for _, record := range req.Records.Records {
+	for record := range req.Records.Records() {
+		// both timestamps of a record are taken from the same instant.
+		timestampNanos := timestamps.Next()
 		switch r := record.(type) {
 		case *model.InsertRecord:
 			// create the 3 required fields
@@ -452,8 +512,8 @@ func (c *BigQueryConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 			// append the row to the records
 			records = append(records, StagingBQRecord{
 				uid:                   uuid.New().String(),
-				timestamp:             time.Now(),
-				timestampNanos:        time.Now().UnixNano(),
+				timestamp:             time.Unix(0, timestampNanos),
+				timestampNanos:        timestampNanos,
 				destinationTableName:  r.DestinationTableName,
 				data:                  itemsJSON,
 				recordType:            0,
@@ -483,8 +543,8 @@ func (c *BigQueryConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 			// append the row to the records
 			records = append(records, StagingBQRecord{
 				uid:                   uuid.New().String(),
-				timestamp:             time.Now(),
-				timestampNanos:        time.Now().UnixNano(),
+				timestamp:             time.Unix(0, timestampNanos),
+				timestampNanos:        timestampNanos,
 				destinationTableName:  r.DestinationTableName,
 				data:                  newItemsJSON,
 				recordType:            1,
@@ -509,8 +569,8 @@ func (c *BigQueryConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 			// append the row to the records
 			records = append(records, StagingBQRecord{
 				uid:                   uuid.New().String(),
-				timestamp:             time.Now(),
-				timestampNanos:        time.Now().UnixNano(),
+				timestamp:             time.Unix(0, timestampNanos),
+				timestampNanos:        timestampNanos,
 				destinationTableName:  r.DestinationTableName,
 				data:                  itemsJSON,
 				recordType:            2,
@@ -519,17 +579,40 @@ func (c *BigQueryConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 				stagingBatchID:        stagingBatchID,
 				unchangedToastColumns: utils.KeysToString(r.UnchangedToastColumns),
 			})
+		case *model.TruncateRecord:
+			// a truncate only needs its position in the stream,
+			// normalize empties the table and skips older records.
+			records = append(records, StagingBQRecord{
+				uid:                   uuid.New().String(),
+				timestamp:             time.Unix(0, timestampNanos),
+				timestampNanos:        timestampNanos,
+				destinationTableName:  r.DestinationTableName,
+				data:                  "{}",
+				recordType:            3,
+				matchData:             "",
+				batchID:               syncBatchID,
+				stagingBatchID:        stagingBatchID,
+				unchangedToastColumns: "",
+			})
 		default:
 			return nil, fmt.Errorf("record type %T not supported", r)
 		}
 
-		if first {
+		if numRecords == 0 {
 			firstCP = record.GetCheckPointID()
-			first = false
+		}
+		numRecords++
+
+		if len(records) == SyncRecordsBatchSize {
+			if err := flushRecords(); err != nil {
+				return nil, err
+			}
 		}
 	}
+	if err := req.Records.Err(); err != nil {
+		return nil, err
+	}
 
-	numRecords := len(records)
 	if numRecords == 0 {
 		return &model.SyncResponse{
 			FirstSyncedCheckPointID: 0,
@@ -537,25 +620,10 @@ func (c *BigQueryConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 			NumRecordsSynced:        0,
 		}, nil
 	}
-
-	// insert the records into the staging table
-	stagingInserter := stagingTable.Inserter()
-	stagingInserter.IgnoreUnknownValues = true
-
-	// insert the records into the staging table in batches of size syncRecordsBatchSize
-	for i := 0; i < numRecords; i += SyncRecordsBatchSize {
-		end := i + SyncRecordsBatchSize
-
-		if end > numRecords {
-			end = numRecords
-		}
-
-		chunk := records[i:end]
-		err = stagingInserter.Put(c.ctx, chunk)
-		if err != nil {
-			return nil, fmt.Errorf("failed to insert chunked rows into staging table: %v", err)
-		}
+	if err := flushRecords(); err != nil {
+		return nil, err
 	}
+	lastCP := req.Records.LastCheckPointID()
 
 	// we have to do the following things in a transaction
 	// 1. append the records in the staging table to the raw table.
@@ -590,6 +658,7 @@ func (c *BigQueryConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 		FirstSyncedCheckPointID: firstCP,
 		LastSyncedCheckPointID:  lastCP,
 		NumRecordsSynced:        int64(numRecords),
+		CurrentSyncBatchID:      syncBatchID,
 	}, nil
 }
 
@@ -632,6 +701,11 @@ func (c *BigQueryConnector) NormalizeRecords(req *model.NormalizeRecordsRequest)
 		return nil, fmt.Errorf("couldn't get tablename to unchanged cols mapping: %w", err)
 	}
 
+	tableNameToLastTruncate, err := c.getTableNameToLastTruncate(req.FlowJobName, syncBatchID, normalizeBatchID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get tablename to last truncate mapping: %w", err)
+	}
+
 	stmts := []string{}
 	// append all the statements to one list
 	log.Printf("merge raw records to corresponding tables: %s %s %v", c.datasetID, rawTableName, distinctTableNames)
@@ -647,6 +721,8 @@ func (c *BigQueryConnector) NormalizeRecords(req *model.NormalizeRecordsRequest)
 			SyncBatchID:           syncBatchID,
 			NormalizeBatchID:      normalizeBatchID,
 			UnchangedToastColumns: tableNametoUnchangedToastCols[tableName],
+			// records of the table up to its last truncate are skipped.
+			LastTruncate: tableNameToLastTruncate[tableName],
 		}
 		// normalize anything between last normalized batch id to last sync batchid
 		mergeStmts := mergeGen.GenerateMergeStmts()
@@ -680,7 +756,7 @@ func (c *BigQueryConnector) NormalizeRecords(req *model.NormalizeRecordsRequest)
 // _peerdb_uid STRING
 // _peerdb_timestamp TIMESTAMP
 // _peerdb_data STRING
-// _peerdb_record_type INT - 0 for insert, 1 for update, 2 for delete
+// _peerdb_record_type INT - 0 for insert, 1 for update, 2 for delete, 3 for truncate
 // _peerdb_match_data STRING - json of the match data (only for update and delete)
 func (c *BigQueryConnector) CreateRawTable(req *protos.CreateRawTableInput) (*protos.CreateRawTableOutput, error) {
 	rawTableName := c.getRawTableName(req.FlowJobName)
@@ -822,10 +898,7 @@ func (c *BigQueryConnector) SetupNormalizedTable(
 	columns := make([]*bigquery.FieldSchema, len(sourceSchema.Columns))
 	idx := 0
 	for colName, genericColType := range sourceSchema.Columns {
-		columns[idx] = &bigquery.FieldSchema{
-			Name: colName,
-			Type: qValueKindToBigQueryType(genericColType),
-		}
+		columns[idx] = qValueKindToBigQueryFieldSchema(colName, genericColType)
 		idx++
 	}
 
@@ -857,6 +930,47 @@ func (c *BigQueryConnector) SetupNormalizedTable(
 	}, nil
 }
 
+// ReplayTableSchemaDelta adds the columns added on the source to the normalized table,
+// implementing the Connector interface.
+func (c *BigQueryConnector) ReplayTableSchemaDelta(flowJobName string,
+	schemaDelta *protos.TableSchemaDelta) error {
+	if len(schemaDelta.AddedColumns) == 0 {
+		return nil
+	}
+
+	table := c.client.Dataset(c.datasetID).Table(schemaDelta.DstTableName)
+	metadata, err := table.Metadata(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get metadata of table %s: %w", schemaDelta.DstTableName, err)
+	}
+
+	existingColumns := make(map[string]bool)
+	for _, field := range metadata.Schema {
+		existingColumns[field.Name] = true
+	}
+
+	schema := metadata.Schema
+	for _, addedColumn := range schemaDelta.AddedColumns {
+		// a retried delta may already have been applied.
+		if existingColumns[addedColumn.ColumnName] {
+			continue
+		}
+		schema = append(schema, qValueKindToBigQueryFieldSchema(addedColumn.ColumnName, addedColumn.ColumnType))
+		log.Infof("[%s] adding column %s of type %s to table %s", flowJobName, addedColumn.ColumnName,
+			addedColumn.ColumnType, schemaDelta.DstTableName)
+	}
+	if len(schema) == len(metadata.Schema) {
+		return nil
+	}
+
+	// the etag makes the update fail if the table changed since the metadata was read.
+	_, err = table.Update(c.ctx, bigquery.TableMetadataToUpdate{Schema: schema}, metadata.ETag)
+	if err != nil {
+		return fmt.Errorf("failed to add columns to table %s: %w", schemaDelta.DstTableName, err)
+	}
+	return nil
+}
+
 // EnsurePullability ensures that the given table is pullable, implementing the Connector interface.
 func (c *BigQueryConnector) EnsurePullability(*protos.EnsurePullabilityInput) (*protos.EnsurePullabilityOutput, error) {
 	panic("not implemented")
@@ -872,6 +986,17 @@ func (c *BigQueryConnector) PullFlowCleanup(jobName string) error {
 	panic("not implemented")
 }
 
+// TruncateTables removes the rows of destination tables.
+func (c *BigQueryConnector) TruncateTables(tableIdentifiers []string) error {
+	for _, tableIdentifier := range tableIdentifiers {
+		query := fmt.Sprintf("TRUNCATE TABLE %s.%s", c.datasetID, tableIdentifier)
+		if _, err := c.client.Query(query).Read(c.ctx); err != nil {
+			return fmt.Errorf("failed to truncate table %s: %w", tableIdentifier, err)
+		}
+	}
+	return nil
+}
+
 func (c *BigQueryConnector) SyncFlowCleanup(jobName string) error {
 	dataset := c.client.Dataset(c.datasetID)
 	// deleting PeerDB specific tables
@@ -939,24 +1064,107 @@ type MergeStmtGenerator struct {
 	NormalizedTableSchema *protos.TableSchema
 	// array of toast column combinations that are unchanged
 	UnchangedToastColumns []string
+	// position of the last truncate of the table in the batch range, zero if it wasn't truncated.
+	LastTruncate model.RawRecordPosition
 }
 
 // GenerateMergeStmt generates a merge statements.
 func (m *MergeStmtGenerator) GenerateMergeStmts() []string {
 	// return an empty array for now
 	flattenedCTE := m.generateFlattenedCTE()
-	deDupedCTE := m.generateDeDupedCTE()
 
-	// create temp table stmt
-	createTempTableStmt := fmt.Sprintf(
-		"CREATE TEMP TABLE _peerdb_de_duplicated_data AS (%s, %s);",
-		flattenedCTE, deDupedCTE)
+	stmts := make([]string, 0)
+	// records before the truncate are skipped by the flattened CTE, so only the rows already
+	// in the table have to be removed. TRUNCATE TABLE isn't allowed within a transaction.
+	if m.LastTruncate.BatchID > 0 {
+		stmts = append(stmts, fmt.Sprintf("DELETE FROM %s.%s WHERE true;", m.Dataset, m.NormalizedTable))
+	}
+
+	// without key columns or a full replica identity rows can't be matched, so only inserts are applied.
+	if m.isAppendOnly() {
+		return append(stmts, m.generateAppendOnlyInsertStmt(flattenedCTE))
+	}
 
-	mergeStmt := m.generateMergeStmt()
+	var createTempTableStmt, mergeStmt string
+	if m.isFullRowMatch() {
+		createTempTableStmt = fmt.Sprintf(
+			"CREATE TEMP TABLE _peerdb_de_duplicated_data AS (%s);", m.generateNetChangesQuery())
+		mergeStmt = m.generateFullRowMergeStmt()
+	} else {
+		createTempTableStmt = fmt.Sprintf(
+			"CREATE TEMP TABLE _peerdb_de_duplicated_data AS (%s, %s);",
+			flattenedCTE, m.generateDeDupedCTE())
+		mergeStmt = m.generateMergeStmt()
+	}
 
 	dropTempTableStmt := "DROP TABLE _peerdb_de_duplicated_data;"
 
-	return []string{createTempTableStmt, mergeStmt, dropTempTableStmt}
+	return append(stmts, createTempTableStmt, mergeStmt, dropTempTableStmt)
+}
+
+// isFullRowMatch returns true if the table has no key columns but the source
+// sends the full old row for updates and deletes.
+func (m *MergeStmtGenerator) isFullRowMatch() bool {
+	return len(m.NormalizedTableSchema.PrimaryKeyColumns) == 0 &&
+		m.NormalizedTableSchema.ReplicaIdentity == protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL
+}
+
+// isAppendOnly returns true if rows of the table can't be identified at all.
+func (m *MergeStmtGenerator) isAppendOnly() bool {
+	return len(m.NormalizedTableSchema.PrimaryKeyColumns) == 0 &&
+		m.NormalizedTableSchema.ReplicaIdentity != protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL
+}
+
+// generateColumnCast generates the expression extracting a column of the given type from a JSON column.
+func generateColumnCast(jsonColName string, colName string, colType string) string {
+	bqType := qValueKindToBigQueryType(colType)
+	// CAST doesn't work for FLOAT, so rewrite it to FLOAT64.
+	if bqType == bigquery.FloatFieldType {
+		bqType = "FLOAT64"
+	}
+
+	kind := qvalue.QValueKind(colType)
+	if kind.IsArray() {
+		// arrays are stored as JSON arrays, NULL arrays become empty as REPEATED columns can't be NULL.
+		return fmt.Sprintf("ARRAY(SELECT CAST(element AS %s) FROM UNNEST(JSON_VALUE_ARRAY(%s, '$.%s')) AS element)",
+			bqType, jsonColName, colName)
+	}
+
+	switch kind {
+	case qvalue.QValueKindJSON:
+		//if the type is JSON, then just extract JSON
+		return fmt.Sprintf("CAST(JSON_EXTRACT(%s, '$.%s') AS %s)", jsonColName, colName, bqType)
+	// expecting data in BASE64 format
+	case qvalue.QValueKindBytes, qvalue.QValueKindBit:
+		return fmt.Sprintf("FROM_BASE64(JSON_EXTRACT_SCALAR(%s, '$.%s'))", jsonColName, colName)
+	// geospatial types are stored as hex encoded WKB
+	case qvalue.QValueKindGeometry, qvalue.QValueKindGeography:
+		return fmt.Sprintf("ST_GEOGFROMWKB(JSON_EXTRACT_SCALAR(%s, '$.%s'))", jsonColName, colName)
+	// MAKE_INTERVAL(years INT64, months INT64, days INT64, hours INT64, minutes INT64, seconds INT64)
+	// Expecting interval to be in the format of {"Microseconds":2000000,"Days":0,"Months":0,"Valid":true}
+	// json.Marshal in SyncRecords for Postgres already does this - once new data-stores are added,
+	// this needs to be handled again
+	// TODO add interval types again
+	// case model.ColumnTypeInterval:
+	// castStmt = fmt.Sprintf("MAKE_INTERVAL(0,CAST(JSON_EXTRACT_SCALAR(_peerdb_data, '$.%s.Months') AS INT64),"+
+	// 	"CAST(JSON_EXTRACT_SCALAR(_peerdb_data, '$.%s.Days') AS INT64),0,0,"+
+	// 	"CAST(CAST(JSON_EXTRACT_SCALAR(_peerdb_data, '$.%s.Microseconds') AS INT64)/1000000 AS  INT64)) AS %s",
+	// 	colName, colName, colName, colName)
+	// TODO add proper granularity for time types, then restore this
+	// case model.ColumnTypeTime:
+	// 	castStmt = fmt.Sprintf("time(timestamp_micros(CAST(JSON_EXTRACT(_peerdb_data, '$.%s.Microseconds')"+
+	// 		" AS int64))) AS %s",
+	// 		colName, colName)
+	default:
+		return fmt.Sprintf("CAST(JSON_EXTRACT_SCALAR(%s, '$.%s') AS %s)", jsonColName, colName, bqType)
+	}
+}
+
+// generateAfterTruncateFilter generates the condition skipping the records up to the last truncate of the table,
+// records are ordered by batch and then by timestamp within the batch.
+func (m *MergeStmtGenerator) generateAfterTruncateFilter() string {
+	return fmt.Sprintf("(_peerdb_batch_id > %d or (_peerdb_batch_id = %d and _peerdb_timestamp_nanos > %d))",
+		m.LastTruncate.BatchID, m.LastTruncate.BatchID, m.LastTruncate.Timestamp)
 }
 
 // generateFlattenedCTE generates a flattened CTE.
@@ -965,41 +1173,7 @@ func (m *MergeStmtGenerator) generateFlattenedCTE() string {
 	// statement.
 	flattenedProjs := make([]string, 0)
 	for colName, colType := range m.NormalizedTableSchema.Columns {
-		bqType := qValueKindToBigQueryType(colType)
-		// CAST doesn't work for FLOAT, so rewrite it to FLOAT64.
-		if bqType == bigquery.FloatFieldType {
-			bqType = "FLOAT64"
-		}
-		var castStmt string
-
-		switch qvalue.QValueKind(colType) {
-		case qvalue.QValueKindJSON:
-			//if the type is JSON, then just extract JSON
-			castStmt = fmt.Sprintf("CAST(JSON_EXTRACT(_peerdb_data, '$.%s') AS %s) AS %s",
-				colName, bqType, colName)
-		// expecting data in BASE64 format
-		case qvalue.QValueKindBytes, qvalue.QValueKindBit:
-			castStmt = fmt.Sprintf("FROM_BASE64(JSON_EXTRACT_SCALAR(_peerdb_data, '$.%s')) AS %s",
-				colName, colName)
-		// MAKE_INTERVAL(years INT64, months INT64, days INT64, hours INT64, minutes INT64, seconds INT64)
-		// Expecting interval to be in the format of {"Microseconds":2000000,"Days":0,"Months":0,"Valid":true}
-		// json.Marshal in SyncRecords for Postgres already does this - once new data-stores are added,
-		// this needs to be handled again
-		// TODO add interval types again
-		// case model.ColumnTypeInterval:
-		// castStmt = fmt.Sprintf("MAKE_INTERVAL(0,CAST(JSON_EXTRACT_SCALAR(_peerdb_data, '$.%s.Months') AS INT64),"+
-		// 	"CAST(JSON_EXTRACT_SCALAR(_peerdb_data, '$.%s.Days') AS INT64),0,0,"+
-		// 	"CAST(CAST(JSON_EXTRACT_SCALAR(_peerdb_data, '$.%s.Microseconds') AS INT64)/1000000 AS  INT64)) AS %s",
-		// 	colName, colName, colName, colName)
-		// TODO add proper granularity for time types, then restore this
-		// case model.ColumnTypeTime:
-		// 	castStmt = fmt.Sprintf("time(timestamp_micros(CAST(JSON_EXTRACT(_peerdb_data, '$.%s.Microseconds')"+
-		// 		" AS int64))) AS %s",
-		// 		colName, colName)
-		default:
-			castStmt = fmt.Sprintf("CAST(JSON_EXTRACT_SCALAR(_peerdb_data, '$.%s') AS %s) AS %s",
-				colName, bqType, colName)
-		}
+		castStmt := fmt.Sprintf("%s AS %s", generateColumnCast("_peerdb_data", colName, colType), colName)
 		flattenedProjs = append(flattenedProjs, castStmt)
 	}
 	flattenedProjs = append(flattenedProjs, "_peerdb_timestamp")
@@ -1010,9 +1184,9 @@ func (m *MergeStmtGenerator) generateFlattenedCTE() string {
 	// normalize anything between last normalized batch id to last sync batchid
 	return fmt.Sprintf(`WITH _peerdb_flattened AS
 	 (SELECT %s FROM %s.%s WHERE _peerdb_batch_id > %d and _peerdb_batch_id <= %d and
-	 _peerdb_destination_table_name='%s')`,
+	 _peerdb_destination_table_name='%s' and %s)`,
 		strings.Join(flattenedProjs, ", "), m.Dataset, m.RawTable, m.NormalizeBatchID,
-		m.SyncBatchID, m.NormalizedTable)
+		m.SyncBatchID, m.NormalizedTable, m.generateAfterTruncateFilter())
 }
 
 // generateDeDupedCTE generates a de-duped CTE.
@@ -1026,13 +1200,20 @@ func (m *MergeStmtGenerator) generateDeDupedCTE() string {
 			) _peerdb_ranked
 			WHERE rank = 1
 	) SELECT * FROM _peerdb_de_duplicated_data_res`
-	pkey := m.NormalizedTableSchema.PrimaryKeyColumn
-	return fmt.Sprintf(cte, pkey)
+	pkeyColsStr := strings.Join(m.NormalizedTableSchema.PrimaryKeyColumns, ", ")
+	return fmt.Sprintf(cte, pkeyColsStr)
 }
 
 // generateMergeStmt generates a merge statement.
 func (m *MergeStmtGenerator) generateMergeStmt() string {
-	pkey := m.NormalizedTableSchema.PrimaryKeyColumn
+	// join on all primary key columns
+	pkeySelectSQLArray := make([]string, 0, len(m.NormalizedTableSchema.PrimaryKeyColumns))
+	for _, pkeyColName := range m.NormalizedTableSchema.PrimaryKeyColumns {
+		pkeySelectSQLArray = append(pkeySelectSQLArray, fmt.Sprintf("_peerdb_target.%s = _peerdb_deduped.%s",
+			pkeyColName, pkeyColName))
+	}
+	// _peerdb_target.<pkey1> = _peerdb_deduped.<pkey1> AND _peerdb_target.<pkey2> = _peerdb_deduped.<pkey2>...
+	pkeySelectSQL := strings.Join(pkeySelectSQLArray, " AND ")
 
 	// comma separated list of column names
 	colNames := make([]string, 0)
@@ -1046,13 +1227,117 @@ func (m *MergeStmtGenerator) generateMergeStmt() string {
 
 	return fmt.Sprintf(`
 	MERGE %s.%s _peerdb_target USING _peerdb_de_duplicated_data _peerdb_deduped
-	ON _peerdb_target.%s = _peerdb_deduped.%s
+	ON %s
 		WHEN NOT MATCHED and (_peerdb_deduped._peerdb_record_type != 2) THEN
 			INSERT (%s) VALUES (%s)
 		%s
 		WHEN MATCHED AND (_peerdb_deduped._peerdb_record_type = 2) THEN
 	DELETE;
-	`, m.Dataset, m.NormalizedTable, pkey, pkey, csep, csep, updateStringToastCols)
+	`, m.Dataset, m.NormalizedTable, pkeySelectSQL, csep, csep, updateStringToastCols)
+}
+
+// generateNetChangesQuery generates the query netting the changes of a table without key columns. Such a
+// table is a multiset of rows: inserts add their row, deletes remove their old row and updates do both.
+// Changes are counted per row image, so a row inserted and then updated, or updated several times, ends
+// up inserted once in its last state whatever the order. _peerdb_delta is 1 for each row image to insert
+// and -1 for each row image to delete. A MERGE deletes all the rows equal to a row image, so the equal rows
+// beyond the number of times the image was removed are kept by inserting them again.
+func (m *MergeStmtGenerator) generateNetChangesQuery() string {
+	colNames := make([]string, 0, len(m.NormalizedTableSchema.Columns))
+	imageProjs := make([]string, 0, len(m.NormalizedTableSchema.Columns))
+	keptCols := make([]string, 0, len(m.NormalizedTableSchema.Columns))
+	for colName, colType := range m.NormalizedTableSchema.Columns {
+		colNames = append(colNames, colName)
+		imageProjs = append(imageProjs, fmt.Sprintf("%s AS %s",
+			generateColumnCast("_peerdb_image", colName, colType), colName))
+		keptCols = append(keptCols, "_peerdb_deduped."+colName)
+	}
+	rawFilter := fmt.Sprintf(`%s.%s WHERE _peerdb_batch_id > %d and _peerdb_batch_id <= %d and
+	 _peerdb_destination_table_name='%s' and %s`,
+		m.Dataset, m.RawTable, m.NormalizeBatchID, m.SyncBatchID, m.NormalizedTable,
+		m.generateAfterTruncateFilter())
+
+	return fmt.Sprintf(`WITH _peerdb_changes AS (
+		SELECT _peerdb_data AS _peerdb_image, 1 AS _peerdb_delta, _peerdb_timestamp_nanos
+		FROM %s and _peerdb_record_type IN (0, 1)
+		UNION ALL
+		SELECT _peerdb_match_data AS _peerdb_image, -1 AS _peerdb_delta, _peerdb_timestamp_nanos
+		FROM %s and _peerdb_record_type IN (1, 2)
+	), _peerdb_net_changes AS (
+		SELECT *,
+			SUM(_peerdb_delta) OVER (PARTITION BY _peerdb_image) AS _peerdb_net,
+			ROW_NUMBER() OVER (
+				PARTITION BY _peerdb_image, _peerdb_delta ORDER BY _peerdb_timestamp_nanos
+			) AS _peerdb_rank
+		FROM _peerdb_changes
+	), _peerdb_netted AS (
+		SELECT _peerdb_image, _peerdb_delta, -_peerdb_net AS _peerdb_count, %s FROM _peerdb_net_changes
+		WHERE (_peerdb_delta = 1 AND _peerdb_rank <= _peerdb_net)
+		OR (_peerdb_delta = -1 AND _peerdb_rank = 1 AND _peerdb_net < 0)
+	), _peerdb_kept AS (
+		SELECT _peerdb_deduped._peerdb_image,
+			GREATEST(COUNT(*) - ANY_VALUE(_peerdb_deduped._peerdb_count), 0) AS _peerdb_kept_count
+		FROM _peerdb_netted _peerdb_deduped JOIN %s.%s _peerdb_target
+		ON _peerdb_deduped._peerdb_delta = -1 AND %s
+		GROUP BY _peerdb_deduped._peerdb_image
+	) SELECT %s, _peerdb_delta FROM _peerdb_netted
+	UNION ALL
+	SELECT %s, 1 FROM _peerdb_netted _peerdb_deduped JOIN _peerdb_kept USING (_peerdb_image)
+		CROSS JOIN UNNEST(GENERATE_ARRAY(1, _peerdb_kept._peerdb_kept_count))`,
+		rawFilter, rawFilter, strings.Join(imageProjs, ", "), m.Dataset, m.NormalizedTable,
+		m.generateFullRowMatchConditions(), strings.Join(colNames, ", "), strings.Join(keptCols, ", "))
+}
+
+// generateFullRowMatchConditions generates the conditions matching a row of the table to a row image on
+// every column, for tables without key columns.
+func (m *MergeStmtGenerator) generateFullRowMatchConditions() string {
+	matchConditions := make([]string, 0, len(m.NormalizedTableSchema.Columns))
+	for colName, colType := range m.NormalizedTableSchema.Columns {
+		// JSON values can't be compared directly, compare their string form instead.
+		if qvalue.QValueKind(colType) == qvalue.QValueKindJSON {
+			matchConditions = append(matchConditions, fmt.Sprintf(
+				"TO_JSON_STRING(_peerdb_target.%s) IS NOT DISTINCT FROM TO_JSON_STRING(_peerdb_deduped.%s)",
+				colName, colName))
+		} else {
+			matchConditions = append(matchConditions, fmt.Sprintf(
+				"_peerdb_target.%s IS NOT DISTINCT FROM _peerdb_deduped.%s", colName, colName))
+		}
+	}
+	return strings.Join(matchConditions, " AND ")
+}
+
+// generateFullRowMergeStmt generates a merge statement for tables without key columns, applying the
+// netted changes. Row images to delete match on every column, removing all the equal rows of the table,
+// the ones to keep are inserted again by the netted changes.
+func (m *MergeStmtGenerator) generateFullRowMergeStmt() string {
+	colNames := make([]string, 0)
+	for colName := range m.NormalizedTableSchema.Columns {
+		colNames = append(colNames, colName)
+	}
+	csep := strings.Join(colNames, ", ")
+
+	return fmt.Sprintf(`
+	MERGE %s.%s _peerdb_target USING _peerdb_de_duplicated_data _peerdb_deduped
+	ON _peerdb_deduped._peerdb_delta = -1 AND %s
+		WHEN NOT MATCHED and (_peerdb_deduped._peerdb_delta = 1) THEN
+			INSERT (%s) VALUES (%s)
+		WHEN MATCHED THEN
+	DELETE;
+	`, m.Dataset, m.NormalizedTable, m.generateFullRowMatchConditions(), csep, csep)
+}
+
+// generateAppendOnlyInsertStmt generates an insert of all new rows, for tables whose rows can't be matched.
+func (m *MergeStmtGenerator) generateAppendOnlyInsertStmt(flattenedCTE string) string {
+	colNames := make([]string, 0)
+	for colName := range m.NormalizedTableSchema.Columns {
+		colNames = append(colNames, colName)
+	}
+	csep := strings.Join(colNames, ", ")
+
+	return fmt.Sprintf(`
+	INSERT INTO %s.%s (%s) %s
+	SELECT %s FROM _peerdb_flattened WHERE _peerdb_record_type = 0;
+	`, m.Dataset, m.NormalizedTable, csep, flattenedCTE, csep)
 }
 
 /*
@@ -4,6 +4,10 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
 )
 
 func TestGenerateUpdateStatement_WithUnchangedToastCols(t *testing.T) {
@@ -56,9 +60,164 @@ func TestGenerateUpdateStatement_NoUnchangedToastCols(t *testing.T) {
 	}
 }
 
+func TestGenerateMergeStmt_CompositeKey(t *testing.T) {
+	m := &MergeStmtGenerator{
+		Dataset:         "dataset",
+		NormalizedTable: "orders",
+		NormalizedTableSchema: &protos.TableSchema{
+			TableIdentifier:   "orders",
+			Columns:           map[string]string{"tenant_id": "int64", "id": "int64"},
+			PrimaryKeyColumns: []string{"tenant_id", "id"},
+		},
+	}
+
+	dedupedCTE := removeSpacesTabsNewlines(m.generateDeDupedCTE())
+	expectedPartition := removeSpacesTabsNewlines("PARTITION BY tenant_id, id ORDER BY")
+	if !strings.Contains(dedupedCTE, expectedPartition) {
+		t.Errorf("Expected de-duped CTE to contain %v, but got: %v", expectedPartition, dedupedCTE)
+	}
+
+	mergeStmt := removeSpacesTabsNewlines(m.generateMergeStmt())
+	expectedJoin := removeSpacesTabsNewlines(`ON _peerdb_target.tenant_id = _peerdb_deduped.tenant_id
+		AND _peerdb_target.id = _peerdb_deduped.id WHEN NOT MATCHED`)
+	if !strings.Contains(mergeStmt, expectedJoin) {
+		t.Errorf("Expected merge statement to contain %v, but got: %v", expectedJoin, mergeStmt)
+	}
+}
+
+func TestGenerateMergeStmts_NoPrimaryKey(t *testing.T) {
+	m := &MergeStmtGenerator{
+		Dataset:         "dataset",
+		NormalizedTable: "audit_log",
+		NormalizedTableSchema: &protos.TableSchema{
+			TableIdentifier: "audit_log",
+			Columns:         map[string]string{"msg": "string"},
+		},
+	}
+
+	// default replica identity without a key only gets inserts.
+	stmts := m.GenerateMergeStmts()
+	if len(stmts) != 1 || !strings.Contains(stmts[0], "INSERT INTO dataset.audit_log (msg)") {
+		t.Errorf("Expected a single append-only insert, but got: %v", stmts)
+	}
+
+	m.NormalizedTableSchema.ReplicaIdentity = protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL
+	stmts = m.GenerateMergeStmts()
+	if len(stmts) != 3 {
+		t.Fatalf("Expected 3 statements for full row matching, but got: %v", stmts)
+	}
+	expectedJoin := removeSpacesTabsNewlines(`ON _peerdb_deduped._peerdb_delta = -1 AND
+		_peerdb_target.msg IS NOT DISTINCT FROM _peerdb_deduped.msg`)
+	if !strings.Contains(removeSpacesTabsNewlines(stmts[1]), expectedJoin) {
+		t.Errorf("Expected merge statement to contain %v, but got: %v", expectedJoin, stmts[1])
+	}
+}
+
+func TestGenerateMergeStmts_FullRowNetsChanges(t *testing.T) {
+	m := &MergeStmtGenerator{
+		Dataset:         "dataset",
+		NormalizedTable: "audit_log",
+		RawTable:        "raw",
+		NormalizedTableSchema: &protos.TableSchema{
+			TableIdentifier: "audit_log",
+			Columns:         map[string]string{"msg": "string"},
+			ReplicaIdentity: protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL,
+		},
+	}
+
+	stmts := m.GenerateMergeStmts()
+	if len(stmts) != 3 {
+		t.Fatalf("Expected 3 statements for full row matching, but got: %v", stmts)
+	}
+	createTempTable := removeSpacesTabsNewlines(stmts[0])
+	// an insert followed by an update of the same row, or a chain of updates, adds and removes the
+	// intermediate row images, so only the last one is inserted and only the first one deleted.
+	for _, expected := range []string{
+		"SELECT _peerdb_data AS _peerdb_image, 1 AS _peerdb_delta",
+		"_peerdb_record_type IN (0, 1)",
+		"SELECT _peerdb_match_data AS _peerdb_image, -1 AS _peerdb_delta",
+		"_peerdb_record_type IN (1, 2)",
+		"SUM(_peerdb_delta) OVER (PARTITION BY _peerdb_image) AS _peerdb_net",
+		"WHERE (_peerdb_delta = 1 AND _peerdb_rank <= _peerdb_net)",
+		"OR (_peerdb_delta = -1 AND _peerdb_rank = 1 AND _peerdb_net < 0)",
+		// a row image removed n times out of m equal rows deletes them all and inserts m-n of them again.
+		"GREATEST(COUNT(*) - ANY_VALUE(_peerdb_deduped._peerdb_count), 0) AS _peerdb_kept_count",
+		"FROM _peerdb_netted _peerdb_deduped JOIN dataset.audit_log _peerdb_target",
+		"ON _peerdb_deduped._peerdb_delta = -1 AND _peerdb_target.msg IS NOT DISTINCT FROM _peerdb_deduped.msg",
+		"SELECT _peerdb_deduped.msg, 1 FROM _peerdb_netted _peerdb_deduped JOIN _peerdb_kept USING (_peerdb_image)",
+		"CROSS JOIN UNNEST(GENERATE_ARRAY(1, _peerdb_kept._peerdb_kept_count))",
+	} {
+		if !strings.Contains(createTempTable, removeSpacesTabsNewlines(expected)) {
+			t.Errorf("Expected net changes to contain %v, but got: %v", expected, stmts[0])
+		}
+	}
+	if strings.Contains(stmts[1], "UPDATE SET") {
+		t.Errorf("Expected updates to be applied as a delete and an insert, but got: %v", stmts[1])
+	}
+}
+
+func TestGenerateMergeStmts_Truncate(t *testing.T) {
+	m := &MergeStmtGenerator{
+		Dataset:         "dataset",
+		NormalizedTable: "users",
+		RawTable:        "raw",
+		NormalizedTableSchema: &protos.TableSchema{
+			TableIdentifier:   "users",
+			Columns:           map[string]string{"id": "int32"},
+			PrimaryKeyColumns: []string{"id"},
+		},
+		LastTruncate: model.RawRecordPosition{BatchID: 2, Timestamp: 42},
+	}
+
+	stmts := m.GenerateMergeStmts()
+	if len(stmts) != 4 || stmts[0] != "DELETE FROM dataset.users WHERE true;" {
+		t.Fatalf("Expected the table to be emptied before the merge, but got: %v", stmts)
+	}
+	if !strings.Contains(stmts[1],
+		"(_peerdb_batch_id > 2 or (_peerdb_batch_id = 2 and _peerdb_timestamp_nanos > 42))") {
+		t.Errorf("Expected records up to the truncate to be skipped, but got: %v", stmts[1])
+	}
+}
+
+func TestGenerateColumnCast_ArraysAndGeography(t *testing.T) {
+	tests := map[string]string{
+		"array_int32": "ARRAY(SELECT CAST(element AS INTEGER) FROM UNNEST(JSON_VALUE_ARRAY(_peerdb_data, '$.col'))" +
+			" AS element)",
+		"array_float64": "ARRAY(SELECT CAST(element AS FLOAT64) FROM UNNEST(JSON_VALUE_ARRAY(_peerdb_data, '$.col'))" +
+			" AS element)",
+		"geography": "ST_GEOGFROMWKB(JSON_EXTRACT_SCALAR(_peerdb_data, '$.col'))",
+		"hstore":    "CAST(JSON_EXTRACT_SCALAR(_peerdb_data, '$.col') AS STRING)",
+	}
+
+	for colType, expected := range tests {
+		result := generateColumnCast("_peerdb_data", "col", colType)
+		if result != expected {
+			t.Errorf("Unexpected cast for %s. Expected: %v, but got: %v", colType, expected, result)
+		}
+	}
+
+	field := qValueKindToBigQueryFieldSchema("col", "array_string")
+	if !field.Repeated || field.Type != bigquery.StringFieldType {
+		t.Errorf("Expected a REPEATED STRING column, but got: %v", field)
+	}
+}
+
 func removeSpacesTabsNewlines(s string) string {
 	s = strings.ReplaceAll(s, " ", "")
 	s = strings.ReplaceAll(s, "\t", "")
 	s = strings.ReplaceAll(s, "\n", "")
 	return s
 }
+
+func TestQuoteIdentifier(t *testing.T) {
+	for identifier, expected := range map[string]string{
+		"id":    "`id`",
+		"a`b":   "`a\\`b`",
+		`a\b`:   "`a\\\\b`",
+		"a b.c": "`a b.c`",
+	} {
+		if got := QuoteIdentifier(identifier); got != expected {
+			t.Errorf("QuoteIdentifier(%q) = %q, expected %q", identifier, got, expected)
+		}
+	}
+}
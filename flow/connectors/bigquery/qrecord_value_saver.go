@@ -96,7 +96,9 @@ func (q QRecordValueSaver) Save() (map[string]bigquery.Value, string, error) {
 			}
 			bqValues[k] = val
 
-		case qvalue.QValueKindString:
+		case qvalue.QValueKindString, qvalue.QValueKindInterval, qvalue.QValueKindINET, qvalue.QValueKindCIDR,
+			qvalue.QValueKindMacaddr, qvalue.QValueKindEnum, qvalue.QValueKindHStore,
+			qvalue.QValueKindGeometry, qvalue.QValueKindGeography:
 			val, ok := v.Value.(string)
 			if !ok {
 				return nil, "", fmt.Errorf("failed to convert %v to string", v.Value)
@@ -133,6 +135,10 @@ func (q QRecordValueSaver) Save() (map[string]bigquery.Value, string, error) {
 			uuidVal := uuid.UUID(val)
 			bqValues[k] = uuidVal.String()
 
+		case qvalue.QValueKindArrayInt32, qvalue.QValueKindArrayInt64, qvalue.QValueKindArrayFloat32,
+			qvalue.QValueKindArrayFloat64, qvalue.QValueKindArrayBoolean, qvalue.QValueKindArrayString:
+			bqValues[k] = v.Value
+
 		default:
 			// Skip invalid QValueKind, but log the type for debugging
 			fmt.Printf("[bigquery] Invalid QValueKind: %v\n", v.Kind)
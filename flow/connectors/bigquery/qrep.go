@@ -1,8 +1,11 @@
 package connbigquery
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"reflect"
+	"text/template"
 	"time"
 
 	"cloud.google.com/go/bigquery"
@@ -19,16 +22,111 @@ func (c *BigQueryConnector) GetQRepPartitions(config *protos.QRepConfig,
 	panic("not implemented")
 }
 
+// PullQRepRecords reads the rows of a partition of a query, it's used to read back destination
+// tables when validating a mirror.
 func (c *BigQueryConnector) PullQRepRecords(config *protos.QRepConfig,
 	partition *protos.QRepPartition,
-) (*model.QRecordBatch, error) {
-	panic("not implemented")
+	stream *model.QRecordStream,
+) (int, error) {
+	var rangeStart interface{}
+	var rangeEnd interface{}
+
+	// Depending on the type of the range, convert the range into the correct type
+	switch x := partition.Range.Range.(type) {
+	case *protos.PartitionRange_IntRange:
+		rangeStart = x.IntRange.Start
+		rangeEnd = x.IntRange.End
+	case *protos.PartitionRange_TimestampRange:
+		rangeStart = x.TimestampRange.Start.AsTime()
+		rangeEnd = x.TimestampRange.End.AsTime()
+	default:
+		return 0, fmt.Errorf("unknown range type: %v", x)
+	}
+
+	query, err := BuildQuery(config.Query)
+	if err != nil {
+		return 0, err
+	}
+
+	q := c.client.Query(query)
+	q.DefaultDatasetID = c.datasetID
+	q.Parameters = []bigquery.QueryParameter{
+		{Name: "startRange", Value: rangeStart},
+		{Name: "endRange", Value: rangeEnd},
+	}
+	it, err := q.Read(c.ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to run query %s on BigQuery: %w", query, err)
+	}
+
+	// the schema of the iterator is only known once the first row is read.
+	var schema *model.QRecordSchema
+	numRecords := 0
+	for {
+		var row []bigquery.Value
+		err := it.Next(&row)
+		if schema == nil && it.Schema != nil {
+			var schemaErr error
+			schema, schemaErr = bigQuerySchemaToQRecordSchema(it.Schema)
+			if schemaErr != nil {
+				return numRecords, schemaErr
+			}
+			stream.SetSchema(schema)
+		}
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return numRecords, fmt.Errorf("failed to read rows of query %s: %w", query, err)
+		}
+
+		record := model.NewQRecord(len(row))
+		for i, value := range row {
+			qValue, err := bigQueryValueToQValue(schema.Fields[i].Type, value)
+			if err != nil {
+				return numRecords, fmt.Errorf("failed to convert value of column %s: %w", schema.Fields[i].Name, err)
+			}
+			record.Set(i, qValue)
+		}
+		if !stream.Send(record) {
+			return numRecords, model.ErrRecordStreamAbandoned
+		}
+		numRecords++
+	}
+
+	if schema == nil {
+		return numRecords, fmt.Errorf("no schema for the results of query %s", query)
+	}
+	return numRecords, nil
+}
+
+func BuildQuery(query string) (string, error) {
+	tmpl, err := template.New("query").Parse(query)
+	if err != nil {
+		return "", err
+	}
+
+	data := map[string]interface{}{
+		"start": "@startRange",
+		"end":   "@endRange",
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = tmpl.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+	res := buf.String()
+
+	log.Infof("templated query: %s", res)
+	return res, nil
 }
 
 func (c *BigQueryConnector) SyncQRepRecords(
 	config *protos.QRepConfig,
 	partition *protos.QRepPartition,
-	records *model.QRecordBatch,
+	stream *model.QRecordStream,
 ) (int, error) {
 	// Ensure the destination table is available.
 	destTable := config.DestinationTableIdentifier
@@ -52,10 +150,10 @@ func (c *BigQueryConnector) SyncQRepRecords(
 	switch syncMode {
 	case protos.QRepSyncMode_QREP_SYNC_MODE_MULTI_INSERT:
 		stagingTableSync := &QRepStagingTableSync{connector: c}
-		return stagingTableSync.SyncQRepRecords(config.FlowJobName, destTable, partition, tblMetadata, records)
+		return stagingTableSync.SyncQRepRecords(config.FlowJobName, destTable, partition, tblMetadata, stream)
 	case protos.QRepSyncMode_QREP_SYNC_MODE_STORAGE_AVRO:
-		avroSync := &QRepAvroSyncMethod{connector: c, gcsBucket: "peerdb_staging"}
-		return avroSync.SyncQRepRecords(config.FlowJobName, destTable, partition, tblMetadata, records)
+		avroSync := NewQRepAvroSyncMethod(c, config.StagingPath)
+		return avroSync.SyncQRepRecords(config.FlowJobName, destTable, partition, tblMetadata, stream)
 	default:
 		return 0, fmt.Errorf("unsupported sync mode: %s", syncMode)
 	}
@@ -1,14 +1,15 @@
 package connbigquery
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"cloud.google.com/go/bigquery"
+	stage "github.com/PeerDB-io/peer-flow/connectors/utils/stage"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/model"
 	"github.com/PeerDB-io/peer-flow/model/qvalue"
@@ -16,16 +17,32 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// defaultStagingPath is the GCS bucket Avro files are staged in for flows without a staging path.
+const defaultStagingPath = "gs://peerdb_staging"
+
 type QRepAvroSyncMethod struct {
-	connector *BigQueryConnector
-	gcsBucket string
+	connector   *BigQueryConnector
+	stagingPath string
 }
 
-func NewQRepAvroSyncMethod(connector *BigQueryConnector, gcsBucket string) *QRepAvroSyncMethod {
+func NewQRepAvroSyncMethod(connector *BigQueryConnector, stagingPath string) *QRepAvroSyncMethod {
 	return &QRepAvroSyncMethod{
-		connector: connector,
-		gcsBucket: gcsBucket,
+		connector:   connector,
+		stagingPath: stagingPath,
+	}
+}
+
+// openStage returns the stage of the staging path, GCS staging paths are accessed with the
+// credentials of the peer.
+func (s *QRepAvroSyncMethod) openStage() (stage.Stage, error) {
+	stagingPath := s.stagingPath
+	if stagingPath == "" {
+		stagingPath = defaultStagingPath
+	}
+	if strings.HasPrefix(stagingPath, "gs://") || strings.HasPrefix(stagingPath, "gcs://") {
+		return stage.NewGCSStageWithClient(s.connector.storageClient, stagingPath)
 	}
+	return stage.NewStage(s.connector.ctx, stagingPath)
 }
 
 func (s *QRepAvroSyncMethod) SyncQRepRecords(
@@ -33,7 +50,7 @@ func (s *QRepAvroSyncMethod) SyncQRepRecords(
 	dstTableName string,
 	partition *protos.QRepPartition,
 	dstTableMetadata *bigquery.TableMetadata,
-	records *model.QRecordBatch) (int, error) {
+	stream *model.QRecordStream) (int, error) {
 	bqClient := s.connector.client
 	datasetID := s.connector.datasetID
 	startTime := time.Now()
@@ -46,60 +63,82 @@ func (s *QRepAvroSyncMethod) SyncQRepRecords(
 
 	fmt.Printf("Avro schema: %s\n", avroSchema)
 
+	schema, err := stream.Schema()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema from stream: %w", err)
+	}
+	colNames := schema.GetColumnNames()
+
 	ctx := context.Background()
-	bucket := s.connector.storageClient.Bucket(s.gcsBucket)
-
-	// Create a GCS object name with flowJobName and partitionID
-	gcsObjectName := fmt.Sprintf("%s/%s.avro", flowJobName, partition.PartitionId)
-	obj := bucket.Object(gcsObjectName)
-	w := obj.NewWriter(ctx)
-
-	// Create OCF Writer
-	var ocfFileContents bytes.Buffer
-	ocfWriter, err := goavro.NewOCFWriter(goavro.OCFConfig{
-		W:      &ocfFileContents,
-		Schema: avroSchema,
-	})
+	stg, err := s.openStage()
 	if err != nil {
-		return 0, fmt.Errorf("failed to create OCF writer: %w", err)
+		return 0, fmt.Errorf("failed to open staging path: %w", err)
 	}
+	defer stg.Close()
 
-	// Write each QRecord to the OCF file
-	for _, qRecord := range records.Records {
-		avroConverter := model.NewQRecordAvroConverter(
-			qRecord,
-			qvalue.QDWHTypeBigQuery,
-			&nullable,
-			records.Schema.GetColumnNames(),
-		)
-		avroMap, err := avroConverter.Convert()
-		if err != nil {
-			return 0, fmt.Errorf("failed to convert QRecord to Avro compatible map: %w", err)
-		}
+	// Create an object name with flowJobName and partitionID
+	objectKey := fmt.Sprintf("%s/%s.avro", flowJobName, partition.PartitionId)
 
-		err = ocfWriter.Append([]interface{}{avroMap})
+	// records are uploaded to the stage as they're written.
+	numRecords := 0
+	err = stage.PutStream(ctx, stg, objectKey, func(w io.Writer) error {
+		ocfWriter, err := goavro.NewOCFWriter(goavro.OCFConfig{
+			W:      w,
+			Schema: avroSchema,
+		})
 		if err != nil {
-			return 0, fmt.Errorf("failed to write record to OCF file: %w", err)
+			return fmt.Errorf("failed to create OCF writer: %w", err)
 		}
-	}
 
-	// Write OCF contents to GCS
-	if _, err = w.Write(ocfFileContents.Bytes()); err != nil {
-		return 0, fmt.Errorf("failed to write OCF file to GCS: %w", err)
-	}
+		// Write each QRecord to the OCF file while they're pulled
+		for qRecord := range stream.Records() {
+			avroConverter := model.NewQRecordAvroConverter(
+				qRecord,
+				qvalue.QDWHTypeBigQuery,
+				&nullable,
+				colNames,
+			)
+			avroMap, err := avroConverter.Convert()
+			if err != nil {
+				return fmt.Errorf("failed to convert QRecord to Avro compatible map: %w", err)
+			}
 
-	if err := w.Close(); err != nil {
-		return 0, fmt.Errorf("failed to close GCS object writer: %w", err)
+			err = ocfWriter.Append([]interface{}{avroMap})
+			if err != nil {
+				return fmt.Errorf("failed to write record to OCF file: %w", err)
+			}
+			numRecords++
+		}
+		if err := stream.Err(); err != nil {
+			return fmt.Errorf("failed to pull records: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to write Avro file to %s: %w", stg.URL(), err)
 	}
 
-	// write this file to bigquery
-	gcsRef := bigquery.NewGCSReference(fmt.Sprintf("gs://%s/%s", s.gcsBucket, gcsObjectName))
-	gcsRef.SourceFormat = bigquery.Avro
+	// write this file to bigquery, files outside of GCS are uploaded with the load job.
+	var source bigquery.LoadSource
+	if _, ok := stg.(*stage.GCSStage); ok {
+		gcsRef := bigquery.NewGCSReference(fmt.Sprintf("%s/%s", stg.URL(), objectKey))
+		gcsRef.SourceFormat = bigquery.Avro
+		source = gcsRef
+	} else {
+		r, err := stg.Get(ctx, objectKey)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read Avro file from %s: %w", stg.URL(), err)
+		}
+		defer r.Close()
+		readerSource := bigquery.NewReaderSource(r)
+		readerSource.SourceFormat = bigquery.Avro
+		source = readerSource
+	}
 
 	// create a staging table name with partitionID replace hyphens with underscores
 	stagingTable := fmt.Sprintf("%s_%s_staging", dstTableName, strings.ReplaceAll(partition.PartitionId, "-", "_"))
 
-	loader := bqClient.Dataset(datasetID).Table(stagingTable).LoaderFrom(gcsRef)
+	loader := bqClient.Dataset(datasetID).Table(stagingTable).LoaderFrom(source)
 	loader.UseAvroLogicalTypes = true
 
 	job, err := loader.Run(ctx)
@@ -145,9 +184,14 @@ func (s *QRepAvroSyncMethod) SyncQRepRecords(
 		log.Errorf("failed to delete staging table %s: %v", stagingTable, err)
 	}
 
-	log.Printf("pushed %d records to GCS %s/%s and loaded into %s.%s",
-		len(records.Records), s.gcsBucket, gcsObjectName, datasetID, dstTableName)
-	return len(records.Records), nil
+	// the file is loaded, it isn't needed in the stage anymore.
+	if err := stg.Delete(ctx, objectKey); err != nil {
+		log.Errorf("failed to delete staged file %s: %v", objectKey, err)
+	}
+
+	log.Printf("pushed %d records to %s/%s and loaded into %s.%s",
+		numRecords, stg.URL(), objectKey, datasetID, dstTableName)
+	return numRecords, nil
 }
 
 type AvroField struct {
@@ -198,8 +242,21 @@ func DefineAvroSchema(dstTableName string, dstTableMetadata *bigquery.TableMetad
 }
 
 func GetAvroType(bqField *bigquery.FieldSchema) (interface{}, error) {
+	if bqField.Repeated {
+		elementField := *bqField
+		elementField.Repeated = false
+		itemsType, err := GetAvroType(&elementField)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"type":  "array",
+			"items": itemsType,
+		}, nil
+	}
+
 	switch bqField.Type {
-	case bigquery.StringFieldType:
+	case bigquery.StringFieldType, bigquery.GeographyFieldType:
 		return "string", nil
 	case bigquery.BytesFieldType:
 		return "bytes", nil
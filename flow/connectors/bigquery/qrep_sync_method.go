@@ -18,7 +18,7 @@ type QRepSyncMethod interface {
 		dstTableName string,
 		partition *protos.QRepPartition,
 		dstTableMetadata *bigquery.TableMetadata,
-		records *model.QRecordBatch) (int, error)
+		stream *model.QRecordStream) (int, error)
 }
 
 type QRepStagingTableSync struct {
@@ -30,7 +30,7 @@ func (s *QRepStagingTableSync) SyncQRepRecords(
 	dstTableName string,
 	partition *protos.QRepPartition,
 	dstTableMetadata *bigquery.TableMetadata,
-	records *model.QRecordBatch) (int, error) {
+	stream *model.QRecordStream) (int, error) {
 	partitionID := partition.PartitionId
 
 	startTime := time.Now()
@@ -68,11 +68,17 @@ func (s *QRepStagingTableSync) SyncQRepRecords(
 	// get an inserter for the staging table and insert the records
 	inserter := stagingBQTable.Inserter()
 
-	// Step 2: Insert records into the staging table.
+	schema, err := stream.Schema()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema from stream: %w", err)
+	}
+	colNames := schema.GetColumnNames()
+
+	// Step 2: Insert records into the staging table, while they're pulled.
 	numRowsInserted := 0
-	for _, qRecord := range records.Records {
+	for qRecord := range stream.Records() {
 		toPut := QRecordValueSaver{
-			ColumnNames: records.Schema.GetColumnNames(),
+			ColumnNames: colNames,
 			Record:      qRecord,
 			PartitionID: partitionID,
 			RunID:       runID,
@@ -86,21 +92,25 @@ func (s *QRepStagingTableSync) SyncQRepRecords(
 
 		numRowsInserted++
 	}
+	if err := stream.Err(); err != nil {
+		return -1, fmt.Errorf("failed to pull records: %w", err)
+	}
+
 	// Copy the records into the destination table in a transaction.
 	// append all the statements to one list
 	stmts := []string{}
 	stmts = append(stmts, "BEGIN TRANSACTION;")
 
 	// col names for the destination table joined by comma
-	colNames := []string{}
+	dstColNames := []string{}
 	for _, col := range dstTableMetadata.Schema {
 		if strings.ToLower(col.Name) == "from" {
-			colNames = append(colNames, "`from`")
+			dstColNames = append(dstColNames, "`from`")
 		} else {
-			colNames = append(colNames, col.Name)
+			dstColNames = append(dstColNames, col.Name)
 		}
 	}
-	colNamesStr := strings.Join(colNames, ", ")
+	colNamesStr := strings.Join(dstColNames, ", ")
 
 	paritionSelect := fmt.Sprintf("SELECT %s FROM %s.%s WHERE partitionID = '%s' AND runID = %d;",
 		colNamesStr, s.connector.datasetID, stagingTable, partitionID, runID)
@@ -2,8 +2,12 @@ package connbigquery
 
 import (
 	"fmt"
+	"math/big"
+	"time"
 
 	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"github.com/PeerDB-io/peer-flow/model"
 	"github.com/PeerDB-io/peer-flow/model/qvalue"
 )
 
@@ -39,12 +43,28 @@ func qValueKindToBigQueryType(colType string) bigquery.FieldType {
 	// bytes
 	case qvalue.QValueKindBit, qvalue.QValueKindBytes:
 		return bigquery.BytesFieldType
+	// geospatial types are all stored as GEOGRAPHY
+	case qvalue.QValueKindGeometry, qvalue.QValueKindGeography:
+		return bigquery.GeographyFieldType
+	// arrays are REPEATED columns of their element type
+	case qvalue.QValueKindArrayInt32, qvalue.QValueKindArrayInt64, qvalue.QValueKindArrayFloat32,
+		qvalue.QValueKindArrayFloat64, qvalue.QValueKindArrayBoolean, qvalue.QValueKindArrayString:
+		return qValueKindToBigQueryType(string(qvalue.QValueKind(colType).ArrayElementKind()))
 	// rest will be strings
 	default:
 		return bigquery.StringFieldType
 	}
 }
 
+// qValueKindToBigQueryFieldSchema returns the schema of a column of the given type.
+func qValueKindToBigQueryFieldSchema(colName string, colType string) *bigquery.FieldSchema {
+	return &bigquery.FieldSchema{
+		Name:     colName,
+		Type:     qValueKindToBigQueryType(colType),
+		Repeated: qvalue.QValueKind(colType).IsArray(),
+	}
+}
+
 // bigqueryTypeToQValueKind converts a bigquery FieldType to a QValueKind.
 func BigQueryTypeToQValueKind(fieldType bigquery.FieldType) (qvalue.QValueKind, error) {
 	switch fieldType {
@@ -74,3 +94,52 @@ func BigQueryTypeToQValueKind(fieldType bigquery.FieldType) (qvalue.QValueKind,
 		return "", fmt.Errorf("unsupported bigquery field type: %v", fieldType)
 	}
 }
+
+// bigQuerySchemaToQRecordSchema converts the schema of query results, repeated fields are arrays.
+func bigQuerySchemaToQRecordSchema(schema bigquery.Schema) (*model.QRecordSchema, error) {
+	fields := make([]*model.QField, 0, len(schema))
+	for _, fieldSchema := range schema {
+		qValueKind, err := BigQueryTypeToQValueKind(fieldSchema.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert type of column %s: %w", fieldSchema.Name, err)
+		}
+		if fieldSchema.Repeated {
+			qValueKind = qvalue.QValueKindArray
+		}
+		fields = append(fields, &model.QField{
+			Name:     fieldSchema.Name,
+			Type:     qValueKind,
+			Nullable: !fieldSchema.Required,
+		})
+	}
+	return model.NewQRecordSchema(fields), nil
+}
+
+// bigQueryValueToQValue converts a value of query results, civil dates and times become time.Time in UTC.
+func bigQueryValueToQValue(kind qvalue.QValueKind, value bigquery.Value) (qvalue.QValue, error) {
+	switch v := value.(type) {
+	case nil:
+		return qvalue.QValue{Kind: kind, Value: nil}, nil
+	case civil.Date:
+		return qvalue.QValue{Kind: kind, Value: v.In(time.UTC)}, nil
+	case civil.Time:
+		return qvalue.QValue{Kind: kind, Value: time.Date(1970, 1, 1,
+			v.Hour, v.Minute, v.Second, v.Nanosecond, time.UTC)}, nil
+	case civil.DateTime:
+		return qvalue.QValue{Kind: kind, Value: v.In(time.UTC)}, nil
+	case []bigquery.Value:
+		elements := make([]interface{}, 0, len(v))
+		for _, element := range v {
+			qValue, err := bigQueryValueToQValue(kind, element)
+			if err != nil {
+				return qvalue.QValue{}, err
+			}
+			elements = append(elements, qValue.Value)
+		}
+		return qvalue.QValue{Kind: kind, Value: elements}, nil
+	case int64, float64, bool, string, []byte, time.Time, *big.Rat:
+		return qvalue.QValue{Kind: kind, Value: v}, nil
+	default:
+		return qvalue.QValue{}, fmt.Errorf("unsupported BigQuery value %T", value)
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	connbigquery "github.com/PeerDB-io/peer-flow/connectors/bigquery"
 	conneventhub "github.com/PeerDB-io/peer-flow/connectors/eventhub"
+	connkafka "github.com/PeerDB-io/peer-flow/connectors/kafka"
+	connmysql "github.com/PeerDB-io/peer-flow/connectors/mysql"
 	connpostgres "github.com/PeerDB-io/peer-flow/connectors/postgres"
 	conns3 "github.com/PeerDB-io/peer-flow/connectors/s3"
 	connsnowflake "github.com/PeerDB-io/peer-flow/connectors/snowflake"
@@ -38,11 +40,13 @@ type Connector interface {
 
 	// Methods related to retrieving and pusing records for this connector as a source and destination.
 
-	// PullRecords pulls records from the source, and returns a RecordBatch.
+	// PullRecords pulls records from the source, and sends them to the record stream of the request.
+	// The caller closes the stream once this returns.
 	// This method should be idempotent, and should be able to be called multiple times with the same request.
-	PullRecords(req *model.PullRecordsRequest) (*model.RecordBatch, error)
+	PullRecords(req *model.PullRecordsRequest) error
 
 	// SyncRecords pushes records to the destination peer and stores it in PeerDB specific tables.
+	// Records are read from the stream of the request while they're pulled.
 	// This method should be idempotent, and should be able to be called multiple times with the same request.
 	SyncRecords(req *model.SyncRecordsRequest) (*model.SyncResponse, error)
 
@@ -53,6 +57,13 @@ type Connector interface {
 	// CreateRawTable creates a raw table for the connector with a given name and a fixed schema.
 	CreateRawTable(req *protos.CreateRawTableInput) (*protos.CreateRawTableOutput, error)
 
+	// ReplayTableSchemaDelta changes a destination table to match the schema change of its source table.
+	// This method should be idempotent, and should be able to be called multiple times with the same request.
+	ReplayTableSchemaDelta(flowJobName string, schemaDelta *protos.TableSchemaDelta) error
+
+	// TruncateTables removes the rows of destination tables, before an initial copy is started over.
+	TruncateTables(tableIdentifiers []string) error
+
 	///// QRep methods /////
 
 	// SetupQRepMetadataTables sets up the metadata tables for QRep.
@@ -61,12 +72,13 @@ type Connector interface {
 	// GetQRepPartitions returns the partitions for a given table that haven't been synced yet.
 	GetQRepPartitions(config *protos.QRepConfig, last *protos.QRepPartition) ([]*protos.QRepPartition, error)
 
-	// GetQRepRecords returns the records for a given partition.
-	PullQRepRecords(config *protos.QRepConfig, partition *protos.QRepPartition) (*model.QRecordBatch, error)
+	// PullQRepRecords sends the records for a given partition to the stream while they're pulled.
+	// returns the number of records pulled, the caller closes the stream.
+	PullQRepRecords(config *protos.QRepConfig, partition *protos.QRepPartition, stream *model.QRecordStream) (int, error)
 
-	// SyncQRepRecords syncs the records for a given partition.
+	// SyncQRepRecords syncs the records for a given partition as they're read from the stream.
 	// returns the number of records synced.
-	SyncQRepRecords(config *protos.QRepConfig, partition *protos.QRepPartition, records *model.QRecordBatch) (int, error)
+	SyncQRepRecords(config *protos.QRepConfig, partition *protos.QRepPartition, stream *model.QRecordStream) (int, error)
 
 	// ConsolidateQRepPartitions consolidates the partitions for a given table.
 	ConsolidateQRepPartitions(config *protos.QRepConfig) error
@@ -93,6 +105,10 @@ func GetConnector(ctx context.Context, config *protos.Peer) (Connector, error) {
 		return conns3.NewS3Connector(ctx, config.GetS3Config())
 	case *protos.Peer_SqlserverConfig:
 		return connsqlserver.NewSQLServerConnector(ctx, config.GetSqlserverConfig())
+	case *protos.Peer_KafkaConfig:
+		return connkafka.NewKafkaConnector(ctx, config.GetKafkaConfig())
+	case *protos.Peer_MysqlConfig:
+		return connmysql.NewMySQLConnector(ctx, config.GetMysqlConfig())
 	default:
 		return nil, fmt.Errorf("requested connector is not yet implemented")
 	}
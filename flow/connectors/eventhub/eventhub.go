@@ -13,17 +13,20 @@ import (
 	eventhub "github.com/Azure/azure-event-hubs-go/v3"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/eventhub/armeventhub"
+	connmetadata "github.com/PeerDB-io/peer-flow/connectors/external_metadata"
 	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	debezium "github.com/PeerDB-io/peer-flow/connectors/utils/debezium"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/model"
 	log "github.com/sirupsen/logrus"
 	"go.temporal.io/sdk/activity"
+	"google.golang.org/protobuf/proto"
 )
 
 type EventHubConnector struct {
 	ctx           context.Context
 	config        *protos.EventHubConfig
-	pgMetadata    *PostgresMetadataStore
+	pgMetadata    *connmetadata.PostgresMetadataStore
 	tableSchemas  map[string]*protos.TableSchema
 	creds         *azidentity.DefaultAzureCredential
 	tokenProvider auth.TokenProvider
@@ -47,7 +50,7 @@ func NewEventHubConnector(
 		return nil, err
 	}
 
-	pgMetadata, err := NewPostgresMetadataStore(ctx, config.GetMetadataDb())
+	pgMetadata, err := connmetadata.NewPostgresMetadataStore(ctx, config.GetMetadataDb(), metadataSchema)
 	if err != nil {
 		log.Errorf("failed to create postgres metadata store: %v", err)
 		return nil, err
@@ -99,11 +102,15 @@ func (c *EventHubConnector) SetupReplication(req *protos.SetupReplicationInput)
 }
 
 func (c *EventHubConnector) InitializeTableSchema(req map[string]*protos.TableSchema) error {
-	c.tableSchemas = req
+	c.tableSchemas = make(map[string]*protos.TableSchema, len(req))
+	for dstTableName, schema := range req {
+		// schema changes are applied to the copy, the request is left as it is.
+		c.tableSchemas[dstTableName] = proto.Clone(schema).(*protos.TableSchema)
+	}
 	return nil
 }
 
-func (c *EventHubConnector) PullRecords(req *model.PullRecordsRequest) (*model.RecordBatch, error) {
+func (c *EventHubConnector) PullRecords(req *model.PullRecordsRequest) error {
 	panic("pull records not implemented for event hub")
 }
 
@@ -114,8 +121,26 @@ func (c *EventHubConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 	eventsPerBatch := 100000
 
 	batchPerTopic := make(map[string][]*eventhub.Event)
-	for i, record := range batch.Records {
-		json, err := record.GetItems().ToJSON()
+	numRecords := 0
+	numAppliedSchemaDeltas := 0
+	var firstCP int64
+	for record := range batch.Records() {
+		i := numRecords
+		if i == 0 {
+			firstCP = record.GetCheckPointID()
+		}
+		numRecords++
+
+		// the source adds schema changes before the records with the columns they added.
+		schemaDeltas := batch.DestinationTableSchemaDeltasSince(numAppliedSchemaDeltas)
+		for _, schemaDelta := range schemaDeltas {
+			if err := c.ReplayTableSchemaDelta(req.FlowJobName, schemaDelta); err != nil {
+				return nil, err
+			}
+		}
+		numAppliedSchemaDeltas += len(schemaDeltas)
+
+		json, err := c.encodeRecord(req.FlowJobName, record)
 		if err != nil {
 			log.Errorf("failed to convert record to json: %v", err)
 			return nil, err
@@ -128,7 +153,12 @@ func (c *EventHubConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 			batchPerTopic[topicName] = make([]*eventhub.Event, 0)
 		}
 
-		batchPerTopic[topicName] = append(batchPerTopic[topicName], eventhub.NewEventFromString(json))
+		event := eventhub.NewEventFromString(json)
+		// a truncate has no items, mark it so consumers can tell it apart from an empty row.
+		if _, ok := record.(*model.TruncateRecord); ok {
+			event.Properties = map[string]interface{}{"peerdb_operation": "truncate"}
+		}
+		batchPerTopic[topicName] = append(batchPerTopic[topicName], event)
 
 		if i%eventsPerHeartBeat == 0 {
 			activity.RecordHeartbeat(c.ctx, fmt.Sprintf("sent %d records to hub: %s", i, topicName))
@@ -144,6 +174,11 @@ func (c *EventHubConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 		}
 	}
 
+	// events already sent are sent again with the next batch, the offset is only moved for complete batches.
+	if err := batch.Err(); err != nil {
+		return nil, err
+	}
+
 	// send the remaining events.
 	if len(batchPerTopic) > 0 {
 		err := c.sendEventBatch(batchPerTopic)
@@ -152,21 +187,45 @@ func (c *EventHubConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 		}
 	}
 
-	log.Infof("[total] successfully sent %d records to event hub", len(batch.Records))
+	if numRecords == 0 {
+		return &model.SyncResponse{
+			FirstSyncedCheckPointID: 0,
+			LastSyncedCheckPointID:  0,
+			NumRecordsSynced:        0,
+		}, nil
+	}
+
+	log.Infof("[total] successfully sent %d records to event hub", numRecords)
 
-	err := c.UpdateLastOffset(req.FlowJobName, batch.LastCheckPointID)
+	err := c.UpdateLastOffset(req.FlowJobName, batch.LastCheckPointID())
 	if err != nil {
 		log.Errorf("failed to update last offset: %v", err)
 		return nil, err
 	}
 
 	return &model.SyncResponse{
-		FirstSyncedCheckPointID: batch.FirstCheckPointID,
-		LastSyncedCheckPointID:  batch.LastCheckPointID,
-		NumRecordsSynced:        int64(len(batch.Records)),
+		FirstSyncedCheckPointID: firstCP,
+		LastSyncedCheckPointID:  batch.LastCheckPointID(),
+		NumRecordsSynced:        int64(numRecords),
 	}, nil
 }
 
+// encodeRecord returns the body of the event of a record, in the change event format of the peer.
+func (c *EventHubConnector) encodeRecord(flowJobName string, record model.Record) (string, error) {
+	switch c.config.EventFormat {
+	case protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_ROW:
+		return record.GetItems().ToJSON()
+	case protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_DEBEZIUM:
+		event, err := debezium.EncodeEnvelope(flowJobName, record, c.tableSchemas[record.GetTableName()])
+		if err != nil {
+			return "", err
+		}
+		return string(event), nil
+	default:
+		return "", fmt.Errorf("unsupported change event format %s", c.config.EventFormat)
+	}
+}
+
 func (c *EventHubConnector) sendEventBatch(events map[string][]*eventhub.Event) error {
 	if len(events) == 0 {
 		log.Info("no events to send")
@@ -307,11 +366,30 @@ func (c *EventHubConnector) SetupNormalizedTable(
 	return nil, nil
 }
 
+// NormalizeRecords is a no-op, there are no normalized tables. No response is returned, so the
+// batches synced before it ran count as normalized.
 func (c *EventHubConnector) NormalizeRecords(req *model.NormalizeRecordsRequest) (*model.NormalizeResponse, error) {
 	log.Infof("normalization for event hub is a no-op")
 	return nil, nil
 }
 
+// ReplayTableSchemaDelta adds the new columns to the schema of the table, which only
+// the envelope of the Debezium change event format carries.
+func (c *EventHubConnector) ReplayTableSchemaDelta(flowJobName string,
+	schemaDelta *protos.TableSchemaDelta) error {
+	if schema, ok := c.tableSchemas[schemaDelta.DstTableName]; ok {
+		model.ApplyTableSchemaDelta(schema, schemaDelta)
+	}
+	return nil
+}
+
+// TruncateTables leaves the event hubs as they are, events can't be removed from them. Consumers see the
+// rows of an initial copy that is started over twice.
+func (c *EventHubConnector) TruncateTables(tableIdentifiers []string) error {
+	log.Warnf("events already sent for tables %v are kept", tableIdentifiers)
+	return nil
+}
+
 // cleanup
 
 func (c *EventHubConnector) PullFlowCleanup(jobName string) error {
@@ -15,12 +15,12 @@ func (c *EventHubConnector) GetQRepPartitions(
 }
 
 func (c *EventHubConnector) PullQRepRecords(
-	config *protos.QRepConfig, partition *protos.QRepPartition) (*model.QRecordBatch, error) {
+	config *protos.QRepConfig, partition *protos.QRepPartition, stream *model.QRecordStream) (int, error) {
 	panic("pull qrep records not implemented for eventhub")
 }
 
 func (c *EventHubConnector) SyncQRepRecords(
-	config *protos.QRepConfig, partition *protos.QRepPartition, records *model.QRecordBatch) (int, error) {
+	config *protos.QRepConfig, partition *protos.QRepPartition, stream *model.QRecordStream) (int, error) {
 	panic("sync qrep records not implemented for eventhub")
 }
 
@@ -0,0 +1,290 @@
+package connmetadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// The name of the table that stores the last sync state.
+	lastSyncStateTableName = "last_sync_state"
+	// The name of the table that stores the source positions of checkpoints.
+	checkpointsTableName = "checkpoints"
+)
+
+// PostgresMetadataStore keeps the offsets synced by each mirror of a peer that has nowhere to keep
+// them itself, and the source positions of the checkpoints of sources whose positions don't fit a
+// checkpoint id. Every connector keeps its metadata in a schema of its own.
+type PostgresMetadataStore struct {
+	ctx        context.Context
+	config     *protos.PostgresConfig
+	pool       *pgxpool.Pool
+	schemaName string
+}
+
+func NewPostgresMetadataStore(ctx context.Context, pgConfig *protos.PostgresConfig,
+	schemaName string) (*PostgresMetadataStore, error) {
+	if pgConfig == nil {
+		return nil, fmt.Errorf("no metadata database configured")
+	}
+	connectionString := utils.GetPGConnectionString(pgConfig)
+
+	pool, err := pgxpool.New(ctx, connectionString)
+	if err != nil {
+		log.Errorf("failed to create connection pool: %v", err)
+		return nil, err
+	}
+	log.Infof("created connection pool for metadata store %s", schemaName)
+
+	return &PostgresMetadataStore{
+		ctx:        ctx,
+		config:     pgConfig,
+		pool:       pool,
+		schemaName: schemaName,
+	}, nil
+}
+
+func (p *PostgresMetadataStore) Close() error {
+	if p.pool != nil {
+		p.pool.Close()
+	}
+
+	return nil
+}
+
+func (p *PostgresMetadataStore) lastSyncStateTable() string {
+	return p.schemaName + "." + lastSyncStateTableName
+}
+
+func (p *PostgresMetadataStore) checkpointsTable() string {
+	return p.schemaName + "." + checkpointsTableName
+}
+
+// NeedsSetupMetadata returns whether the schema of the store is missing.
+func (p *PostgresMetadataStore) NeedsSetupMetadata() bool {
+	var exists bool
+	err := p.pool.QueryRow(p.ctx,
+		"SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_namespace WHERE nspname = $1)", p.schemaName).Scan(&exists)
+	if err != nil {
+		log.Errorf("failed to check if schema exists: %v", err)
+		return true
+	}
+
+	return !exists
+}
+
+// SetupMetadata creates the schema and tables of the store.
+func (p *PostgresMetadataStore) SetupMetadata() error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		log.Errorf("failed to start transaction: %v", err)
+		return err
+	}
+	defer func() {
+		// a no-op once the transaction is committed.
+		_ = tx.Rollback(p.ctx)
+	}()
+
+	_, err = tx.Exec(p.ctx, "CREATE SCHEMA IF NOT EXISTS "+p.schemaName)
+	if err != nil {
+		log.Errorf("failed to create schema: %v", err)
+		return err
+	}
+
+	_, err = tx.Exec(p.ctx, `
+		CREATE TABLE IF NOT EXISTS `+p.lastSyncStateTable()+` (
+			job_name TEXT PRIMARY KEY NOT NULL,
+			last_offset BIGINT NOT NULL,
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		log.Errorf("failed to create last sync state table: %v", err)
+		return err
+	}
+
+	_, err = tx.Exec(p.ctx, `
+		CREATE TABLE IF NOT EXISTS `+p.checkpointsTable()+` (
+			job_name TEXT NOT NULL,
+			checkpoint_id BIGINT NOT NULL,
+			position TEXT NOT NULL,
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (job_name, checkpoint_id)
+		)
+	`)
+	if err != nil {
+		log.Errorf("failed to create checkpoints table: %v", err)
+		return err
+	}
+
+	err = tx.Commit(p.ctx)
+	if err != nil {
+		log.Errorf("failed to commit transaction: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// FetchLastOffset returns the offset a job has synced up to, 0 if it hasn't synced yet.
+func (p *PostgresMetadataStore) FetchLastOffset(jobName string) (*protos.LastSyncState, error) {
+	var offset int64
+	err := p.pool.QueryRow(p.ctx, `
+		SELECT last_offset
+		FROM `+p.lastSyncStateTable()+`
+		WHERE job_name = $1
+	`, jobName).Scan(&offset)
+	if err != nil {
+		// if the job doesn't exist, return 0
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &protos.LastSyncState{
+				Checkpoint: 0,
+			}, nil
+		}
+
+		log.Errorf("failed to get last offset: %v", err)
+		return nil, err
+	}
+
+	log.Infof("got last offset for job `%s`: %d", jobName, offset)
+
+	return &protos.LastSyncState{
+		Checkpoint: offset,
+	}, nil
+}
+
+// UpdateLastOffset records the offset a job has synced up to.
+func (p *PostgresMetadataStore) UpdateLastOffset(jobName string, offset int64) error {
+	log.Infof("updating last offset for job `%s` to `%d`", jobName, offset)
+	_, err := p.pool.Exec(p.ctx, `
+		INSERT INTO `+p.lastSyncStateTable()+` (job_name, last_offset)
+		VALUES ($1, $2)
+		ON CONFLICT (job_name)
+		DO UPDATE SET last_offset = $2, updated_at = NOW()
+	`, jobName, offset)
+	if err != nil {
+		log.Errorf("failed to update last offset: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// FetchLastCheckpoint returns the last checkpoint a source has recorded the position of for a job,
+// 0 if it hasn't recorded any yet.
+func (p *PostgresMetadataStore) FetchLastCheckpoint(jobName string) (*protos.LastSyncState, error) {
+	var checkpointID int64
+	err := p.pool.QueryRow(p.ctx, `
+		SELECT COALESCE(MAX(checkpoint_id), 0)
+		FROM `+p.checkpointsTable()+`
+		WHERE job_name = $1
+	`, jobName).Scan(&checkpointID)
+	if err != nil {
+		log.Errorf("failed to get last checkpoint: %v", err)
+		return nil, err
+	}
+
+	log.Infof("got last checkpoint for job `%s`: %d", jobName, checkpointID)
+
+	return &protos.LastSyncState{
+		Checkpoint: checkpointID,
+	}, nil
+}
+
+// GetCheckpointPosition returns the source position recorded for a checkpoint of a job.
+func (p *PostgresMetadataStore) GetCheckpointPosition(jobName string, checkpointID int64) (string, error) {
+	var position string
+	err := p.pool.QueryRow(p.ctx, `
+		SELECT position
+		FROM `+p.checkpointsTable()+`
+		WHERE job_name = $1 AND checkpoint_id = $2
+	`, jobName, checkpointID).Scan(&position)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("no position recorded for checkpoint %d of job %s", checkpointID, jobName)
+		}
+		return "", fmt.Errorf("failed to get position of checkpoint %d: %w", checkpointID, err)
+	}
+
+	return position, nil
+}
+
+// InsertCheckpoint records the source position of a checkpoint, keeping the one already recorded.
+func (p *PostgresMetadataStore) InsertCheckpoint(jobName string, checkpointID int64, position string) error {
+	_, err := p.pool.Exec(p.ctx, `
+		INSERT INTO `+p.checkpointsTable()+` (job_name, checkpoint_id, position)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (job_name, checkpoint_id) DO NOTHING
+	`, jobName, checkpointID, position)
+	if err != nil {
+		return fmt.Errorf("failed to record position of checkpoint %d: %w", checkpointID, err)
+	}
+
+	return nil
+}
+
+// SaveCheckpoint records the source position of the last checkpoint of a pulled batch. Checkpoints
+// before the one the batch was pulled from are forgotten, the destination has synced past them, and
+// so are checkpoints after the batch's, they were recorded by pulls the destination didn't sync.
+func (p *PostgresMetadataStore) SaveCheckpoint(jobName string, startCheckpointID int64, checkpointID int64,
+	position string) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		// a no-op once the transaction is committed.
+		_ = tx.Rollback(p.ctx)
+	}()
+
+	log.Infof("recording position of checkpoint %d for job `%s`", checkpointID, jobName)
+	_, err = tx.Exec(p.ctx, `
+		INSERT INTO `+p.checkpointsTable()+` (job_name, checkpoint_id, position)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (job_name, checkpoint_id)
+		DO UPDATE SET position = $3, updated_at = NOW()
+	`, jobName, checkpointID, position)
+	if err != nil {
+		return fmt.Errorf("failed to record position of checkpoint %d: %w", checkpointID, err)
+	}
+
+	_, err = tx.Exec(p.ctx, `
+		DELETE FROM `+p.checkpointsTable()+`
+		WHERE job_name = $1 AND (checkpoint_id < $2 OR checkpoint_id > $3)
+	`, jobName, startCheckpointID, checkpointID)
+	if err != nil {
+		return fmt.Errorf("failed to delete old checkpoints: %w", err)
+	}
+
+	return tx.Commit(p.ctx)
+}
+
+// DropMetadata removes everything kept for a job.
+func (p *PostgresMetadataStore) DropMetadata(jobName string) error {
+	_, err := p.pool.Exec(p.ctx, `
+		DELETE FROM `+p.lastSyncStateTable()+`
+		WHERE job_name = $1
+	`, jobName)
+	if err != nil {
+		log.Errorf("failed to delete last offset: %v", err)
+		return err
+	}
+
+	_, err = p.pool.Exec(p.ctx, `
+		DELETE FROM `+p.checkpointsTable()+`
+		WHERE job_name = $1
+	`, jobName)
+	if err != nil {
+		log.Errorf("failed to delete checkpoints: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,343 @@
+package connkafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	debezium "github.com/PeerDB-io/peer-flow/connectors/utils/debezium"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/linkedin/goavro/v2"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const (
+	// headers set on every record, so consumers can tell changes apart without decoding the value.
+	operationHeader             = "peerdb_operation"
+	sourceTableHeader           = "peerdb_source_table"
+	checkpointHeader            = "peerdb_checkpoint"
+	unchangedToastColumnsHeader = "peerdb_unchanged_toast_columns"
+)
+
+var invalidAvroNameChars = regexp.MustCompile(`[^A-Za-z0-9_.]`)
+
+// avroTableCodec encodes the rows of a table with the Avro schema derived from its columns.
+type avroTableCodec struct {
+	codec   *goavro.Codec
+	columns []string
+	// id of the schema in the schema registry.
+	schemaID int32
+}
+
+// avroField is a field of the Avro schema of a table. Every field defaults to null, so that
+// schemas with added columns stay backward compatible in the schema registry.
+type avroField struct {
+	Name    string      `json:"name"`
+	Type    interface{} `json:"type"`
+	Default interface{} `json:"default"`
+}
+
+type avroRecordSchema struct {
+	Type   string      `json:"type"`
+	Name   string      `json:"name"`
+	Fields []avroField `json:"fields"`
+}
+
+// recordEncoder turns records into Kafka records. Keys are the JSON of the primary key columns,
+// so that the changes of a row land on the same partition in order.
+type recordEncoder struct {
+	encoding     protos.KafkaValueEncoding
+	eventFormat  protos.ChangeEventFormat
+	tableSchemas map[string]*protos.TableSchema
+	// codecs of the destination tables, built and registered on first use.
+	avroCodecs map[string]*avroTableCodec
+	registry   *schemaRegistryClient
+}
+
+func newRecordEncoder(encoding protos.KafkaValueEncoding, eventFormat protos.ChangeEventFormat,
+	registry *schemaRegistryClient) *recordEncoder {
+	return &recordEncoder{
+		encoding:     encoding,
+		eventFormat:  eventFormat,
+		tableSchemas: make(map[string]*protos.TableSchema),
+		avroCodecs:   make(map[string]*avroTableCodec),
+		registry:     registry,
+	}
+}
+
+// setTableSchema sets the schema of a destination table, values of the table are encoded with it.
+func (e *recordEncoder) setTableSchema(dstTableName string, schema *protos.TableSchema) {
+	e.tableSchemas[dstTableName] = schema
+	delete(e.avroCodecs, dstTableName)
+}
+
+// applySchemaDelta adds the new columns of a table to the schema its values are encoded with.
+func (e *recordEncoder) applySchemaDelta(schemaDelta *protos.TableSchemaDelta) {
+	schema, ok := e.tableSchemas[schemaDelta.DstTableName]
+	if !ok {
+		return
+	}
+	model.ApplyTableSchemaDelta(schema, schemaDelta)
+	e.setTableSchema(schemaDelta.DstTableName, schema)
+}
+
+// encodeStream encodes the records of a stream until it's closed and hands each one to send, along with its
+// position in the stream. Deletes of rows with a key are followed by their tombstone. Schema changes are
+// applied as the source adds them to the stream, before the records with the columns they added.
+// It returns the number of records and the checkpoint of the first one.
+func (e *recordEncoder) encodeStream(serverName string, stream *model.CDCRecordStream,
+	send func(i int, kr *kgo.Record) error) (int, int64, error) {
+	numRecords := 0
+	numAppliedSchemaDeltas := 0
+	var firstCP int64
+	for record := range stream.Records() {
+		if numRecords == 0 {
+			firstCP = record.GetCheckPointID()
+		}
+
+		schemaDeltas := stream.DestinationTableSchemaDeltasSince(numAppliedSchemaDeltas)
+		for _, schemaDelta := range schemaDeltas {
+			e.applySchemaDelta(schemaDelta)
+		}
+		numAppliedSchemaDeltas += len(schemaDeltas)
+
+		kr, err := e.encodeRecord(serverName, record)
+		if err != nil {
+			return 0, 0, err
+		}
+		if err := send(numRecords, kr); err != nil {
+			return 0, 0, err
+		}
+		if _, ok := record.(*model.DeleteRecord); ok && kr.Key != nil {
+			if err := send(numRecords, tombstoneRecord(kr)); err != nil {
+				return 0, 0, err
+			}
+		}
+		numRecords++
+	}
+	return numRecords, firstCP, nil
+}
+
+// encodeRecord returns the Kafka record of a change, written to the topic of its destination table.
+// serverName names the source of the changes in Debezium envelopes.
+func (e *recordEncoder) encodeRecord(serverName string, record model.Record) (*kgo.Record, error) {
+	var operation, sourceTableName, dstTableName string
+	var items model.RecordItems
+	var unchangedToastColumns map[string]bool
+	switch r := record.(type) {
+	case *model.InsertRecord:
+		operation, sourceTableName, dstTableName, items, unchangedToastColumns = "insert", r.SourceTableName,
+			r.DestinationTableName, r.Items, r.UnchangedToastColumns
+	case *model.UpdateRecord:
+		operation, sourceTableName, dstTableName, items, unchangedToastColumns = "update", r.SourceTableName,
+			r.DestinationTableName, r.NewItems, r.UnchangedToastColumns
+	case *model.DeleteRecord:
+		operation, sourceTableName, dstTableName, items = "delete", r.SourceTableName, r.DestinationTableName,
+			r.Items
+	case *model.TruncateRecord:
+		operation, sourceTableName, dstTableName = "truncate", r.SourceTableName, r.DestinationTableName
+	default:
+		return nil, fmt.Errorf("unsupported record type %T", record)
+	}
+
+	kr := &kgo.Record{
+		Topic: dstTableName,
+		Headers: []kgo.RecordHeader{
+			{Key: operationHeader, Value: []byte(operation)},
+			{Key: sourceTableHeader, Value: []byte(sourceTableName)},
+			{Key: checkpointHeader, Value: []byte(strconv.FormatInt(record.GetCheckPointID(), 10))},
+		},
+	}
+	if len(unchangedToastColumns) > 0 {
+		kr.Headers = append(kr.Headers, kgo.RecordHeader{
+			Key:   unchangedToastColumnsHeader,
+			Value: []byte(strings.Join(sortedColumns(unchangedToastColumns), ",")),
+		})
+	}
+
+	// a truncate has no row, it is sent without key, and without value unless it is enveloped.
+	if items != nil {
+		key, err := e.encodeKey(dstTableName, items)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode key of record for %s: %w", dstTableName, err)
+		}
+		kr.Key = key
+	}
+
+	var err error
+	switch {
+	case e.eventFormat == protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_DEBEZIUM:
+		kr.Value, err = debezium.EncodeEnvelope(serverName, record, e.tableSchemas[dstTableName])
+	case items != nil:
+		kr.Value, err = e.encodeValue(dstTableName, items)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value of record for %s: %w", dstTableName, err)
+	}
+
+	return kr, nil
+}
+
+// tombstoneRecord returns the tombstone following the delete of a row: a record with the key of the row
+// and a null value, so that compacted topics drop the earlier records of the row.
+func tombstoneRecord(deleteRecord *kgo.Record) *kgo.Record {
+	headers := make([]kgo.RecordHeader, 0, len(deleteRecord.Headers))
+	for _, header := range deleteRecord.Headers {
+		if header.Key == operationHeader {
+			header.Value = []byte("tombstone")
+		}
+		headers = append(headers, header)
+	}
+	return &kgo.Record{
+		Topic:   deleteRecord.Topic,
+		Key:     deleteRecord.Key,
+		Headers: headers,
+	}
+}
+
+// encodeKey returns the JSON of the primary key columns of a row, nil for tables without a key.
+func (e *recordEncoder) encodeKey(dstTableName string, items model.RecordItems) ([]byte, error) {
+	schema, ok := e.tableSchemas[dstTableName]
+	if !ok || len(schema.PrimaryKeyColumns) == 0 {
+		return nil, nil
+	}
+
+	keyItems := make(model.RecordItems, len(schema.PrimaryKeyColumns))
+	for _, col := range schema.PrimaryKeyColumns {
+		val, ok := items[col]
+		if !ok {
+			return nil, fmt.Errorf("row is missing key column %s", col)
+		}
+		keyItems[col] = val
+	}
+
+	key, err := keyItems.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(key), nil
+}
+
+func (e *recordEncoder) encodeValue(dstTableName string, items model.RecordItems) ([]byte, error) {
+	switch e.encoding {
+	case protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_JSON:
+		value, err := items.ToJSON()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(value), nil
+	case protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_AVRO:
+		tableCodec, err := e.getAvroCodec(dstTableName)
+		if err != nil {
+			return nil, err
+		}
+
+		// rows of updates and deletes may not carry every column, missing columns are null.
+		native := make(map[string]interface{}, len(tableCodec.columns))
+		for _, col := range tableCodec.columns {
+			val, ok := items[col]
+			if !ok {
+				native[col] = nil
+				continue
+			}
+			// avro has no 16 bit integers, smallints are written as longs.
+			if i16, ok := val.Value.(int16); ok {
+				val.Value = int64(i16)
+			}
+			avroVal, err := qvalue.NewQValueAvroConverter(&val, 0, true).ToAvroValue()
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert column %s to avro: %w", col, err)
+			}
+			native[col] = avroVal
+		}
+		return tableCodec.codec.BinaryFromNative(wireFormatHeader(tableCodec.schemaID), native)
+	default:
+		return nil, fmt.Errorf("unsupported value encoding %s", e.encoding)
+	}
+}
+
+// getAvroCodec returns the codec of a destination table, registering its schema under the subject
+// <topic>-value. Every field is nullable, as the rows of updates and deletes may only carry some
+// of the columns.
+func (e *recordEncoder) getAvroCodec(dstTableName string) (*avroTableCodec, error) {
+	if tableCodec, ok := e.avroCodecs[dstTableName]; ok {
+		return tableCodec, nil
+	}
+
+	schema, ok := e.tableSchemas[dstTableName]
+	if !ok {
+		return nil, fmt.Errorf("no schema for table %s", dstTableName)
+	}
+
+	columns := make([]string, 0, len(schema.Columns))
+	for col := range schema.Columns {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+
+	fields := make([]avroField, 0, len(columns))
+	for _, col := range columns {
+		kind := qvalue.QValueKind(schema.Columns[col])
+		var avroType interface{}
+		switch kind {
+		case qvalue.QValueKindTime, qvalue.QValueKindTimeTZ, qvalue.QValueKindDate,
+			qvalue.QValueKindTimestamp, qvalue.QValueKindTimestampTZ:
+			// times are converted to microseconds since the epoch.
+			avroType = map[string]string{"type": "long", "logicalType": "timestamp-micros"}
+		default:
+			kindSchema, err := qvalue.GetAvroSchemaFromQValueKind(kind, true)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", col, err)
+			}
+			avroType = kindSchema.AvroLogicalSchema
+		}
+		fields = append(fields, avroField{
+			Name: col,
+			Type: []interface{}{"null", avroType},
+		})
+	}
+
+	avroSchema, err := json.Marshal(avroRecordSchema{
+		Type:   "record",
+		Name:   invalidAvroNameChars.ReplaceAllString(dstTableName, "_"),
+		Fields: fields,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal avro schema: %w", err)
+	}
+
+	codec, err := goavro.NewCodec(string(avroSchema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create avro codec for table %s: %w", dstTableName, err)
+	}
+
+	if e.registry == nil {
+		return nil, fmt.Errorf("avro values need a schema registry")
+	}
+	schemaID, err := e.registry.register(dstTableName+"-value", string(avroSchema))
+	if err != nil {
+		return nil, err
+	}
+
+	tableCodec := &avroTableCodec{
+		codec:    codec,
+		columns:  columns,
+		schemaID: schemaID,
+	}
+	e.avroCodecs[dstTableName] = tableCodec
+	return tableCodec, nil
+}
+
+func sortedColumns(columns map[string]bool) []string {
+	names := make([]string, 0, len(columns))
+	for col := range columns {
+		names = append(names, col)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,366 @@
+package connkafka
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// fakeSchemaRegistry records the schemas registered with it, giving each schema of a subject a new id.
+type fakeSchemaRegistry struct {
+	server   *httptest.Server
+	schemas  map[string][]string
+	username string
+}
+
+func newFakeSchemaRegistry(t *testing.T) *fakeSchemaRegistry {
+	registry := &fakeSchemaRegistry{schemas: make(map[string][]string)}
+	registry.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/subjects/") ||
+			!strings.HasSuffix(r.URL.Path, "/versions") {
+			http.NotFound(w, r)
+			return
+		}
+		subject := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/subjects/"), "/versions")
+		registry.username, _, _ = r.BasicAuth()
+
+		var body struct {
+			Schema string `json:"schema"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		registry.schemas[subject] = append(registry.schemas[subject], body.Schema)
+		fmt.Fprintf(w, `{"id":%d}`, 100*len(registry.schemas)+len(registry.schemas[subject]))
+	}))
+	t.Cleanup(registry.server.Close)
+	return registry
+}
+
+func (r *fakeSchemaRegistry) client() *schemaRegistryClient {
+	return newSchemaRegistryClient(context.Background(), r.server.URL+"/", "peerdb", "secret")
+}
+
+func testEncoder(encoding protos.KafkaValueEncoding, eventFormat protos.ChangeEventFormat) *recordEncoder {
+	return testEncoderWithRegistry(encoding, eventFormat, nil)
+}
+
+func testEncoderWithRegistry(encoding protos.KafkaValueEncoding, eventFormat protos.ChangeEventFormat,
+	registry *schemaRegistryClient) *recordEncoder {
+	encoder := newRecordEncoder(encoding, eventFormat, registry)
+	encoder.setTableSchema("public.users", &protos.TableSchema{
+		TableIdentifier: "public.users",
+		Columns: map[string]string{
+			"id":         string(qvalue.QValueKindInt32),
+			"name":       string(qvalue.QValueKindString),
+			"age":        string(qvalue.QValueKindInt16),
+			"created_at": string(qvalue.QValueKindTimestamp),
+		},
+		PrimaryKeyColumns: []string{"id"},
+	})
+	return encoder
+}
+
+func headerValue(t *testing.T, headers map[string]string, key string) string {
+	value, ok := headers[key]
+	require.True(t, ok, "missing header %s", key)
+	return value
+}
+
+func TestEncodeRecordJSON(t *testing.T) {
+	encoder := testEncoder(protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_JSON,
+		protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_ROW)
+
+	kr, err := encoder.encodeRecord("test_flow", &model.UpdateRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+		CheckPointID:         42,
+		NewItems: model.RecordItems{
+			"id":   qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(1)},
+			"name": qvalue.QValue{Kind: qvalue.QValueKindString, Value: "ada"},
+		},
+		UnchangedToastColumns: map[string]bool{"bio": true},
+	})
+	require.NoError(t, err)
+
+	headers := make(map[string]string)
+	for _, header := range kr.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	assert.Equal(t, "public.users", kr.Topic)
+	assert.Equal(t, `{"id":1}`, string(kr.Key))
+	assert.JSONEq(t, `{"id":1,"name":"ada"}`, string(kr.Value))
+	assert.Equal(t, "update", headerValue(t, headers, operationHeader))
+	assert.Equal(t, "42", headerValue(t, headers, checkpointHeader))
+	assert.Equal(t, "bio", headerValue(t, headers, unchangedToastColumnsHeader))
+
+	// a truncate has no row to encode.
+	kr, err = encoder.encodeRecord("test_flow", &model.TruncateRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+	})
+	require.NoError(t, err)
+	assert.Nil(t, kr.Key)
+	assert.Nil(t, kr.Value)
+}
+
+func TestEncodeRecordAvro(t *testing.T) {
+	registry := newFakeSchemaRegistry(t)
+	encoder := testEncoderWithRegistry(protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_AVRO,
+		protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_ROW, registry.client())
+	createdAt := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	kr, err := encoder.encodeRecord("test_flow", &model.InsertRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+		Items: model.RecordItems{
+			"id":         qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(1)},
+			"age":        qvalue.QValue{Kind: qvalue.QValueKindInt16, Value: int16(36)},
+			"created_at": qvalue.QValue{Kind: qvalue.QValueKindTimestamp, Value: createdAt},
+		},
+	})
+	require.NoError(t, err)
+
+	// the schema of the value is registered under the subject of the topic.
+	require.Len(t, registry.schemas["public.users-value"], 1)
+	assert.Equal(t, "peerdb", registry.username)
+	var schema struct {
+		Fields []map[string]interface{} `json:"fields"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(registry.schemas["public.users-value"][0]), &schema))
+	for _, field := range schema.Fields {
+		assert.Contains(t, field, "default")
+		assert.Nil(t, field["default"])
+	}
+
+	// values are in the wire format, prefixed with the id of their schema.
+	require.Greater(t, len(kr.Value), 5)
+	assert.Equal(t, byte(0), kr.Value[0])
+	assert.Equal(t, uint32(101), binary.BigEndian.Uint32(kr.Value[1:5]))
+	tableCodec, err := encoder.getAvroCodec("public.users")
+	require.NoError(t, err)
+	native, rest, err := tableCodec.codec.NativeFromBinary(kr.Value[5:])
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+
+	row := native.(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"long": int64(1)}, row["id"])
+	assert.Equal(t, map[string]interface{}{"long": int64(36)}, row["age"])
+	// columns missing from the row are null.
+	assert.Nil(t, row["name"])
+	assert.Equal(t, map[string]interface{}{"long.timestamp-micros": createdAt}, row["created_at"])
+}
+
+func TestEncodeRecordDebezium(t *testing.T) {
+	encoder := testEncoder(protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_JSON,
+		protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_DEBEZIUM)
+
+	kr, err := encoder.encodeRecord("test_flow", &model.DeleteRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+		Items: model.RecordItems{
+			"id": qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(1)},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1}`, string(kr.Key))
+
+	var event struct {
+		Payload struct {
+			Before map[string]interface{} `json:"before"`
+			After  map[string]interface{} `json:"after"`
+			Op     string                 `json:"op"`
+		} `json:"payload"`
+	}
+	require.NoError(t, json.Unmarshal(kr.Value, &event))
+	assert.Equal(t, "d", event.Payload.Op)
+	assert.Equal(t, map[string]interface{}{"id": float64(1)}, event.Payload.Before)
+	assert.Nil(t, event.Payload.After)
+
+	// enveloped truncates carry their operation in the value too.
+	kr, err = encoder.encodeRecord("test_flow", &model.TruncateRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+	})
+	require.NoError(t, err)
+	assert.Nil(t, kr.Key)
+	require.NoError(t, json.Unmarshal(kr.Value, &event))
+	assert.Equal(t, "t", event.Payload.Op)
+}
+
+func TestEncodeRecordAvroSchemaChange(t *testing.T) {
+	registry := newFakeSchemaRegistry(t)
+	encoder := testEncoderWithRegistry(protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_AVRO,
+		protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_ROW, registry.client())
+	insert := &model.InsertRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+		Items: model.RecordItems{
+			"id": qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(1)},
+		},
+	}
+
+	_, err := encoder.encodeRecord("test_flow", insert)
+	require.NoError(t, err)
+	_, err = encoder.encodeRecord("test_flow", insert)
+	require.NoError(t, err)
+	require.Len(t, registry.schemas["public.users-value"], 1)
+
+	// a new column registers a new version of the schema.
+	schema := encoder.tableSchemas["public.users"]
+	schema.Columns["email"] = string(qvalue.QValueKindString)
+	encoder.setTableSchema("public.users", schema)
+	kr, err := encoder.encodeRecord("test_flow", insert)
+	require.NoError(t, err)
+	require.Len(t, registry.schemas["public.users-value"], 2)
+	assert.Contains(t, registry.schemas["public.users-value"][1], `"email"`)
+	assert.Equal(t, uint32(102), binary.BigEndian.Uint32(kr.Value[1:5]))
+}
+
+func TestEncodeRecordAvroNeedsSchemaRegistry(t *testing.T) {
+	encoder := testEncoder(protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_AVRO,
+		protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_ROW)
+	_, err := encoder.encodeRecord("test_flow", &model.InsertRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+		Items:                model.RecordItems{"id": qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(1)}},
+	})
+	require.ErrorContains(t, err, "schema registry")
+}
+
+func TestEncodeRecordTopics(t *testing.T) {
+	encoder := testEncoder(protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_JSON,
+		protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_ROW)
+	schema := encoder.tableSchemas["public.users"]
+	encoder.setTableSchema("users_changes", schema)
+	items := model.RecordItems{"id": qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(1)}}
+
+	// every change is written to the topic of its destination table, not the one of its source table.
+	for _, record := range []model.Record{
+		&model.InsertRecord{SourceTableName: "public.users", DestinationTableName: "users_changes", Items: items},
+		&model.UpdateRecord{SourceTableName: "public.users", DestinationTableName: "users_changes", NewItems: items},
+		&model.DeleteRecord{SourceTableName: "public.users", DestinationTableName: "users_changes", Items: items},
+		&model.TruncateRecord{SourceTableName: "public.users", DestinationTableName: "users_changes"},
+	} {
+		kr, err := encoder.encodeRecord("test_flow", record)
+		require.NoError(t, err)
+		assert.Equal(t, "users_changes", kr.Topic, "%T", record)
+	}
+}
+
+func TestEncodeStreamAvroColumnAddedMidBatch(t *testing.T) {
+	registry := newFakeSchemaRegistry(t)
+	encoder := testEncoderWithRegistry(protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_AVRO,
+		protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_ROW, registry.client())
+	newInsert := func(items model.RecordItems) *model.InsertRecord {
+		return &model.InsertRecord{
+			SourceTableName:      "public.users",
+			DestinationTableName: "public.users",
+			Items:                items,
+		}
+	}
+
+	stream := model.NewCDCRecordStream(4, nil)
+	require.NoError(t, stream.Send(newInsert(model.RecordItems{
+		"id": qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(1)},
+	})))
+
+	var values [][]byte
+	numRecords, _, err := encoder.encodeStream("test_flow", stream, func(i int, kr *kgo.Record) error {
+		values = append(values, kr.Value)
+		if i == 0 {
+			// the source adds the schema change before the first record with the added column.
+			stream.AddSchemaDelta(&protos.TableSchemaDelta{
+				SrcTableName: "public.users",
+				DstTableName: "public.users",
+				AddedColumns: []*protos.DeltaColumn{{ColumnName: "email", ColumnType: string(qvalue.QValueKindString)}},
+			})
+			require.NoError(t, stream.Send(newInsert(model.RecordItems{
+				"id":    qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(2)},
+				"email": qvalue.QValue{Kind: qvalue.QValueKindString, Value: "ada@example.com"},
+			})))
+			stream.Close(nil)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, numRecords)
+
+	// the second record is encoded with a new version of the schema, carrying the added column.
+	require.Len(t, registry.schemas["public.users-value"], 2)
+	assert.NotContains(t, registry.schemas["public.users-value"][0], `"email"`)
+	assert.Equal(t, uint32(101), binary.BigEndian.Uint32(values[0][1:5]))
+	assert.Equal(t, uint32(102), binary.BigEndian.Uint32(values[1][1:5]))
+	tableCodec, err := encoder.getAvroCodec("public.users")
+	require.NoError(t, err)
+	native, _, err := tableCodec.codec.NativeFromBinary(values[1][5:])
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"string": "ada@example.com"}, native.(map[string]interface{})["email"])
+}
+
+func recordHeaders(kr *kgo.Record) map[string]string {
+	headers := make(map[string]string)
+	for _, header := range kr.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	return headers
+}
+
+func TestEncodeStreamDeleteTombstone(t *testing.T) {
+	encoder := testEncoder(protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_JSON,
+		protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_ROW)
+	encoder.setTableSchema("public.events", &protos.TableSchema{
+		TableIdentifier: "public.events",
+		Columns:         map[string]string{"msg": string(qvalue.QValueKindString)},
+	})
+
+	stream := model.NewCDCRecordStream(2, nil)
+	require.NoError(t, stream.Send(&model.DeleteRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+		Items: model.RecordItems{
+			"id": qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(1)},
+		},
+	}))
+	// rows of tables without a key have no key to compact on.
+	require.NoError(t, stream.Send(&model.DeleteRecord{
+		SourceTableName:      "public.events",
+		DestinationTableName: "public.events",
+		Items: model.RecordItems{
+			"msg": qvalue.QValue{Kind: qvalue.QValueKindString, Value: "hello"},
+		},
+	}))
+	stream.Close(nil)
+
+	var records []*kgo.Record
+	numRecords, _, err := encoder.encodeStream("test_flow", stream, func(i int, kr *kgo.Record) error {
+		records = append(records, kr)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, numRecords)
+	require.Len(t, records, 3)
+
+	// the delete is followed by a record with the same key and a null value.
+	assert.NotNil(t, records[0].Value)
+	assert.Equal(t, "public.users", records[1].Topic)
+	assert.Equal(t, records[0].Key, records[1].Key)
+	assert.Nil(t, records[1].Value)
+	assert.Equal(t, "tombstone", headerValue(t, recordHeaders(records[1]), operationHeader))
+	assert.Equal(t, "delete", headerValue(t, recordHeaders(records[0]), operationHeader))
+	assert.Equal(t, "public.events", records[2].Topic)
+}
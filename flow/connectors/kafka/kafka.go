@@ -0,0 +1,274 @@
+package connkafka
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	connmetadata "github.com/PeerDB-io/peer-flow/connectors/external_metadata"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	log "github.com/sirupsen/logrus"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+	"go.temporal.io/sdk/activity"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	recordsPerHeartBeat = 1000
+	// records handed to the producer at once, matches its default buffer size.
+	recordsPerProduce = 10000
+)
+
+// KafkaConnector writes the changes of each table to a topic named after its destination table.
+// The producer is idempotent, so retries within a sync don't duplicate records, but a sync that
+// is retried as a whole writes its records again. Consumers can use the checkpoint header to
+// drop records they have already seen.
+type KafkaConnector struct {
+	ctx        context.Context
+	config     *protos.KafkaConfig
+	client     *kgo.Client
+	pgMetadata *connmetadata.PostgresMetadataStore
+	encoder    *recordEncoder
+}
+
+// NewKafkaConnector creates a new KafkaConnector.
+func NewKafkaConnector(
+	ctx context.Context,
+	config *protos.KafkaConfig,
+) (*KafkaConnector, error) {
+	if config.EventFormat == protos.ChangeEventFormat_CHANGE_EVENT_FORMAT_DEBEZIUM &&
+		config.ValueEncoding != protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_JSON {
+		return nil, fmt.Errorf("the debezium change event format is only supported with json values")
+	}
+	var registry *schemaRegistryClient
+	if config.ValueEncoding == protos.KafkaValueEncoding_KAFKA_VALUE_ENCODING_AVRO {
+		if config.SchemaRegistryUrl == "" {
+			return nil, fmt.Errorf("avro values need a schema registry to register their schemas with")
+		}
+		registry = newSchemaRegistryClient(ctx, config.SchemaRegistryUrl,
+			config.SchemaRegistryUsername, config.SchemaRegistryPassword)
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(config.Servers...),
+		// idempotent writes need the acknowledgement of every in-sync replica.
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+	}
+	if !config.DisableTls {
+		opts = append(opts, kgo.DialTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+	switch config.SaslMechanism {
+	case "":
+	case "PLAIN":
+		opts = append(opts, kgo.SASL(plain.Auth{User: config.Username, Pass: config.Password}.AsMechanism()))
+	case "SCRAM-SHA-256":
+		opts = append(opts, kgo.SASL(scram.Auth{User: config.Username, Pass: config.Password}.AsSha256Mechanism()))
+	case "SCRAM-SHA-512":
+		opts = append(opts, kgo.SASL(scram.Auth{User: config.Username, Pass: config.Password}.AsSha512Mechanism()))
+	default:
+		return nil, fmt.Errorf("unsupported sasl mechanism %s", config.SaslMechanism)
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		log.Errorf("failed to create kafka client: %v", err)
+		return nil, err
+	}
+
+	pgMetadata, err := connmetadata.NewPostgresMetadataStore(ctx, config.GetMetadataDb(), metadataSchema)
+	if err != nil {
+		log.Errorf("failed to create postgres metadata store: %v", err)
+		client.Close()
+		return nil, err
+	}
+
+	return &KafkaConnector{
+		ctx:        ctx,
+		config:     config,
+		client:     client,
+		pgMetadata: pgMetadata,
+		encoder:    newRecordEncoder(config.ValueEncoding, config.EventFormat, registry),
+	}, nil
+}
+
+func (c *KafkaConnector) Close() error {
+	c.client.Close()
+
+	err := c.pgMetadata.Close()
+	if err != nil {
+		log.Errorf("failed to close postgres metadata store: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *KafkaConnector) ConnectionActive() bool {
+	return c.client.Ping(c.ctx) == nil
+}
+
+func (c *KafkaConnector) EnsurePullability(
+	req *protos.EnsurePullabilityInput) (*protos.EnsurePullabilityOutput, error) {
+	panic("ensure pullability not implemented for kafka")
+}
+
+func (c *KafkaConnector) SetupReplication(req *protos.SetupReplicationInput) error {
+	panic("setup replication not implemented for kafka")
+}
+
+func (c *KafkaConnector) GetTableSchema(req *protos.GetTableSchemaInput) (*protos.TableSchema, error) {
+	panic("get table schema not implemented for kafka")
+}
+
+func (c *KafkaConnector) PullRecords(req *model.PullRecordsRequest) error {
+	panic("pull records not implemented for kafka")
+}
+
+func (c *KafkaConnector) PullFlowCleanup(jobName string) error {
+	panic("pull flow cleanup not implemented for kafka")
+}
+
+// InitializeTableSchema sets the schemas the keys and values of records are encoded with.
+func (c *KafkaConnector) InitializeTableSchema(req map[string]*protos.TableSchema) error {
+	for dstTableName, schema := range req {
+		// schema changes are applied to the copy, the request is left as it is.
+		c.encoder.setTableSchema(dstTableName, proto.Clone(schema).(*protos.TableSchema))
+	}
+	return nil
+}
+
+// CreateRawTable creates the topics of the destination tables, there are no raw tables on Kafka.
+func (c *KafkaConnector) CreateRawTable(req *protos.CreateRawTableInput) (*protos.CreateRawTableOutput, error) {
+	topics := make([]string, 0, len(req.TableNameMapping))
+	for _, dstTableName := range req.TableNameMapping {
+		topics = append(topics, dstTableName)
+	}
+
+	// -1 leaves the number of partitions and replicas to the broker defaults.
+	partitions := c.config.Partitions
+	if partitions == 0 {
+		partitions = -1
+	}
+	replicationFactor := int16(c.config.ReplicationFactor)
+	if replicationFactor == 0 {
+		replicationFactor = -1
+	}
+
+	admin := kadm.NewClient(c.client)
+	responses, err := admin.CreateTopics(c.ctx, partitions, replicationFactor, nil, topics...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create topics: %w", err)
+	}
+	for _, response := range responses.Sorted() {
+		if response.Err != nil && !errors.Is(response.Err, kerr.TopicAlreadyExists) {
+			return nil, fmt.Errorf("failed to create topic %s: %w", response.Topic, response.Err)
+		}
+		log.Infof("topic %s is ready for flow job %s", response.Topic, req.FlowJobName)
+	}
+
+	return &protos.CreateRawTableOutput{}, nil
+}
+
+// SyncRecords writes the records to the topics of their tables and records the offset synced.
+func (c *KafkaConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.SyncResponse, error) {
+	batch := req.Records
+
+	records := make([]*kgo.Record, 0, recordsPerProduce)
+	numRecords, firstCP, err := c.encoder.encodeStream(req.FlowJobName, batch, func(i int, kr *kgo.Record) error {
+		records = append(records, kr)
+
+		if i%recordsPerHeartBeat == 0 {
+			activity.RecordHeartbeat(c.ctx, fmt.Sprintf("sent %d records to kafka", i))
+		}
+
+		if len(records) == recordsPerProduce {
+			if err := c.produce(records); err != nil {
+				return err
+			}
+			records = records[:0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// records already produced are produced again with the next batch,
+	// the offset is only moved for complete batches.
+	if err := batch.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := c.produce(records); err != nil {
+		return nil, err
+	}
+
+	if numRecords == 0 {
+		return &model.SyncResponse{
+			FirstSyncedCheckPointID: 0,
+			LastSyncedCheckPointID:  0,
+			NumRecordsSynced:        0,
+		}, nil
+	}
+
+	log.Infof("[total] successfully sent %d records to kafka", numRecords)
+
+	err = c.UpdateLastOffset(req.FlowJobName, batch.LastCheckPointID())
+	if err != nil {
+		log.Errorf("failed to update last offset: %v", err)
+		return nil, err
+	}
+
+	return &model.SyncResponse{
+		FirstSyncedCheckPointID: firstCP,
+		LastSyncedCheckPointID:  batch.LastCheckPointID(),
+		NumRecordsSynced:        int64(numRecords),
+	}, nil
+}
+
+// produce writes records and waits for all of them to be acknowledged.
+func (c *KafkaConnector) produce(records []*kgo.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Minute)
+	defer cancel()
+
+	if err := c.client.ProduceSync(ctx, records...).FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce records: %w", err)
+	}
+	return nil
+}
+
+// Normalization
+
+func (c *KafkaConnector) SetupNormalizedTable(
+	req *protos.SetupNormalizedTableInput) (*protos.SetupNormalizedTableOutput, error) {
+	log.Infof("normalization for kafka is a no-op")
+	return &protos.SetupNormalizedTableOutput{
+		TableIdentifier: req.TableIdentifier,
+	}, nil
+}
+
+// NormalizeRecords is a no-op, there are no normalized tables. No response is returned, so the
+// batches synced before it ran count as normalized.
+func (c *KafkaConnector) NormalizeRecords(req *model.NormalizeRecordsRequest) (*model.NormalizeResponse, error) {
+	log.Infof("normalization for kafka is a no-op")
+	return nil, nil
+}
+
+// ReplayTableSchemaDelta adds the new columns to the schema values are encoded with. Schema changes
+// are already applied while syncing the batch they're part of.
+func (c *KafkaConnector) ReplayTableSchemaDelta(flowJobName string,
+	schemaDelta *protos.TableSchemaDelta) error {
+	c.encoder.applySchemaDelta(schemaDelta)
+	return nil
+}
@@ -0,0 +1,40 @@
+package connkafka
+
+import (
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// schema for the peerdb metadata, Kafka itself has nowhere to keep the offsets synced by each mirror.
+	metadataSchema = "peerdb_kafka_metadata"
+)
+
+func (c *KafkaConnector) NeedsSetupMetadataTables() bool {
+	return c.pgMetadata.NeedsSetupMetadata()
+}
+
+func (c *KafkaConnector) SetupMetadataTables() error {
+	return c.pgMetadata.SetupMetadata()
+}
+
+func (c *KafkaConnector) GetLastOffset(jobName string) (*protos.LastSyncState, error) {
+	return c.pgMetadata.FetchLastOffset(jobName)
+}
+
+// UpdateLastOffset records the offset a job has synced up to.
+func (c *KafkaConnector) UpdateLastOffset(jobName string, offset int64) error {
+	return c.pgMetadata.UpdateLastOffset(jobName, offset)
+}
+
+// TruncateTables leaves the topics as they are, messages can't be removed from them. Consumers see the
+// rows of an initial copy that is started over twice.
+func (c *KafkaConnector) TruncateTables(tableIdentifiers []string) error {
+	log.Warnf("messages already produced for tables %v are kept", tableIdentifiers)
+	return nil
+}
+
+// SyncFlowCleanup removes the offsets of a job, the topics are left in place.
+func (c *KafkaConnector) SyncFlowCleanup(jobName string) error {
+	return c.pgMetadata.DropMetadata(jobName)
+}
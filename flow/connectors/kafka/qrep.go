@@ -0,0 +1,33 @@
+package connkafka
+
+import (
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+)
+
+func (c *KafkaConnector) SetupQRepMetadataTables(config *protos.QRepConfig) error {
+	panic("setup qrep metadata tables not implemented for kafka")
+}
+
+func (c *KafkaConnector) GetQRepPartitions(
+	config *protos.QRepConfig, last *protos.QRepPartition) ([]*protos.QRepPartition, error) {
+	panic("get qrep partitions not implemented for kafka")
+}
+
+func (c *KafkaConnector) PullQRepRecords(
+	config *protos.QRepConfig, partition *protos.QRepPartition, stream *model.QRecordStream) (int, error) {
+	panic("pull qrep records not implemented for kafka")
+}
+
+func (c *KafkaConnector) SyncQRepRecords(
+	config *protos.QRepConfig, partition *protos.QRepPartition, stream *model.QRecordStream) (int, error) {
+	panic("sync qrep records not implemented for kafka")
+}
+
+func (c *KafkaConnector) ConsolidateQRepPartitions(config *protos.QRepConfig) error {
+	panic("consolidate qrep partitions not implemented for kafka")
+}
+
+func (c *KafkaConnector) CleanupQRepFlow(config *protos.QRepConfig) error {
+	panic("cleanup qrep flow not implemented for kafka")
+}
@@ -0,0 +1,88 @@
+package connkafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const schemaRegistryContentType = "application/vnd.schemaregistry.v1+json"
+
+// schemaRegistryClient registers the Avro schemas of values with a Confluent compatible schema registry,
+// consumers look the schemas up by the id each value is prefixed with.
+type schemaRegistryClient struct {
+	ctx      context.Context
+	url      string
+	username string
+	password string
+	client   *http.Client
+}
+
+func newSchemaRegistryClient(ctx context.Context, registryURL string, username string,
+	password string) *schemaRegistryClient {
+	return &schemaRegistryClient{
+		ctx:      ctx,
+		url:      strings.TrimSuffix(registryURL, "/"),
+		username: username,
+		password: password,
+		client:   &http.Client{Timeout: time.Minute},
+	}
+}
+
+// register registers a schema under a subject and returns its id. Registering a schema that is
+// already registered returns the id it was given then.
+func (r *schemaRegistryClient) register(subject string, schema string) (int32, error) {
+	body, err := json.Marshal(map[string]string{"schema": schema})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodPost,
+		fmt.Sprintf("%s/subjects/%s/versions", r.url, url.PathEscape(subject)), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", schemaRegistryContentType)
+	req.Header.Set("Accept", schemaRegistryContentType)
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to register schema of subject %s: %w", subject, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema registry response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to register schema of subject %s: %s: %s",
+			subject, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	var registered struct {
+		ID int32 `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &registered); err != nil {
+		return 0, fmt.Errorf("invalid schema registry response: %w", err)
+	}
+	return registered.ID, nil
+}
+
+// wireFormatHeader returns the header of values in the Confluent wire format,
+// a zero magic byte followed by the big endian id of the schema of the value.
+func wireFormatHeader(schemaID int32) []byte {
+	header := make([]byte, 5, 64)
+	binary.BigEndian.PutUint32(header[1:], uint32(schemaID))
+	return header
+}
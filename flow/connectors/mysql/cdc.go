@@ -0,0 +1,351 @@
+package connmysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	gomysql "github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	log "github.com/sirupsen/logrus"
+)
+
+// mysqlCDCSource turns the row events of the binlog into records. Records are buffered until their
+// transaction commits, so that a batch always ends at a commit with a known GTID set.
+type mysqlCDCSource struct {
+	connector *MySQLConnector
+	req       *model.PullRecordsRequest
+	// source table names of the mirror, keyed by database.table as the binlog names them.
+	srcTableNames map[string]string
+	// columns of the source tables as the table map events of the binlog describe them, keyed by
+	// table id. A table gets a new id when it is altered.
+	tableColumns map[uint64][]*mysqlColumn
+
+	// checkpoint of the last commit sent, checkpoints count the transactions read since replication
+	// was set up.
+	checkpointID  int64
+	commitTime    time.Time
+	inTransaction bool
+	// records of the transaction in progress.
+	pending []model.Record
+	// number of records sent to the stream of the request.
+	numRecords int
+	// GTID set executed up to the last commit of the batch.
+	gtidSet string
+}
+
+// PullRecords streams the binlog from the GTID set of the checkpoint the destination has synced,
+// and records the GTID set of the batch's last checkpoint for the next pull.
+func (c *MySQLConnector) PullRecords(req *model.PullRecordsRequest) error {
+	if c.pgMetadata == nil {
+		return errNoMetadataDB
+	}
+
+	var startCheckpointID int64
+	if req.LastSyncState != nil {
+		startCheckpointID = req.LastSyncState.Checkpoint
+	}
+	startGTIDSet, err := c.pgMetadata.GetCheckpointPosition(req.FlowJobName, startCheckpointID)
+	if err != nil {
+		return err
+	}
+	gtidSet, err := gomysql.ParseGTIDSet(gomysql.MySQLFlavor, startGTIDSet)
+	if err != nil {
+		return fmt.Errorf("failed to parse gtid set %s: %w", startGTIDSet, err)
+	}
+
+	syncer := replication.NewBinlogSyncer(replication.BinlogSyncerConfig{
+		ServerID: c.serverID(req.FlowJobName),
+		Flavor:   gomysql.MySQLFlavor,
+		Host:     c.config.Host,
+		Port:     uint16(c.config.Port),
+		User:     c.config.User,
+		Password: c.config.Password,
+		// dates and times are parsed from strings, DATETIME and TIMESTAMP values alike in UTC.
+		TimestampStringLocation: time.UTC,
+		Logger:                  log.StandardLogger(),
+	})
+	defer syncer.Close()
+
+	streamer, err := syncer.StartSyncGTID(gtidSet)
+	if err != nil {
+		return fmt.Errorf("error starting replication after gtid set %s: %w", startGTIDSet, err)
+	}
+	log.Infof("started replication for flow job %s after gtid set %s", req.FlowJobName, startGTIDSet)
+
+	source := &mysqlCDCSource{
+		connector:     c,
+		req:           req,
+		srcTableNames: make(map[string]string, len(req.TableNameMapping)),
+		tableColumns:  make(map[uint64][]*mysqlColumn),
+		checkpointID:  startCheckpointID,
+	}
+	for srcTableName := range req.TableNameMapping {
+		database, table := c.parseTableName(srcTableName)
+		source.srcTableNames[database+"."+table] = srcTableName
+	}
+
+	err = source.consumeStream(streamer)
+	if err != nil {
+		return err
+	}
+
+	if source.numRecords > 0 {
+		err = c.pgMetadata.SaveCheckpoint(req.FlowJobName, startCheckpointID, req.RecordStream.LastCheckPointID(),
+			source.gtidSet)
+		if err != nil {
+			return fmt.Errorf("failed to save checkpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *mysqlCDCSource) consumeStream(streamer *replication.BinlogStreamer) error {
+	ctx := s.connector.ctx
+
+	for {
+		eventCtx, cancel := context.WithTimeout(ctx, s.req.IdleTimeout)
+		ev, err := streamer.GetEvent(eventCtx)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				// a batch can only end at a commit.
+				if s.inTransaction {
+					continue
+				}
+				log.Infof("Idle timeout reached, returning currently accumulated records")
+				return nil
+			}
+			return fmt.Errorf("failed to read binlog event: %w", err)
+		}
+
+		switch e := ev.Event.(type) {
+		case *replication.GTIDEvent:
+			s.inTransaction = true
+			s.commitTime = e.ImmediateCommitTime()
+			if s.commitTime.IsZero() {
+				s.commitTime = time.Unix(int64(ev.Header.Timestamp), 0)
+			}
+		case *replication.RowsEvent:
+			err = s.processRowsEvent(ev.Header, e)
+		case *replication.XIDEvent:
+			err = s.commit(e.GSet)
+		case *replication.QueryEvent:
+			// statements other than BEGIN, like DDL, commit on their own.
+			if string(e.Query) != "BEGIN" {
+				log.Debugf("QueryEvent => %s", e.Query)
+				err = s.commit(e.GSet)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if !s.inTransaction && s.numRecords >= int(s.req.MaxBatchSize) && s.numRecords > 0 {
+			return nil
+		}
+	}
+}
+
+// commit sends the records of the transaction to the stream, the batch ends at the commit's checkpoint.
+func (s *mysqlCDCSource) commit(gtidSet gomysql.GTIDSet) error {
+	checkpointID := s.checkpointID + 1
+	if gtidSet == nil {
+		return fmt.Errorf("no gtid set known at checkpoint %d", checkpointID)
+	}
+
+	for _, record := range s.pending {
+		if err := s.req.RecordStream.Send(record); err != nil {
+			return err
+		}
+		s.numRecords++
+	}
+	s.pending = nil
+	s.checkpointID = checkpointID
+	s.req.RecordStream.UpdateLastCheckPointID(checkpointID)
+	s.gtidSet = gtidSet.String()
+	s.inTransaction = false
+	return nil
+}
+
+func (s *mysqlCDCSource) processRowsEvent(header *replication.EventHeader, e *replication.RowsEvent) error {
+	database, table := string(e.Table.Schema), string(e.Table.Table)
+	srcTableName, ok := s.srcTableNames[database+"."+table]
+	if !ok {
+		return nil
+	}
+	dstTableName := s.req.TableNameMapping[srcTableName]
+
+	columns, err := s.getColumns(srcTableName, dstTableName, e.Table)
+	if err != nil {
+		return err
+	}
+
+	// records get the checkpoint of the transaction's commit.
+	checkpointID := s.checkpointID + 1
+
+	switch header.EventType {
+	case replication.WRITE_ROWS_EVENTv0, replication.WRITE_ROWS_EVENTv1, replication.WRITE_ROWS_EVENTv2:
+		for _, row := range e.Rows {
+			items, err := s.rowItems(dstTableName, columns, row)
+			if err != nil {
+				return err
+			}
+			s.pending = append(s.pending, &model.InsertRecord{
+				SourceTableName:       srcTableName,
+				DestinationTableName:  dstTableName,
+				CheckPointID:          checkpointID,
+				Items:                 items,
+				UnchangedToastColumns: make(map[string]bool),
+				CommitTime:            s.commitTime,
+			})
+		}
+	case replication.UPDATE_ROWS_EVENTv0, replication.UPDATE_ROWS_EVENTv1, replication.UPDATE_ROWS_EVENTv2:
+		// rows come in pairs, the row before the update followed by the row after it.
+		for i := 0; i+1 < len(e.Rows); i += 2 {
+			oldItems, err := s.rowItems(dstTableName, columns, e.Rows[i])
+			if err != nil {
+				return err
+			}
+			newItems, err := s.rowItems(dstTableName, columns, e.Rows[i+1])
+			if err != nil {
+				return err
+			}
+			s.pending = append(s.pending, &model.UpdateRecord{
+				SourceTableName:       srcTableName,
+				DestinationTableName:  dstTableName,
+				CheckPointID:          checkpointID,
+				OldItems:              oldItems,
+				NewItems:              newItems,
+				UnchangedToastColumns: make(map[string]bool),
+				CommitTime:            s.commitTime,
+			})
+		}
+	case replication.DELETE_ROWS_EVENTv0, replication.DELETE_ROWS_EVENTv1, replication.DELETE_ROWS_EVENTv2:
+		for _, row := range e.Rows {
+			items, err := s.rowItems(dstTableName, columns, row)
+			if err != nil {
+				return err
+			}
+			s.pending = append(s.pending, &model.DeleteRecord{
+				SourceTableName:       srcTableName,
+				DestinationTableName:  dstTableName,
+				CheckPointID:          checkpointID,
+				Items:                 items,
+				UnchangedToastColumns: make(map[string]bool),
+				CommitTime:            s.commitTime,
+			})
+		}
+	default:
+		return fmt.Errorf("unsupported rows event %s for table %s", header.EventType, srcTableName)
+	}
+
+	return nil
+}
+
+// getColumns returns the columns of a table at the time of a rows event, from the table map event
+// preceding it. The columns are described the first time a table id is seen, and diffed against the
+// schema of the destination table. Any difference is added to the stream as a TableSchemaDelta.
+func (s *mysqlCDCSource) getColumns(
+	srcTableName string,
+	dstTableName string,
+	tableMap *replication.TableMapEvent,
+) ([]*mysqlColumn, error) {
+	if columns, ok := s.tableColumns[tableMap.TableID]; ok {
+		return columns, nil
+	}
+
+	columns, err := tableMapColumns(tableMap)
+	if err != nil {
+		return nil, fmt.Errorf("table %s: %w", srcTableName, err)
+	}
+	s.tableColumns[tableMap.TableID] = columns
+
+	schema, ok := s.req.TableNameSchemaMapping[dstTableName]
+	if !ok {
+		return columns, nil
+	}
+	delta := &protos.TableSchemaDelta{
+		SrcTableName:   srcTableName,
+		DstTableName:   dstTableName,
+		AddedColumns:   make([]*protos.DeltaColumn, 0),
+		DroppedColumns: make([]string, 0),
+		RetypedColumns: make([]*protos.DeltaColumn, 0),
+	}
+	tableColumns := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		if !utils.IsColumnReplicated(s.req.ColumnFilters[srcTableName], col.name) {
+			continue
+		}
+		if col.kind == qvalue.QValueKindInvalid {
+			log.Warnf("skipping column %s of %s with an unsupported type", col.name, srcTableName)
+			continue
+		}
+		tableColumns[col.name] = true
+
+		prevType, ok := schema.Columns[col.name]
+		if !ok {
+			delta.AddedColumns = append(delta.AddedColumns, &protos.DeltaColumn{
+				ColumnName: col.name,
+				ColumnType: string(col.kind),
+			})
+		} else if prevType != string(col.kind) {
+			delta.RetypedColumns = append(delta.RetypedColumns, &protos.DeltaColumn{
+				ColumnName: col.name,
+				ColumnType: string(col.kind),
+			})
+		}
+	}
+	for colName := range schema.Columns {
+		if !tableColumns[colName] {
+			delta.DroppedColumns = append(delta.DroppedColumns, colName)
+		}
+	}
+	sort.Strings(delta.DroppedColumns)
+
+	if len(delta.AddedColumns) == 0 && len(delta.DroppedColumns) == 0 && len(delta.RetypedColumns) == 0 {
+		return columns, nil
+	}
+
+	log.Infof("schema change for %s at checkpoint %d => added: %v, dropped: %v, retyped: %v",
+		srcTableName, s.checkpointID+1, delta.AddedColumns, delta.DroppedColumns, delta.RetypedColumns)
+	s.req.RecordStream.AddSchemaDelta(delta)
+	// keep diffing later table map events against the changed schema.
+	model.ApplyTableSchemaDelta(schema, delta)
+	return columns, nil
+}
+
+// rowItems converts a row of the binlog to the items of a record, leaving out the columns that
+// aren't in the destination table's schema.
+func (s *mysqlCDCSource) rowItems(
+	dstTableName string,
+	columns []*mysqlColumn,
+	row []interface{},
+) (model.RecordItems, error) {
+	tableSchema, ok := s.req.TableNameSchemaMapping[dstTableName]
+	if !ok {
+		return nil, fmt.Errorf("no schema known for table %s", dstTableName)
+	}
+
+	items := make(model.RecordItems, len(tableSchema.Columns))
+	for i, col := range columns {
+		if _, ok := tableSchema.Columns[col.name]; !ok {
+			continue
+		}
+		if i >= len(row) {
+			return nil, fmt.Errorf("row of table %s is missing column %s", dstTableName, col.name)
+		}
+		val, err := binlogValueToQValue(col, row[i])
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", dstTableName, err)
+		}
+		items[col.name] = val
+	}
+	return items, nil
+}
@@ -0,0 +1,141 @@
+package connmysql
+
+import (
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	gomysql "github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCDCSource(startCheckpointID int64) *mysqlCDCSource {
+	return &mysqlCDCSource{
+		req: &model.PullRecordsRequest{
+			TableNameMapping: map[string]string{"shop.users": "public.users"},
+			TableNameSchemaMapping: map[string]*protos.TableSchema{
+				"public.users": {
+					TableIdentifier: "shop.users",
+					Columns:         map[string]string{"id": string(qvalue.QValueKindInt64)},
+				},
+			},
+			RecordStream: model.NewCDCRecordStream(16, nil),
+		},
+		srcTableNames: map[string]string{"shop.users": "shop.users"},
+		tableColumns:  make(map[uint64][]*mysqlColumn),
+		checkpointID:  startCheckpointID,
+	}
+}
+
+// testUsersTableMap returns the table map event of shop.users with an id and the given string columns.
+func testUsersTableMap(tableID uint64, stringColumns ...string) *replication.TableMapEvent {
+	e := &replication.TableMapEvent{
+		TableID:     tableID,
+		Schema:      []byte("shop"),
+		Table:       []byte("users"),
+		ColumnCount: uint64(1 + len(stringColumns)),
+		ColumnType:  []byte{gomysql.MYSQL_TYPE_LONGLONG},
+		ColumnMeta:  []uint16{0},
+		ColumnName:  [][]byte{[]byte("id")},
+	}
+	for _, name := range stringColumns {
+		e.ColumnType = append(e.ColumnType, gomysql.MYSQL_TYPE_VARCHAR)
+		e.ColumnMeta = append(e.ColumnMeta, 255)
+		e.ColumnName = append(e.ColumnName, []byte(name))
+	}
+	return e
+}
+
+func testInsertEvent(ids ...int64) (*replication.EventHeader, *replication.RowsEvent) {
+	rows := make([][]interface{}, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, []interface{}{id})
+	}
+	return &replication.EventHeader{EventType: replication.WRITE_ROWS_EVENTv2}, &replication.RowsEvent{
+		Table:       testUsersTableMap(1),
+		ColumnCount: 1,
+		Rows:        rows,
+	}
+}
+
+func TestCDCSourceCheckpoints(t *testing.T) {
+	s := newTestCDCSource(10)
+	stream := s.req.RecordStream
+
+	gtidSet := func(set string) gomysql.GTIDSet {
+		parsed, err := gomysql.ParseGTIDSet(gomysql.MySQLFlavor, set)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	// checkpoints count the transactions from the checkpoint the pull started at,
+	// the records of a transaction get the checkpoint of its commit.
+	require.NoError(t, s.processRowsEvent(testInsertEvent(1, 2)))
+	require.NoError(t, s.commit(gtidSet("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5")))
+	// statements committing on their own, like DDL, take a checkpoint without records.
+	require.NoError(t, s.commit(gtidSet("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-6")))
+	require.NoError(t, s.processRowsEvent(testInsertEvent(3)))
+	require.NoError(t, s.commit(gtidSet("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-7")))
+	stream.Close(nil)
+
+	var checkpointIDs []int64
+	for record := range stream.Records() {
+		checkpointIDs = append(checkpointIDs, record.GetCheckPointID())
+	}
+	assert.Equal(t, []int64{11, 11, 13}, checkpointIDs)
+	assert.Equal(t, 3, s.numRecords)
+	assert.Equal(t, int64(13), stream.LastCheckPointID())
+	assert.Equal(t, "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-7", s.gtidSet)
+}
+
+func TestCDCSourceCommitNeedsGTIDSet(t *testing.T) {
+	s := newTestCDCSource(0)
+	require.NoError(t, s.processRowsEvent(testInsertEvent(1)))
+	require.ErrorContains(t, s.commit(nil), "no gtid set known at checkpoint 1")
+	// the transaction's records aren't sent and its checkpoint isn't taken.
+	assert.Equal(t, 0, s.numRecords)
+	assert.Equal(t, int64(0), s.checkpointID)
+}
+
+func TestCDCSourceColumnAddedByAlter(t *testing.T) {
+	s := newTestCDCSource(0)
+	require.NoError(t, s.processRowsEvent(testInsertEvent(1)))
+	assert.Empty(t, s.req.RecordStream.TableSchemaDeltas())
+
+	// an altered table gets a new table id, its rows are read with the columns of the new table map.
+	header := &replication.EventHeader{EventType: replication.WRITE_ROWS_EVENTv2}
+	require.NoError(t, s.processRowsEvent(header, &replication.RowsEvent{
+		Table:       testUsersTableMap(2, "email"),
+		ColumnCount: 2,
+		Rows:        [][]interface{}{{int64(2), "a@example.com"}},
+	}))
+	deltas := s.req.RecordStream.TableSchemaDeltas()
+	require.Len(t, deltas, 1)
+	assert.Equal(t, "public.users", deltas[0].DstTableName)
+	require.Len(t, deltas[0].AddedColumns, 1)
+	assert.Equal(t, "email", deltas[0].AddedColumns[0].ColumnName)
+	assert.Equal(t, string(qvalue.QValueKindString), deltas[0].AddedColumns[0].ColumnType)
+	assert.Empty(t, deltas[0].DroppedColumns)
+
+	require.Len(t, s.pending, 2)
+	assert.Equal(t, qvalue.QValue{Kind: qvalue.QValueKindString, Value: "a@example.com"},
+		s.pending[1].(*model.InsertRecord).Items["email"])
+
+	// the binlog can only be read with the columns named in the table maps.
+	tableMap := testUsersTableMap(3)
+	tableMap.ColumnName = nil
+	require.ErrorContains(t, s.processRowsEvent(header, &replication.RowsEvent{
+		Table:       tableMap,
+		ColumnCount: 1,
+		Rows:        [][]interface{}{{int64(3)}},
+	}), "binlog_row_metadata must be FULL")
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, "`id`", QuoteIdentifier("id"))
+	assert.Equal(t, "`first name`", QuoteIdentifier("first name"))
+	assert.Equal(t, "`a``b`", QuoteIdentifier("a`b"))
+}
@@ -0,0 +1,38 @@
+package connmysql
+
+import (
+	"errors"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+)
+
+const (
+	// schema for the peerdb metadata. Checkpoints count the transactions pulled since replication was
+	// set up, a binlog position doesn't carry over to other servers, so the GTID set executed up to each
+	// checkpoint is kept and replication resumes from the one of the checkpoint the destination synced.
+	metadataSchema = "peerdb_mysql_metadata"
+)
+
+var errNoMetadataDB = errors.New("a metadata database is needed to replicate changes from mysql")
+
+func (c *MySQLConnector) NeedsSetupMetadataTables() bool {
+	if c.pgMetadata == nil {
+		return false
+	}
+	return c.pgMetadata.NeedsSetupMetadata()
+}
+
+func (c *MySQLConnector) SetupMetadataTables() error {
+	if c.pgMetadata == nil {
+		return errNoMetadataDB
+	}
+	return c.pgMetadata.SetupMetadata()
+}
+
+// GetLastOffset returns the last checkpoint pulled for a job.
+func (c *MySQLConnector) GetLastOffset(jobName string) (*protos.LastSyncState, error) {
+	if c.pgMetadata == nil {
+		return nil, errNoMetadataDB
+	}
+	return c.pgMetadata.FetchLastCheckpoint(jobName)
+}
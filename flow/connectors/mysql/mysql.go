@@ -0,0 +1,323 @@
+package connmysql
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	connmetadata "github.com/PeerDB-io/peer-flow/connectors/external_metadata"
+	peersql "github.com/PeerDB-io/peer-flow/connectors/sql"
+	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+)
+
+// MySQLConnector reads MySQL tables, copying them with query replication and streaming their changes
+// from the binlog. It is only supported as a source.
+type MySQLConnector struct {
+	peersql.GenericSQLQueryExecutor
+
+	ctx        context.Context
+	config     *protos.MySqlConfig
+	db         *sqlx.DB
+	pgMetadata *connmetadata.PostgresMetadataStore
+}
+
+// NewMySQLConnector creates a new MySQL connection
+func NewMySQLConnector(ctx context.Context, config *protos.MySqlConfig) (*MySQLConnector, error) {
+	connConfig := mysql.NewConfig()
+	connConfig.User = config.User
+	connConfig.Passwd = config.Password
+	connConfig.Net = "tcp"
+	connConfig.Addr = fmt.Sprintf("%s:%d", config.Host, config.Port)
+	connConfig.DBName = config.Database
+	connConfig.ParseTime = true
+	connConfig.Loc = time.UTC
+	connConfig.Params = map[string]string{
+		// identifiers are quoted with double quotes, like for the other peers.
+		"sql_mode":  "CONCAT(@@sql_mode, ',ANSI_QUOTES')",
+		"time_zone": "'+00:00'",
+	}
+
+	db, err := sqlx.Open("mysql", connConfig.FormatDSN())
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	// the metadata database is only needed to replicate changes.
+	var pgMetadata *connmetadata.PostgresMetadataStore
+	if config.MetadataDb != nil {
+		pgMetadata, err = connmetadata.NewPostgresMetadataStore(ctx, config.MetadataDb, metadataSchema)
+		if err != nil {
+			log.Errorf("failed to create postgres metadata store: %v", err)
+			db.Close()
+			return nil, err
+		}
+	}
+
+	genericExecutor := *peersql.NewGenericSQLQueryExecutor(
+		ctx, db, mysqlTypeToQValueKindMap, qValueKindToMySQLTypeMap)
+
+	return &MySQLConnector{
+		GenericSQLQueryExecutor: genericExecutor,
+		ctx:                     ctx,
+		config:                  config,
+		db:                      db,
+		pgMetadata:              pgMetadata,
+	}, nil
+}
+
+// Close closes the database connection
+func (c *MySQLConnector) Close() error {
+	if c.pgMetadata != nil {
+		c.pgMetadata.Close()
+	}
+	if c.db != nil {
+		return c.db.Close()
+	}
+	return nil
+}
+
+// ConnectionActive checks if the connection is still active
+func (c *MySQLConnector) ConnectionActive() bool {
+	if err := c.db.Ping(); err != nil {
+		return false
+	}
+	return true
+}
+
+// QuoteIdentifier quotes an identifier with backticks, escaping the backticks it contains.
+func QuoteIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
+
+// parseTableName splits a table identifier into its database and table, unqualified tables are in the
+// database of the peer.
+func (c *MySQLConnector) parseTableName(tableIdentifier string) (string, string) {
+	if database, table, ok := strings.Cut(tableIdentifier, "."); ok {
+		return strings.Trim(database, "\"`"), strings.Trim(table, "\"`")
+	}
+	return c.config.Database, strings.Trim(tableIdentifier, "\"`")
+}
+
+// getTableColumns returns the columns of a table in the order the binlog sends them.
+func (c *MySQLConnector) getTableColumns(database string, table string) ([]*mysqlColumn, error) {
+	rows, err := c.db.QueryContext(c.ctx, `
+		SELECT COLUMN_NAME, DATA_TYPE, COLUMN_TYPE FROM information_schema.columns
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+		ORDER BY ORDINAL_POSITION`, database, table)
+	if err != nil {
+		return nil, fmt.Errorf("error getting columns of table %s.%s: %w", database, table, err)
+	}
+	defer rows.Close()
+
+	var columns []*mysqlColumn
+	for rows.Next() {
+		var name, dataType, columnType string
+		if err := rows.Scan(&name, &dataType, &columnType); err != nil {
+			return nil, fmt.Errorf("error scanning columns of table %s.%s: %w", database, table, err)
+		}
+		col, err := newMySQLColumn(name, dataType, columnType)
+		if err != nil {
+			return nil, fmt.Errorf("table %s.%s: %w", database, table, err)
+		}
+		columns = append(columns, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over columns of table %s.%s: %w", database, table, err)
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s.%s does not exist", database, table)
+	}
+	return columns, nil
+}
+
+func (c *MySQLConnector) getPrimaryKeyColumns(database string, table string) ([]string, error) {
+	var pkeyCols []string
+	err := c.db.SelectContext(c.ctx, &pkeyCols, `
+		SELECT COLUMN_NAME FROM information_schema.key_column_usage
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY'
+		ORDER BY ORDINAL_POSITION`, database, table)
+	if err != nil {
+		return nil, fmt.Errorf("error getting primary key columns of table %s.%s: %w", database, table, err)
+	}
+	return pkeyCols, nil
+}
+
+// GetTableSchema returns the schema of a table. The binlog carries the full row image, so rows of
+// tables without a primary key are matched on all of their columns.
+func (c *MySQLConnector) GetTableSchema(req *protos.GetTableSchemaInput) (*protos.TableSchema, error) {
+	database, table := c.parseTableName(req.TableIdentifier)
+
+	columns, err := c.getTableColumns(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	pkeyCols, err := c.getPrimaryKeyColumns(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &protos.TableSchema{
+		TableIdentifier:   req.TableIdentifier,
+		Columns:           make(map[string]string),
+		PrimaryKeyColumns: pkeyCols,
+	}
+	if len(pkeyCols) == 0 {
+		log.Infof("table %s.%s has no primary key, rows will be matched on all columns", database, table)
+		res.ReplicaIdentity = protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL
+	}
+
+	for _, col := range columns {
+		if !utils.IsColumnReplicated(req.ColumnFilter, col.name) {
+			continue
+		}
+		res.Columns[col.name] = string(col.kind)
+	}
+
+	// rows can't be matched on the destination without their key columns.
+	for _, pkeyCol := range pkeyCols {
+		if !utils.IsColumnReplicated(req.ColumnFilter, pkeyCol) {
+			return nil, fmt.Errorf("key column %s of table %s.%s can't be filtered out", pkeyCol, database, table)
+		}
+	}
+
+	return res, nil
+}
+
+// EnsurePullability checks that the changes of the table can be read from the binlog. MySQL tables
+// have no stable identifier like Postgres relation ids, so the output identifies none.
+func (c *MySQLConnector) EnsurePullability(
+	req *protos.EnsurePullabilityInput,
+) (*protos.EnsurePullabilityOutput, error) {
+	required := map[string]string{
+		"log_bin":          "1",
+		"binlog_format":    "ROW",
+		"binlog_row_image": "FULL",
+		// the columns of rows are described by the table map events preceding them.
+		"binlog_row_metadata": "FULL",
+		"gtid_mode":           "ON",
+	}
+	for variable, want := range required {
+		var value string
+		err := c.db.QueryRowxContext(c.ctx, "SELECT @@GLOBAL."+variable).Scan(&value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", variable, err)
+		}
+		if !strings.EqualFold(value, want) {
+			return nil, fmt.Errorf("%s must be %s to replicate changes from mysql, it is %s", variable, want, value)
+		}
+	}
+
+	database, table := c.parseTableName(req.SourceTableIdentifier)
+	if _, err := c.getTableColumns(database, table); err != nil {
+		return nil, err
+	}
+
+	return &protos.EnsurePullabilityOutput{TableIdentifier: &protos.TableIdentifier{}}, nil
+}
+
+// SetupReplication records the GTID set the server has executed so far, changes are streamed from
+// there on. Replication is set up again without effect.
+func (c *MySQLConnector) SetupReplication(req *protos.SetupReplicationInput) error {
+	if c.pgMetadata == nil {
+		return errNoMetadataDB
+	}
+	if len(req.RowFilters) > 0 {
+		return fmt.Errorf("row filters are not supported for mysql sources")
+	}
+
+	if c.NeedsSetupMetadataTables() {
+		if err := c.SetupMetadataTables(); err != nil {
+			return fmt.Errorf("failed to setup metadata tables: %w", err)
+		}
+	}
+
+	var gtidSet string
+	err := c.db.QueryRowxContext(c.ctx, "SELECT @@GLOBAL.gtid_executed").Scan(&gtidSet)
+	if err != nil {
+		return fmt.Errorf("failed to read executed gtid set: %w", err)
+	}
+
+	err = c.pgMetadata.InsertCheckpoint(req.FlowJobName, 0, gtidSet)
+	if err != nil {
+		return fmt.Errorf("failed to record starting gtid set: %w", err)
+	}
+	log.Infof("replication for flow job %s starts after gtid set %s", req.FlowJobName, gtidSet)
+
+	return nil
+}
+
+// PullFlowCleanup removes the checkpoints of a job, MySQL keeps no replication state for it.
+func (c *MySQLConnector) PullFlowCleanup(jobName string) error {
+	if c.pgMetadata == nil || c.NeedsSetupMetadataTables() {
+		return nil
+	}
+	return c.pgMetadata.DropMetadata(jobName)
+}
+
+// serverID returns the server id to read the binlog with. Without one configured it is derived from
+// the flow job name, so that concurrent mirrors of a server don't disconnect each other.
+func (c *MySQLConnector) serverID(flowJobName string) uint32 {
+	if c.config.ServerId != 0 {
+		return c.config.ServerId
+	}
+	h := fnv.New32a()
+	h.Write([]byte(flowJobName))
+	// stay clear of the small ids usually given to servers.
+	return h.Sum32() | 1<<31
+}
+
+func (c *MySQLConnector) SetupNormalizedTable(
+	req *protos.SetupNormalizedTableInput) (*protos.SetupNormalizedTableOutput, error) {
+	log.Errorf("SetupNormalizedTable not supported for MySQL")
+	return nil, fmt.Errorf("cdc based replication is not currently supported for MySQL target")
+}
+
+func (c *MySQLConnector) InitializeTableSchema(req map[string]*protos.TableSchema) error {
+	log.Errorf("InitializeTableSchema not supported for MySQL")
+	return fmt.Errorf("cdc based replication is not currently supported for MySQL target")
+}
+
+func (c *MySQLConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.SyncResponse, error) {
+	log.Errorf("SyncRecords not supported for MySQL")
+	return nil, fmt.Errorf("cdc based replication is not currently supported for MySQL target")
+}
+
+func (c *MySQLConnector) NormalizeRecords(req *model.NormalizeRecordsRequest) (*model.NormalizeResponse, error) {
+	log.Errorf("NormalizeRecords not supported for MySQL")
+	return nil, fmt.Errorf("cdc based replication is not currently supported for MySQL target")
+}
+
+func (c *MySQLConnector) CreateRawTable(req *protos.CreateRawTableInput) (*protos.CreateRawTableOutput, error) {
+	log.Errorf("CreateRawTable not supported for MySQL")
+	return nil, fmt.Errorf("cdc based replication is not currently supported for MySQL target")
+}
+
+func (c *MySQLConnector) ReplayTableSchemaDelta(flowJobName string, schemaDelta *protos.TableSchemaDelta) error {
+	log.Errorf("ReplayTableSchemaDelta not supported for MySQL")
+	return fmt.Errorf("cdc based replication is not currently supported for MySQL target")
+}
+
+func (c *MySQLConnector) TruncateTables(tableIdentifiers []string) error {
+	log.Errorf("TruncateTables not supported for MySQL")
+	return fmt.Errorf("cdc based replication is not currently supported for MySQL target")
+}
+
+func (c *MySQLConnector) SyncFlowCleanup(jobName string) error {
+	log.Errorf("SyncFlowCleanup not supported for MySQL")
+	return fmt.Errorf("cdc based replication is not currently supported for MySQL target")
+}
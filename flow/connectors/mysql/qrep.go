@@ -0,0 +1,386 @@
+package connmysql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"text/template"
+	"time"
+
+	utils "github.com/PeerDB-io/peer-flow/connectors/utils/partition"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (c *MySQLConnector) SetupQRepMetadataTables(config *protos.QRepConfig) error {
+	log.Infof("Setting up metadata tables for query replication on mysql is a no-op")
+	return nil
+}
+
+func (c *MySQLConnector) GetQRepPartitions(
+	config *protos.QRepConfig,
+	last *protos.QRepPartition,
+) ([]*protos.QRepPartition, error) {
+	// read the table in one consistent snapshot while we get the partitions.
+	tx, err := c.db.BeginTxx(c.ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		deferErr := tx.Rollback()
+		if deferErr != sql.ErrTxDone && deferErr != nil {
+			log.Errorf("unexpected error rolling back transaction for get partitions: %v", deferErr)
+		}
+	}()
+
+	if config.NumRowsPerPartition > 0 {
+		return c.getNumRowsPartitions(tx, config, last)
+	}
+
+	minValue, maxValue, err := c.getMinMaxValues(tx, config, last)
+	if err != nil {
+		return nil, err
+	}
+
+	var partitions []*protos.QRepPartition
+	switch v := minValue.(type) {
+	case int64:
+		maxValue := maxValue.(int64) + 1
+		partitions, err = c.getIntPartitions(v, maxValue, config.BatchSizeInt)
+	case time.Time:
+		maxValue := maxValue.(time.Time).Add(time.Microsecond)
+		partitions, err = c.getTimePartitions(v, maxValue, config.BatchDurationSeconds)
+	// only hit when there is no data in the source table
+	case nil:
+		log.Warnf("no records to replicate for flow job %s, returning", config.FlowJobName)
+		return make([]*protos.QRepPartition, 0), nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %T", v)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return partitions, nil
+}
+
+// watermarkValue converts a watermark scanned from MySQL to an int64 or a time. Queries without
+// arguments use the text protocol, which returns integers as text.
+func watermarkValue(val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case nil, int64, time.Time:
+		return v, nil
+	case []byte:
+		n, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported watermark value %s: %w", v, err)
+		}
+		return n, nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return nil, fmt.Errorf("watermark value %d is out of range", v)
+		}
+		return int64(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported watermark type: %T", v)
+	}
+}
+
+// lastPartitionEnd returns where the partitions after the last one start.
+func lastPartitionEnd(last *protos.QRepPartition) interface{} {
+	if last == nil || last.Range == nil {
+		return nil
+	}
+	switch lastRange := last.Range.Range.(type) {
+	case *protos.PartitionRange_IntRange:
+		return lastRange.IntRange.End
+	case *protos.PartitionRange_TimestampRange:
+		return lastRange.TimestampRange.End.AsTime()
+	}
+	return nil
+}
+
+func (c *MySQLConnector) getNumRowsPartitions(
+	tx *sqlx.Tx,
+	config *protos.QRepConfig,
+	last *protos.QRepPartition,
+) ([]*protos.QRepPartition, error) {
+	var err error
+	numRowsPerPartition := int64(config.NumRowsPerPartition)
+	quotedWatermarkColumn := QuoteIdentifier(config.WatermarkColumn)
+
+	var args []interface{}
+	whereClause := ""
+	if minVal := lastPartitionEnd(last); minVal != nil {
+		whereClause = fmt.Sprintf(`WHERE %s > ?`, quotedWatermarkColumn)
+		args = append(args, minVal)
+	}
+
+	// Query to get the total number of rows in the table
+	//nolint:gosec
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s %s", config.WatermarkTable, whereClause)
+	var totalRows int64
+	if err = tx.QueryRowxContext(c.ctx, countQuery, args...).Scan(&totalRows); err != nil {
+		return nil, fmt.Errorf("failed to query for total rows: %w", err)
+	}
+
+	if totalRows == 0 {
+		log.Warnf("no records to replicate for flow job %s, returning", config.FlowJobName)
+		return make([]*protos.QRepPartition, 0), nil
+	}
+
+	// Calculate the number of partitions
+	numPartitions := totalRows / numRowsPerPartition
+	if totalRows%numRowsPerPartition != 0 {
+		numPartitions++
+	}
+	log.Infof("total rows: %d, num partitions: %d, num rows per partition: %d",
+		totalRows, numPartitions, numRowsPerPartition)
+
+	// Query to get partitions using window functions, available from MySQL 8.0
+	//nolint:gosec
+	partitionsQuery := fmt.Sprintf(
+		`SELECT bucket_v, MIN(v_from) AS start_v, MAX(v_from) AS end_v
+		FROM (
+				SELECT NTILE(%[1]d) OVER (ORDER BY %[2]s) AS bucket_v, %[2]s AS v_from
+				FROM %[3]s %[4]s
+		) subquery
+		GROUP BY bucket_v
+		ORDER BY start_v`,
+		numPartitions,
+		quotedWatermarkColumn,
+		config.WatermarkTable,
+		whereClause,
+	)
+	log.Infof("partitions query: %s", partitionsQuery)
+	rows, err := tx.QueryxContext(c.ctx, partitionsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query for partitions: %w", err)
+	}
+	defer rows.Close()
+
+	partitionHelper := utils.NewPartitionHelper()
+	for rows.Next() {
+		var bucket int64
+		var start, end interface{}
+		if err := rows.Scan(&bucket, &start, &end); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if start, err = watermarkValue(start); err != nil {
+			return nil, err
+		}
+		if end, err = watermarkValue(end); err != nil {
+			return nil, err
+		}
+		err = partitionHelper.AddPartition(start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add partition: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over partitions: %w", err)
+	}
+
+	return partitionHelper.GetPartitions(), nil
+}
+
+func (c *MySQLConnector) getMinMaxValues(
+	tx *sqlx.Tx,
+	config *protos.QRepConfig,
+	last *protos.QRepPartition,
+) (interface{}, interface{}, error) {
+	var minValue, maxValue interface{}
+	quotedWatermarkColumn := QuoteIdentifier(config.WatermarkColumn)
+	// Get the maximum value from the database
+	//nolint:gosec
+	maxQuery := fmt.Sprintf("SELECT MAX(%[1]s) FROM %[2]s", quotedWatermarkColumn, config.WatermarkTable)
+	if err := tx.QueryRowxContext(c.ctx, maxQuery).Scan(&maxValue); err != nil {
+		return nil, nil, fmt.Errorf("failed to query for max value: %w", err)
+	}
+	maxValue, err := watermarkValue(maxValue)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If there's a last partition, start from its end
+	minValue = lastPartitionEnd(last)
+	if minValue == nil {
+		// Otherwise get the minimum value from the database
+		//nolint:gosec
+		minQuery := fmt.Sprintf("SELECT MIN(%[1]s) FROM %[2]s", quotedWatermarkColumn, config.WatermarkTable)
+		if err := tx.QueryRowxContext(c.ctx, minQuery).Scan(&minValue); err != nil {
+			log.Errorf("failed to query [%s] for min value: %v", minQuery, err)
+			return nil, nil, fmt.Errorf("failed to query for min value: %w", err)
+		}
+		minValue, err = watermarkValue(minValue)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return minValue, maxValue, nil
+}
+
+func (c *MySQLConnector) getTimePartitions(
+	start time.Time,
+	end time.Time,
+	batchDurationSeconds uint32,
+) ([]*protos.QRepPartition, error) {
+	if batchDurationSeconds == 0 {
+		return nil, fmt.Errorf("batch duration must be greater than 0")
+	}
+
+	batchDuration := time.Duration(batchDurationSeconds) * time.Second
+	var partitions []*protos.QRepPartition
+
+	for start.Before(end) {
+		partitionEnd := start.Add(batchDuration)
+		if partitionEnd.After(end) {
+			partitionEnd = end
+		}
+
+		rangePartition := protos.PartitionRange{
+			Range: &protos.PartitionRange_TimestampRange{
+				TimestampRange: &protos.TimestampPartitionRange{
+					Start: timestamppb.New(start),
+					End:   timestamppb.New(partitionEnd),
+				},
+			},
+		}
+
+		partitions = append(partitions, &protos.QRepPartition{
+			PartitionId: uuid.New().String(),
+			Range:       &rangePartition,
+		})
+
+		start = partitionEnd
+	}
+
+	return partitions, nil
+}
+
+func (c *MySQLConnector) getIntPartitions(
+	start int64, end int64, batchSizeInt uint32) ([]*protos.QRepPartition, error) {
+	var partitions []*protos.QRepPartition
+	batchSize := int64(batchSizeInt)
+
+	if batchSize == 0 {
+		return nil, fmt.Errorf("batch size cannot be 0")
+	}
+
+	for start <= end {
+		partitionEnd := start + batchSize
+		// safeguard against integer overflow
+		if partitionEnd > end || partitionEnd < start {
+			partitionEnd = end
+		}
+
+		rangePartition := protos.PartitionRange{
+			Range: &protos.PartitionRange_IntRange{
+				IntRange: &protos.IntPartitionRange{
+					Start: start,
+					End:   partitionEnd,
+				},
+			},
+		}
+
+		partitions = append(partitions, &protos.QRepPartition{
+			PartitionId: uuid.New().String(),
+			Range:       &rangePartition,
+		})
+
+		if partitionEnd == end {
+			break
+		}
+
+		start = partitionEnd
+	}
+
+	return partitions, nil
+}
+
+func (c *MySQLConnector) PullQRepRecords(
+	config *protos.QRepConfig, partition *protos.QRepPartition,
+	stream *model.QRecordStream) (int, error) {
+	var rangeStart interface{}
+	var rangeEnd interface{}
+
+	// Depending on the type of the range, convert the range into the correct type
+	switch x := partition.Range.Range.(type) {
+	case *protos.PartitionRange_IntRange:
+		rangeStart = x.IntRange.Start
+		rangeEnd = x.IntRange.End
+	case *protos.PartitionRange_TimestampRange:
+		rangeStart = x.TimestampRange.Start.AsTime()
+		rangeEnd = x.TimestampRange.End.AsTime()
+	default:
+		return 0, fmt.Errorf("unknown range type: %v", x)
+	}
+
+	// Build the query to pull records within the range from the source table
+	// Be sure to order the results by the watermark column to ensure consistency across pulls
+	query, err := BuildQuery(config.Query)
+	if err != nil {
+		return 0, err
+	}
+
+	rangeParams := map[string]interface{}{
+		"startRange": rangeStart,
+		"endRange":   rangeEnd,
+	}
+
+	return c.NamedExecuteAndProcessQueryStream(stream, query, rangeParams)
+}
+
+func BuildQuery(query string) (string, error) {
+	tmpl, err := template.New("query").Parse(query)
+	if err != nil {
+		return "", err
+	}
+
+	data := map[string]interface{}{
+		"start": ":startRange",
+		"end":   ":endRange",
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = tmpl.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+	res := buf.String()
+
+	log.Infof("templated query: %s", res)
+	return res, nil
+}
+
+func (c *MySQLConnector) SyncQRepRecords(
+	config *protos.QRepConfig,
+	partition *protos.QRepPartition,
+	stream *model.QRecordStream,
+) (int, error) {
+	log.Errorf("SyncQRepRecords not supported for MySQL")
+	return 0, fmt.Errorf("query replication is not currently supported for MySQL target")
+}
+
+func (c *MySQLConnector) ConsolidateQRepPartitions(config *protos.QRepConfig) error {
+	log.Errorf("ConsolidateQRepPartitions not supported for MySQL")
+	return fmt.Errorf("query replication is not currently supported for MySQL target")
+}
+
+func (c *MySQLConnector) CleanupQRepFlow(config *protos.QRepConfig) error {
+	log.Errorf("CleanupQRepFlow not supported for MySQL")
+	return fmt.Errorf("query replication is not currently supported for MySQL target")
+}
@@ -0,0 +1,55 @@
+package connmysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIntPartitions(t *testing.T) {
+	c := &MySQLConnector{}
+
+	partitions, err := c.getIntPartitions(1, 101, 40)
+	require.NoError(t, err)
+	require.Len(t, partitions, 3)
+	var ranges [][2]int64
+	for _, p := range partitions {
+		r := p.Range.GetIntRange()
+		ranges = append(ranges, [2]int64{r.Start, r.End})
+	}
+	assert.Equal(t, [][2]int64{{1, 41}, {41, 81}, {81, 101}}, ranges)
+
+	_, err = c.getIntPartitions(1, 101, 0)
+	assert.Error(t, err)
+}
+
+func TestGetTimePartitions(t *testing.T) {
+	c := &MySQLConnector{}
+	start := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	partitions, err := c.getTimePartitions(start, start.Add(90*time.Minute), 3600)
+	require.NoError(t, err)
+	require.Len(t, partitions, 2)
+	assert.Equal(t, start.Add(time.Hour), partitions[0].Range.GetTimestampRange().End.AsTime())
+	assert.Equal(t, start.Add(90*time.Minute), partitions[1].Range.GetTimestampRange().End.AsTime())
+
+	_, err = c.getTimePartitions(start, start.Add(time.Hour), 0)
+	assert.Error(t, err)
+}
+
+func TestWatermarkValue(t *testing.T) {
+	v, err := watermarkValue([]byte("42"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), v)
+
+	v, err = watermarkValue(uint64(42))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), v)
+
+	_, err = watermarkValue([]byte("abc"))
+	assert.Error(t, err)
+	_, err = watermarkValue(1.5)
+	assert.Error(t, err)
+}
@@ -0,0 +1,411 @@
+package connmysql
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	gomysql "github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+)
+
+// types are keyed by their upper case name, as the driver reports them. Unsigned integers are
+// prefixed with UNSIGNED and widened so that their values fit. Booleans are TINYINT in MySQL.
+var mysqlTypeToQValueKindMap = map[string]qvalue.QValueKind{
+	"TINYINT":            qvalue.QValueKindInt32,
+	"SMALLINT":           qvalue.QValueKindInt32,
+	"MEDIUMINT":          qvalue.QValueKindInt32,
+	"INT":                qvalue.QValueKindInt32,
+	"BIGINT":             qvalue.QValueKindInt64,
+	"UNSIGNED TINYINT":   qvalue.QValueKindInt32,
+	"UNSIGNED SMALLINT":  qvalue.QValueKindInt32,
+	"UNSIGNED MEDIUMINT": qvalue.QValueKindInt32,
+	"UNSIGNED INT":       qvalue.QValueKindInt64,
+	"UNSIGNED BIGINT":    qvalue.QValueKindNumeric,
+	"YEAR":               qvalue.QValueKindInt32,
+	"FLOAT":              qvalue.QValueKindFloat32,
+	"DOUBLE":             qvalue.QValueKindFloat64,
+	"DECIMAL":            qvalue.QValueKindNumeric,
+	"CHAR":               qvalue.QValueKindString,
+	"VARCHAR":            qvalue.QValueKindString,
+	"TINYTEXT":           qvalue.QValueKindString,
+	"TEXT":               qvalue.QValueKindString,
+	"MEDIUMTEXT":         qvalue.QValueKindString,
+	"LONGTEXT":           qvalue.QValueKindString,
+	"ENUM":               qvalue.QValueKindString,
+	"SET":                qvalue.QValueKindString,
+	// the driver can't scan TIME into a time, it can be longer than a day or negative.
+	"TIME":       qvalue.QValueKindString,
+	"JSON":       qvalue.QValueKindString,
+	"BINARY":     qvalue.QValueKindBytes,
+	"VARBINARY":  qvalue.QValueKindBytes,
+	"TINYBLOB":   qvalue.QValueKindBytes,
+	"BLOB":       qvalue.QValueKindBytes,
+	"MEDIUMBLOB": qvalue.QValueKindBytes,
+	"LONGBLOB":   qvalue.QValueKindBytes,
+	"BIT":        qvalue.QValueKindBytes,
+	"DATE":       qvalue.QValueKindDate,
+	"DATETIME":   qvalue.QValueKindTimestamp,
+	// TIMESTAMP values are stored in UTC and converted to the session time zone.
+	"TIMESTAMP": qvalue.QValueKindTimestampTZ,
+}
+
+var qValueKindToMySQLTypeMap = map[qvalue.QValueKind]string{
+	qvalue.QValueKindBoolean:     "TINYINT(1)",
+	qvalue.QValueKindInt16:       "SMALLINT",
+	qvalue.QValueKindInt32:       "INT",
+	qvalue.QValueKindInt64:       "BIGINT",
+	qvalue.QValueKindFloat32:     "FLOAT",
+	qvalue.QValueKindFloat64:     "DOUBLE",
+	qvalue.QValueKindNumeric:     "DECIMAL(65, 9)",
+	qvalue.QValueKindString:      "LONGTEXT",
+	qvalue.QValueKindJSON:        "JSON",
+	qvalue.QValueKindTimestamp:   "DATETIME(6)",
+	qvalue.QValueKindTimestampTZ: "TIMESTAMP(6)",
+	qvalue.QValueKindTime:        "TIME(6)",
+	qvalue.QValueKindDate:        "DATE",
+	qvalue.QValueKindBit:         "LONGBLOB",
+	qvalue.QValueKindBytes:       "LONGBLOB",
+	qvalue.QValueKindUUID:        "CHAR(36)",
+}
+
+// mysqlColumn is a column of a source table, as the binlog sends the values of a row in column order.
+type mysqlColumn struct {
+	name string
+	kind qvalue.QValueKind
+	// the binlog sends integers as signed values of the width of their type.
+	unsigned bool
+	// MEDIUMINT values are sign extended to 32 bits.
+	mediumInt bool
+	// labels of ENUM and SET columns, the binlog sends their index or bitmap.
+	labels []string
+	isSet  bool
+	// width of BIT columns in bytes.
+	bitBytes int
+}
+
+// newMySQLColumn describes a column from its DATA_TYPE and COLUMN_TYPE in information_schema.columns.
+func newMySQLColumn(name string, dataType string, columnType string) (*mysqlColumn, error) {
+	dataType = strings.ToUpper(dataType)
+	col := &mysqlColumn{
+		name:      name,
+		unsigned:  strings.Contains(strings.ToLower(columnType), "unsigned"),
+		mediumInt: dataType == "MEDIUMINT",
+	}
+
+	typeName := dataType
+	if col.unsigned {
+		typeName = "UNSIGNED " + dataType
+	}
+	kind, ok := mysqlTypeToQValueKindMap[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %s of column %s", columnType, name)
+	}
+	col.kind = kind
+
+	switch dataType {
+	case "ENUM", "SET":
+		col.isSet = dataType == "SET"
+		col.labels = parseTypeLabels(columnType)
+	case "BIT":
+		bits, err := strconv.Atoi(typeArgs(columnType))
+		if err != nil {
+			bits = 1
+		}
+		col.bitBytes = (bits + 7) / 8
+	}
+	return col, nil
+}
+
+// tableMapColumns describes the columns of a table from a table map event of the binlog. The event
+// names the columns and has their signedness and labels with binlog_row_metadata=FULL. Columns of
+// unsupported types are described with QValueKindInvalid.
+func tableMapColumns(e *replication.TableMapEvent) ([]*mysqlColumn, error) {
+	if len(e.ColumnName) != int(e.ColumnCount) {
+		return nil, fmt.Errorf("the binlog has no column names, binlog_row_metadata must be FULL")
+	}
+
+	unsigned := e.UnsignedMap()
+	collations := e.CollationMap()
+	enumLabels := e.EnumStrValueMap()
+	setLabels := e.SetStrValueMap()
+	columns := make([]*mysqlColumn, 0, e.ColumnCount)
+	for i, name := range e.ColumnNameString() {
+		dataType := binlogDataType(e, i, collations)
+		col := &mysqlColumn{
+			name:      name,
+			kind:      qvalue.QValueKindInvalid,
+			unsigned:  unsigned[i],
+			mediumInt: dataType == "MEDIUMINT",
+		}
+		kind, ok := mysqlTypeToQValueKindMap["UNSIGNED "+dataType]
+		if !ok || !col.unsigned {
+			kind, ok = mysqlTypeToQValueKindMap[dataType]
+		}
+		if ok {
+			col.kind = kind
+		}
+
+		switch dataType {
+		case "ENUM":
+			col.labels = enumLabels[i]
+		case "SET":
+			col.isSet = true
+			col.labels = setLabels[i]
+		case "BIT":
+			// the metadata of BIT columns is the number of whole bytes followed by the remaining bits.
+			meta := e.ColumnMeta[i]
+			bits := int(meta>>8)*8 + int(meta&0xff)
+			col.bitBytes = (bits + 7) / 8
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+// binlogDataType returns the DATA_TYPE in information_schema.columns of a column of a table map event,
+// or an empty string for unsupported types. Text and binary strings are told apart by their collation.
+func binlogDataType(e *replication.TableMapEvent, i int, collations map[int]uint64) string {
+	const binaryCollationID = 63
+	isBinary := collations[i] == binaryCollationID
+
+	switch {
+	case e.IsEnumColumn(i):
+		return "ENUM"
+	case e.IsSetColumn(i):
+		return "SET"
+	}
+	switch e.ColumnType[i] {
+	case gomysql.MYSQL_TYPE_TINY:
+		return "TINYINT"
+	case gomysql.MYSQL_TYPE_SHORT:
+		return "SMALLINT"
+	case gomysql.MYSQL_TYPE_INT24:
+		return "MEDIUMINT"
+	case gomysql.MYSQL_TYPE_LONG:
+		return "INT"
+	case gomysql.MYSQL_TYPE_LONGLONG:
+		return "BIGINT"
+	case gomysql.MYSQL_TYPE_YEAR:
+		return "YEAR"
+	case gomysql.MYSQL_TYPE_FLOAT:
+		return "FLOAT"
+	case gomysql.MYSQL_TYPE_DOUBLE:
+		return "DOUBLE"
+	case gomysql.MYSQL_TYPE_DECIMAL, gomysql.MYSQL_TYPE_NEWDECIMAL:
+		return "DECIMAL"
+	case gomysql.MYSQL_TYPE_STRING:
+		if isBinary {
+			return "BINARY"
+		}
+		return "CHAR"
+	case gomysql.MYSQL_TYPE_VARCHAR, gomysql.MYSQL_TYPE_VAR_STRING:
+		if isBinary {
+			return "VARBINARY"
+		}
+		return "VARCHAR"
+	case gomysql.MYSQL_TYPE_TINY_BLOB, gomysql.MYSQL_TYPE_BLOB, gomysql.MYSQL_TYPE_MEDIUM_BLOB,
+		gomysql.MYSQL_TYPE_LONG_BLOB:
+		if isBinary {
+			return "BLOB"
+		}
+		return "TEXT"
+	case gomysql.MYSQL_TYPE_JSON:
+		return "JSON"
+	case gomysql.MYSQL_TYPE_BIT:
+		return "BIT"
+	case gomysql.MYSQL_TYPE_DATE, gomysql.MYSQL_TYPE_NEWDATE:
+		return "DATE"
+	case gomysql.MYSQL_TYPE_TIME, gomysql.MYSQL_TYPE_TIME2:
+		return "TIME"
+	case gomysql.MYSQL_TYPE_DATETIME, gomysql.MYSQL_TYPE_DATETIME2:
+		return "DATETIME"
+	case gomysql.MYSQL_TYPE_TIMESTAMP, gomysql.MYSQL_TYPE_TIMESTAMP2:
+		return "TIMESTAMP"
+	default:
+		return ""
+	}
+}
+
+// typeArgs returns what is between the parentheses of a column type, like 'a','b' of enum('a','b').
+func typeArgs(columnType string) string {
+	start := strings.Index(columnType, "(")
+	end := strings.LastIndex(columnType, ")")
+	if start < 0 || end < start {
+		return ""
+	}
+	return columnType[start+1 : end]
+}
+
+// parseTypeLabels returns the labels of an ENUM or SET column type.
+func parseTypeLabels(columnType string) []string {
+	args := typeArgs(columnType)
+	var labels []string
+	var label strings.Builder
+	inQuotes := false
+	for i := 0; i < len(args); i++ {
+		switch ch := args[i]; {
+		case ch == '\'' && inQuotes && i+1 < len(args) && args[i+1] == '\'':
+			// a quote is escaped by doubling it.
+			label.WriteByte('\'')
+			i++
+		case ch == '\'':
+			inQuotes = !inQuotes
+			if !inQuotes {
+				labels = append(labels, label.String())
+				label.Reset()
+			}
+		case inQuotes:
+			label.WriteByte(ch)
+		}
+	}
+	return labels
+}
+
+// binlogValueToQValue converts a value of a row of the binlog to a QValue of the column's kind.
+// Without ParseTime the binlog sends dates and times as strings, TIMESTAMP columns in UTC.
+func binlogValueToQValue(col *mysqlColumn, val interface{}) (qvalue.QValue, error) {
+	if val == nil {
+		return qvalue.QValue{Kind: col.kind, Value: nil}, nil
+	}
+
+	switch col.kind {
+	case qvalue.QValueKindInt32, qvalue.QValueKindInt64, qvalue.QValueKindNumeric:
+		if col.kind == qvalue.QValueKindNumeric {
+			if s, ok := val.(string); ok {
+				numeric, ok := new(big.Rat).SetString(s)
+				if !ok {
+					return qvalue.QValue{}, fmt.Errorf("failed to parse numeric %s of column %s", s, col.name)
+				}
+				return qvalue.QValue{Kind: col.kind, Value: numeric}, nil
+			}
+		}
+		n, err := binlogInt(val, col.unsigned)
+		if err != nil {
+			return qvalue.QValue{}, fmt.Errorf("column %s: %w", col.name, err)
+		}
+		if col.unsigned && col.mediumInt {
+			n.And(n, big.NewInt(1<<24-1))
+		}
+		switch col.kind {
+		case qvalue.QValueKindInt32:
+			return qvalue.QValue{Kind: col.kind, Value: int32(n.Int64())}, nil
+		case qvalue.QValueKindInt64:
+			return qvalue.QValue{Kind: col.kind, Value: n.Int64()}, nil
+		default:
+			return qvalue.QValue{Kind: col.kind, Value: new(big.Rat).SetInt(n)}, nil
+		}
+	case qvalue.QValueKindFloat32:
+		if f, ok := val.(float32); ok {
+			return qvalue.QValue{Kind: col.kind, Value: f}, nil
+		}
+	case qvalue.QValueKindFloat64:
+		switch f := val.(type) {
+		case float64:
+			return qvalue.QValue{Kind: col.kind, Value: f}, nil
+		case float32:
+			return qvalue.QValue{Kind: col.kind, Value: float64(f)}, nil
+		}
+	case qvalue.QValueKindString:
+		if col.labels != nil {
+			n, err := binlogInt(val, true)
+			if err != nil {
+				return qvalue.QValue{}, fmt.Errorf("column %s: %w", col.name, err)
+			}
+			return qvalue.QValue{Kind: col.kind, Value: col.labelValue(n.Int64())}, nil
+		}
+		switch s := val.(type) {
+		case string:
+			return qvalue.QValue{Kind: col.kind, Value: s}, nil
+		case []byte:
+			return qvalue.QValue{Kind: col.kind, Value: string(s)}, nil
+		}
+	case qvalue.QValueKindBytes:
+		switch b := val.(type) {
+		case []byte:
+			return qvalue.QValue{Kind: col.kind, Value: b}, nil
+		case string:
+			return qvalue.QValue{Kind: col.kind, Value: []byte(b)}, nil
+		case int64:
+			// BIT columns, big endian like the driver returns them.
+			buf := make([]byte, 8)
+			binary.BigEndian.PutUint64(buf, uint64(b))
+			return qvalue.QValue{Kind: col.kind, Value: buf[8-col.bitBytes:]}, nil
+		}
+	case qvalue.QValueKindDate, qvalue.QValueKindTimestamp, qvalue.QValueKindTimestampTZ:
+		if s, ok := val.(string); ok {
+			// MySQL allows zero dates, they have no time equivalent.
+			if strings.HasPrefix(s, "0000-00-00") {
+				return qvalue.QValue{Kind: col.kind, Value: nil}, nil
+			}
+			layout := "2006-01-02 15:04:05.999999"
+			if col.kind == qvalue.QValueKindDate {
+				layout = "2006-01-02"
+			}
+			t, err := time.ParseInLocation(layout, s, time.UTC)
+			if err != nil {
+				return qvalue.QValue{}, fmt.Errorf("failed to parse time %s of column %s: %w", s, col.name, err)
+			}
+			return qvalue.QValue{Kind: col.kind, Value: t}, nil
+		}
+	}
+
+	return qvalue.QValue{}, fmt.Errorf("unsupported value %v of type %T for column %s of kind %s",
+		val, val, col.name, col.kind)
+}
+
+// binlogInt returns the value of an integer of the binlog, which are signed values of the width of
+// their column's type, reinterpreting them for unsigned columns.
+func binlogInt(val interface{}, unsigned bool) (*big.Int, error) {
+	switch n := val.(type) {
+	case int8:
+		if unsigned {
+			return big.NewInt(int64(uint8(n))), nil
+		}
+		return big.NewInt(int64(n)), nil
+	case int16:
+		if unsigned {
+			return big.NewInt(int64(uint16(n))), nil
+		}
+		return big.NewInt(int64(n)), nil
+	case int32:
+		if unsigned {
+			return big.NewInt(int64(uint32(n))), nil
+		}
+		return big.NewInt(int64(n)), nil
+	case int64:
+		if unsigned {
+			return new(big.Int).SetUint64(uint64(n)), nil
+		}
+		return big.NewInt(n), nil
+	case int:
+		return big.NewInt(int64(n)), nil
+	case uint64:
+		return new(big.Int).SetUint64(n), nil
+	default:
+		return nil, fmt.Errorf("invalid integer %v of type %T", val, val)
+	}
+}
+
+// labelValue returns the label of an ENUM index, or the comma separated labels of a SET bitmap.
+func (c *mysqlColumn) labelValue(n int64) string {
+	if !c.isSet {
+		// index 0 is the empty string MySQL stores for invalid values.
+		if n <= 0 || int(n) > len(c.labels) {
+			return ""
+		}
+		return c.labels[n-1]
+	}
+
+	var labels []string
+	for i, label := range c.labels {
+		if n&(1<<i) != 0 {
+			labels = append(labels, label)
+		}
+	}
+	return strings.Join(labels, ",")
+}
@@ -0,0 +1,113 @@
+package connmysql
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	gomysql "github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMySQLColumn(t *testing.T) {
+	col, err := newMySQLColumn("id", "int", "int unsigned")
+	require.NoError(t, err)
+	assert.Equal(t, qvalue.QValueKindInt64, col.kind)
+	assert.True(t, col.unsigned)
+
+	col, err = newMySQLColumn("size", "enum", "enum('small','it''s big')")
+	require.NoError(t, err)
+	assert.Equal(t, qvalue.QValueKindString, col.kind)
+	assert.Equal(t, []string{"small", "it's big"}, col.labels)
+
+	col, err = newMySQLColumn("flags", "bit", "bit(12)")
+	require.NoError(t, err)
+	assert.Equal(t, 2, col.bitBytes)
+
+	_, err = newMySQLColumn("shape", "geometry", "geometry")
+	assert.Error(t, err)
+}
+
+func TestTableMapColumns(t *testing.T) {
+	e := &replication.TableMapEvent{
+		ColumnCount: 6,
+		ColumnType: []byte{gomysql.MYSQL_TYPE_LONG, gomysql.MYSQL_TYPE_STRING, gomysql.MYSQL_TYPE_BIT,
+			gomysql.MYSQL_TYPE_BLOB, gomysql.MYSQL_TYPE_BLOB, gomysql.MYSQL_TYPE_GEOMETRY},
+		ColumnMeta: []uint16{0, uint16(gomysql.MYSQL_TYPE_ENUM)<<8 | 1, 1<<8 | 4, 2, 2, 4},
+		ColumnName: [][]byte{[]byte("id"), []byte("size"), []byte("flags"), []byte("data"), []byte("notes"),
+			[]byte("shape")},
+		SignednessBitmap: []byte{0x80},
+		ColumnCharset:    []uint64{63, 45},
+		EnumStrValue:     [][][]byte{{[]byte("small"), []byte("big")}},
+	}
+	columns, err := tableMapColumns(e)
+	require.NoError(t, err)
+	require.Len(t, columns, 6)
+
+	assert.Equal(t, "id", columns[0].name)
+	assert.Equal(t, qvalue.QValueKindInt64, columns[0].kind)
+	assert.True(t, columns[0].unsigned)
+	assert.Equal(t, qvalue.QValueKindString, columns[1].kind)
+	assert.Equal(t, []string{"small", "big"}, columns[1].labels)
+	assert.Equal(t, qvalue.QValueKindBytes, columns[2].kind)
+	assert.Equal(t, 2, columns[2].bitBytes)
+	assert.Equal(t, qvalue.QValueKindBytes, columns[3].kind)
+	assert.Equal(t, qvalue.QValueKindString, columns[4].kind)
+	assert.Equal(t, qvalue.QValueKindInvalid, columns[5].kind)
+}
+
+func TestBinlogValueToQValue(t *testing.T) {
+	newColumn := func(dataType string, columnType string) *mysqlColumn {
+		col, err := newMySQLColumn("c", dataType, columnType)
+		require.NoError(t, err)
+		return col
+	}
+
+	tests := []struct {
+		name string
+		col  *mysqlColumn
+		val  interface{}
+		want interface{}
+	}{
+		{"int", newColumn("int", "int"), int32(-5), int32(-5)},
+		{"unsigned tinyint", newColumn("tinyint", "tinyint unsigned"), int8(-1), int32(255)},
+		{"unsigned mediumint", newColumn("mediumint", "mediumint unsigned"), int32(-1), int32(1<<24 - 1)},
+		{"unsigned int", newColumn("int", "int unsigned"), int32(-1), int64(1<<32 - 1)},
+		{"unsigned bigint", newColumn("bigint", "bigint unsigned"), int64(-1),
+			new(big.Rat).SetUint64(1<<64 - 1)},
+		{"year", newColumn("year", "year"), 2023, int32(2023)},
+		{"decimal", newColumn("decimal", "decimal(10,2)"), "12.50", big.NewRat(25, 2)},
+		{"double", newColumn("double", "double"), 1.5, 1.5},
+		{"varchar", newColumn("varchar", "varchar(10)"), "abc", "abc"},
+		{"text", newColumn("text", "text"), []byte("abc"), "abc"},
+		{"json", newColumn("json", "json"), []byte(`{"a":1}`), `{"a":1}`},
+		{"enum", newColumn("enum", "enum('a','b')"), int64(2), "b"},
+		{"set", newColumn("set", "set('a','b','c')"), int64(5), "a,c"},
+		{"bit", newColumn("bit", "bit(12)"), int64(0x0102), []byte{0x01, 0x02}},
+		{"blob", newColumn("blob", "blob"), []byte{0xff}, []byte{0xff}},
+		{"date", newColumn("date", "date"), "2023-07-04", time.Date(2023, 7, 4, 0, 0, 0, 0, time.UTC)},
+		{"datetime", newColumn("datetime", "datetime(6)"), "2023-07-04 10:11:12.123456",
+			time.Date(2023, 7, 4, 10, 11, 12, 123456000, time.UTC)},
+		{"zero datetime", newColumn("datetime", "datetime"), "0000-00-00 00:00:00", nil},
+		{"null", newColumn("int", "int"), nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := binlogValueToQValue(tt.col, tt.val)
+			require.NoError(t, err)
+			assert.Equal(t, tt.col.kind, got.Kind)
+			if want, ok := tt.want.(*big.Rat); ok {
+				assert.Equal(t, 0, want.Cmp(got.Value.(*big.Rat)))
+				return
+			}
+			assert.Equal(t, tt.want, got.Value)
+		})
+	}
+
+	_, err := binlogValueToQValue(newColumn("int", "int"), "not a number")
+	assert.Error(t, err)
+}
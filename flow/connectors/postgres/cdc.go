@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
+	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/model"
 	"github.com/PeerDB-io/peer-flow/model/qvalue"
 	"github.com/jackc/pglogrepl"
@@ -15,6 +18,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lib/pq/oid"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
 )
 
 type PostgresCDCSource struct {
@@ -24,9 +28,23 @@ type PostgresCDCSource struct {
 	TableNameMapping      map[string]string
 	slot                  string
 	publication           string
-	relations             map[uint32]*pglogrepl.RelationMessage
-	typeMap               *pgtype.Map
-	startLSN              pglogrepl.LSN
+	// relations seen in the stream or by earlier pulls, keyed by relation id.
+	relations map[uint32]*protos.RelationMessage
+	// regular connection to look up relations that are not cached.
+	catalogConn *pgxpool.Pool
+	// toastable columns of relations, keyed by relation id.
+	toastableColumns map[uint32]map[string]bool
+	// destination table name to schema, used to detect schema changes on the source.
+	tableNameSchemaMapping map[string]*protos.TableSchema
+	typeMap                *pgtype.Map
+	customTypeMapping      map[uint32]qvalue.QValueKind
+	columnFilters          map[string]*protos.ColumnFilter
+	rowFilters             map[string]*rowFilter
+	startLSN               pglogrepl.LSN
+	// commit time of the transaction being decoded.
+	commitTime time.Time
+	// whether the stream is between the begin and commit of a transaction.
+	inTransaction bool
 }
 
 type PostgresCDCConfig struct {
@@ -36,10 +54,39 @@ type PostgresCDCConfig struct {
 	Publication           string
 	SrcTableIDNameMapping map[uint32]string
 	TableNameMapping      map[string]string
+	// TableNameSchemaMapping is the known schema of each destination table.
+	TableNameSchemaMapping map[string]*protos.TableSchema
+	// RelationMessageMapping are the relations seen by earlier pulls of the flow job.
+	RelationMessageMapping map[uint32]*protos.RelationMessage
+	// CatalogConnection is a non-replication connection, used to look up relations missing from the cache.
+	CatalogConnection *pgxpool.Pool
+	// CustomTypeMapping holds the kinds of enums and extension types of the source.
+	CustomTypeMapping map[uint32]qvalue.QValueKind
+	// ColumnFilters are the columns to replicate, keyed by source table name.
+	ColumnFilters map[string]*protos.ColumnFilter
+	// RowFilters are the rows to replicate, keyed by source table name.
+	RowFilters map[string]*rowFilter
 }
 
 // Create a new PostgresCDCSource
 func NewPostgresCDCSource(cdcConfig *PostgresCDCConfig) (*PostgresCDCSource, error) {
+	// the slot only sends a RelationMessage before the first change to a relation it hasn't
+	// sent yet, so relations of earlier pulls are needed to decode changes when resuming.
+	relations := make(map[uint32]*protos.RelationMessage)
+	for relID, rel := range cdcConfig.RelationMessageMapping {
+		relations[relID] = rel
+	}
+
+	// schema changes are applied to the schemas as they're seen, while the destination may be
+	// reading the same schemas to sync the records already pulled.
+	var tableNameSchemaMapping map[string]*protos.TableSchema
+	if cdcConfig.TableNameSchemaMapping != nil {
+		tableNameSchemaMapping = make(map[string]*protos.TableSchema, len(cdcConfig.TableNameSchemaMapping))
+		for dstTableName, schema := range cdcConfig.TableNameSchemaMapping {
+			tableNameSchemaMapping[dstTableName] = proto.Clone(schema).(*protos.TableSchema)
+		}
+	}
+
 	return &PostgresCDCSource{
 		ctx:                   cdcConfig.AppContext,
 		conn:                  cdcConfig.Connection,
@@ -47,8 +94,14 @@ func NewPostgresCDCSource(cdcConfig *PostgresCDCConfig) (*PostgresCDCSource, err
 		TableNameMapping:      cdcConfig.TableNameMapping,
 		slot:                  cdcConfig.Slot,
 		publication:           cdcConfig.Publication,
-		relations:             make(map[uint32]*pglogrepl.RelationMessage),
+		relations:             relations,
+		catalogConn:           cdcConfig.CatalogConnection,
 		typeMap:               pgtype.NewMap(),
+		customTypeMapping:     cdcConfig.CustomTypeMapping,
+		columnFilters:         cdcConfig.ColumnFilters,
+		rowFilters:            cdcConfig.RowFilters,
+		// the mapping may be nil, then schema changes are not tracked.
+		tableNameSchemaMapping: tableNameSchemaMapping,
 	}, nil
 }
 
@@ -58,8 +111,63 @@ func (p *PostgresCDCSource) Close() error {
 	return nil
 }
 
+// maxLastSeenSize is roughly how many bytes of rows a batch keeps to fill in unchanged toast columns,
+// the batch ends once it's reached.
+const maxLastSeenSize = 64 << 20
+
+// cdcBatch is the state of the batch being pulled, its records are sent to the stream as they're decoded.
+type cdcBatch struct {
+	stream     *model.CDCRecordStream
+	numRecords int
+	// destination table name to schema, for the key columns of the tables.
+	tableSchemas map[string]*protos.TableSchema
+	// values of the toastable columns of the last row of each key seen in the batch, to fill in the
+	// unchanged toast columns of later updates. Only rows are kept, not records, so this grows with
+	// the rows changed rather than the changes.
+	tablePKeyLastSeen map[model.TableWithPkey]model.RecordItems
+	// estimated size of the rows in tablePKeyLastSeen.
+	lastSeenSize int
+}
+
+// keepRow keeps the values of a row to fill in the unchanged toast columns of later updates of its key.
+func (b *cdcBatch) keepRow(tablePkey model.TableWithPkey, items model.RecordItems) {
+	b.forgetRow(tablePkey)
+	b.tablePKeyLastSeen[tablePkey] = items
+	b.lastSeenSize += estimateItemsSize(items)
+}
+
+func (b *cdcBatch) forgetRow(tablePkey model.TableWithPkey) {
+	if items, ok := b.tablePKeyLastSeen[tablePkey]; ok {
+		b.lastSeenSize -= estimateItemsSize(items)
+		delete(b.tablePKeyLastSeen, tablePkey)
+	}
+}
+
+// estimateItemsSize estimates the memory held by the values of a row, toast values are strings or bytes.
+func estimateItemsSize(items model.RecordItems) int {
+	size := 64
+	for col, val := range items {
+		size += len(col) + 32
+		switch v := val.Value.(type) {
+		case string:
+			size += len(v)
+		case []byte:
+			size += len(v)
+		}
+	}
+	return size
+}
+
+func (b *cdcBatch) send(record model.Record) error {
+	if err := b.stream.Send(record); err != nil {
+		return err
+	}
+	b.numRecords++
+	return nil
+}
+
 // PullRecords pulls records from the cdc stream
-func (p *PostgresCDCSource) PullRecords(req *model.PullRecordsRequest) (*model.RecordBatch, error) {
+func (p *PostgresCDCSource) PullRecords(req *model.PullRecordsRequest) error {
 	// setup options
 	pluginArguments := []string{
 		"proto_version '1'",
@@ -70,7 +178,7 @@ func (p *PostgresCDCSource) PullRecords(req *model.PullRecordsRequest) (*model.R
 	// create replication connection
 	replicationConn, err := p.conn.Acquire(p.ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error acquiring connection for replication: %w", err)
+		return fmt.Errorf("error acquiring connection for replication: %w", err)
 	}
 
 	defer replicationConn.Release()
@@ -80,7 +188,7 @@ func (p *PostgresCDCSource) PullRecords(req *model.PullRecordsRequest) (*model.R
 
 	sysident, err := pglogrepl.IdentifySystem(p.ctx, pgConn)
 	if err != nil {
-		return nil, fmt.Errorf("IdentifySystem failed: %w", err)
+		return fmt.Errorf("IdentifySystem failed: %w", err)
 	}
 	log.Debugf("SystemID: %s, Timeline: %d, XLogPos: %d, DBName: %s",
 		sysident.SystemID, sysident.Timeline, sysident.XLogPos, sysident.DBName)
@@ -94,11 +202,16 @@ func (p *PostgresCDCSource) PullRecords(req *model.PullRecordsRequest) (*model.R
 
 	err = pglogrepl.StartReplication(p.ctx, pgConn, p.slot, p.startLSN, replicationOpts)
 	if err != nil {
-		return nil, fmt.Errorf("error starting replication at startLsn - %d: %w", p.startLSN, err)
+		return fmt.Errorf("error starting replication at startLsn - %d: %w", p.startLSN, err)
 	}
 	log.Infof("started replication on slot %s at startLSN: %d", p.slot, p.startLSN)
 
-	return p.consumeStream(pgConn, req, p.startLSN)
+	err = p.consumeStream(pgConn, req, p.startLSN)
+	if err != nil {
+		return err
+	}
+	req.RecordStream.SetRelationMessageMapping(p.relations)
+	return nil
 }
 
 // start consuming the cdc stream
@@ -106,12 +219,11 @@ func (p *PostgresCDCSource) consumeStream(
 	conn *pgconn.PgConn,
 	req *model.PullRecordsRequest,
 	clientXLogPos pglogrepl.LSN,
-) (*model.RecordBatch, error) {
-	// TODO (kaushik): take into consideration the MaxBatchSize
-	// parameters in the original request.
-	result := &model.RecordBatch{
-		Records:           make([]model.Record, 0),
-		TablePKeyLastSeen: make(map[model.TableWithPkey]int),
+) error {
+	batch := &cdcBatch{
+		stream:            req.RecordStream,
+		tableSchemas:      req.TableNameSchemaMapping,
+		tablePKeyLastSeen: make(map[model.TableWithPkey]model.RecordItems),
 	}
 
 	standbyMessageTimeout := req.IdleTimeout
@@ -128,7 +240,7 @@ func (p *PostgresCDCSource) consumeStream(
 			err := pglogrepl.SendStandbyStatusUpdate(p.ctx, conn,
 				pglogrepl.StandbyStatusUpdate{WALWritePosition: clientXLogPos})
 			if err != nil {
-				return nil, fmt.Errorf("SendStandbyStatusUpdate failed: %w", err)
+				return fmt.Errorf("SendStandbyStatusUpdate failed: %w", err)
 			}
 			log.Debugf("Sent Standby status message")
 			nextStandbyMessageDeadline = time.Now().Add(standbyMessageTimeout)
@@ -139,14 +251,17 @@ func (p *PostgresCDCSource) consumeStream(
 		cancel()
 		if err != nil {
 			if pgconn.Timeout(err) {
-				log.Infof("Idle timeout reached, returning currently accumulated records")
-				return result, nil
+				if req.TransactionalBatches && p.inTransaction {
+					continue
+				}
+				log.Infof("Idle timeout reached, ending batch of %d records", batch.numRecords)
+				return nil
 			}
-			return nil, fmt.Errorf("ReceiveMessage failed: %w", err)
+			return fmt.Errorf("ReceiveMessage failed: %w", err)
 		}
 
 		if errMsg, ok := rawMsg.(*pgproto3.ErrorResponse); ok {
-			return nil, fmt.Errorf("received Postgres WAL error: %+v", errMsg)
+			return fmt.Errorf("received Postgres WAL error: %+v", errMsg)
 		}
 
 		msg, ok := rawMsg.(*pgproto3.CopyData)
@@ -155,13 +270,11 @@ func (p *PostgresCDCSource) consumeStream(
 			continue
 		}
 
-		firstProcessed := false
-
 		switch msg.Data[0] {
 		case pglogrepl.PrimaryKeepaliveMessageByteID:
 			pkm, err := pglogrepl.ParsePrimaryKeepaliveMessage(msg.Data[1:])
 			if err != nil {
-				return nil, fmt.Errorf("ParsePrimaryKeepaliveMessage failed: %w", err)
+				return fmt.Errorf("ParsePrimaryKeepaliveMessage failed: %w", err)
 			}
 
 			log.Debugf("Primary Keepalive Message => ServerWALEnd: %s ServerTime: %s ReplyRequested: %t",
@@ -174,76 +287,42 @@ func (p *PostgresCDCSource) consumeStream(
 		case pglogrepl.XLogDataByteID:
 			xld, err := pglogrepl.ParseXLogData(msg.Data[1:])
 			if err != nil {
-				return nil, fmt.Errorf("ParseXLogData failed: %w", err)
+				return fmt.Errorf("ParseXLogData failed: %w", err)
 			}
 
 			log.Debugf("XLogData => WALStart %s ServerWALEnd %s ServerTime %s\n",
 				xld.WALStart, xld.ServerWALEnd, xld.ServerTime)
-			rec, err := p.processMessage(result, xld)
-
+			rec, err := p.processMessage(batch, xld)
 			if err != nil {
-				return nil, fmt.Errorf("error processing message: %w", err)
+				return fmt.Errorf("error processing message: %w", err)
 			}
 
-			if !firstProcessed {
-				firstProcessed = true
-				result.FirstCheckPointID = int64(xld.WALStart)
-			}
 			if rec != nil {
-				tableName := rec.GetTableName()
-				switch r := rec.(type) {
-				case *model.UpdateRecord:
-					// tableName here is destination tableName.
-					// should be ideally sourceTableName as we are in pullRecrods.
-					// will change in future
-					pkeyCol := req.TableNameSchemaMapping[tableName].PrimaryKeyColumn
-					pkeyColVal := rec.GetItems()[pkeyCol]
-					tablePkeyVal := model.TableWithPkey{
-						TableName:  tableName,
-						PkeyColVal: pkeyColVal,
-					}
-					_, ok := result.TablePKeyLastSeen[tablePkeyVal]
-					if !ok {
-						result.Records = append(result.Records, rec)
-						result.TablePKeyLastSeen[tablePkeyVal] = len(result.Records) - 1
-					} else {
-						oldRec := result.Records[result.TablePKeyLastSeen[tablePkeyVal]]
-						// iterate through unchanged toast cols and set them
-						for col, val := range oldRec.GetItems() {
-							if _, ok := r.NewItems[col]; !ok {
-								r.NewItems[col] = val
-								delete(r.UnchangedToastColumns, col)
-							}
-						}
-						result.Records = append(result.Records, rec)
-						result.TablePKeyLastSeen[tablePkeyVal] = len(result.Records) - 1
-					}
-				case *model.InsertRecord:
-					pkeyCol := req.TableNameSchemaMapping[tableName].PrimaryKeyColumn
-					pkeyColVal := rec.GetItems()[pkeyCol]
-					tablePkeyVal := model.TableWithPkey{
-						TableName:  tableName,
-						PkeyColVal: pkeyColVal,
-					}
-					result.Records = append(result.Records, rec)
-					// all columns will be set in insert record, so add it to the map
-					result.TablePKeyLastSeen[tablePkeyVal] = len(result.Records) - 1
-				case *model.DeleteRecord:
-					result.Records = append(result.Records, rec)
+				if err := batch.send(rec); err != nil {
+					return err
 				}
 			}
-			result.LastCheckPointID = int64(xld.WALStart)
+			req.RecordStream.UpdateLastCheckPointID(int64(xld.WALStart))
 
 			clientXLogPos = xld.WALStart + pglogrepl.LSN(len(xld.WALData))
 
-			if result.Records != nil && len(result.Records) == int(req.MaxBatchSize) {
-				return result, nil
+			// a truncate can add several records at once, so the batch may overshoot MaxBatchSize slightly.
+			// transactional batches overshoot it until the transaction in progress commits.
+			if batch.numRecords >= int(req.MaxBatchSize) && !(req.TransactionalBatches && p.inTransaction) {
+				return nil
+			}
+			// the rows kept for toast backfill grow with the distinct rows changed, end the batch early
+			// rather than holding on to more of them.
+			if batch.lastSeenSize >= maxLastSeenSize && !(req.TransactionalBatches && p.inTransaction) {
+				log.Infof("ending batch of %d records, %d bytes of rows kept to fill in unchanged toast columns",
+					batch.numRecords, batch.lastSeenSize)
+				return nil
 			}
 		}
 	}
 }
 
-func (p *PostgresCDCSource) processMessage(batch *model.RecordBatch, xld pglogrepl.XLogData) (model.Record, error) {
+func (p *PostgresCDCSource) processMessage(batch *cdcBatch, xld pglogrepl.XLogData) (model.Record, error) {
 	logicalMsg, err := pglogrepl.Parse(xld.WALData)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing logical message: %w", err)
@@ -251,25 +330,39 @@ func (p *PostgresCDCSource) processMessage(batch *model.RecordBatch, xld pglogre
 
 	switch msg := logicalMsg.(type) {
 	case *pglogrepl.BeginMessage:
-		log.Debugf("Ignoring BeginMessage")
+		p.commitTime = msg.CommitTime
+		p.inTransaction = true
 	case *pglogrepl.InsertMessage:
-		return p.processInsertMessage(xld.WALStart, msg)
+		rec, err := p.processInsertMessage(xld.WALStart, msg)
+		if err != nil {
+			return nil, err
+		}
+		return rec, p.trackLastSeenRow(batch, msg.RelationID, rec)
 	case *pglogrepl.UpdateMessage:
-		return p.processUpdateMessage(xld.WALStart, msg)
+		rec, err := p.processUpdateMessage(xld.WALStart, msg)
+		if err != nil {
+			return nil, err
+		}
+		return rec, p.trackLastSeenRow(batch, msg.RelationID, rec)
 	case *pglogrepl.DeleteMessage:
-		return p.processDeleteMessage(xld.WALStart, msg)
+		rec, err := p.processDeleteMessage(xld.WALStart, msg)
+		if err != nil {
+			return nil, err
+		}
+		return rec, p.trackLastSeenRow(batch, msg.RelationID, rec)
 	case *pglogrepl.CommitMessage:
 		// for a commit message, update the last checkpoint id for the record batch.
-		batch.LastCheckPointID = int64(xld.WALStart)
+		batch.stream.UpdateLastCheckPointID(int64(xld.WALStart))
+		p.inTransaction = false
 	case *pglogrepl.RelationMessage:
 		// TODO (kaushik): consider persistent state for a mirror job
 		// to be stored somewhere in temporal state. We might need to persist
 		// the state of the relation message somewhere
 		log.Infof("RelationMessage => RelationID: %d, Namespace: %s, RelationName: %s, Columns: %v",
 			msg.RelationID, msg.Namespace, msg.RelationName, msg.Columns)
-		p.relations[msg.RelationID] = msg
+		p.processRelationMessage(batch, xld.WALStart, msg)
 	case *pglogrepl.TruncateMessage:
-		log.Warnf("TruncateMessage not supported")
+		return nil, p.processTruncateMessage(batch, xld.WALStart, msg)
 	default:
 		// Ignore other message types
 		log.Warnf("Ignoring message type: %T", reflect.TypeOf(logicalMsg))
@@ -290,19 +383,33 @@ func (p *PostgresCDCSource) processInsertMessage(
 	// log lsn and relation id for debugging
 	log.Debugf("InsertMessage => LSN: %d, RelationID: %d, Relation Name: %s", lsn, msg.RelationID, tableName)
 
-	rel, ok := p.relations[msg.RelationID]
-	if !ok {
-		return nil, fmt.Errorf("unknown relation id: %d", msg.RelationID)
+	rel, err := p.getRelation(msg.RelationID, tableName)
+	if err != nil {
+		return nil, err
 	}
 
 	// create empty map of string to interface{}
-	items, unchangedToastColumns, err := p.convertTupleToMap(msg.Tuple, rel)
+	items, unchangedToastColumns, err := p.convertTupleToMap(msg.Tuple, rel, tableName)
 	if err != nil {
 		return nil, fmt.Errorf("error converting tuple to map: %w", err)
 	}
 
+	if filter, ok := p.rowFilters[tableName]; ok {
+		matched, known, err := filter.evaluate(items)
+		if err != nil {
+			return nil, err
+		}
+		if !known {
+			return nil, fmt.Errorf("inserted row of table %s is missing columns of its row filter", tableName)
+		}
+		if !matched {
+			return nil, nil
+		}
+	}
+
 	return &model.InsertRecord{
 		CheckPointID:          int64(lsn),
+		CommitTime:            p.commitTime,
 		Items:                 items,
 		DestinationTableName:  p.TableNameMapping[tableName],
 		SourceTableName:       tableName,
@@ -323,24 +430,38 @@ func (p *PostgresCDCSource) processUpdateMessage(
 	// log lsn and relation id for debugging
 	log.Debugf("UpdateMessage => LSN: %d, RelationID: %d, Relation Name: %s", lsn, msg.RelationID, tableName)
 
-	rel, ok := p.relations[msg.RelationID]
-	if !ok {
-		return nil, fmt.Errorf("unknown relation id: %d", msg.RelationID)
+	rel, err := p.getRelation(msg.RelationID, tableName)
+	if err != nil {
+		return nil, err
 	}
 
 	// create empty map of string to interface{}
-	oldItems, _, err := p.convertTupleToMap(msg.OldTuple, rel)
+	oldItems, _, err := p.convertTupleToMap(msg.OldTuple, rel, tableName)
 	if err != nil {
 		return nil, fmt.Errorf("error converting old tuple to map: %w", err)
 	}
 
-	newItems, unchangedToastColumns, err := p.convertTupleToMap(msg.NewTuple, rel)
+	newItems, unchangedToastColumns, err := p.convertTupleToMap(msg.NewTuple, rel, tableName)
 	if err != nil {
 		return nil, fmt.Errorf("error converting new tuple to map: %w", err)
 	}
 
+	// with REPLICA IDENTITY FULL the old tuple carries every column,
+	// so unchanged toast columns can be filled in from it.
+	for col := range unchangedToastColumns {
+		if val, ok := oldItems[col]; ok {
+			newItems[col] = val
+			delete(unchangedToastColumns, col)
+		}
+	}
+
+	if filter, ok := p.rowFilters[tableName]; ok {
+		return p.filterUpdate(lsn, filter, rel, tableName, oldItems, newItems, unchangedToastColumns)
+	}
+
 	return &model.UpdateRecord{
 		CheckPointID:          int64(lsn),
+		CommitTime:            p.commitTime,
 		OldItems:              oldItems,
 		NewItems:              newItems,
 		DestinationTableName:  p.TableNameMapping[tableName],
@@ -349,6 +470,82 @@ func (p *PostgresCDCSource) processUpdateMessage(
 	}, nil
 }
 
+// filterUpdate applies the row filter of a table to an update. An update moving a row out of
+// the filter becomes a delete, an update of a row that stays filtered out is dropped.
+func (p *PostgresCDCSource) filterUpdate(
+	lsn pglogrepl.LSN,
+	filter *rowFilter,
+	rel *protos.RelationMessage,
+	tableName string,
+	oldItems model.RecordItems,
+	newItems model.RecordItems,
+	unchangedToastColumns map[string]bool,
+) (model.Record, error) {
+	matched, known, err := filter.evaluate(newItems)
+	if err != nil {
+		return nil, err
+	}
+	// the filter references unchanged toast columns, whose values are only sent in the old row
+	// with REPLICA IDENTITY FULL. Passing the update on could replicate a row that is filtered out.
+	if !known {
+		return nil, fmt.Errorf("row filter of table %s references unchanged toast columns of an update, "+
+			"the table needs REPLICA IDENTITY FULL", tableName)
+	}
+	if matched {
+		return &model.UpdateRecord{
+			CheckPointID:          int64(lsn),
+			CommitTime:            p.commitTime,
+			OldItems:              oldItems,
+			NewItems:              newItems,
+			DestinationTableName:  p.TableNameMapping[tableName],
+			SourceTableName:       tableName,
+			UnchangedToastColumns: unchangedToastColumns,
+		}, nil
+	}
+
+	// the old row is only sent if the key changed or with REPLICA IDENTITY FULL, and only the columns
+	// of the replica identity are set. Without it the key and so the filtered columns didn't change,
+	// the row was already filtered out. Whether the row was moved out of the filter can only be told
+	// if the filter references replica identity columns only, otherwise the update is dropped.
+	if len(oldItems) == 0 || !rowFilterInReplicaIdentity(filter, rel) {
+		return nil, nil
+	}
+	oldMatched, _, err := filter.evaluate(oldItems)
+	if err != nil {
+		return nil, err
+	}
+	if !oldMatched {
+		return nil, nil
+	}
+
+	return &model.DeleteRecord{
+		CheckPointID:          int64(lsn),
+		CommitTime:            p.commitTime,
+		Items:                 oldItems,
+		DestinationTableName:  p.TableNameMapping[tableName],
+		SourceTableName:       tableName,
+		UnchangedToastColumns: make(map[string]bool),
+	}, nil
+}
+
+// rowFilterInReplicaIdentity returns true if every column referenced by the filter is part of the replica
+// identity of the relation, which are all the columns with REPLICA IDENTITY FULL.
+func rowFilterInReplicaIdentity(filter *rowFilter, rel *protos.RelationMessage) bool {
+	replicaIdentity := make(map[string]bool, len(rel.Columns))
+	for _, col := range rel.Columns {
+		// pgoutput flags the columns of the replica identity with 1.
+		if col.Flags&1 != 0 {
+			replicaIdentity[col.Name] = true
+		}
+	}
+	for _, col := range filter.columns() {
+		if !replicaIdentity[col] {
+			return false
+		}
+	}
+	return true
+}
+
 // processDeleteMessage processes a delete message and returns a DeleteRecord
 func (p *PostgresCDCSource) processDeleteMessage(
 	lsn pglogrepl.LSN,
@@ -362,19 +559,32 @@ func (p *PostgresCDCSource) processDeleteMessage(
 	// log lsn and relation id for debugging
 	log.Debugf("DeleteMessage => LSN: %d, RelationID: %d, Relation Name: %s", lsn, msg.RelationID, tableName)
 
-	rel, ok := p.relations[msg.RelationID]
-	if !ok {
-		return nil, fmt.Errorf("unknown relation id: %d", msg.RelationID)
+	rel, err := p.getRelation(msg.RelationID, tableName)
+	if err != nil {
+		return nil, err
 	}
 
 	// create empty map of string to interface{}
-	items, unchangedToastColumns, err := p.convertTupleToMap(msg.OldTuple, rel)
+	items, unchangedToastColumns, err := p.convertTupleToMap(msg.OldTuple, rel, tableName)
 	if err != nil {
 		return nil, fmt.Errorf("error converting tuple to map: %w", err)
 	}
 
+	// the old row may only carry the key columns, the delete is only dropped
+	// if the deleted row is known to be filtered out.
+	if filter, ok := p.rowFilters[tableName]; ok {
+		matched, known, err := filter.evaluate(items)
+		if err != nil {
+			return nil, err
+		}
+		if known && !matched {
+			return nil, nil
+		}
+	}
+
 	return &model.DeleteRecord{
 		CheckPointID:          int64(lsn),
+		CommitTime:            p.commitTime,
 		Items:                 items,
 		DestinationTableName:  p.TableNameMapping[tableName],
 		SourceTableName:       tableName,
@@ -382,16 +592,288 @@ func (p *PostgresCDCSource) processDeleteMessage(
 	}, nil
 }
 
+// trackLastSeenRow fills in the unchanged toast columns of an update from the last row of its key seen
+// in the batch, and keeps the toastable columns of inserted and updated rows for later updates.
+func (p *PostgresCDCSource) trackLastSeenRow(batch *cdcBatch, relID uint32, rec model.Record) error {
+	if rec == nil {
+		return nil
+	}
+	// without key columns rows can't be told apart, so there is nothing to fill in from.
+	schema, ok := batch.tableSchemas[rec.GetTableName()]
+	if !ok || len(schema.PrimaryKeyColumns) == 0 {
+		return nil
+	}
+	pkeyCols := schema.PrimaryKeyColumns
+
+	var items model.RecordItems
+	var tablePkey model.TableWithPkey
+	switch r := rec.(type) {
+	case *model.InsertRecord:
+		items = r.Items
+		tablePkey = model.RecToTablePKey(r.DestinationTableName, pkeyCols, r.Items)
+	case *model.UpdateRecord:
+		tablePkey = model.RecToTablePKey(r.DestinationTableName, pkeyCols, r.NewItems)
+		if lastItems, ok := batch.tablePKeyLastSeen[tablePkey]; ok {
+			for col := range r.UnchangedToastColumns {
+				if val, ok := lastItems[col]; ok {
+					r.NewItems[col] = val
+					delete(r.UnchangedToastColumns, col)
+				}
+			}
+		}
+		items = r.NewItems
+		// the row under a key that was changed is gone.
+		oldTablePkey := model.RecToTablePKey(r.DestinationTableName, pkeyCols, r.OldItems)
+		if oldTablePkey != tablePkey {
+			batch.forgetRow(oldTablePkey)
+		}
+	case *model.DeleteRecord:
+		batch.forgetRow(model.RecToTablePKey(r.DestinationTableName, pkeyCols, r.Items))
+		return nil
+	default:
+		return nil
+	}
+
+	toastableColumns, err := p.getToastableColumns(relID)
+	if err != nil {
+		return err
+	}
+	// values are copied, sending the record replaces its items with the transformed items.
+	kept := make(model.RecordItems)
+	for col, val := range items {
+		if toastableColumns == nil || toastableColumns[col] {
+			kept[col] = val
+		}
+	}
+	batch.keepRow(tablePkey, kept)
+	return nil
+}
+
+// getToastableColumns returns the columns of a relation whose values can be stored out of line, only these
+// are left out of updates as unchanged toast columns. It returns nil when there is no catalog connection to
+// look them up, then any column has to be assumed toastable.
+func (p *PostgresCDCSource) getToastableColumns(relID uint32) (map[string]bool, error) {
+	if cols, ok := p.toastableColumns[relID]; ok {
+		return cols, nil
+	}
+	if p.catalogConn == nil {
+		return nil, nil
+	}
+
+	rows, err := p.catalogConn.Query(p.ctx, getToastableColumnsSQL, relID)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up toastable columns of relation id %d: %w", relID, err)
+	}
+	defer rows.Close()
+
+	cols := make(map[string]bool)
+	for rows.Next() {
+		var colName string
+		if err := rows.Scan(&colName); err != nil {
+			return nil, fmt.Errorf("error reading toastable columns of relation id %d: %w", relID, err)
+		}
+		cols[colName] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading toastable columns of relation id %d: %w", relID, err)
+	}
+
+	if p.toastableColumns == nil {
+		p.toastableColumns = make(map[uint32]map[string]bool)
+	}
+	p.toastableColumns[relID] = cols
+	return cols, nil
+}
+
+// processTruncateMessage sends a TruncateRecord for every mapped relation in the message.
+// A single TRUNCATE can cover several tables, so records are sent directly instead of returned.
+func (p *PostgresCDCSource) processTruncateMessage(
+	batch *cdcBatch,
+	lsn pglogrepl.LSN,
+	msg *pglogrepl.TruncateMessage,
+) error {
+	for _, relID := range msg.RelationIDs {
+		tableName, exists := p.SrcTableIDNameMapping[relID]
+		if !exists {
+			continue
+		}
+
+		log.Infof("TruncateMessage => LSN: %d, RelationID: %d, Relation Name: %s", lsn, relID, tableName)
+
+		destinationTableName := p.TableNameMapping[tableName]
+		err := batch.send(&model.TruncateRecord{
+			CheckPointID:         int64(lsn),
+			CommitTime:           p.commitTime,
+			DestinationTableName: destinationTableName,
+			SourceTableName:      tableName,
+		})
+		if err != nil {
+			return err
+		}
+
+		// rows seen before the truncate must not be used to fill in unchanged toast columns.
+		for tablePkey := range batch.tablePKeyLastSeen {
+			if tablePkey.TableName == destinationTableName {
+				batch.forgetRow(tablePkey)
+			}
+		}
+	}
+	return nil
+}
+
+// processRelationMessage caches the relation and diffs its columns against the known schema
+// of the destination table. Any difference is added to the stream as a TableSchemaDelta.
+func (p *PostgresCDCSource) processRelationMessage(
+	batch *cdcBatch,
+	lsn pglogrepl.LSN,
+	msg *pglogrepl.RelationMessage,
+) {
+	relCols := make([]*protos.RelationMessageColumn, 0, len(msg.Columns))
+	for _, col := range msg.Columns {
+		relCols = append(relCols, &protos.RelationMessageColumn{
+			Flags:    uint32(col.Flags),
+			Name:     col.Name,
+			DataType: col.DataType,
+		})
+	}
+	p.relations[msg.RelationID] = &protos.RelationMessage{
+		RelationId:   msg.RelationID,
+		RelationName: msg.RelationName,
+		Columns:      relCols,
+	}
+	// the columns may have changed, their storage is looked up again.
+	delete(p.toastableColumns, msg.RelationID)
+
+	tableName, exists := p.SrcTableIDNameMapping[msg.RelationID]
+	if !exists {
+		return
+	}
+	dstTableName := p.TableNameMapping[tableName]
+	schema, ok := p.tableNameSchemaMapping[dstTableName]
+	if !ok {
+		return
+	}
+
+	delta := &protos.TableSchemaDelta{
+		SrcTableName:   tableName,
+		DstTableName:   dstTableName,
+		AddedColumns:   make([]*protos.DeltaColumn, 0),
+		DroppedColumns: make([]string, 0),
+		RetypedColumns: make([]*protos.DeltaColumn, 0),
+	}
+	relColumns := make(map[string]bool)
+	for _, col := range msg.Columns {
+		if !utils.IsColumnReplicated(p.columnFilters[tableName], col.Name) {
+			continue
+		}
+		relColumns[col.Name] = true
+		colType := postgresOIDToQValueKind(col.DataType, p.customTypeMapping)
+		if colType == qvalue.QValueKindInvalid {
+			log.Warnf("skipping column %s of %s with unsupported type OID %d", col.Name, tableName, col.DataType)
+			continue
+		}
+
+		prevType, ok := schema.Columns[col.Name]
+		if !ok {
+			delta.AddedColumns = append(delta.AddedColumns, &protos.DeltaColumn{
+				ColumnName: col.Name,
+				ColumnType: string(colType),
+			})
+		} else if prevType != string(colType) {
+			delta.RetypedColumns = append(delta.RetypedColumns, &protos.DeltaColumn{
+				ColumnName: col.Name,
+				ColumnType: string(colType),
+			})
+		}
+	}
+	for colName := range schema.Columns {
+		if !relColumns[colName] {
+			delta.DroppedColumns = append(delta.DroppedColumns, colName)
+		}
+	}
+	sort.Strings(delta.DroppedColumns)
+
+	if len(delta.AddedColumns) == 0 && len(delta.DroppedColumns) == 0 && len(delta.RetypedColumns) == 0 {
+		return
+	}
+
+	log.Infof("schema change for %s at LSN %d => added: %v, dropped: %v, retyped: %v",
+		tableName, lsn, delta.AddedColumns, delta.DroppedColumns, delta.RetypedColumns)
+	batch.stream.AddSchemaDelta(delta)
+	// keep diffing later relation messages against the changed schema.
+	model.ApplyTableSchemaDelta(schema, delta)
+}
+
+// getRelation returns the cached relation for a relation id. Relations that were never seen
+// are looked up in the catalog, which has the current columns of the table in the column list
+// of the publication. These match the columns at the time of the change unless the table or
+// the publication was altered since.
+func (p *PostgresCDCSource) getRelation(relID uint32, tableName string) (*protos.RelationMessage, error) {
+	if rel, ok := p.relations[relID]; ok {
+		return rel, nil
+	}
+	if p.catalogConn == nil {
+		return nil, fmt.Errorf("unknown relation id: %d", relID)
+	}
+
+	log.Warnf("relation %s with id %d not in cache, looking up its columns in the catalog", tableName, relID)
+	var serverVersion int
+	err := p.catalogConn.QueryRow(p.ctx, "SELECT current_setting('server_version_num')::INT").Scan(&serverVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error getting server version: %w", err)
+	}
+	query, args := getRelationColumnsSQL, []interface{}{relID}
+	if serverVersion >= 150000 {
+		query, args = getPublishedRelationColumnsSQL, []interface{}{relID, p.publication}
+	}
+	rows, err := p.catalogConn.Query(p.ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up columns of relation id %d: %w", relID, err)
+	}
+	defer rows.Close()
+
+	relCols := make([]*protos.RelationMessageColumn, 0)
+	for rows.Next() {
+		var colName string
+		var dataType, flags uint32
+		err = rows.Scan(&colName, &dataType, &flags)
+		if err != nil {
+			return nil, fmt.Errorf("error reading columns of relation id %d: %w", relID, err)
+		}
+		relCols = append(relCols, &protos.RelationMessageColumn{
+			Name:     colName,
+			DataType: dataType,
+			Flags:    flags,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading columns of relation id %d: %w", relID, err)
+	}
+	if len(relCols) == 0 {
+		return nil, fmt.Errorf("unknown relation id: %d", relID)
+	}
+
+	rel := &protos.RelationMessage{
+		RelationId:   relID,
+		RelationName: tableName,
+		Columns:      relCols,
+	}
+	p.relations[relID] = rel
+	return rel, nil
+}
+
 /*
 convertTupleToMap converts a PostgreSQL logical replication
 tuple to a map representation.
-It takes a tuple and a relation message as input and returns
+It takes a tuple, its relation message and the source table name as input and returns
 1. a map of column names to values and
 2. a string slice of unchanged TOAST column names
+Columns filtered out for the table are left out of both.
 */
 func (p *PostgresCDCSource) convertTupleToMap(
 	tuple *pglogrepl.TupleData,
-	rel *pglogrepl.RelationMessage,
+	rel *protos.RelationMessage,
+	tableName string,
 ) (model.RecordItems, map[string]bool, error) {
 	// if the tuple is nil, return an empty map
 	if tuple == nil {
@@ -402,8 +884,18 @@ func (p *PostgresCDCSource) convertTupleToMap(
 	items := make(model.RecordItems)
 	unchangedToastColumns := make(map[string]bool)
 
+	// the columns of a tuple are those of its relation, in their order.
+	if len(tuple.Columns) > len(rel.Columns) {
+		return nil, nil, fmt.Errorf("tuple of relation %s has %d columns, the relation has %d",
+			rel.RelationName, len(tuple.Columns), len(rel.Columns))
+	}
+
+	columnFilter := p.columnFilters[tableName]
 	for idx, col := range tuple.Columns {
 		colName := rel.Columns[idx].Name
+		if !utils.IsColumnReplicated(columnFilter, colName) {
+			continue
+		}
 		switch col.DataType {
 		case 'n': // null
 			items[colName] = qvalue.QValue{Kind: qvalue.QValueKindInvalid, Value: nil}
@@ -443,13 +935,14 @@ func (p *PostgresCDCSource) decodeColumnData(data []byte, dataType uint32, forma
 		if err != nil {
 			return nil, err
 		}
-		retVal, err := parseFieldFromPostgresOID(dataType, parsedData)
+		retVal, err := parseFieldFromPostgresOID(dataType, parsedData, p.customTypeMapping)
 		if err != nil {
 			return nil, err
 		}
 		return retVal, nil
-	} else if dataType == uint32(oid.T_timetz) { // ugly TIMETZ workaround for CDC decoding.
-		retVal, err := parseFieldFromPostgresOID(dataType, string(data))
+	} else if _, ok := p.customTypeMapping[dataType]; ok || dataType == uint32(oid.T_timetz) {
+		// ugly TIMETZ workaround for CDC decoding, enums and extension types are decoded from text as well.
+		retVal, err := parseFieldFromPostgresOID(dataType, string(data), p.customTypeMapping)
 		if err != nil {
 			return nil, err
 		}
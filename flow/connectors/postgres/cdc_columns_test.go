@@ -0,0 +1,104 @@
+package connpostgres
+
+import (
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/jackc/pglogrepl"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFilteredCDCSource(t *testing.T) *PostgresCDCSource {
+	source, err := NewPostgresCDCSource(&PostgresCDCConfig{
+		SrcTableIDNameMapping: map[uint32]string{testRelID: "public.users"},
+		TableNameMapping:      map[string]string{"public.users": "public.users_dst"},
+		TableNameSchemaMapping: map[string]*protos.TableSchema{
+			"public.users_dst": {
+				TableIdentifier: "public.users_dst",
+				Columns: map[string]string{
+					"id":   string(qvalue.QValueKindInt64),
+					"name": string(qvalue.QValueKindString),
+				},
+				PrimaryKeyColumns: []string{"id"},
+			},
+		},
+		RelationMessageMapping: map[uint32]*protos.RelationMessage{
+			testRelID: {
+				RelationId:   testRelID,
+				RelationName: "users",
+				Columns: []*protos.RelationMessageColumn{
+					{Name: "id", DataType: uint32(oid.T_int8)},
+					{Name: "name", DataType: uint32(oid.T_text)},
+					{Name: "password", DataType: uint32(oid.T_text)},
+				},
+			},
+		},
+		ColumnFilters: map[string]*protos.ColumnFilter{
+			"public.users": {ExcludeColumns: []string{"password", "token"}},
+		},
+	})
+	require.NoError(t, err)
+	return source
+}
+
+func TestFilteredColumnsAreNotDecoded(t *testing.T) {
+	source := newTestFilteredCDCSource(t)
+
+	record, err := source.processInsertMessage(0, &pglogrepl.InsertMessage{
+		RelationID: testRelID,
+		Tuple: &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{
+			{DataType: 't', Data: []byte("1")},
+			{DataType: 't', Data: []byte("ada")},
+			{DataType: 't', Data: []byte("hunter2")},
+		}},
+	})
+	require.NoError(t, err)
+	insert, ok := record.(*model.InsertRecord)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), insert.Items["id"].Value)
+	assert.Equal(t, "ada", insert.Items["name"].Value)
+	assert.NotContains(t, insert.Items, "password")
+}
+
+func TestFilteredColumnsAreNotAdded(t *testing.T) {
+	source := newTestFilteredCDCSource(t)
+	stream := model.NewCDCRecordStream(1, nil)
+
+	// a column added to the source table is only added to the destination if it is replicated.
+	source.processRelationMessage(&cdcBatch{stream: stream}, 0, &pglogrepl.RelationMessage{
+		RelationID:   testRelID,
+		RelationName: "users",
+		Columns: []*pglogrepl.RelationMessageColumn{
+			{Flags: 1, Name: "id", DataType: uint32(oid.T_int8)},
+			{Name: "name", DataType: uint32(oid.T_text)},
+			{Name: "password", DataType: uint32(oid.T_text)},
+			{Name: "token", DataType: uint32(oid.T_text)},
+			{Name: "email", DataType: uint32(oid.T_text)},
+		},
+	})
+
+	deltas := stream.TableSchemaDeltas()
+	require.Len(t, deltas, 1)
+	require.Len(t, deltas[0].AddedColumns, 1)
+	assert.Equal(t, "email", deltas[0].AddedColumns[0].ColumnName)
+	assert.Empty(t, deltas[0].DroppedColumns)
+}
+
+func TestTupleLongerThanRelationIsRejected(t *testing.T) {
+	source := newTestFilteredCDCSource(t)
+
+	_, err := source.processInsertMessage(0, &pglogrepl.InsertMessage{
+		RelationID: testRelID,
+		Tuple: &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{
+			{DataType: 't', Data: []byte("1")},
+			{DataType: 't', Data: []byte("ada")},
+			{DataType: 't', Data: []byte("hunter2")},
+			{DataType: 't', Data: []byte("extra")},
+		}},
+	})
+	require.ErrorContains(t, err, "tuple of relation users has 4 columns, the relation has 3")
+}
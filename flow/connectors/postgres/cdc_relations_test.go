@@ -0,0 +1,155 @@
+package connpostgres
+
+import (
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/jackc/pglogrepl"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRelID = 16384
+
+func newTestCDCSource(t *testing.T, relations map[uint32]*protos.RelationMessage) *PostgresCDCSource {
+	source, err := NewPostgresCDCSource(&PostgresCDCConfig{
+		SrcTableIDNameMapping: map[uint32]string{testRelID: "public.users"},
+		TableNameMapping:      map[string]string{"public.users": "public.users_dst"},
+		TableNameSchemaMapping: map[string]*protos.TableSchema{
+			"public.users_dst": {
+				TableIdentifier:   "public.users_dst",
+				Columns:           map[string]string{"id": string(qvalue.QValueKindInt64)},
+				PrimaryKeyColumns: []string{"id"},
+			},
+		},
+		RelationMessageMapping: relations,
+	})
+	require.NoError(t, err)
+	return source
+}
+
+func TestCachedRelationDecodesChanges(t *testing.T) {
+	// the relation was sent to an earlier pull, this pull only gets the insert.
+	relations := map[uint32]*protos.RelationMessage{
+		testRelID: {
+			RelationId:   testRelID,
+			RelationName: "users",
+			Columns: []*protos.RelationMessageColumn{
+				{Name: "id", DataType: uint32(oid.T_int8)},
+				{Name: "name", DataType: uint32(oid.T_text)},
+			},
+		},
+	}
+	source := newTestCDCSource(t, relations)
+
+	record, err := source.processInsertMessage(0, &pglogrepl.InsertMessage{
+		RelationID: testRelID,
+		Tuple: &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{
+			{DataType: 't', Data: []byte("1")},
+			{DataType: 't', Data: []byte("ada")},
+		}},
+	})
+	require.NoError(t, err)
+	insert, ok := record.(*model.InsertRecord)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), insert.Items["id"].Value)
+	assert.Equal(t, "ada", insert.Items["name"].Value)
+}
+
+func TestRelationMessageIsCached(t *testing.T) {
+	relations := map[uint32]*protos.RelationMessage{}
+	source := newTestCDCSource(t, relations)
+	stream := model.NewCDCRecordStream(1, nil)
+
+	source.processRelationMessage(&cdcBatch{stream: stream}, 0, &pglogrepl.RelationMessage{
+		RelationID:   testRelID,
+		RelationName: "users",
+		Columns: []*pglogrepl.RelationMessageColumn{
+			{Flags: 1, Name: "id", DataType: uint32(oid.T_int8)},
+			{Name: "email", DataType: uint32(oid.T_text)},
+		},
+	})
+
+	rel, err := source.getRelation(testRelID, "public.users")
+	require.NoError(t, err)
+	assert.Equal(t, "users", rel.RelationName)
+	require.Len(t, rel.Columns, 2)
+	assert.Equal(t, uint32(1), rel.Columns[0].Flags)
+	assert.Equal(t, "email", rel.Columns[1].Name)
+	assert.Equal(t, uint32(oid.T_text), rel.Columns[1].DataType)
+	// the mapping the pull started from is passed on as is, the cache is a copy.
+	assert.Empty(t, relations)
+
+	// the new column is reported as a schema change of the destination table.
+	deltas := stream.TableSchemaDeltas()
+	require.Len(t, deltas, 1)
+	assert.Equal(t, "public.users_dst", deltas[0].DstTableName)
+	require.Len(t, deltas[0].AddedColumns, 1)
+	assert.Equal(t, "email", deltas[0].AddedColumns[0].ColumnName)
+}
+
+func TestUnknownRelationWithoutCatalog(t *testing.T) {
+	source := newTestCDCSource(t, nil)
+
+	_, err := source.processDeleteMessage(0, &pglogrepl.DeleteMessage{
+		RelationID: testRelID,
+		OldTuple:   &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{{DataType: 't', Data: []byte("1")}}},
+	})
+	assert.ErrorContains(t, err, "unknown relation id: 16384")
+}
+
+func TestLastSeenRowFillsUnchangedToastColumns(t *testing.T) {
+	relations := map[uint32]*protos.RelationMessage{
+		testRelID: {
+			RelationId:   testRelID,
+			RelationName: "users",
+			Columns: []*protos.RelationMessageColumn{
+				{Flags: 1, Name: "id", DataType: uint32(oid.T_int8)},
+				{Name: "bio", DataType: uint32(oid.T_text)},
+			},
+		},
+	}
+	source := newTestCDCSource(t, relations)
+	source.toastableColumns = map[uint32]map[string]bool{testRelID: {"bio": true}}
+	batch := &cdcBatch{
+		stream:            model.NewCDCRecordStream(4, nil),
+		tableSchemas:      source.tableNameSchemaMapping,
+		tablePKeyLastSeen: make(map[model.TableWithPkey]model.RecordItems),
+	}
+	tuple := func(id string, bio *pglogrepl.TupleDataColumn) *pglogrepl.TupleData {
+		return &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{{DataType: 't', Data: []byte(id)}, bio}}
+	}
+
+	insert, err := source.processInsertMessage(0, &pglogrepl.InsertMessage{
+		RelationID: testRelID,
+		Tuple:      tuple("1", &pglogrepl.TupleDataColumn{DataType: 't', Data: []byte("a long bio")}),
+	})
+	require.NoError(t, err)
+	require.NoError(t, source.trackLastSeenRow(batch, testRelID, insert))
+	// only the toastable columns of the row are kept.
+	tablePkey := model.RecToTablePKey("public.users_dst", []string{"id"}, insert.(*model.InsertRecord).Items)
+	assert.Equal(t, model.RecordItems{"bio": insert.(*model.InsertRecord).Items["bio"]}, batch.tablePKeyLastSeen[tablePkey])
+	assert.Positive(t, batch.lastSeenSize)
+
+	update, err := source.processUpdateMessage(0, &pglogrepl.UpdateMessage{
+		RelationID: testRelID,
+		NewTuple:   tuple("1", &pglogrepl.TupleDataColumn{DataType: 'u'}),
+	})
+	require.NoError(t, err)
+	require.NoError(t, source.trackLastSeenRow(batch, testRelID, update))
+	updateRecord := update.(*model.UpdateRecord)
+	assert.Equal(t, "a long bio", updateRecord.NewItems["bio"].Value)
+	assert.Empty(t, updateRecord.UnchangedToastColumns)
+
+	del, err := source.processDeleteMessage(0, &pglogrepl.DeleteMessage{
+		RelationID: testRelID,
+		OldTuple:   &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{{DataType: 't', Data: []byte("1")}, {DataType: 'n'}}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, source.trackLastSeenRow(batch, testRelID, del))
+	assert.Empty(t, batch.tablePKeyLastSeen)
+	assert.Zero(t, batch.lastSeenSize)
+}
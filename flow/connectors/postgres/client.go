@@ -1,13 +1,18 @@
 package connpostgres
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/PeerDB-io/peer-flow/connectors/utils"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/jackc/pglogrepl"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -24,10 +29,11 @@ const (
 		_peerdb_record_type INTEGER NOT NULL, _peerdb_match_data JSONB,_peerdb_batch_id INTEGER,
 		_peerdb_unchanged_toast_columns TEXT)`
 
-	getLastOffsetSQL            = "SELECT lsn_offset FROM %s.%s WHERE mirror_job_name=$1"
-	getLastSyncBatchID_SQL      = "SELECT sync_batch_id FROM %s.%s WHERE mirror_job_name=$1"
-	getLastNormalizeBatchID_SQL = "SELECT normalize_batch_id FROM %s.%s WHERE mirror_job_name=$1"
-	createNormalizedTableSQL    = "CREATE TABLE IF NOT EXISTS %s(%s)"
+	getLastOffsetSQL              = "SELECT lsn_offset FROM %s.%s WHERE mirror_job_name=$1"
+	getLastSyncBatchID_SQL        = "SELECT sync_batch_id FROM %s.%s WHERE mirror_job_name=$1"
+	getLastNormalizeBatchID_SQL   = "SELECT normalize_batch_id FROM %s.%s WHERE mirror_job_name=$1"
+	createNormalizedTableSQL      = "CREATE TABLE IF NOT EXISTS %s(%s)"
+	addColumnToNormalizedTableSQL = "ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s"
 
 	insertJobMetadataSQL                 = "INSERT INTO %s.%s VALUES ($1,$2,$3,$4)"
 	checkIfJobMetadataExistsSQL          = "SELECT COUNT(1)::TEXT::BOOL FROM %s.%s WHERE mirror_job_name=$1"
@@ -37,21 +43,111 @@ const (
 	getTableNameToUnchangedToastColsSQL = `SELECT _peerdb_destination_table_name,
 	ARRAY_AGG(DISTINCT _peerdb_unchanged_toast_columns) FROM %s.%s WHERE
 	_peerdb_batch_id>$1 AND _peerdb_batch_id<=$2 GROUP BY _peerdb_destination_table_name`
+	// only the last truncate in a batch range matters, everything before it is discarded.
+	getTableNameToLastTruncateSQL = `SELECT DISTINCT ON (_peerdb_destination_table_name)
+	_peerdb_destination_table_name,_peerdb_batch_id,_peerdb_timestamp
+	FROM %s.%s WHERE _peerdb_batch_id>$1 AND _peerdb_batch_id<=$2 AND _peerdb_record_type=3
+	ORDER BY _peerdb_destination_table_name,_peerdb_batch_id DESC,_peerdb_timestamp DESC`
+	truncateTableSQL             = "TRUNCATE TABLE %s"
+	setTransactionSnapshotSQL    = "SET TRANSACTION SNAPSHOT '%s'"
+	dropReplicationSlotSQL       = "SELECT pg_drop_replication_slot($1)"
+	alterPublicationAddTableSQL  = "ALTER PUBLICATION %s ADD TABLE %s"
+	alterPublicationDropTableSQL = "ALTER PUBLICATION %s DROP TABLE %s"
+	// flags pgoutput sends for the column a of the relation c, 1 for the columns of the replica identity:
+	// the primary key by default, the columns of an index or all of them with REPLICA IDENTITY FULL.
+	replicaIdentityFlagSQL = `CASE WHEN c.relreplident='f' OR EXISTS(SELECT 1 FROM pg_index i
+	WHERE i.indrelid=a.attrelid AND a.attnum=ANY(i.indkey)
+	AND ((c.relreplident='d' AND i.indisprimary) OR (c.relreplident='i' AND i.indisreplident))) THEN 1 ELSE 0 END`
+	// columns in the order pgoutput sends them in a tuple, with their flags.
+	getRelationColumnsSQL = `SELECT a.attname,a.atttypid,` + replicaIdentityFlagSQL + ` FROM pg_attribute a
+	JOIN pg_class c ON c.oid=a.attrelid
+	WHERE a.attrelid=$1 AND a.attnum>0 AND NOT a.attisdropped ORDER BY a.attnum`
+	// columns of a relation in the column list of a publication, publications only have column lists
+	// on Postgres 15 and later.
+	getPublishedRelationColumnsSQL = `SELECT a.attname,a.atttypid,` + replicaIdentityFlagSQL + ` FROM pg_attribute a
+	JOIN pg_class c ON c.oid=a.attrelid JOIN pg_namespace n ON n.oid=c.relnamespace
+	JOIN pg_publication_tables pt ON pt.schemaname=n.nspname AND pt.tablename=c.relname
+	WHERE a.attrelid=$1 AND pt.pubname=$2 AND a.attnum>0 AND NOT a.attisdropped AND a.attname=ANY(pt.attnames)
+	ORDER BY a.attnum`
+	// columns whose values can be stored out of line, these aren't sent for updates that leave them unchanged.
+	getToastableColumnsSQL = `SELECT attname FROM pg_attribute
+	WHERE attrelid=$1 AND attnum>0 AND NOT attisdropped AND attstorage<>'p'`
+	// number of blocks of a table and the estimated number of rows in them, 0 rows if never analyzed.
+	getBlocksAndRowsEstimateSQL = `SELECT pg_relation_size(c.oid)/current_setting('block_size')::BIGINT,
+	GREATEST(c.reltuples,0)::FLOAT8 FROM pg_class c WHERE c.oid=$1::REGCLASS`
+	// enums, extension types and one dimensional arrays of these, their OIDs differ between databases.
+	getCustomTypesSQL = `SELECT t.oid,t.typname,t.typtype::TEXT,COALESCE(e.typname,''),COALESCE(e.typtype::TEXT,'')
+	FROM pg_type t LEFT JOIN pg_type e ON t.typelem=e.oid AND t.typcategory='A'
+	WHERE t.typtype='e' OR t.typname=ANY($1) OR e.typtype='e' OR e.typname=ANY($1)`
 	srcTableName      = "src"
 	mergeStatementSQL = `WITH src_rank AS (
 		SELECT _peerdb_data,_peerdb_record_type,_peerdb_unchanged_toast_columns,
 		RANK() OVER (PARTITION BY %s ORDER BY _peerdb_timestamp DESC) AS rank
-		FROM %s.%s WHERE _peerdb_batch_id>$1 AND _peerdb_batch_id<=$2 AND _peerdb_destination_table_name=$3 
+		FROM %s.%s WHERE _peerdb_batch_id>$1 AND _peerdb_batch_id<=$2 AND _peerdb_destination_table_name=$3
+		AND (_peerdb_batch_id,_peerdb_timestamp)>($4,$5)
 	)
 	MERGE INTO %s dst
 	USING (SELECT %s,_peerdb_record_type,_peerdb_unchanged_toast_columns FROM src_rank WHERE rank=1) src
-	ON dst.%s=src.%s
+	ON %s
 	WHEN NOT MATCHED THEN
 	INSERT (%s) VALUES (%s)
 	%s
 	WHEN MATCHED AND src._peerdb_record_type=2 THEN
 	DELETE`
 
+	// tables without key columns are multisets of rows: inserts add their row, deletes remove their old row
+	// and updates do both. The changes of a batch range are netted per row image, so a row inserted and
+	// then updated, or updated several times, is inserted once in its last state whatever the order.
+	// A row image removed n more times than it was added deletes n of its equal rows, picked by ctid.
+	fullRowMergeStatementSQL = `WITH src_changes AS (
+		SELECT _peerdb_data AS _peerdb_image,1 AS _peerdb_delta,_peerdb_timestamp
+		FROM %[1]s.%[2]s WHERE _peerdb_batch_id>$1 AND _peerdb_batch_id<=$2 AND _peerdb_destination_table_name=$3
+		AND (_peerdb_batch_id,_peerdb_timestamp)>($4,$5) AND _peerdb_record_type IN (0,1)
+		UNION ALL
+		SELECT _peerdb_match_data,-1,_peerdb_timestamp
+		FROM %[1]s.%[2]s WHERE _peerdb_batch_id>$1 AND _peerdb_batch_id<=$2 AND _peerdb_destination_table_name=$3
+		AND (_peerdb_batch_id,_peerdb_timestamp)>($4,$5) AND _peerdb_record_type IN (1,2)
+	), src_net AS (
+		SELECT _peerdb_image,_peerdb_delta,
+		SUM(_peerdb_delta) OVER (PARTITION BY _peerdb_image) AS _peerdb_net,
+		ROW_NUMBER() OVER (PARTITION BY _peerdb_image,_peerdb_delta ORDER BY _peerdb_timestamp) AS _peerdb_rank
+		FROM src_changes
+	), src_deleted AS (
+		SELECT _peerdb_image,-_peerdb_net AS _peerdb_count,%[4]s FROM src_net
+		WHERE _peerdb_delta=-1 AND _peerdb_rank=1 AND _peerdb_net<0
+	), dst_matched AS (
+		SELECT dst.ctid AS _peerdb_ctid,src._peerdb_count,
+		ROW_NUMBER() OVER (PARTITION BY src._peerdb_image ORDER BY dst.ctid) AS _peerdb_rank
+		FROM %[3]s dst JOIN src_deleted src ON %[5]s
+	), dst_deleted AS (
+		DELETE FROM %[3]s dst USING dst_matched
+		WHERE dst.ctid=dst_matched._peerdb_ctid AND dst_matched._peerdb_rank<=dst_matched._peerdb_count
+	)
+	INSERT INTO %[3]s (%[6]s) SELECT %[7]s FROM (SELECT %[4]s FROM src_net
+		WHERE _peerdb_delta=1 AND _peerdb_rank<=_peerdb_net) src`
+	appendOnlyInsertSQL = `INSERT INTO %s(%s) SELECT %s FROM (SELECT %s FROM %s.%s
+		WHERE _peerdb_batch_id>$1 AND _peerdb_batch_id<=$2 AND _peerdb_destination_table_name=$3
+		AND (_peerdb_batch_id,_peerdb_timestamp)>($4,$5) AND _peerdb_record_type=0) src`
+
+	getIndexColumnsSQL = `SELECT a.attname FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = $1 AND %s
+		ORDER BY array_position(i.indkey::int2[], a.attnum)`
+
+	// changes applied directly are staged in a temporary table per destination table.
+	createDirectApplyStagingTableSQL = `CREATE TEMP TABLE %s(_peerdb_data JSONB NOT NULL,
+		_peerdb_record_type INTEGER NOT NULL,_peerdb_unchanged_toast_columns TEXT NOT NULL) ON COMMIT DROP`
+	directApplyDeleteSQL = `DELETE FROM %s dst USING (SELECT %s FROM %s WHERE _peerdb_record_type=%d) src
+		WHERE %s`
+	directApplyUpsertSQL = `INSERT INTO %s(%s) SELECT %s FROM %s
+		WHERE _peerdb_record_type=%d AND _peerdb_unchanged_toast_columns=$1
+		ON CONFLICT(%s) DO %s`
+	// tables without key columns are changed row by row, a change only affects one of several equal rows.
+	directApplyInsertRowSQL = "INSERT INTO %s(%s) SELECT %s FROM (SELECT $1::JSONB AS _peerdb_data) src"
+	directApplyMatchRowSQL  = "ctid=(SELECT ctid FROM %s WHERE %s LIMIT 1)"
+	directApplyUpdateRowSQL = "UPDATE %s SET %s WHERE %s"
+	directApplyDeleteRowSQL = "DELETE FROM %s WHERE %s"
+
 	dropTableIfExistsSQL = "DROP TABLE IF EXISTS %s.%s"
 	deleteJobMetadataSQL = "DELETE FROM %s.%s WHERE MIRROR_JOB_NAME=?"
 )
@@ -70,39 +166,68 @@ func (c *PostgresConnector) getRelIDForTable(schemaTable *SchemaTable) (uint32,
 	return relID, nil
 }
 
-// getPrimaryKeyColumn for table returns the primary key column for a given table
-// errors if there is no primary key column or if there is more than one primary key column.
-func (c *PostgresConnector) getPrimaryKeyColumn(schemaTable *SchemaTable) (string, error) {
-	relID, err := c.getRelIDForTable(schemaTable)
+// getReplicaIdentityType returns the replica identity of a table, as recorded in pg_class.relreplident.
+func (c *PostgresConnector) getReplicaIdentityType(relID uint32,
+	schemaTable *SchemaTable) (protos.ReplicaIdentityType, error) {
+	var replicaIdentity string
+	err := c.pool.QueryRow(c.ctx,
+		`SELECT relreplident::TEXT FROM pg_class WHERE oid = $1`, relID).Scan(&replicaIdentity)
 	if err != nil {
-		return "", fmt.Errorf("failed to get relation id for table %s: %w", schemaTable, err)
+		return protos.ReplicaIdentityType_REPLICA_IDENTITY_DEFAULT,
+			fmt.Errorf("error getting replica identity for table %s: %w", schemaTable, err)
+	}
+
+	switch replicaIdentity {
+	case "d":
+		return protos.ReplicaIdentityType_REPLICA_IDENTITY_DEFAULT, nil
+	case "n":
+		return protos.ReplicaIdentityType_REPLICA_IDENTITY_NOTHING, nil
+	case "f":
+		return protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL, nil
+	case "i":
+		return protos.ReplicaIdentityType_REPLICA_IDENTITY_INDEX, nil
+	default:
+		return protos.ReplicaIdentityType_REPLICA_IDENTITY_DEFAULT,
+			fmt.Errorf("unknown replica identity %q for table %s", replicaIdentity, schemaTable)
 	}
+}
 
-	// Get the primary key column name
-	var pkCol string
-	rows, err := c.pool.Query(c.ctx,
-		`SELECT a.attname FROM pg_index i
-		 JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
-		 WHERE i.indrelid = $1 AND i.indisprimary`,
-		relID)
+// getPrimaryKeyColumns for table returns the primary key columns for a given table,
+// in the order they appear in the primary key. Returns an empty slice if the table has no primary key.
+func (c *PostgresConnector) getPrimaryKeyColumns(relID uint32, schemaTable *SchemaTable) ([]string, error) {
+	return c.getIndexColumns(relID, schemaTable, "i.indisprimary")
+}
+
+// getReplicaIdentityIndexColumns returns the columns of the index chosen with REPLICA IDENTITY USING INDEX,
+// in the order they appear in the index.
+func (c *PostgresConnector) getReplicaIdentityIndexColumns(relID uint32, schemaTable *SchemaTable) ([]string, error) {
+	return c.getIndexColumns(relID, schemaTable, "i.indisreplident")
+}
+
+// getIndexColumns returns the columns of the index on the table matching indexFilter, ordered by
+// their position in the index.
+func (c *PostgresConnector) getIndexColumns(relID uint32, schemaTable *SchemaTable,
+	indexFilter string) ([]string, error) {
+	rows, err := c.pool.Query(c.ctx, fmt.Sprintf(getIndexColumnsSQL, indexFilter), relID)
 	if err != nil {
-		return "", fmt.Errorf("error getting primary key column for table %s: %w", schemaTable, err)
+		return nil, fmt.Errorf("error getting index columns for table %s: %w", schemaTable, err)
 	}
 	defer rows.Close()
-	// 0 rows returned, table has no primary keys
-	if !rows.Next() {
-		return "", fmt.Errorf("table %s has no primary keys", schemaTable)
-	}
-	err = rows.Scan(&pkCol)
-	if err != nil {
-		return "", fmt.Errorf("error scanning primary key column for table %s: %w", schemaTable, err)
+
+	indexCols := make([]string, 0)
+	for rows.Next() {
+		var indexCol string
+		err = rows.Scan(&indexCol)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning index column for table %s: %w", schemaTable, err)
+		}
+		indexCols = append(indexCols, indexCol)
 	}
-	// more than 1 row returned, table has more than 1 primary key
-	if rows.Next() {
-		return "", fmt.Errorf("table %s has more than one primary key", schemaTable)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over index columns for table %s: %w", schemaTable, err)
 	}
 
-	return pkCol, nil
+	return indexCols, nil
 }
 
 func (c *PostgresConnector) tableExists(schemaTable *SchemaTable) (bool, error) {
@@ -163,11 +288,15 @@ func (c *PostgresConnector) checkSlotAndPublication(slot string, publication str
 }
 
 // createSlotAndPublication creates the replication slot and publication.
+// If signal is not nil, the slot exports a snapshot, see createSlotWithSnapshot.
 func (c *PostgresConnector) createSlotAndPublication(
+	signal *SlotSignal,
 	s *SlotCheckResult,
 	slot string,
 	publication string,
 	tableNameMapping map[string]string,
+	columnFilters map[string]*protos.ColumnFilter,
+	rowFilters map[string]string,
 ) error {
 	/*
 		iterating through source tables and creating a publication.
@@ -180,18 +309,26 @@ func (c *PostgresConnector) createSlotAndPublication(
 		}
 		srcTableNames = append(srcTableNames, srcTableName)
 	}
-	tableNameString := strings.Join(srcTableNames, ", ")
 
 	if !s.PublicationExists {
+		publicationTables, err := c.getPublicationTables(srcTableNames, columnFilters, rowFilters)
+		if err != nil {
+			return err
+		}
+		tableNameString := strings.Join(publicationTables, ", ")
+
 		// Create the publication to help filter changes only for the given tables
 		stmt := fmt.Sprintf("CREATE PUBLICATION %s FOR TABLE %s", publication, tableNameString)
-		_, err := c.pool.Exec(c.ctx, stmt)
+		_, err = c.pool.Exec(c.ctx, stmt)
 		if err != nil {
 			return fmt.Errorf("error creating publication: %w", err)
 		}
 	}
 
 	// create slot only after we succeeded in creating publication.
+	if signal != nil {
+		return c.createSlotWithSnapshot(signal, s.SlotExists, slot)
+	}
 	if !s.SlotExists {
 		// Create the logical replication slot
 		_, err := c.pool.Exec(c.ctx,
@@ -205,6 +342,324 @@ func (c *PostgresConnector) createSlotAndPublication(
 	return nil
 }
 
+// getPublicationTables returns the tables to add to a publication, with their column lists and row
+// filters on Postgres 15 and later, so that filtered out columns and rows never leave the source.
+// Column lists are left out for tables with REPLICA IDENTITY FULL, as these would have to cover every
+// column for updates and deletes to be published, and column filters leaving out columns of the replica
+// identity of other tables are rejected. Row filters are left out if they reference columns
+// outside of the replica identity, which would make updates and deletes on the table fail.
+// Columns and rows not filtered by the publication are filtered while decoding changes.
+func (c *PostgresConnector) getPublicationTables(
+	srcTableNames []string,
+	columnFilters map[string]*protos.ColumnFilter,
+	rowFilters map[string]string,
+) ([]string, error) {
+	if len(columnFilters) == 0 && len(rowFilters) == 0 {
+		return srcTableNames, nil
+	}
+
+	supportsFilters, err := c.majorVersionCheck(150000)
+	if err != nil {
+		return nil, err
+	}
+	if !supportsFilters {
+		log.Warnf("publication column lists and row filters need Postgres 15, changes are filtered while decoding")
+	}
+
+	publicationTables := make([]string, 0, len(srcTableNames))
+	for _, srcTableName := range srcTableNames {
+		columnFilter, hasColumnFilter := columnFilters[srcTableName]
+		rowFilterExpr, hasRowFilter := rowFilters[srcTableName]
+		if !hasColumnFilter && !hasRowFilter {
+			publicationTables = append(publicationTables, srcTableName)
+			continue
+		}
+
+		schemaTable, err := parseSchemaTable(srcTableName)
+		if err != nil {
+			return nil, err
+		}
+		relID, err := c.getRelIDForTable(schemaTable)
+		if err != nil {
+			return nil, err
+		}
+		tableColumns, err := c.getTableColumns(relID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting columns of table %s: %w", srcTableName, err)
+		}
+		replicatedColumns := make([]string, 0, len(tableColumns))
+		for _, column := range tableColumns {
+			if utils.IsColumnReplicated(columnFilter, column) {
+				replicatedColumns = append(replicatedColumns, column)
+			}
+		}
+		if len(replicatedColumns) == 0 {
+			return nil, fmt.Errorf("column filter of table %s leaves no columns to replicate", srcTableName)
+		}
+
+		var filter *rowFilter
+		if hasRowFilter {
+			filter, err = parseRowFilter(rowFilterExpr)
+			if err != nil {
+				return nil, err
+			}
+			// the filter is evaluated on decoded rows, which only have the replicated columns.
+			for _, column := range filter.columns() {
+				if !containsString(replicatedColumns, column) {
+					return nil, fmt.Errorf("row filter of table %s references column %s, which is not replicated",
+						srcTableName, column)
+				}
+			}
+		}
+
+		replicaIdentity, err := c.getReplicaIdentityType(relID, schemaTable)
+		if err != nil {
+			return nil, err
+		}
+		identityColumns, err := c.getReplicaIdentityColumns(relID, schemaTable, replicaIdentity, tableColumns)
+		if err != nil {
+			return nil, err
+		}
+
+		// Postgres rejects updates and deletes on a table whose published column list leaves out
+		// columns of its replica identity, which would break the application writing to it.
+		if hasColumnFilter && replicaIdentity != protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL {
+			if missingColumns := missingStrings(identityColumns, replicatedColumns); len(missingColumns) > 0 {
+				return nil, fmt.Errorf("column filter of table %s leaves out columns of its replica identity: %s",
+					srcTableName, strings.Join(missingColumns, ", "))
+			}
+		}
+
+		if !supportsFilters {
+			if filter != nil {
+				if err := c.checkDecodedRowFilter(relID, srcTableName, filter, replicaIdentity); err != nil {
+					return nil, err
+				}
+			}
+			publicationTables = append(publicationTables, srcTableName)
+			continue
+		}
+
+		publicationTable := srcTableName
+		if hasColumnFilter {
+			if replicaIdentity == protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL {
+				log.Warnf("table %s has REPLICA IDENTITY FULL, its columns are filtered while decoding changes",
+					srcTableName)
+			} else {
+				quotedColumns := make([]string, 0, len(replicatedColumns))
+				for _, column := range replicatedColumns {
+					quotedColumns = append(quotedColumns, utils.QuoteIdentifier(column))
+				}
+				publicationTable = fmt.Sprintf("%s(%s)", publicationTable, strings.Join(quotedColumns, ","))
+			}
+		}
+		if filter != nil {
+			coveredByIdentity := true
+			for _, column := range filter.columns() {
+				coveredByIdentity = coveredByIdentity && containsString(identityColumns, column)
+			}
+			if coveredByIdentity {
+				publicationTable = fmt.Sprintf("%s WHERE (%s)", publicationTable, filter.expression)
+			} else {
+				if err := c.checkDecodedRowFilter(relID, srcTableName, filter, replicaIdentity); err != nil {
+					return nil, err
+				}
+				log.Warnf("row filter of table %s references columns outside of its replica identity, "+
+					"rows are filtered while decoding changes", srcTableName)
+			}
+		}
+		publicationTables = append(publicationTables, publicationTable)
+	}
+	return publicationTables, nil
+}
+
+// getReplicaIdentityColumns returns the columns identifying the old rows of updates and deletes of a table,
+// all of its columns with REPLICA IDENTITY FULL and none with REPLICA IDENTITY NOTHING.
+func (c *PostgresConnector) getReplicaIdentityColumns(
+	relID uint32,
+	schemaTable *SchemaTable,
+	replicaIdentity protos.ReplicaIdentityType,
+	tableColumns []string,
+) ([]string, error) {
+	switch replicaIdentity {
+	case protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL:
+		return tableColumns, nil
+	case protos.ReplicaIdentityType_REPLICA_IDENTITY_NOTHING:
+		return nil, nil
+	case protos.ReplicaIdentityType_REPLICA_IDENTITY_INDEX:
+		return c.getReplicaIdentityIndexColumns(relID, schemaTable)
+	default:
+		return c.getPrimaryKeyColumns(relID, schemaTable)
+	}
+}
+
+// checkDecodedRowFilter checks that a row filter applied while decoding changes can be evaluated on
+// every update. Unchanged toast columns are left out of updates, their values are only sent in
+// the old row with REPLICA IDENTITY FULL.
+func (c *PostgresConnector) checkDecodedRowFilter(
+	relID uint32,
+	srcTableName string,
+	filter *rowFilter,
+	replicaIdentity protos.ReplicaIdentityType,
+) error {
+	if replicaIdentity == protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL {
+		return nil
+	}
+
+	rows, err := c.pool.Query(c.ctx, getToastableColumnsSQL, relID)
+	if err != nil {
+		return fmt.Errorf("error getting toastable columns of table %s: %w", srcTableName, err)
+	}
+	defer rows.Close()
+
+	filterColumns := filter.columns()
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return fmt.Errorf("error getting toastable columns of table %s: %w", srcTableName, err)
+		}
+		if containsString(filterColumns, column) {
+			return fmt.Errorf("row filter of table %s references column %s, which may be toasted, "+
+				"the table needs REPLICA IDENTITY FULL", srcTableName, column)
+		}
+	}
+	return rows.Err()
+}
+
+// getTableColumns returns the names of the columns of a relation.
+func (c *PostgresConnector) getTableColumns(relID uint32) ([]string, error) {
+	rows, err := c.pool.Query(c.ctx, getRelationColumnsSQL, relID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make([]string, 0)
+	for rows.Next() {
+		var colName string
+		var dataType, flags uint32
+		if err := rows.Scan(&colName, &dataType, &flags); err != nil {
+			return nil, err
+		}
+		columns = append(columns, colName)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// missingStrings returns the values that aren't in others, in their order.
+func missingStrings(values []string, others []string) []string {
+	var missing []string
+	for _, value := range values {
+		if !containsString(others, value) {
+			missing = append(missing, value)
+		}
+	}
+	return missing
+}
+
+// createSlotWithSnapshot creates the replication slot over a replication connection and exports
+// the snapshot of the slot's starting point. The snapshot is only valid while that connection is
+// idle and open, so after sending the result on signal.SlotCreated this blocks until
+// signal.CloneComplete is closed. If the slot already exists, no snapshot is exported.
+func (c *PostgresConnector) createSlotWithSnapshot(signal *SlotSignal, slotExists bool, slot string) error {
+	if slotExists {
+		log.Warnf("replication slot %s already exists, no snapshot is exported", slot)
+		signal.SlotCreated <- &SlotCreationResult{SlotName: slot}
+		return nil
+	}
+
+	connConfig, err := pgx.ParseConfig(c.connStr)
+	if err != nil {
+		return fmt.Errorf("failed to parse connection string: %w", err)
+	}
+	connConfig.RuntimeParams["replication"] = "database"
+
+	replConn, err := pgconn.ConnectConfig(c.ctx, &connConfig.Config)
+	if err != nil {
+		return fmt.Errorf("failed to create replication connection: %w", err)
+	}
+	defer replConn.Close(context.Background())
+
+	res, err := pglogrepl.CreateReplicationSlot(c.ctx, replConn, slot, "pgoutput",
+		pglogrepl.CreateReplicationSlotOptions{
+			SnapshotAction: "EXPORT_SNAPSHOT",
+			Mode:           pglogrepl.LogicalReplication,
+		})
+	if err != nil {
+		return fmt.Errorf("error creating replication slot: %w", err)
+	}
+	log.Infof("created replication slot %s at %s with snapshot %s", slot, res.ConsistentPoint, res.SnapshotName)
+
+	signal.SlotCreated <- &SlotCreationResult{
+		SlotName:     res.SlotName,
+		SnapshotName: res.SnapshotName,
+	}
+
+	select {
+	case <-signal.CloneComplete:
+		log.Infof("snapshot %s of replication slot %s no longer needed", res.SnapshotName, slot)
+	case <-c.ctx.Done():
+		log.Warnf("context done while keeping snapshot %s of replication slot %s", res.SnapshotName, slot)
+	}
+	return nil
+}
+
+// getCustomTypeMapping returns the QValueKinds of the enum and extension types of the database.
+func (c *PostgresConnector) getCustomTypeMapping() (map[uint32]qvalue.QValueKind, error) {
+	customTypeNames := make([]string, 0, len(customTypeNameToQValueKind))
+	for typeName := range customTypeNameToQValueKind {
+		customTypeNames = append(customTypeNames, typeName)
+	}
+
+	rows, err := c.pool.Query(c.ctx, getCustomTypesSQL, customTypeNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query custom types: %w", err)
+	}
+	defer rows.Close()
+
+	customTypeMapping := make(map[uint32]qvalue.QValueKind)
+	for rows.Next() {
+		var typeOID uint32
+		var typeName, typeType, elemName, elemType string
+		if err := rows.Scan(&typeOID, &typeName, &typeType, &elemName, &elemType); err != nil {
+			return nil, fmt.Errorf("failed to scan custom type: %w", err)
+		}
+
+		customTypeMapping[typeOID] = customTypeKind(typeName, typeType, elemName)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over custom types: %w", err)
+	}
+
+	return customTypeMapping, nil
+}
+
+// customTypeKind returns the QValueKind of an enum or extension type, or of an array of these
+// if elemName, the name of its element type, is set.
+func customTypeKind(typeName string, typeType string, elemName string) qvalue.QValueKind {
+	switch {
+	case elemName != "":
+		// arrays of enums and extension types keep the text form of their elements.
+		return qvalue.QValueKindArrayString
+	case typeType == "e":
+		return qvalue.QValueKindEnum
+	default:
+		return customTypeNameToQValueKind[typeName]
+	}
+}
+
 func (c *PostgresConnector) createInternalSchema(createSchemaTx pgx.Tx) error {
 	_, err := createSchemaTx.Exec(c.ctx, fmt.Sprintf(createInternalSchemaSQL, internalSchema))
 	if err != nil {
@@ -220,15 +675,14 @@ func getRawTableIdentifier(jobName string) string {
 
 func generateCreateTableSQLForNormalizedTable(sourceTableIdentifier string,
 	sourceTableSchema *protos.TableSchema) string {
-	createTableSQLArray := make([]string, 0, len(sourceTableSchema.Columns))
+	createTableSQLArray := make([]string, 0, len(sourceTableSchema.Columns)+1)
 	for columnName, genericColumnType := range sourceTableSchema.Columns {
-		if sourceTableSchema.PrimaryKeyColumn == strings.ToLower(columnName) {
-			createTableSQLArray = append(createTableSQLArray, fmt.Sprintf("%s %s PRIMARY KEY,",
-				columnName, qValueKindToPostgresType(genericColumnType)))
-		} else {
-			createTableSQLArray = append(createTableSQLArray, fmt.Sprintf("%s %s,", columnName,
-				qValueKindToPostgresType(genericColumnType)))
-		}
+		createTableSQLArray = append(createTableSQLArray, fmt.Sprintf("%s %s,", columnName,
+			qValueKindToPostgresType(genericColumnType)))
+	}
+	if len(sourceTableSchema.PrimaryKeyColumns) > 0 {
+		createTableSQLArray = append(createTableSQLArray, fmt.Sprintf("PRIMARY KEY(%s),",
+			strings.Join(sourceTableSchema.PrimaryKeyColumns, ",")))
 	}
 	return fmt.Sprintf(createNormalizedTableSQL, sourceTableIdentifier,
 		strings.TrimSuffix(strings.Join(createTableSQLArray, ""), ","))
@@ -376,6 +830,46 @@ func (c *PostgresConnector) getTableNametoUnchangedCols(flowJobName string, sync
 	return resultMap, nil
 }
 
+// getTableNameToLastTruncate returns the position of the last truncate record for each destination table
+// truncated between the two batch IDs.
+func (c *PostgresConnector) getTableNameToLastTruncate(flowJobName string, syncBatchID int64,
+	normalizeBatchID int64) (map[string]model.RawRecordPosition, error) {
+	rawTableIdentifier := getRawTableIdentifier(flowJobName)
+
+	rows, err := c.pool.Query(c.ctx, fmt.Sprintf(getTableNameToLastTruncateSQL, internalSchema,
+		rawTableIdentifier), normalizeBatchID, syncBatchID)
+	if err != nil {
+		return nil, fmt.Errorf("error while retrieving truncated tables for normalization: %w", err)
+	}
+	defer rows.Close()
+
+	resultMap := make(map[string]model.RawRecordPosition)
+	var destinationTableName string
+	var truncatePosition model.RawRecordPosition
+	for rows.Next() {
+		err := rows.Scan(&destinationTableName, &truncatePosition.BatchID, &truncatePosition.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan truncated table row: %w", err)
+		}
+		resultMap[destinationTableName] = truncatePosition
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over truncated table rows: %w", err)
+	}
+	return resultMap, nil
+}
+
+// generateRawColumnCast extracts a column from a JSONB column of the raw table as its Postgres type.
+// Arrays are stored as JSON arrays, these are rebuilt from their elements.
+func generateRawColumnCast(jsonColumn string, columnName string, genericColumnType string) string {
+	pgType := qValueKindToPostgresType(genericColumnType)
+	if qvalue.QValueKind(genericColumnType).IsArray() {
+		return fmt.Sprintf("(CASE WHEN JSONB_TYPEOF(%s->'%s')='array' THEN ARRAY(SELECT JSONB_ARRAY_ELEMENTS_TEXT(%s->'%s'))"+
+			" END)::%s", jsonColumn, columnName, jsonColumn, columnName, pgType)
+	}
+	return fmt.Sprintf("(%s->>'%s')::%s", jsonColumn, columnName, pgType)
+}
+
 func (c *PostgresConnector) generateMergeStatement(destinationTableIdentifier string, unchangedToastColumns []string,
 	rawTableIdentifier string) string {
 	normalizedTableSchema := c.tableSchemaMapping[destinationTableIdentifier]
@@ -386,28 +880,67 @@ func (c *PostgresConnector) generateMergeStatement(destinationTableIdentifier st
 	}
 
 	flattenedCastsSQLArray := make([]string, 0, len(normalizedTableSchema.Columns))
-	var primaryKeyColumnCast string
+	primaryKeyColumnCasts := make(map[string]string)
 	for columnName, genericColumnType := range normalizedTableSchema.Columns {
-		pgType := qValueKindToPostgresType(genericColumnType)
-		flattenedCastsSQLArray = append(flattenedCastsSQLArray, fmt.Sprintf("(_peerdb_data->>'%s')::%s AS %s",
-			columnName, pgType, columnName))
-		if normalizedTableSchema.PrimaryKeyColumn == columnName {
-			primaryKeyColumnCast = fmt.Sprintf("(_peerdb_data->>'%s')::%s", columnName, pgType)
-		}
+		columnCast := generateRawColumnCast("_peerdb_data", columnName, genericColumnType)
+		flattenedCastsSQLArray = append(flattenedCastsSQLArray, fmt.Sprintf("%s AS %s", columnCast, columnName))
+		primaryKeyColumnCasts[columnName] = columnCast
 	}
 	flattenedCastsSQL := strings.TrimSuffix(strings.Join(flattenedCastsSQLArray, ","), ",")
 
+	// partition and join on all primary key columns, in key order
+	primaryKeyCastsArray := make([]string, 0, len(normalizedTableSchema.PrimaryKeyColumns))
+	primaryKeyJoinArray := make([]string, 0, len(normalizedTableSchema.PrimaryKeyColumns))
+	for _, pkeyColumn := range normalizedTableSchema.PrimaryKeyColumns {
+		primaryKeyCastsArray = append(primaryKeyCastsArray, primaryKeyColumnCasts[pkeyColumn])
+		primaryKeyJoinArray = append(primaryKeyJoinArray, fmt.Sprintf("dst.%s=src.%s", pkeyColumn, pkeyColumn))
+	}
+	primaryKeyColumnCastsSQL := strings.Join(primaryKeyCastsArray, ",")
+	primaryKeyJoinSQL := strings.Join(primaryKeyJoinArray, " AND ")
+
 	insertColumnsSQL := strings.TrimSuffix(strings.Join(columnNames, ","), ",")
 	insertValuesSQLArray := make([]string, 0, len(columnNames))
 	for _, columnName := range columnNames {
 		insertValuesSQLArray = append(insertValuesSQLArray, fmt.Sprintf("src.%s", columnName))
 	}
 	insertValuesSQL := strings.TrimSuffix(strings.Join(insertValuesSQLArray, ","), ",")
+
+	if len(normalizedTableSchema.PrimaryKeyColumns) == 0 {
+		if normalizedTableSchema.ReplicaIdentity != protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL {
+			// no way to identify rows, only inserts are replicated.
+			return fmt.Sprintf(appendOnlyInsertSQL, destinationTableIdentifier, insertColumnsSQL,
+				insertColumnsSQL, flattenedCastsSQL, internalSchema, rawTableIdentifier)
+		}
+		return c.generateFullRowMergeStatement(destinationTableIdentifier, columnNames,
+			insertColumnsSQL, insertValuesSQL, rawTableIdentifier)
+	}
+
 	updateStatements := c.generateUpdateStatement(columnNames, unchangedToastColumns)
 
-	return fmt.Sprintf(mergeStatementSQL, primaryKeyColumnCast, internalSchema, rawTableIdentifier,
-		destinationTableIdentifier, flattenedCastsSQL, normalizedTableSchema.PrimaryKeyColumn,
-		normalizedTableSchema.PrimaryKeyColumn, insertColumnsSQL, insertValuesSQL, updateStatements)
+	return fmt.Sprintf(mergeStatementSQL, primaryKeyColumnCastsSQL, internalSchema, rawTableIdentifier,
+		destinationTableIdentifier, flattenedCastsSQL, primaryKeyJoinSQL, insertColumnsSQL, insertValuesSQL,
+		updateStatements)
+}
+
+// generateFullRowMergeStatement generates the statement normalizing tables without key columns but with
+// REPLICA IDENTITY FULL. Rows are matched on every column of the old row image stored in _peerdb_match_data,
+// a removed row image deletes as many of the equal rows of the destination table as it was removed.
+func (c *PostgresConnector) generateFullRowMergeStatement(destinationTableIdentifier string, columnNames []string,
+	insertColumnsSQL string, insertValuesSQL string, rawTableIdentifier string) string {
+	normalizedTableSchema := c.tableSchemaMapping[destinationTableIdentifier]
+
+	imageCastsSQLArray := make([]string, 0, len(columnNames))
+	matchConditionsArray := make([]string, 0, len(columnNames))
+	for _, columnName := range columnNames {
+		columnCast := generateRawColumnCast("_peerdb_image", columnName, normalizedTableSchema.Columns[columnName])
+		imageCastsSQLArray = append(imageCastsSQLArray, fmt.Sprintf("%s AS %s", columnCast, columnName))
+		matchConditionsArray = append(matchConditionsArray, fmt.Sprintf("dst.%s IS NOT DISTINCT FROM src.%s",
+			columnName, columnName))
+	}
+
+	return fmt.Sprintf(fullRowMergeStatementSQL, internalSchema, rawTableIdentifier, destinationTableIdentifier,
+		strings.Join(imageCastsSQLArray, ","), strings.Join(matchConditionsArray, " AND "),
+		insertColumnsSQL, insertValuesSQL)
 }
 
 func (c *PostgresConnector) generateUpdateStatement(allCols []string, unchangedToastColsLists []string) string {
@@ -0,0 +1,52 @@
+package connpostgres
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMergeStatementFullRow(t *testing.T) {
+	c := &PostgresConnector{
+		tableSchemaMapping: map[string]*protos.TableSchema{
+			"public.audit_log": {
+				TableIdentifier: "public.audit_log",
+				Columns:         map[string]string{"msg": string(qvalue.QValueKindString)},
+				ReplicaIdentity: protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL,
+			},
+		},
+	}
+
+	mergeStatement := strings.Join(strings.Fields(c.generateMergeStatement("public.audit_log", nil, "raw")), " ")
+	// an insert followed by an update of the same row, or a chain of updates, adds and removes the
+	// intermediate row images, so only the last one is inserted and only the first one deleted.
+	for _, expected := range []string{
+		"SELECT _peerdb_data AS _peerdb_image,1 AS _peerdb_delta,_peerdb_timestamp",
+		"_peerdb_record_type IN (0,1)",
+		"SELECT _peerdb_match_data,-1,_peerdb_timestamp",
+		"_peerdb_record_type IN (1,2)",
+		"SUM(_peerdb_delta) OVER (PARTITION BY _peerdb_image) AS _peerdb_net",
+		"WHERE _peerdb_delta=1 AND _peerdb_rank<=_peerdb_net",
+		"WHERE _peerdb_delta=-1 AND _peerdb_rank=1 AND _peerdb_net<0",
+		"JOIN src_deleted src ON dst.msg IS NOT DISTINCT FROM src.msg",
+		// records up to the last truncate are skipped, by batch and then by timestamp within the batch.
+		"AND (_peerdb_batch_id,_peerdb_timestamp)>($4,$5)",
+		// a row image removed n times deletes n of its equal rows, not all of them.
+		"ROW_NUMBER() OVER (PARTITION BY src._peerdb_image ORDER BY dst.ctid) AS _peerdb_rank",
+		"WHERE dst.ctid=dst_matched._peerdb_ctid AND dst_matched._peerdb_rank<=dst_matched._peerdb_count",
+	} {
+		assert.Contains(t, mergeStatement, expected)
+	}
+	assert.NotContains(t, mergeStatement, "UPDATE SET")
+}
+
+func TestMissingIdentityColumns(t *testing.T) {
+	// a column list has to keep every column of the replica identity.
+	assert.Empty(t, missingStrings([]string{"id"}, []string{"id", "name"}))
+	assert.Equal(t, []string{"tenant", "id"},
+		missingStrings([]string{"tenant", "id"}, []string{"name"}))
+	assert.Empty(t, missingStrings(nil, []string{"name"}))
+}
@@ -0,0 +1,516 @@
+package connpostgres
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// directApplyChunkSize is how many records are held in memory before they're applied.
+const directApplyChunkSize = 1 << 14
+
+const (
+	// record types of the staged changes, matching those of the raw table.
+	directApplyInsert = 0
+	directApplyUpsert = 1
+	directApplyDelete = 2
+)
+
+// directApplyRow is the last change of a row within a batch.
+type directApplyRow struct {
+	recordType int
+	// the key columns for deletes, the new row otherwise.
+	items                 model.RecordItems
+	unchangedToastColumns string
+}
+
+// directApplyTable holds the changes of a destination table within a batch.
+type directApplyTable struct {
+	name   string
+	schema *protos.TableSchema
+	// the table is truncated before the changes are applied, changes before the truncate are dropped.
+	truncated bool
+	// last change of each row, for tables with key columns.
+	rows map[model.TableWithPkey]*directApplyRow
+	// changes in the order they were made, for tables without key columns.
+	records []model.Record
+}
+
+func (t *directApplyTable) hasKey() bool {
+	return len(t.schema.PrimaryKeyColumns) > 0
+}
+
+// directApplyChanges groups the records of a batch by destination table as they're read. Rows changed
+// several times within the batch are only applied in their final state, like normalize does for the raw table.
+type directApplyChanges struct {
+	tableSchemas map[string]*protos.TableSchema
+	tables       []*directApplyTable
+	tablesByName map[string]*directApplyTable
+	numRecords   int
+}
+
+func newDirectApplyChanges(tableSchemas map[string]*protos.TableSchema) *directApplyChanges {
+	return &directApplyChanges{
+		tableSchemas: tableSchemas,
+		tables:       make([]*directApplyTable, 0),
+		tablesByName: make(map[string]*directApplyTable),
+	}
+}
+
+func (d *directApplyChanges) getTable(name string) (*directApplyTable, error) {
+	if table, ok := d.tablesByName[name]; ok {
+		return table, nil
+	}
+	schema, ok := d.tableSchemas[name]
+	if !ok {
+		return nil, fmt.Errorf("no schema for destination table %s", name)
+	}
+	table := &directApplyTable{
+		name:   name,
+		schema: schema,
+		rows:   make(map[model.TableWithPkey]*directApplyRow),
+	}
+	d.tables = append(d.tables, table)
+	d.tablesByName[name] = table
+	return table, nil
+}
+
+// applySchemaDeltas refreshes the schemas of the tables with the schema changes of the batch.
+func (d *directApplyChanges) applySchemaDeltas(deltas []*protos.TableSchemaDelta) {
+	for _, delta := range deltas {
+		schema, ok := d.tableSchemas[delta.DstTableName]
+		if !ok {
+			continue
+		}
+		schema = proto.Clone(schema).(*protos.TableSchema)
+		model.ApplyTableSchemaDelta(schema, delta)
+		d.tableSchemas[delta.DstTableName] = schema
+		if table, ok := d.tablesByName[delta.DstTableName]; ok {
+			table.schema = schema
+		}
+	}
+}
+
+// groupDirectApplyChanges groups the records of a batch by destination table.
+func groupDirectApplyChanges(
+	records []model.Record,
+	tableSchemas map[string]*protos.TableSchema,
+) ([]*directApplyTable, error) {
+	changes := newDirectApplyChanges(tableSchemas)
+	for _, record := range records {
+		if err := changes.add(record); err != nil {
+			return nil, err
+		}
+	}
+	return changes.tables, nil
+}
+
+// add merges a record into the changes of its table.
+func (d *directApplyChanges) add(record model.Record) error {
+	table, err := d.getTable(record.GetTableName())
+	if err != nil {
+		return err
+	}
+	d.numRecords++
+	pkeyCols := table.schema.PrimaryKeyColumns
+
+	switch r := record.(type) {
+	case *model.TruncateRecord:
+		table.truncated = true
+		table.rows = make(map[model.TableWithPkey]*directApplyRow)
+		table.records = nil
+		return nil
+	case *model.InsertRecord:
+		if table.hasKey() {
+			key := model.RecToTablePKey(table.name, pkeyCols, r.Items)
+			table.rows[key] = newDirectApplyUpsert(table.rows[key], r.Items, r.UnchangedToastColumns)
+		}
+	case *model.UpdateRecord:
+		if table.hasKey() {
+			newKey := model.RecToTablePKey(table.name, pkeyCols, r.NewItems)
+			prev := table.rows[newKey]
+			// the old row is only sent if the key changed, the row under the old key is gone.
+			if hasColumns(r.OldItems, pkeyCols) {
+				oldKey := model.RecToTablePKey(table.name, pkeyCols, r.OldItems)
+				if oldKey != newKey {
+					prev = table.rows[oldKey]
+					table.rows[oldKey] = &directApplyRow{
+						recordType: directApplyDelete,
+						items:      keyItems(r.OldItems, pkeyCols),
+					}
+				}
+			}
+			table.rows[newKey] = newDirectApplyUpsert(prev, r.NewItems, r.UnchangedToastColumns)
+		}
+	case *model.DeleteRecord:
+		if table.hasKey() {
+			table.rows[model.RecToTablePKey(table.name, pkeyCols, r.Items)] = &directApplyRow{
+				recordType: directApplyDelete,
+				items:      keyItems(r.Items, pkeyCols),
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported record type for Postgres flow connector: %T", record)
+	}
+
+	if !table.hasKey() {
+		table.records = append(table.records, record)
+	}
+	return nil
+}
+
+// newDirectApplyUpsert returns the change upserting a row. Unchanged toast columns keep the value
+// they have on the destination, unless an earlier change of the row within the batch has it: the row
+// might not be on the destination yet.
+func newDirectApplyUpsert(prev *directApplyRow, items model.RecordItems,
+	unchangedToastColumns map[string]bool) *directApplyRow {
+	if prev == nil || prev.recordType == directApplyDelete || len(unchangedToastColumns) == 0 {
+		return &directApplyRow{
+			recordType:            directApplyUpsert,
+			items:                 items,
+			unchangedToastColumns: sortedKeysToString(unchangedToastColumns),
+		}
+	}
+
+	merged := make(model.RecordItems, len(items)+len(unchangedToastColumns))
+	for col, val := range items {
+		merged[col] = val
+	}
+	stillUnchanged := make(map[string]bool)
+	for col := range unchangedToastColumns {
+		if val, ok := prev.items[col]; ok {
+			merged[col] = val
+		} else {
+			stillUnchanged[col] = true
+		}
+	}
+	return &directApplyRow{
+		recordType:            directApplyUpsert,
+		items:                 merged,
+		unchangedToastColumns: sortedKeysToString(stillUnchanged),
+	}
+}
+
+// syncRecordsDirectApply applies the records of a batch to the destination tables, in one transaction
+// with the checkpoint of the batch. Batches end between source transactions, so the destination tables
+// only show states the source committed. The changes of a table are copied into a temporary table and
+// applied from there with DELETE and INSERT ... ON CONFLICT.
+//
+// Records are applied in chunks as they're read, so that only a chunk is held in memory. Chunks are
+// applied in order within the transaction, a row changed in several chunks ends up in its last state,
+// and unchanged toast columns of a row upserted by an earlier chunk keep the value it wrote.
+func (c *PostgresConnector) syncRecordsDirectApply(req *model.SyncRecordsRequest,
+	syncBatchID int64) (*model.SyncResponse, error) {
+	applyTx, err := c.pool.Begin(c.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction for applying records: %w", err)
+	}
+	defer func() {
+		deferErr := applyTx.Rollback(c.ctx)
+		if deferErr != pgx.ErrTxClosed && deferErr != nil {
+			log.Errorf("unexpected error rolling back transaction for applying records: %v", deferErr)
+		}
+	}()
+
+	changes := newDirectApplyChanges(c.tableSchemaMapping)
+	numRecords := 0
+	numAppliedSchemaDeltas := 0
+	numStagingTables := 0
+	var firstCP int64
+	applyChanges := func() error {
+		// the source adds schema changes before the records with the columns they added, these are
+		// added before the records are applied and the changes are applied with the refreshed schemas.
+		schemaDeltas := req.Records.DestinationTableSchemaDeltas()[numAppliedSchemaDeltas:]
+		for _, schemaDelta := range schemaDeltas {
+			err := c.addDeltaColumns(applyTx, req.FlowJobName, schemaDelta)
+			if err != nil {
+				return err
+			}
+		}
+		numAppliedSchemaDeltas += len(schemaDeltas)
+		changes.applySchemaDeltas(schemaDeltas)
+
+		for _, table := range changes.tables {
+			err := c.applyTableChanges(applyTx, fmt.Sprintf("_peerdb_apply_%d", numStagingTables), table)
+			if err != nil {
+				return fmt.Errorf("error applying records to table %s: %w", table.name, err)
+			}
+			numStagingTables++
+		}
+		changes = newDirectApplyChanges(changes.tableSchemas)
+		return nil
+	}
+
+	for record := range req.Records.Records() {
+		if numRecords == 0 {
+			firstCP = record.GetCheckPointID()
+		}
+		numRecords++
+		if err := changes.add(record); err != nil {
+			return nil, err
+		}
+		if changes.numRecords >= directApplyChunkSize {
+			if err := applyChanges(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := req.Records.Err(); err != nil {
+		return nil, err
+	}
+	if numRecords == 0 {
+		return &model.SyncResponse{
+			FirstSyncedCheckPointID: 0,
+			LastSyncedCheckPointID:  0,
+			NumRecordsSynced:        0,
+		}, nil
+	}
+	if err := applyChanges(); err != nil {
+		return nil, err
+	}
+	log.Printf("applied %d records to Postgres tables for flow job %s", numRecords, req.FlowJobName)
+
+	lastCP := req.Records.LastCheckPointID()
+	err = c.updateSyncMetadata(req.FlowJobName, lastCP, syncBatchID, applyTx)
+	if err != nil {
+		return nil, err
+	}
+	// there is nothing to normalize, normalize is kept caught up with sync.
+	_, err = applyTx.Exec(c.ctx,
+		fmt.Sprintf(updateMetadataForNormalizeRecordsSQL, internalSchema, mirrorJobsTableIdentifier),
+		syncBatchID, req.FlowJobName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update normalize batch id: %w", err)
+	}
+
+	err = applyTx.Commit(c.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error committing transaction for applying records: %w", err)
+	}
+
+	return &model.SyncResponse{
+		FirstSyncedCheckPointID: firstCP,
+		LastSyncedCheckPointID:  lastCP,
+		NumRecordsSynced:        int64(numRecords),
+		CurrentSyncBatchID:      syncBatchID,
+	}, nil
+}
+
+// applyTableChanges applies the changes of a table within the transaction of the batch.
+func (c *PostgresConnector) applyTableChanges(tx pgx.Tx, stagingTable string, table *directApplyTable) error {
+	if table.truncated {
+		_, err := tx.Exec(c.ctx, fmt.Sprintf(truncateTableSQL, table.name))
+		if err != nil {
+			return fmt.Errorf("error truncating table: %w", err)
+		}
+	}
+
+	if !table.hasKey() {
+		if table.schema.ReplicaIdentity != protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL {
+			// checked when the mirror is set up, updates and deletes couldn't be applied.
+			return fmt.Errorf("table has no key columns and no REPLICA IDENTITY FULL")
+		}
+		return c.applyFullRowChanges(tx, table)
+	}
+
+	if len(table.rows) == 0 {
+		return nil
+	}
+	rows := make([]*directApplyRow, 0, len(table.rows))
+	unchangedToastColumnSets := make(map[string]bool)
+	hasDeletes := false
+	for _, row := range table.rows {
+		rows = append(rows, row)
+		if row.recordType == directApplyDelete {
+			hasDeletes = true
+		} else {
+			unchangedToastColumnSets[row.unchangedToastColumns] = true
+		}
+	}
+	if err := c.stageRows(tx, stagingTable, rows); err != nil {
+		return err
+	}
+
+	pkeyCols := table.schema.PrimaryKeyColumns
+	if hasDeletes {
+		keyCasts := make([]string, 0, len(pkeyCols))
+		keyJoins := make([]string, 0, len(pkeyCols))
+		for _, col := range pkeyCols {
+			keyCasts = append(keyCasts, fmt.Sprintf("%s AS %s",
+				generateRawColumnCast("_peerdb_data", col, table.schema.Columns[col]), col))
+			keyJoins = append(keyJoins, fmt.Sprintf("dst.%s=src.%s", col, col))
+		}
+		_, err := tx.Exec(c.ctx, fmt.Sprintf(directApplyDeleteSQL, table.name, strings.Join(keyCasts, ","),
+			stagingTable, directApplyDelete, strings.Join(keyJoins, " AND ")))
+		if err != nil {
+			return fmt.Errorf("error deleting rows: %w", err)
+		}
+	}
+
+	// unchanged toast columns keep the value they have on the destination.
+	for unchangedToastColumns := range unchangedToastColumnSets {
+		var excluded []string
+		if unchangedToastColumns != "" {
+			excluded = strings.Split(unchangedToastColumns, ",")
+		}
+		columnNames, castsSQL := directApplyColumns(table.schema, excluded)
+
+		updateSetArray := make([]string, 0, len(columnNames))
+		for _, col := range columnNames {
+			if !containsString(pkeyCols, col) {
+				updateSetArray = append(updateSetArray, fmt.Sprintf("%s=EXCLUDED.%s", col, col))
+			}
+		}
+		conflictAction := "NOTHING"
+		if len(updateSetArray) > 0 {
+			conflictAction = "UPDATE SET " + strings.Join(updateSetArray, ",")
+		}
+
+		_, err := tx.Exec(c.ctx, fmt.Sprintf(directApplyUpsertSQL, table.name, strings.Join(columnNames, ","),
+			castsSQL, stagingTable, directApplyUpsert, strings.Join(pkeyCols, ","), conflictAction),
+			unchangedToastColumns)
+		if err != nil {
+			return fmt.Errorf("error upserting rows: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// applyFullRowChanges applies the changes of a table without key columns but with REPLICA IDENTITY FULL
+// one by one and in order, updates and deletes change a single row matching the old row on every column.
+func (c *PostgresConnector) applyFullRowChanges(tx pgx.Tx, table *directApplyTable) error {
+	if len(table.records) == 0 {
+		return nil
+	}
+
+	allColumnNames, _ := directApplyColumns(table.schema, nil)
+	matchConditions := make([]string, 0, len(allColumnNames))
+	for _, col := range allColumnNames {
+		matchConditions = append(matchConditions, fmt.Sprintf("%s IS NOT DISTINCT FROM %s", col,
+			generateRawColumnCast("$1::JSONB", col, table.schema.Columns[col])))
+	}
+	matchSQL := fmt.Sprintf(directApplyMatchRowSQL, table.name, strings.Join(matchConditions, " AND "))
+
+	_, castsSQL := directApplyColumns(table.schema, nil)
+	batch := &pgx.Batch{}
+	for _, record := range table.records {
+		switch r := record.(type) {
+		case *model.InsertRecord:
+			itemsJSON, err := r.Items.ToJSON()
+			if err != nil {
+				return fmt.Errorf("failed to serialize insert record items to JSON: %w", err)
+			}
+			batch.Queue(fmt.Sprintf(directApplyInsertRowSQL, table.name, strings.Join(allColumnNames, ","),
+				castsSQL), itemsJSON)
+		case *model.UpdateRecord:
+			oldItemsJSON, err := r.OldItems.ToJSON()
+			if err != nil {
+				return fmt.Errorf("failed to serialize update record old items to JSON: %w", err)
+			}
+			newItemsJSON, err := r.NewItems.ToJSON()
+			if err != nil {
+				return fmt.Errorf("failed to serialize update record new items to JSON: %w", err)
+			}
+			columnNames, _ := directApplyColumns(table.schema, sortedKeys(r.UnchangedToastColumns))
+			updateSetArray := make([]string, 0, len(columnNames))
+			for _, col := range columnNames {
+				updateSetArray = append(updateSetArray, fmt.Sprintf("%s=%s", col,
+					generateRawColumnCast("$2::JSONB", col, table.schema.Columns[col])))
+			}
+			batch.Queue(fmt.Sprintf(directApplyUpdateRowSQL, table.name, strings.Join(updateSetArray, ","),
+				matchSQL), oldItemsJSON, newItemsJSON)
+		case *model.DeleteRecord:
+			itemsJSON, err := r.Items.ToJSON()
+			if err != nil {
+				return fmt.Errorf("failed to serialize delete record items to JSON: %w", err)
+			}
+			batch.Queue(fmt.Sprintf(directApplyDeleteRowSQL, table.name, matchSQL), itemsJSON)
+		}
+	}
+
+	return tx.SendBatch(c.ctx, batch).Close()
+}
+
+// stageRows copies rows into a temporary table dropped at the end of the transaction.
+func (c *PostgresConnector) stageRows(tx pgx.Tx, stagingTable string, rows []*directApplyRow) error {
+	_, err := tx.Exec(c.ctx, fmt.Sprintf(createDirectApplyStagingTableSQL, stagingTable))
+	if err != nil {
+		return fmt.Errorf("error creating staging table: %w", err)
+	}
+
+	copyRows := make([][]interface{}, 0, len(rows))
+	for _, row := range rows {
+		itemsJSON, err := row.items.ToJSON()
+		if err != nil {
+			return fmt.Errorf("failed to serialize record items to JSON: %w", err)
+		}
+		copyRows = append(copyRows, []interface{}{itemsJSON, row.recordType, row.unchangedToastColumns})
+	}
+
+	_, err = tx.CopyFrom(c.ctx, pgx.Identifier{stagingTable},
+		[]string{"_peerdb_data", "_peerdb_record_type", "_peerdb_unchanged_toast_columns"},
+		pgx.CopyFromRows(copyRows))
+	if err != nil {
+		return fmt.Errorf("error copying rows into staging table: %w", err)
+	}
+	return nil
+}
+
+// directApplyColumns returns the columns of a table except the excluded ones, in a stable order,
+// and the casts extracting them from the staged rows.
+func directApplyColumns(schema *protos.TableSchema, excluded []string) ([]string, string) {
+	columnNames := make([]string, 0, len(schema.Columns))
+	for col := range schema.Columns {
+		if !containsString(excluded, col) {
+			columnNames = append(columnNames, col)
+		}
+	}
+	sort.Strings(columnNames)
+
+	casts := make([]string, 0, len(columnNames))
+	for _, col := range columnNames {
+		casts = append(casts, generateRawColumnCast("_peerdb_data", col, schema.Columns[col]))
+	}
+	return columnNames, strings.Join(casts, ",")
+}
+
+func hasColumns(items model.RecordItems, columns []string) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, col := range columns {
+		if _, ok := items[col]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func keyItems(items model.RecordItems, pkeyCols []string) model.RecordItems {
+	key := make(model.RecordItems, len(pkeyCols))
+	for _, col := range pkeyCols {
+		key[col] = items[col]
+	}
+	return key
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedKeysToString is utils.KeysToString with the keys sorted, so that equal sets compare equal.
+func sortedKeysToString(m map[string]bool) string {
+	return strings.Join(sortedKeys(m), ",")
+}
@@ -0,0 +1,181 @@
+package connpostgres
+
+import (
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testItems(id int64, name string) model.RecordItems {
+	items := model.RecordItems{"id": qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: id}}
+	if name != "" {
+		items["name"] = qvalue.QValue{Kind: qvalue.QValueKindString, Value: name}
+	}
+	return items
+}
+
+func TestGroupDirectApplyChanges(t *testing.T) {
+	schemas := map[string]*protos.TableSchema{
+		"public.users": {
+			TableIdentifier: "public.users",
+			Columns: map[string]string{
+				"id":   string(qvalue.QValueKindInt64),
+				"name": string(qvalue.QValueKindString),
+			},
+			PrimaryKeyColumns: []string{"id"},
+		},
+		"public.events": {
+			TableIdentifier: "public.events",
+			Columns:         map[string]string{"id": string(qvalue.QValueKindInt64)},
+		},
+	}
+
+	tables, err := groupDirectApplyChanges([]model.Record{
+		&model.InsertRecord{DestinationTableName: "public.users", Items: testItems(1, "ada")},
+		&model.InsertRecord{DestinationTableName: "public.users", Items: testItems(2, "grace")},
+		&model.InsertRecord{DestinationTableName: "public.events", Items: testItems(1, "")},
+		// the key of row 2 changes to 3.
+		&model.UpdateRecord{
+			DestinationTableName:  "public.users",
+			OldItems:              testItems(2, ""),
+			NewItems:              testItems(3, "grace"),
+			UnchangedToastColumns: map[string]bool{},
+		},
+		&model.DeleteRecord{DestinationTableName: "public.users", Items: testItems(1, "")},
+		&model.DeleteRecord{DestinationTableName: "public.events", Items: testItems(1, "")},
+	}, schemas)
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+
+	users := tables[0]
+	assert.Equal(t, "public.users", users.name)
+	require.Len(t, users.rows, 3)
+	finalTypes := make(map[int64]int)
+	for _, row := range users.rows {
+		finalTypes[row.items["id"].Value.(int64)] = row.recordType
+	}
+	assert.Equal(t, map[int64]int{1: directApplyDelete, 2: directApplyDelete, 3: directApplyUpsert}, finalTypes)
+
+	// rows of tables without key columns are applied change by change.
+	events := tables[1]
+	assert.Len(t, events.records, 2)
+	assert.Empty(t, events.rows)
+}
+
+func TestGroupDirectApplyChangesTruncate(t *testing.T) {
+	schemas := map[string]*protos.TableSchema{
+		"public.users": {
+			TableIdentifier:   "public.users",
+			Columns:           map[string]string{"id": string(qvalue.QValueKindInt64)},
+			PrimaryKeyColumns: []string{"id"},
+		},
+	}
+
+	tables, err := groupDirectApplyChanges([]model.Record{
+		&model.InsertRecord{DestinationTableName: "public.users", Items: testItems(1, "")},
+		&model.TruncateRecord{DestinationTableName: "public.users"},
+		&model.InsertRecord{DestinationTableName: "public.users", Items: testItems(2, "")},
+	}, schemas)
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.True(t, tables[0].truncated)
+	// only the changes after the truncate are applied.
+	require.Len(t, tables[0].rows, 1)
+	for _, row := range tables[0].rows {
+		assert.Equal(t, int64(2), row.items["id"].Value)
+	}
+
+	_, err = groupDirectApplyChanges([]model.Record{
+		&model.InsertRecord{DestinationTableName: "public.unknown", Items: testItems(1, "")},
+	}, schemas)
+	assert.Error(t, err)
+}
+
+func TestGroupDirectApplyChangesUnchangedToast(t *testing.T) {
+	schemas := map[string]*protos.TableSchema{
+		"public.users": {
+			TableIdentifier: "public.users",
+			Columns: map[string]string{
+				"id":   string(qvalue.QValueKindInt64),
+				"name": string(qvalue.QValueKindString),
+				"bio":  string(qvalue.QValueKindString),
+			},
+			PrimaryKeyColumns: []string{"id"},
+		},
+	}
+	withBio := func(items model.RecordItems, bio string) model.RecordItems {
+		items["bio"] = qvalue.QValue{Kind: qvalue.QValueKindString, Value: bio}
+		return items
+	}
+
+	tables, err := groupDirectApplyChanges([]model.Record{
+		// a row inserted and updated within the batch isn't on the destination yet.
+		&model.InsertRecord{DestinationTableName: "public.users", Items: withBio(testItems(1, "ada"), "long")},
+		&model.UpdateRecord{
+			DestinationTableName:  "public.users",
+			NewItems:              testItems(1, "ada lovelace"),
+			UnchangedToastColumns: map[string]bool{"bio": true},
+		},
+		// so is a row whose key changed after it was inserted.
+		&model.InsertRecord{DestinationTableName: "public.users", Items: withBio(testItems(2, "grace"), "longer")},
+		&model.UpdateRecord{
+			DestinationTableName:  "public.users",
+			OldItems:              testItems(2, ""),
+			NewItems:              testItems(3, "grace"),
+			UnchangedToastColumns: map[string]bool{"bio": true},
+		},
+		// a row only updated keeps the value it has on the destination.
+		&model.UpdateRecord{
+			DestinationTableName:  "public.users",
+			NewItems:              testItems(4, "alan"),
+			UnchangedToastColumns: map[string]bool{"bio": true},
+		},
+	}, schemas)
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+
+	upserts := make(map[int64]*directApplyRow)
+	for _, row := range tables[0].rows {
+		if row.recordType == directApplyUpsert {
+			upserts[row.items["id"].Value.(int64)] = row
+		}
+	}
+	require.Len(t, upserts, 3)
+	assert.Equal(t, withBio(testItems(1, "ada lovelace"), "long"), upserts[1].items)
+	assert.Empty(t, upserts[1].unchangedToastColumns)
+	assert.Equal(t, withBio(testItems(3, "grace"), "longer"), upserts[3].items)
+	assert.Empty(t, upserts[3].unchangedToastColumns)
+	assert.Equal(t, testItems(4, "alan"), upserts[4].items)
+	assert.Equal(t, "bio", upserts[4].unchangedToastColumns)
+}
+
+func TestDirectApplyChangesSchemaDeltas(t *testing.T) {
+	schema := &protos.TableSchema{
+		TableIdentifier:   "public.users",
+		Columns:           map[string]string{"id": string(qvalue.QValueKindInt64)},
+		PrimaryKeyColumns: []string{"id"},
+	}
+	schemas := map[string]*protos.TableSchema{"public.users": schema}
+
+	changes := newDirectApplyChanges(schemas)
+	require.NoError(t, changes.add(&model.InsertRecord{
+		DestinationTableName: "public.users",
+		Items:                testItems(1, "ada"),
+	}))
+	changes.applySchemaDeltas([]*protos.TableSchemaDelta{{
+		SrcTableName: "public.users",
+		DstTableName: "public.users",
+		AddedColumns: []*protos.DeltaColumn{{ColumnName: "name", ColumnType: string(qvalue.QValueKindString)}},
+	}})
+
+	// the rows are applied with the columns added within the batch.
+	columnNames, _ := directApplyColumns(changes.tables[0].schema, nil)
+	assert.Equal(t, []string{"id", "name"}, columnNames)
+	assert.Equal(t, changes.tables[0].schema, schemas["public.users"])
+	// the schema the batch started with is left alone.
+	assert.Len(t, schema.Columns, 1)
+}
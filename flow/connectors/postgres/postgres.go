@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/PeerDB-io/peer-flow/connectors/utils"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
@@ -24,6 +23,7 @@ type PostgresConnector struct {
 	config             *protos.PostgresConfig
 	pool               *pgxpool.Pool
 	tableSchemaMapping map[string]*protos.TableSchema
+	customTypeMapping  map[uint32]qvalue.QValueKind
 }
 
 // SchemaTable is a table in a schema.
@@ -47,12 +47,22 @@ func NewPostgresConnector(ctx context.Context, pgConfig *protos.PostgresConfig)
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
 
-	return &PostgresConnector{
+	c := &PostgresConnector{
 		connStr: connectionString,
 		ctx:     ctx,
 		config:  pgConfig,
 		pool:    pool,
-	}, nil
+	}
+
+	// enums and extension types have no fixed OIDs, they are looked up once per connector.
+	// failing to look them up only affects tables with columns of these types.
+	c.customTypeMapping, err = c.getCustomTypeMapping()
+	if err != nil {
+		log.Warnf("failed to get custom type mapping, enums and extension types won't be recognized: %v", err)
+		c.customTypeMapping = nil
+	}
+
+	return c, nil
 }
 
 // Close closes all connections.
@@ -142,7 +152,7 @@ func (c *PostgresConnector) GetLastOffset(jobName string) (*protos.LastSyncState
 }
 
 // PullRecords pulls records from the source.
-func (c *PostgresConnector) PullRecords(req *model.PullRecordsRequest) (*model.RecordBatch, error) {
+func (c *PostgresConnector) PullRecords(req *model.PullRecordsRequest) error {
 	// Slotname would be the job name prefixed with "peerflow_slot_"
 	slotName := fmt.Sprintf("peerflow_slot_%s", req.FlowJobName)
 
@@ -152,19 +162,19 @@ func (c *PostgresConnector) PullRecords(req *model.PullRecordsRequest) (*model.R
 	// Check if the replication slot and publication exist
 	exists, err := c.checkSlotAndPublication(slotName, publicationName)
 	if err != nil {
-		return nil, fmt.Errorf("error checking for replication slot and publication: %w", err)
+		return fmt.Errorf("error checking for replication slot and publication: %w", err)
 	}
 	if !exists.PublicationExists {
-		return nil, fmt.Errorf("publication %s does not exist", publicationName)
+		return fmt.Errorf("publication %s does not exist", publicationName)
 	}
 	if !exists.SlotExists {
-		return nil, fmt.Errorf("replication slot %s does not exist", slotName)
+		return fmt.Errorf("replication slot %s does not exist", slotName)
 	}
 
 	// ensure that replication is set to database
 	connConfig, err := pgxpool.ParseConfig(c.connStr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse connection string: %w", err)
+		return fmt.Errorf("failed to parse connection string: %w", err)
 	}
 
 	connConfig.ConnConfig.RuntimeParams["replication"] = "database"
@@ -176,19 +186,34 @@ func (c *PostgresConnector) PullRecords(req *model.PullRecordsRequest) (*model.R
 
 	replPool, err := pgxpool.NewWithConfig(c.ctx, connConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+		return fmt.Errorf("failed to create connection pool: %w", err)
+	}
+
+	rowFilters := make(map[string]*rowFilter, len(req.RowFilters))
+	for srcTableName, expression := range req.RowFilters {
+		filter, err := parseRowFilter(expression)
+		if err != nil {
+			return fmt.Errorf("invalid row filter for table %s: %w", srcTableName, err)
+		}
+		rowFilters[srcTableName] = filter
 	}
 
 	cdc, err := NewPostgresCDCSource(&PostgresCDCConfig{
-		AppContext:            c.ctx,
-		Connection:            replPool,
-		SrcTableIDNameMapping: req.SrcTableIDNameMapping,
-		Slot:                  slotName,
-		Publication:           publicationName,
-		TableNameMapping:      req.TableNameMapping,
+		AppContext:             c.ctx,
+		Connection:             replPool,
+		SrcTableIDNameMapping:  req.SrcTableIDNameMapping,
+		Slot:                   slotName,
+		Publication:            publicationName,
+		TableNameMapping:       req.TableNameMapping,
+		TableNameSchemaMapping: req.TableNameSchemaMapping,
+		RelationMessageMapping: req.RelationMessageMapping,
+		CatalogConnection:      c.pool,
+		CustomTypeMapping:      c.customTypeMapping,
+		ColumnFilters:          req.ColumnFilters,
+		RowFilters:             rowFilters,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create cdc source: %w", err)
+		return fmt.Errorf("failed to create cdc source: %w", err)
 	}
 
 	// NOTE that the connection pool is shared by PostgresConnector and PostgresCDCSource [passed by pointer]
@@ -199,91 +224,18 @@ func (c *PostgresConnector) PullRecords(req *model.PullRecordsRequest) (*model.R
 
 // SyncRecords pushes records to the destination.
 func (c *PostgresConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.SyncResponse, error) {
-	rawTableIdentifier := getRawTableIdentifier(req.FlowJobName)
-	log.Printf("pushing %d records to Postgres table %s via COPY", len(req.Records.Records), rawTableIdentifier)
-
 	syncBatchID, err := c.getLastSyncBatchID(req.FlowJobName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get previous syncBatchID: %w", err)
 	}
 	syncBatchID = syncBatchID + 1
-	records := make([][]interface{}, 0)
-
-	first := true
-	var firstCP int64 = 0
-	lastCP := req.Records.LastCheckPointID
-
-	for _, record := range req.Records.Records {
-		switch typedRecord := record.(type) {
-		case *model.InsertRecord:
-			itemsJSON, err := typedRecord.Items.ToJSON()
-			if err != nil {
-				return nil, fmt.Errorf("failed to serialize insert record items to JSON: %w", err)
-			}
-
-			records = append(records, []interface{}{
-				uuid.New().String(),
-				time.Now().UnixNano(),
-				typedRecord.DestinationTableName,
-				itemsJSON,
-				0,
-				"{}",
-				syncBatchID,
-				utils.KeysToString(typedRecord.UnchangedToastColumns),
-			})
-		case *model.UpdateRecord:
-			newItemsJSON, err := typedRecord.NewItems.ToJSON()
-			if err != nil {
-				return nil, fmt.Errorf("failed to serialize update record new items to JSON: %w", err)
-			}
-			oldItemsJSON, err := typedRecord.OldItems.ToJSON()
-			if err != nil {
-				return nil, fmt.Errorf("failed to serialize update record old items to JSON: %w", err)
-			}
-
-			records = append(records, []interface{}{
-				uuid.New().String(),
-				time.Now().UnixNano(),
-				typedRecord.DestinationTableName,
-				newItemsJSON,
-				1,
-				oldItemsJSON,
-				syncBatchID,
-				utils.KeysToString(typedRecord.UnchangedToastColumns),
-			})
-		case *model.DeleteRecord:
-			itemsJSON, err := typedRecord.Items.ToJSON()
-			if err != nil {
-				return nil, fmt.Errorf("failed to serialize delete record items to JSON: %w", err)
-			}
-
-			records = append(records, []interface{}{
-				uuid.New().String(),
-				time.Now().UnixNano(),
-				typedRecord.DestinationTableName,
-				itemsJSON,
-				2,
-				itemsJSON,
-				syncBatchID,
-				utils.KeysToString(typedRecord.UnchangedToastColumns),
-			})
-		default:
-			return nil, fmt.Errorf("unsupported record type for Postgres flow connector: %T", typedRecord)
-		}
 
-		if first {
-			firstCP = record.GetCheckPointID()
-			first = false
-		}
+	if req.SyncMode == protos.CDCSyncMode_CDC_SYNC_MODE_DIRECT_APPLY {
+		return c.syncRecordsDirectApply(req, syncBatchID)
 	}
 
-	if len(records) == 0 {
-		return &model.SyncResponse{
-			FirstSyncedCheckPointID: 0,
-			LastSyncedCheckPointID:  0,
-			NumRecordsSynced:        0,
-		}, nil
-	}
+	rawTableIdentifier := getRawTableIdentifier(req.FlowJobName)
+	log.Printf("pushing records to Postgres table %s via COPY", rawTableIdentifier)
 
 	syncRecordsTx, err := c.pool.Begin(c.ctx)
 	if err != nil {
@@ -296,20 +248,33 @@ func (c *PostgresConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 		}
 	}()
 
+	// records are copied into the raw table while they're pulled.
+	timestamps := model.NewRawRecordTimestamps()
+	copySource := newRecordStreamCopyFromSource(req.Records, func(record model.Record) ([]interface{}, error) {
+		return recordToRawTableRow(record, syncBatchID, timestamps.Next())
+	})
 	syncedRecordsCount, err := syncRecordsTx.CopyFrom(c.ctx, pgx.Identifier{internalSchema, rawTableIdentifier},
 		[]string{"_peerdb_uid", "_peerdb_timestamp", "_peerdb_destination_table_name", "_peerdb_data",
 			"_peerdb_record_type", "_peerdb_match_data", "_peerdb_batch_id", "_peerdb_unchanged_toast_columns"},
-		pgx.CopyFromRows(records))
+		copySource)
 	if err != nil {
 		return nil, fmt.Errorf("error syncing records: %w", err)
 	}
-	if syncedRecordsCount != int64(len(records)) {
+	if syncedRecordsCount != int64(copySource.numRecords) {
 		return nil, fmt.Errorf("error syncing records: expected %d records to be synced, but %d were synced",
-			len(records), syncedRecordsCount)
+			copySource.numRecords, syncedRecordsCount)
+	}
+	if syncedRecordsCount == 0 {
+		return &model.SyncResponse{
+			FirstSyncedCheckPointID: 0,
+			LastSyncedCheckPointID:  0,
+			NumRecordsSynced:        0,
+		}, nil
 	}
 	log.Printf("synced %d records to Postgres table %s via COPY", syncedRecordsCount, rawTableIdentifier)
 
 	// updating metadata with new offset and syncBatchID
+	lastCP := req.Records.LastCheckPointID()
 	err = c.updateSyncMetadata(req.FlowJobName, lastCP, syncBatchID, syncRecordsTx)
 	if err != nil {
 		return nil, err
@@ -321,12 +286,132 @@ func (c *PostgresConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.S
 	}
 
 	return &model.SyncResponse{
-		FirstSyncedCheckPointID: firstCP,
+		FirstSyncedCheckPointID: copySource.firstCheckPointID,
 		LastSyncedCheckPointID:  lastCP,
-		NumRecordsSynced:        int64(len(records)),
+		NumRecordsSynced:        syncedRecordsCount,
+		CurrentSyncBatchID:      syncBatchID,
 	}, nil
 }
 
+// recordToRawTableRow converts a record to a row of the raw table.
+func recordToRawTableRow(record model.Record, syncBatchID int64, timestamp int64) ([]interface{}, error) {
+	switch typedRecord := record.(type) {
+	case *model.InsertRecord:
+		itemsJSON, err := typedRecord.Items.ToJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize insert record items to JSON: %w", err)
+		}
+
+		return []interface{}{
+			uuid.New().String(),
+			timestamp,
+			typedRecord.DestinationTableName,
+			itemsJSON,
+			0,
+			"{}",
+			syncBatchID,
+			utils.KeysToString(typedRecord.UnchangedToastColumns),
+		}, nil
+	case *model.UpdateRecord:
+		newItemsJSON, err := typedRecord.NewItems.ToJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize update record new items to JSON: %w", err)
+		}
+		oldItemsJSON, err := typedRecord.OldItems.ToJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize update record old items to JSON: %w", err)
+		}
+
+		return []interface{}{
+			uuid.New().String(),
+			timestamp,
+			typedRecord.DestinationTableName,
+			newItemsJSON,
+			1,
+			oldItemsJSON,
+			syncBatchID,
+			utils.KeysToString(typedRecord.UnchangedToastColumns),
+		}, nil
+	case *model.DeleteRecord:
+		itemsJSON, err := typedRecord.Items.ToJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize delete record items to JSON: %w", err)
+		}
+
+		return []interface{}{
+			uuid.New().String(),
+			timestamp,
+			typedRecord.DestinationTableName,
+			itemsJSON,
+			2,
+			itemsJSON,
+			syncBatchID,
+			utils.KeysToString(typedRecord.UnchangedToastColumns),
+		}, nil
+	case *model.TruncateRecord:
+		return []interface{}{
+			uuid.New().String(),
+			timestamp,
+			typedRecord.DestinationTableName,
+			"{}",
+			3,
+			"{}",
+			syncBatchID,
+			"",
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported record type for Postgres flow connector: %T", typedRecord)
+	}
+}
+
+// recordStreamCopyFromSource is a pgx.CopyFromSource converting the records of a stream to rows
+// as they're read.
+type recordStreamCopyFromSource struct {
+	stream            *model.CDCRecordStream
+	recordToRow       func(model.Record) ([]interface{}, error)
+	currentRecord     model.Record
+	numRecords        int
+	firstCheckPointID int64
+	err               error
+}
+
+func newRecordStreamCopyFromSource(
+	stream *model.CDCRecordStream,
+	recordToRow func(model.Record) ([]interface{}, error),
+) *recordStreamCopyFromSource {
+	return &recordStreamCopyFromSource{
+		stream:      stream,
+		recordToRow: recordToRow,
+	}
+}
+
+func (src *recordStreamCopyFromSource) Next() bool {
+	record, ok := <-src.stream.Records()
+	if !ok {
+		src.err = src.stream.Err()
+		return false
+	}
+	if src.numRecords == 0 {
+		src.firstCheckPointID = record.GetCheckPointID()
+	}
+	src.currentRecord = record
+	src.numRecords++
+	return true
+}
+
+func (src *recordStreamCopyFromSource) Values() ([]interface{}, error) {
+	row, err := src.recordToRow(src.currentRecord)
+	if err != nil {
+		src.err = err
+		return nil, err
+	}
+	return row, nil
+}
+
+func (src *recordStreamCopyFromSource) Err() error {
+	return src.err
+}
+
 func (c *PostgresConnector) NormalizeRecords(req *model.NormalizeRecordsRequest) (*model.NormalizeResponse, error) {
 	good, err := c.majorVersionCheck(150000)
 	if err != nil {
@@ -364,6 +449,10 @@ func (c *PostgresConnector) NormalizeRecords(req *model.NormalizeRecordsRequest)
 	if err != nil {
 		return nil, err
 	}
+	lastTruncateMap, err := c.getTableNameToLastTruncate(req.FlowJobName, syncBatchID, normalizeBatchID)
+	if err != nil {
+		return nil, err
+	}
 
 	normalizeRecordsTx, err := c.pool.Begin(c.ctx)
 	if err != nil {
@@ -378,8 +467,14 @@ func (c *PostgresConnector) NormalizeRecords(req *model.NormalizeRecordsRequest)
 
 	mergeStatementsBatch := &pgx.Batch{}
 	for destinationTableName, unchangedToastCols := range unchangedToastColsMap {
+		// a truncate empties the table, only records after the last truncate need to be merged.
+		lastTruncate, truncated := lastTruncateMap[destinationTableName]
+		if truncated {
+			mergeStatementsBatch.Queue(fmt.Sprintf(truncateTableSQL, destinationTableName))
+		}
 		mergeStatementsBatch.Queue(c.generateMergeStatement(destinationTableName, unchangedToastCols,
-			rawTableIdentifier), normalizeBatchID, syncBatchID, destinationTableName)
+			rawTableIdentifier), normalizeBatchID, syncBatchID, destinationTableName,
+			lastTruncate.BatchID, lastTruncate.Timestamp)
 	}
 	mergeResults := normalizeRecordsTx.SendBatch(c.ctx, mergeStatementsBatch)
 	err = mergeResults.Close()
@@ -412,6 +507,11 @@ type SlotCheckResult struct {
 
 // CreateRawTable creates a raw table, implementing the Connector interface.
 func (c *PostgresConnector) CreateRawTable(req *protos.CreateRawTableInput) (*protos.CreateRawTableOutput, error) {
+	if req.CdcSyncMode == protos.CDCSyncMode_CDC_SYNC_MODE_DIRECT_APPLY {
+		log.Infof("changes of flow job %s are applied directly, no raw table is needed", req.FlowJobName)
+		return nil, nil
+	}
+
 	rawTableIdentifier := getRawTableIdentifier(req.FlowJobName)
 
 	createRawTableTx, err := c.pool.Begin(c.ctx)
@@ -456,23 +556,52 @@ func (c *PostgresConnector) GetTableSchema(req *protos.GetTableSchemaInput) (*pr
 	}
 	defer rows.Close()
 
-	pkey, err := c.getPrimaryKeyColumn(schemaTable)
+	relID, err := c.getRelIDForTable(schemaTable)
 	if err != nil {
-		return nil, fmt.Errorf("error getting primary key column for table %s: %w", schemaTable, err)
+		return nil, err
+	}
+
+	replicaIdentity, err := c.getReplicaIdentityType(relID, schemaTable)
+	if err != nil {
+		return nil, err
+	}
+
+	// rows are identified by the replica identity index if one is set, otherwise by the primary key.
+	var pkeyCols []string
+	if replicaIdentity == protos.ReplicaIdentityType_REPLICA_IDENTITY_INDEX {
+		pkeyCols, err = c.getReplicaIdentityIndexColumns(relID, schemaTable)
+	} else {
+		pkeyCols, err = c.getPrimaryKeyColumns(relID, schemaTable)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting primary key columns for table %s: %w", schemaTable, err)
+	}
+
+	if len(pkeyCols) == 0 {
+		if replicaIdentity == protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL {
+			log.Infof("table %s has no primary key, rows will be matched on all columns", schemaTable)
+		} else {
+			log.Warnf("table %s has no primary key or replica identity, it will be replicated append-only",
+				schemaTable)
+		}
 	}
 
 	res := &protos.TableSchema{
-		TableIdentifier:  req.TableIdentifier,
-		Columns:          make(map[string]string),
-		PrimaryKeyColumn: pkey,
+		TableIdentifier:   req.TableIdentifier,
+		Columns:           make(map[string]string),
+		PrimaryKeyColumns: pkeyCols,
+		ReplicaIdentity:   replicaIdentity,
 	}
 
 	for _, fieldDescription := range rows.FieldDescriptions() {
-		genericColType := postgresOIDToQValueKind(fieldDescription.DataTypeOID)
+		genericColType := postgresOIDToQValueKind(fieldDescription.DataTypeOID, c.customTypeMapping)
 		if genericColType == qvalue.QValueKindInvalid {
 			return nil, fmt.Errorf("error converting Postgres OID to QValueKind")
 		}
 
+		if !utils.IsColumnReplicated(req.ColumnFilter, fieldDescription.Name) {
+			continue
+		}
 		res.Columns[fieldDescription.Name] = string(genericColType)
 	}
 
@@ -480,6 +609,13 @@ func (c *PostgresConnector) GetTableSchema(req *protos.GetTableSchemaInput) (*pr
 		return nil, fmt.Errorf("error iterating over table schema: %w", err)
 	}
 
+	// rows can't be matched on the destination without their key columns.
+	for _, pkeyCol := range pkeyCols {
+		if !utils.IsColumnReplicated(req.ColumnFilter, pkeyCol) {
+			return nil, fmt.Errorf("key column %s of table %s can't be filtered out", pkeyCol, schemaTable)
+		}
+	}
+
 	return res, nil
 }
 
@@ -521,6 +657,52 @@ func (c *PostgresConnector) InitializeTableSchema(req map[string]*protos.TableSc
 	return nil
 }
 
+// ReplayTableSchemaDelta adds the columns added on the source to the normalized table,
+// implementing the Connector interface.
+func (c *PostgresConnector) ReplayTableSchemaDelta(flowJobName string, schemaDelta *protos.TableSchemaDelta) error {
+	if len(schemaDelta.AddedColumns) == 0 {
+		return nil
+	}
+
+	tx, err := c.pool.Begin(c.ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction for schema change: %w", err)
+	}
+	defer func() {
+		deferErr := tx.Rollback(c.ctx)
+		if deferErr != pgx.ErrTxClosed && deferErr != nil {
+			log.Errorf("unexpected error rolling back transaction for schema change: %v", deferErr)
+		}
+	}()
+
+	err = c.addDeltaColumns(tx, flowJobName, schemaDelta)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(c.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to commit schema change for table %s: %w", schemaDelta.DstTableName, err)
+	}
+	return nil
+}
+
+// addDeltaColumns adds the columns added by a schema change to the normalized table within a transaction,
+// columns that were already added are skipped.
+func (c *PostgresConnector) addDeltaColumns(tx pgx.Tx, flowJobName string, schemaDelta *protos.TableSchemaDelta) error {
+	for _, addedColumn := range schemaDelta.AddedColumns {
+		_, err := tx.Exec(c.ctx, fmt.Sprintf(addColumnToNormalizedTableSQL, schemaDelta.DstTableName,
+			addedColumn.ColumnName, qValueKindToPostgresType(addedColumn.ColumnType)))
+		if err != nil {
+			return fmt.Errorf("failed to add column %s for table %s: %w", addedColumn.ColumnName,
+				schemaDelta.DstTableName, err)
+		}
+		log.Infof("[%s] added column %s of type %s to table %s", flowJobName, addedColumn.ColumnName,
+			addedColumn.ColumnType, schemaDelta.DstTableName)
+	}
+	return nil
+}
+
 // EnsurePullability ensures that a table is pullable, implementing the Connector interface.
 func (c *PostgresConnector) EnsurePullability(req *protos.EnsurePullabilityInput,
 ) (*protos.EnsurePullabilityOutput, error) {
@@ -559,13 +741,119 @@ func (c *PostgresConnector) SetupReplication(req *protos.SetupReplicationInput)
 	}
 
 	// Create the replication slot and publication
-	err = c.createSlotAndPublication(exists, slotName, publicationName, req.TableNameMapping)
+	err = c.createSlotAndPublication(nil, exists, slotName, publicationName, req.TableNameMapping,
+		req.ColumnFilters, req.RowFilters)
 	if err != nil {
 		return fmt.Errorf("error creating replication slot and publication: %w", err)
 	}
 	return nil
 }
 
+// SetupReplicationWithSnapshot sets up replication like SetupReplication, but creates the slot with
+// an exported snapshot for copying the existing rows. It reports the slot on signal.SlotCreated and
+// then blocks, keeping the snapshot valid, until signal.CloneComplete is closed.
+// It returns without reporting on signal.SlotCreated if the slot couldn't be created.
+func (c *PostgresConnector) SetupReplicationWithSnapshot(signal *SlotSignal, req *protos.SetupReplicationInput) error {
+	slotName := fmt.Sprintf("peerflow_slot_%s", req.FlowJobName)
+	publicationName := fmt.Sprintf("peerflow_pub_%s", req.FlowJobName)
+
+	exists, err := c.checkSlotAndPublication(slotName, publicationName)
+	if err != nil {
+		return fmt.Errorf("error checking for replication slot and publication: %w", err)
+	}
+
+	// a slot left by an earlier attempt of the initial copy no longer has a snapshot to copy from.
+	// nothing has been replicated from it yet, so it is created again to export a new snapshot.
+	if exists.SlotExists {
+		log.Warnf("replication slot %s already exists, dropping it to export a new snapshot", slotName)
+		if _, err := c.pool.Exec(c.ctx, dropReplicationSlotSQL, slotName); err != nil {
+			return fmt.Errorf("error dropping replication slot: %w", err)
+		}
+		exists.SlotExists = false
+	}
+
+	err = c.createSlotAndPublication(signal, exists, slotName, publicationName, req.TableNameMapping,
+		req.ColumnFilters, req.RowFilters)
+	if err != nil {
+		return fmt.Errorf("error creating replication slot and publication: %w", err)
+	}
+	return nil
+}
+
+// AlterPublication adds tables to and removes tables from the publication of a running flow job.
+func (c *PostgresConnector) AlterPublication(req *protos.AlterPublicationInput) error {
+	// Publication name would be the job name prefixed with "peerflow_pub_"
+	publicationName := fmt.Sprintf("peerflow_pub_%s", req.FlowJobName)
+
+	for _, srcTableName := range append(req.AddedTables, req.RemovedTables...) {
+		if len(strings.Split(srcTableName, ".")) != 2 {
+			return fmt.Errorf("source tables identifier is invalid: %v", srcTableName)
+		}
+	}
+
+	alterPublicationTx, err := c.pool.Begin(c.ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction for altering publication: %w", err)
+	}
+	defer func() {
+		deferErr := alterPublicationTx.Rollback(c.ctx)
+		if deferErr != pgx.ErrTxClosed && deferErr != nil {
+			log.Errorf("unexpected error rolling back transaction for altering publication: %v", deferErr)
+		}
+	}()
+
+	if len(req.AddedTables) > 0 {
+		addedTables, err := c.getPublicationTables(req.AddedTables, req.ColumnFilters, req.RowFilters)
+		if err != nil {
+			return err
+		}
+		_, err = alterPublicationTx.Exec(c.ctx, fmt.Sprintf(alterPublicationAddTableSQL,
+			publicationName, strings.Join(addedTables, ", ")))
+		if err != nil {
+			return fmt.Errorf("error adding tables to publication: %w", err)
+		}
+	}
+	if len(req.RemovedTables) > 0 {
+		_, err = alterPublicationTx.Exec(c.ctx, fmt.Sprintf(alterPublicationDropTableSQL,
+			publicationName, strings.Join(req.RemovedTables, ", ")))
+		if err != nil {
+			return fmt.Errorf("error removing tables from publication: %w", err)
+		}
+	}
+
+	err = alterPublicationTx.Commit(c.ctx)
+	if err != nil {
+		return fmt.Errorf("error committing transaction for altering publication: %w", err)
+	}
+	return nil
+}
+
+// ValidateAlterPublication checks that the publication of a flow can be altered as requested, without
+// altering it. Added tables must exist and be publishable with the column and row filters of the flow.
+func (c *PostgresConnector) ValidateAlterPublication(req *protos.AlterPublicationInput) error {
+	for _, srcTableName := range append(req.AddedTables, req.RemovedTables...) {
+		if len(strings.Split(srcTableName, ".")) != 2 {
+			return fmt.Errorf("source tables identifier is invalid: %v", srcTableName)
+		}
+	}
+
+	for _, srcTableName := range req.AddedTables {
+		schemaTable, err := parseSchemaTable(srcTableName)
+		if err != nil {
+			return fmt.Errorf("error parsing schema and table: %w", err)
+		}
+		if _, err := c.getRelIDForTable(schemaTable); err != nil {
+			return err
+		}
+	}
+	if len(req.AddedTables) > 0 {
+		if _, err := c.getPublicationTables(req.AddedTables, req.ColumnFilters, req.RowFilters); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *PostgresConnector) PullFlowCleanup(jobName string) error {
 	// Slotname would be the job name prefixed with "peerflow_slot_"
 	slotName := fmt.Sprintf("peerflow_slot_%s", jobName)
@@ -599,6 +887,30 @@ func (c *PostgresConnector) PullFlowCleanup(jobName string) error {
 	return nil
 }
 
+// TruncateTables removes the rows of destination tables in a single transaction.
+func (c *PostgresConnector) TruncateTables(tableIdentifiers []string) error {
+	tx, err := c.pool.Begin(c.ctx)
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction for truncating tables: %w", err)
+	}
+	defer func() {
+		deferErr := tx.Rollback(c.ctx)
+		if deferErr != pgx.ErrTxClosed && deferErr != nil {
+			log.Errorf("unexpected error while rolling back transaction for truncating tables: %v", deferErr)
+		}
+	}()
+
+	for _, tableIdentifier := range tableIdentifiers {
+		if _, err := tx.Exec(c.ctx, fmt.Sprintf(truncateTableSQL, tableIdentifier)); err != nil {
+			return fmt.Errorf("failed to truncate table %s: %w", tableIdentifier, err)
+		}
+	}
+	if err := tx.Commit(c.ctx); err != nil {
+		return fmt.Errorf("unable to commit transaction for truncating tables: %w", err)
+	}
+	return nil
+}
+
 func (c *PostgresConnector) SyncFlowCleanup(jobName string) error {
 	syncFlowCleanupTx, err := c.pool.Begin(c.ctx)
 	if err != nil {
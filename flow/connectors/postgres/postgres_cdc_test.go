@@ -25,6 +25,15 @@ func (suite *PostgresCDCTestSuite) failTestError(err error) {
 	}
 }
 
+// pullRecords pulls a batch through a record stream and reads it back into a batch.
+func (suite *PostgresCDCTestSuite) pullRecords(req *model.PullRecordsRequest) (*model.RecordBatch, error) {
+	req.RecordStream = model.NewCDCRecordStream(int(req.MaxBatchSize), nil)
+	go func() {
+		req.RecordStream.Close(suite.connector.PullRecords(req))
+	}()
+	return req.RecordStream.ToRecordBatch()
+}
+
 func (suite *PostgresCDCTestSuite) dropTable(tableName string) {
 	_, err := suite.connector.pool.Exec(context.Background(), fmt.Sprintf("DROP TABLE IF EXISTS %s", tableName))
 	suite.failTestError(err)
@@ -371,9 +380,9 @@ func (suite *PostgresCDCTestSuite) TestErrorForTableNotExist() {
 			"id":   string(qvalue.QValueKindInt32),
 			"name": string(qvalue.QValueKindString),
 		},
-		PrimaryKeyColumn: "id",
+		PrimaryKeyColumns: []string{"id"},
 	}
-	records, err := suite.connector.PullRecords(&model.PullRecordsRequest{
+	records, err := suite.pullRecords(&model.PullRecordsRequest{
 		FlowJobName:            nonExistentFlowName,
 		LastSyncState:          nil,
 		IdleTimeout:            5 * time.Second,
@@ -410,7 +419,7 @@ func (suite *PostgresCDCTestSuite) TestErrorForTableNotExist() {
 	})
 	suite.failTestError(err)
 	suite.dropTable(nonExistentFlowSrcTableName)
-	records, err = suite.connector.PullRecords(&model.PullRecordsRequest{
+	records, err = suite.pullRecords(&model.PullRecordsRequest{
 		FlowJobName:            nonExistentFlowName,
 		LastSyncState:          nil,
 		IdleTimeout:            5 * time.Second,
@@ -468,12 +477,12 @@ func (suite *PostgresCDCTestSuite) TestSimpleHappyFlow() {
 			"id":   string(qvalue.QValueKindInt32),
 			"name": string(qvalue.QValueKindString),
 		},
-		PrimaryKeyColumn: "id",
+		PrimaryKeyColumns: []string{"id"},
 	}, tableNameSchema)
 	tableNameSchemaMapping[simpleHappyFlowDstTableName] = tableNameSchema
 
 	// pulling with no records.
-	records, err := suite.connector.PullRecords(&model.PullRecordsRequest{
+	records, err := suite.pullRecords(&model.PullRecordsRequest{
 		FlowJobName:            simpleHappyFlowName,
 		LastSyncState:          nil,
 		IdleTimeout:            5 * time.Second,
@@ -489,7 +498,7 @@ func (suite *PostgresCDCTestSuite) TestSimpleHappyFlow() {
 
 	// pulling after inserting records.
 	suite.insertSimpleRecords(simpleHappyFlowSrcTableName)
-	records, err = suite.connector.PullRecords(&model.PullRecordsRequest{
+	records, err = suite.pullRecords(&model.PullRecordsRequest{
 		FlowJobName:            simpleHappyFlowName,
 		LastSyncState:          nil,
 		IdleTimeout:            5 * time.Second,
@@ -506,7 +515,7 @@ func (suite *PostgresCDCTestSuite) TestSimpleHappyFlow() {
 
 	// pulling after mutating records.
 	suite.mutateSimpleRecords(simpleHappyFlowSrcTableName)
-	records, err = suite.connector.PullRecords(&model.PullRecordsRequest{
+	records, err = suite.pullRecords(&model.PullRecordsRequest{
 		FlowJobName: simpleHappyFlowName,
 		LastSyncState: &protos.LastSyncState{
 			Checkpoint:   records.LastCheckPointID,
@@ -610,7 +619,7 @@ func (suite *PostgresCDCTestSuite) TestAllTypesHappyFlow() {
 			"c40": string(qvalue.QValueKindUUID),
 			"c41": string(qvalue.QValueKindString),
 		},
-		PrimaryKeyColumn: "id",
+		PrimaryKeyColumns: []string{"id"},
 	}, tableNameSchema)
 	tableNameSchemaMapping[allTypesHappyFlowDstTableName] = tableNameSchema
 
@@ -626,7 +635,7 @@ func (suite *PostgresCDCTestSuite) TestAllTypesHappyFlow() {
 			allTypesHappyFlowSrcTableName),
 		suite.randBytea(32))
 	suite.failTestError(err)
-	records, err := suite.connector.PullRecords(&model.PullRecordsRequest{
+	records, err := suite.pullRecords(&model.PullRecordsRequest{
 		FlowJobName:            allTypesHappyFlowName,
 		LastSyncState:          nil,
 		IdleTimeout:            5 * time.Second,
@@ -691,12 +700,12 @@ func (suite *PostgresCDCTestSuite) TestToastHappyFlow() {
 			"n_b":   string(qvalue.QValueKindBytes),
 			"lz4_b": string(qvalue.QValueKindBytes),
 		},
-		PrimaryKeyColumn: "id",
+		PrimaryKeyColumns: []string{"id"},
 	}, tableNameSchema)
 	tableNameSchemaMapping[toastHappyFlowDstTableName] = tableNameSchema
 
 	suite.insertToastRecords(toastHappyFlowSrcTableName)
-	records, err := suite.connector.PullRecords(&model.PullRecordsRequest{
+	records, err := suite.pullRecords(&model.PullRecordsRequest{
 		FlowJobName:            toastHappyFlowName,
 		LastSyncState:          nil,
 		IdleTimeout:            10 * time.Second,
@@ -711,7 +720,7 @@ func (suite *PostgresCDCTestSuite) TestToastHappyFlow() {
 	suite.GreaterOrEqual(records.LastCheckPointID, records.FirstCheckPointID)
 
 	suite.mutateToastRecords(toastHappyFlowSrcTableName)
-	records, err = suite.connector.PullRecords(&model.PullRecordsRequest{
+	records, err = suite.pullRecords(&model.PullRecordsRequest{
 		FlowJobName: toastHappyFlowName,
 		LastSyncState: &protos.LastSyncState{
 			Checkpoint:   records.LastCheckPointID,
@@ -3,26 +3,37 @@ package connpostgres
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"text/template"
 	"time"
 
 	utils "github.com/PeerDB-io/peer-flow/connectors/utils/partition"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/shared"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-const qRepMetadataTableName = "_peerdb_query_replication_metadata"
+const (
+	qRepMetadataTableName = "_peerdb_query_replication_metadata"
+	// the highest block number of a table.
+	maxCTIDBlock = int64(math.MaxUint32 - 1)
+)
 
 func (c *PostgresConnector) GetQRepPartitions(
 	config *protos.QRepConfig,
 	last *protos.QRepPartition,
 ) ([]*protos.QRepPartition, error) {
-	// begin a transaction
-	tx, err := c.pool.Begin(c.ctx)
+	// begin a transaction, reading from the exported snapshot if there is one.
+	txOptions := pgx.TxOptions{}
+	if config.SnapshotName != "" {
+		txOptions.IsoLevel = pgx.RepeatableRead
+		txOptions.AccessMode = pgx.ReadOnly
+	}
+	tx, err := c.pool.BeginTx(c.ctx, txOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -33,10 +44,21 @@ func (c *PostgresConnector) GetQRepPartitions(
 		}
 	}()
 
-	// lock the table while we get the partitions.
-	lockQuery := fmt.Sprintf("LOCK %s IN EXCLUSIVE MODE", config.WatermarkTable)
-	if _, err = tx.Exec(c.ctx, lockQuery); err != nil {
-		return nil, fmt.Errorf("failed to lock table: %w", err)
+	if config.SnapshotName != "" {
+		// the snapshot already gives a consistent view of the table, no need to lock it.
+		if _, err = tx.Exec(c.ctx, fmt.Sprintf(setTransactionSnapshotSQL, config.SnapshotName)); err != nil {
+			return nil, fmt.Errorf("failed to set transaction snapshot: %w", err)
+		}
+	} else {
+		// lock the table while we get the partitions.
+		lockQuery := fmt.Sprintf("LOCK %s IN EXCLUSIVE MODE", config.WatermarkTable)
+		if _, err = tx.Exec(c.ctx, lockQuery); err != nil {
+			return nil, fmt.Errorf("failed to lock table: %w", err)
+		}
+	}
+
+	if config.WatermarkColumn == shared.CTIDWatermarkColumn {
+		return c.getCTIDBlockPartitions(tx, config, last)
 	}
 
 	if config.NumRowsPerPartition > 0 {
@@ -71,6 +93,61 @@ func (c *PostgresConnector) GetQRepPartitions(
 	return partitions, nil
 }
 
+// getCTIDBlockPartitions partitions a table on the blocks its rows are stored in, each partition is a
+// range of block numbers holding about NumRowsPerPartition rows as estimated by the last ANALYZE.
+// The last partition goes up to the last possible block, rows stored in blocks added since the
+// table was measured are still read.
+func (c *PostgresConnector) getCTIDBlockPartitions(
+	tx pgx.Tx,
+	config *protos.QRepConfig,
+	last *protos.QRepPartition,
+) ([]*protos.QRepPartition, error) {
+	var numBlocks int64
+	var numRows float64
+	err := tx.QueryRow(c.ctx, getBlocksAndRowsEstimateSQL, config.WatermarkTable).Scan(&numBlocks, &numRows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query for the number of blocks: %w", err)
+	}
+
+	startBlock := int64(0)
+	if last != nil && last.Range != nil && last.Range.GetIntRange() != nil {
+		startBlock = last.Range.GetIntRange().End + 1
+	}
+	if startBlock > maxCTIDBlock {
+		log.Warnf("no records to replicate for flow job %s, returning", config.FlowJobName)
+		return make([]*protos.QRepPartition, 0), nil
+	}
+
+	// without an estimate of the number of rows, the whole table is a single partition.
+	blocksPerPartition := numBlocks
+	if config.NumRowsPerPartition > 0 && numRows > 0 {
+		blocksPerPartition = int64(float64(config.NumRowsPerPartition) * float64(numBlocks) / numRows)
+	}
+	if blocksPerPartition < 1 {
+		blocksPerPartition = 1
+	}
+	log.Infof("blocks: %d, estimated rows: %.0f, blocks per partition: %d", numBlocks, numRows, blocksPerPartition)
+
+	partitionHelper := utils.NewPartitionHelper()
+	for start := startBlock; ; start += blocksPerPartition {
+		end := start + blocksPerPartition - 1
+		if end >= numBlocks-1 {
+			end = maxCTIDBlock
+		}
+		if err := partitionHelper.AddPartition(start, end); err != nil {
+			return nil, fmt.Errorf("failed to add partition: %w", err)
+		}
+		if end == maxCTIDBlock {
+			break
+		}
+	}
+
+	if err := tx.Commit(c.ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return partitionHelper.GetPartitions(), nil
+}
+
 func (c *PostgresConnector) getNumRowsPartitions(
 	tx pgx.Tx,
 	config *protos.QRepConfig,
@@ -236,7 +313,8 @@ func (c *PostgresConnector) getMinMaxValues(
 
 func (c *PostgresConnector) PullQRepRecords(
 	config *protos.QRepConfig,
-	partition *protos.QRepPartition) (*model.QRecordBatch, error) {
+	partition *protos.QRepPartition,
+	stream *model.QRecordStream) (int, error) {
 	var rangeStart interface{}
 	var rangeEnd interface{}
 
@@ -249,22 +327,22 @@ func (c *PostgresConnector) PullQRepRecords(
 		rangeStart = x.TimestampRange.Start.AsTime()
 		rangeEnd = x.TimestampRange.End.AsTime()
 	default:
-		return nil, fmt.Errorf("unknown range type: %v", x)
+		return 0, fmt.Errorf("unknown range type: %v", x)
 	}
 
 	// Build the query to pull records within the range from the source table
 	// Be sure to order the results by the watermark column to ensure consistency across pulls
 	query, err := BuildQuery(config.Query)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	executor := NewQRepQueryExecutor(c.pool, c.ctx)
-	return executor.ExecuteAndProcessQuery(query, rangeStart, rangeEnd)
+	executor := NewQRepQueryExecutorSnapshot(c.pool, c.ctx, config.SnapshotName, c.customTypeMapping)
+	return executor.ExecuteAndProcessQueryStream(stream, query, rangeStart, rangeEnd)
 }
 
 func (c *PostgresConnector) SyncQRepRecords(config *protos.QRepConfig,
-	partition *protos.QRepPartition, records *model.QRecordBatch,
+	partition *protos.QRepPartition, stream *model.QRecordStream,
 ) (int, error) {
 	dstTable, err := parseSchemaTable(config.DestinationTableIdentifier)
 	if err != nil {
@@ -294,7 +372,7 @@ func (c *PostgresConnector) SyncQRepRecords(config *protos.QRepConfig,
 	switch syncMode {
 	case protos.QRepSyncMode_QREP_SYNC_MODE_MULTI_INSERT:
 		stagingTableSync := &QRepStagingTableSync{connector: c}
-		return stagingTableSync.SyncQRepRecords(config.FlowJobName, dstTable, partition, records)
+		return stagingTableSync.SyncQRepRecords(config.FlowJobName, dstTable, partition, stream)
 	case protos.QRepSyncMode_QREP_SYNC_MODE_STORAGE_AVRO:
 		return 0, fmt.Errorf("[postgres] SyncQRepRecords not implemented for storage avro sync mode")
 	default:
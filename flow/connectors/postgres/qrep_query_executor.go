@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -12,8 +13,11 @@ import (
 )
 
 type QRepQueryExecutor struct {
-	pool *pgxpool.Pool
-	ctx  context.Context
+	pool     *pgxpool.Pool
+	ctx      context.Context
+	snapshot string
+	// customTypeMapping holds the kinds of enums and extension types, these are pulled as text without it.
+	customTypeMapping map[uint32]qvalue.QValueKind
 }
 
 func NewQRepQueryExecutor(pool *pgxpool.Pool, ctx context.Context) *QRepQueryExecutor {
@@ -23,6 +27,22 @@ func NewQRepQueryExecutor(pool *pgxpool.Pool, ctx context.Context) *QRepQueryExe
 	}
 }
 
+// NewQRepQueryExecutorSnapshot returns an executor that runs its queries against the given
+// exported snapshot. An empty snapshot reads the latest data, same as NewQRepQueryExecutor.
+func NewQRepQueryExecutorSnapshot(
+	pool *pgxpool.Pool,
+	ctx context.Context,
+	snapshot string,
+	customTypeMapping map[uint32]qvalue.QValueKind,
+) *QRepQueryExecutor {
+	return &QRepQueryExecutor{
+		pool:              pool,
+		ctx:               ctx,
+		snapshot:          snapshot,
+		customTypeMapping: customTypeMapping,
+	}
+}
+
 func (qe *QRepQueryExecutor) ExecuteQuery(query string, args ...interface{}) (pgx.Rows, error) {
 	rows, err := qe.pool.Query(qe.ctx, query, args...)
 	if err != nil {
@@ -32,11 +52,14 @@ func (qe *QRepQueryExecutor) ExecuteQuery(query string, args ...interface{}) (pg
 }
 
 // FieldDescriptionsToSchema converts a slice of pgconn.FieldDescription to a QRecordSchema.
-func fieldDescriptionsToSchema(fds []pgconn.FieldDescription) *model.QRecordSchema {
+func fieldDescriptionsToSchema(
+	fds []pgconn.FieldDescription,
+	customTypeMapping map[uint32]qvalue.QValueKind,
+) *model.QRecordSchema {
 	qfields := make([]*model.QField, len(fds))
 	for i, fd := range fds {
 		cname := fd.Name
-		ctype := postgresOIDToQValueKind(fd.DataTypeOID)
+		ctype := postgresOIDToQValueKind(fd.DataTypeOID, customTypeMapping)
 		// there isn't a way to know if a column is nullable or not
 		// TODO fix this.
 		cnullable := true
@@ -58,7 +81,7 @@ func (qe *QRepQueryExecutor) ProcessRows(
 
 	// Iterate over the rows
 	for rows.Next() {
-		record, err := mapRowToQRecord(rows, fieldDescriptions)
+		record, err := mapRowToQRecord(rows, fieldDescriptions, qe.customTypeMapping)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map row to QRecord: %w", err)
 		}
@@ -73,7 +96,7 @@ func (qe *QRepQueryExecutor) ProcessRows(
 	batch := &model.QRecordBatch{
 		NumRecords: uint32(len(records)),
 		Records:    records,
-		Schema:     fieldDescriptionsToSchema(fieldDescriptions),
+		Schema:     fieldDescriptionsToSchema(fieldDescriptions, qe.customTypeMapping),
 	}
 
 	log.Infof("[postgres] pulled %d records", batch.NumRecords)
@@ -81,27 +104,129 @@ func (qe *QRepQueryExecutor) ProcessRows(
 	return batch, nil
 }
 
+// ProcessRowsToStream sends the rows of a query to a stream as they're read,
+// it returns the number of rows sent.
+func (qe *QRepQueryExecutor) ProcessRowsToStream(
+	rows pgx.Rows,
+	fieldDescriptions []pgconn.FieldDescription,
+	stream *model.QRecordStream,
+) (int, error) {
+	stream.SetSchema(fieldDescriptionsToSchema(fieldDescriptions, qe.customTypeMapping))
+
+	numRecords := 0
+	for rows.Next() {
+		record, err := mapRowToQRecord(rows, fieldDescriptions, qe.customTypeMapping)
+		if err != nil {
+			return numRecords, fmt.Errorf("failed to map row to QRecord: %w", err)
+		}
+		if !stream.Send(record) {
+			return numRecords, model.ErrRecordStreamAbandoned
+		}
+		numRecords++
+	}
+
+	if rows.Err() != nil {
+		return numRecords, fmt.Errorf("row iteration failed: %w", rows.Err())
+	}
+
+	log.Infof("[postgres] pulled %d records", numRecords)
+	return numRecords, nil
+}
+
 func (qe *QRepQueryExecutor) ExecuteAndProcessQuery(
 	query string,
 	args ...interface{},
 ) (*model.QRecordBatch, error) {
+	var batch *model.QRecordBatch
+	err := qe.executeQuery(query, args, func(rows pgx.Rows) error {
+		var err error
+		batch, err = qe.ProcessRows(rows, rows.FieldDescriptions())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// ExecuteAndProcessQueryStream sends the rows of a query to a stream while they're read,
+// it returns the number of rows sent. The stream is left open for the caller to close.
+func (qe *QRepQueryExecutor) ExecuteAndProcessQueryStream(
+	stream *model.QRecordStream,
+	query string,
+	args ...interface{},
+) (int, error) {
+	numRecords := 0
+	err := qe.executeQuery(query, args, func(rows pgx.Rows) error {
+		var err error
+		numRecords, err = qe.ProcessRowsToStream(rows, rows.FieldDescriptions(), stream)
+		return err
+	})
+	return numRecords, err
+}
+
+// executeQuery runs a query, within the snapshot of the executor if it has one, and processes its rows.
+func (qe *QRepQueryExecutor) executeQuery(
+	query string,
+	args []interface{},
+	processRows func(pgx.Rows) error,
+) error {
+	if qe.snapshot != "" {
+		return qe.executeQueryInSnapshot(query, args, processRows)
+	}
+
 	rows, err := qe.ExecuteQuery(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+		return fmt.Errorf("failed to execute query: %w", err)
 	}
 	defer rows.Close()
 
-	fieldDescriptions := rows.FieldDescriptions()
+	if err := processRows(rows); err != nil {
+		return fmt.Errorf("failed to process rows: %w", err)
+	}
+	return nil
+}
 
-	batch, err := qe.ProcessRows(rows, fieldDescriptions)
+func (qe *QRepQueryExecutor) executeQueryInSnapshot(
+	query string,
+	args []interface{},
+	processRows func(pgx.Rows) error,
+) error {
+	tx, err := qe.pool.BeginTx(qe.ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to process rows: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer func() {
+		deferErr := tx.Rollback(qe.ctx)
+		if deferErr != pgx.ErrTxClosed && deferErr != nil {
+			log.Errorf("unexpected error rolling back transaction for snapshot query: %v", deferErr)
+		}
+	}()
 
-	return batch, nil
+	if _, err = tx.Exec(qe.ctx, fmt.Sprintf(setTransactionSnapshotSQL, qe.snapshot)); err != nil {
+		return fmt.Errorf("failed to set transaction snapshot: %w", err)
+	}
+
+	rows, err := tx.Query(qe.ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	if err := processRows(rows); err != nil {
+		return fmt.Errorf("failed to process rows: %w", err)
+	}
+	return nil
 }
 
-func mapRowToQRecord(row pgx.Rows, fds []pgconn.FieldDescription) (*model.QRecord, error) {
+func mapRowToQRecord(
+	row pgx.Rows,
+	fds []pgconn.FieldDescription,
+	customTypeMapping map[uint32]qvalue.QValueKind,
+) (*model.QRecord, error) {
 	// make vals an empty array of QValue of size len(fds)
 	record := model.NewQRecord(len(fds))
 
@@ -111,7 +236,7 @@ func mapRowToQRecord(row pgx.Rows, fds []pgconn.FieldDescription) (*model.QRecor
 	}
 
 	for i, fd := range fds {
-		tmp, err := parseFieldFromPostgresOID(fd.DataTypeOID, values[i])
+		tmp, err := parseFieldFromPostgresOID(fd.DataTypeOID, values[i], customTypeMapping)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse field: %w", err)
 		}
@@ -19,7 +19,7 @@ type QRepSyncMethod interface {
 		flowJobName string,
 		dstTableName string,
 		partition *protos.QRepPartition,
-		records *model.QRecordBatch) (int, error)
+		stream *model.QRecordStream) (int, error)
 }
 
 type QRepStagingTableSync struct {
@@ -30,7 +30,7 @@ func (s *QRepStagingTableSync) SyncQRepRecords(
 	flowJobName string,
 	dstTableName *SchemaTable,
 	partition *protos.QRepPartition,
-	records *model.QRecordBatch) (int, error) {
+	stream *model.QRecordStream) (int, error) {
 	partitionID := partition.PartitionId
 	runID, err := util.RandomUInt64()
 	if err != nil {
@@ -60,14 +60,19 @@ func (s *QRepStagingTableSync) SyncQRepRecords(
 		return 0, fmt.Errorf("failed to create staging temporary table %s: %w", stagingTable, err)
 	}
 
-	// Step 2: Insert records into the staging table.
-	copySource := model.NewQRecordBatchCopyFromSource(records)
+	schema, err := stream.Schema()
+	if err != nil {
+		return -1, fmt.Errorf("failed to get schema from stream: %w", err)
+	}
+
+	// Step 2: Insert records into the staging table, while they're pulled.
+	copySource := model.NewQRecordStreamCopyFromSource(stream)
 
 	// Perform the COPY FROM operation
-	_, err = pool.CopyFrom(
+	numRowsInserted, err := pool.CopyFrom(
 		context.Background(),
 		pgx.Identifier{stagingTable},
-		records.Schema.GetColumnNames(),
+		schema.GetColumnNames(),
 		copySource,
 	)
 	if err != nil {
@@ -87,7 +92,7 @@ func (s *QRepStagingTableSync) SyncQRepRecords(
 		}
 	}()
 
-	colNames := records.Schema.GetColumnNames()
+	colNames := schema.GetColumnNames()
 	colNamesStr := strings.Join(colNames, ", ")
 	insertFromStagingStmt := fmt.Sprintf(
 		"INSERT INTO %s SELECT %s FROM %s",
@@ -128,7 +133,6 @@ func (s *QRepStagingTableSync) SyncQRepRecords(
 		return -1, fmt.Errorf("failed to commit transaction: %v", err)
 	}
 
-	numRowsInserted := records.NumRecords
 	log.Printf("pushed %d records to %s", numRowsInserted, dstTableName)
 	return int(numRowsInserted), nil
 }
@@ -1,21 +1,39 @@
 package connpostgres
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"net"
+	"net/netip"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/lib/pq/oid"
 	log "github.com/sirupsen/logrus"
 )
 
-func postgresOIDToQValueKind(recvOID uint32) qvalue.QValueKind {
+// customTypeNameToQValueKind maps extension types without a fixed OID to their QValueKind.
+var customTypeNameToQValueKind = map[string]qvalue.QValueKind{
+	"hstore":    qvalue.QValueKindHStore,
+	"geometry":  qvalue.QValueKindGeometry,
+	"geography": qvalue.QValueKindGeography,
+}
+
+// postgresOIDToQValueKind maps a type OID to its QValueKind. customTypeMapping holds the kinds of
+// types whose OIDs differ between databases, like enums and extension types; it may be nil.
+func postgresOIDToQValueKind(recvOID uint32, customTypeMapping map[uint32]qvalue.QValueKind) qvalue.QValueKind {
+	if kind, ok := customTypeMapping[recvOID]; ok {
+		return kind
+	}
+
 	switch recvOID {
 	case pgtype.BoolOID:
 		return qvalue.QValueKindBoolean
@@ -49,7 +67,32 @@ func postgresOIDToQValueKind(recvOID uint32) qvalue.QValueKind {
 		return qvalue.QValueKindNumeric
 	case pgtype.BitOID, pgtype.VarbitOID:
 		return qvalue.QValueKindBit
+	case pgtype.IntervalOID:
+		return qvalue.QValueKindInterval
+	case pgtype.InetOID:
+		return qvalue.QValueKindINET
+	case pgtype.CIDROID:
+		return qvalue.QValueKindCIDR
+	case pgtype.MacaddrOID:
+		return qvalue.QValueKindMacaddr
+	case pgtype.Int2ArrayOID, pgtype.Int4ArrayOID:
+		return qvalue.QValueKindArrayInt32
+	case pgtype.Int8ArrayOID:
+		return qvalue.QValueKindArrayInt64
+	case pgtype.Float4ArrayOID:
+		return qvalue.QValueKindArrayFloat32
+	case pgtype.Float8ArrayOID:
+		return qvalue.QValueKindArrayFloat64
+	case pgtype.BoolArrayOID:
+		return qvalue.QValueKindArrayBoolean
+	case pgtype.TextArrayOID, pgtype.VarcharArrayOID, pgtype.BPCharArrayOID:
+		return qvalue.QValueKindArrayString
 	default:
+		// ranges are kept in their text form, see parseFieldFromPostgresOID.
+		if _, ok := rangeElementOIDs[recvOID]; ok {
+			return qvalue.QValueKindString
+		}
+
 		typeName, ok := pgtype.NewMap().TypeForOID(recvOID)
 		if !ok {
 			// workaround for some types not being defined by pgtype
@@ -68,6 +111,9 @@ func postgresOIDToQValueKind(recvOID uint32) qvalue.QValueKind {
 			}
 			log.Warnf("failed to get type name for oid: %v", recvOID)
 			return qvalue.QValueKindInvalid
+		} else if strings.HasPrefix(typeName.Name, "_") {
+			// remaining one dimensional arrays keep the text form of their elements.
+			return qvalue.QValueKindArrayString
 		} else {
 			log.Warnf("unsupported field type: %v - type name - %s; returning as string", recvOID, typeName.Name)
 			return qvalue.QValueKindString
@@ -109,7 +155,30 @@ func qValueKindToPostgresType(qvalueKind string) string {
 		return "NUMERIC"
 	case qvalue.QValueKindBit:
 		return "BIT"
+	case qvalue.QValueKindInterval:
+		return "INTERVAL"
+	case qvalue.QValueKindINET:
+		return "INET"
+	case qvalue.QValueKindCIDR:
+		return "CIDR"
+	case qvalue.QValueKindMacaddr:
+		return "MACADDR"
+	case qvalue.QValueKindHStore:
+		return "JSONB"
+	case qvalue.QValueKindArrayInt32:
+		return "INTEGER[]"
+	case qvalue.QValueKindArrayInt64:
+		return "BIGINT[]"
+	case qvalue.QValueKindArrayFloat32:
+		return "REAL[]"
+	case qvalue.QValueKindArrayFloat64:
+		return "DOUBLE PRECISION[]"
+	case qvalue.QValueKindArrayBoolean:
+		return "BOOLEAN[]"
+	case qvalue.QValueKindArrayString:
+		return "TEXT[]"
 	default:
+		// enums and geospatial types may not exist on the destination, these are kept as text.
 		return "TEXT"
 	}
 }
@@ -218,6 +287,54 @@ func parseFieldFromQValueKind(qvalueKind qvalue.QValueKind, value interface{}) (
 			}
 			val = &qvalue.QValue{Kind: qvalue.QValueKindNumeric, Value: rat}
 		}
+	case qvalue.QValueKindInterval:
+		switch intervalVal := value.(type) {
+		case string:
+			val = &qvalue.QValue{Kind: qvalue.QValueKindInterval, Value: intervalVal}
+		case pgtype.Interval:
+			intervalStr, err := intervalVal.Value()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse interval: %w", err)
+			}
+			if intervalStr != nil {
+				val = &qvalue.QValue{Kind: qvalue.QValueKindInterval, Value: intervalStr.(string)}
+			}
+		}
+	case qvalue.QValueKindINET, qvalue.QValueKindCIDR:
+		switch addrVal := value.(type) {
+		case string:
+			val = &qvalue.QValue{Kind: qvalueKind, Value: addrVal}
+		case netip.Prefix:
+			addrStr := addrVal.String()
+			// Postgres prints inet host addresses without their netmask.
+			if qvalueKind == qvalue.QValueKindINET && addrVal.IsSingleIP() {
+				addrStr = addrVal.Addr().String()
+			}
+			val = &qvalue.QValue{Kind: qvalueKind, Value: addrStr}
+		}
+	case qvalue.QValueKindMacaddr:
+		switch macVal := value.(type) {
+		case string:
+			val = &qvalue.QValue{Kind: qvalue.QValueKindMacaddr, Value: macVal}
+		case net.HardwareAddr:
+			val = &qvalue.QValue{Kind: qvalue.QValueKindMacaddr, Value: macVal.String()}
+		}
+	case qvalue.QValueKindEnum, qvalue.QValueKindGeometry, qvalue.QValueKindGeography:
+		// enums arrive as their label, geospatial types as hex encoded EWKB.
+		val = &qvalue.QValue{Kind: qvalueKind, Value: fmt.Sprint(value)}
+	case qvalue.QValueKindHStore:
+		hstoreVal, err := hstoreToJSON(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse hstore: %w", err)
+		}
+		val = &qvalue.QValue{Kind: qvalue.QValueKindHStore, Value: hstoreVal}
+	case qvalue.QValueKindArrayInt32, qvalue.QValueKindArrayInt64, qvalue.QValueKindArrayFloat32,
+		qvalue.QValueKindArrayFloat64, qvalue.QValueKindArrayBoolean, qvalue.QValueKindArrayString:
+		arrayVal, err := convertArray(qvalueKind, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse array: %w", err)
+		}
+		val = &qvalue.QValue{Kind: qvalueKind, Value: arrayVal}
 	default:
 		log.Errorf("unhandled QValueKind => %v\n", qvalueKind)
 		return nil, fmt.Errorf("unhandled QValueKind => %v", qvalueKind)
@@ -230,8 +347,277 @@ func parseFieldFromQValueKind(qvalueKind qvalue.QValueKind, value interface{}) (
 	return val, nil
 }
 
-func parseFieldFromPostgresOID(oid uint32, value interface{}) (*qvalue.QValue, error) {
-	return parseFieldFromQValueKind(postgresOIDToQValueKind(oid), value)
+func parseFieldFromPostgresOID(
+	oid uint32,
+	value interface{},
+	customTypeMapping map[uint32]qvalue.QValueKind,
+) (*qvalue.QValue, error) {
+	// ranges are decoded into pgtype.Range or pgtype.Multirange values, kept in the form Postgres prints them in.
+	if elementOID, ok := rangeElementOIDs[oid]; ok && value != nil {
+		rangeVal, err := rangeToString(elementOID, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse range: %w", err)
+		}
+		value = rangeVal
+	}
+	return parseFieldFromQValueKind(postgresOIDToQValueKind(oid, customTypeMapping), value)
+}
+
+// rangeElementOIDs maps the built-in range and multirange types to the type of their bounds.
+var rangeElementOIDs = map[uint32]uint32{
+	pgtype.Int4rangeOID:      pgtype.Int4OID,
+	pgtype.Int8rangeOID:      pgtype.Int8OID,
+	pgtype.NumrangeOID:       pgtype.NumericOID,
+	pgtype.DaterangeOID:      pgtype.DateOID,
+	pgtype.TsrangeOID:        pgtype.TimestampOID,
+	pgtype.TstzrangeOID:      pgtype.TimestamptzOID,
+	pgtype.Int4multirangeOID: pgtype.Int4OID,
+	pgtype.Int8multirangeOID: pgtype.Int8OID,
+	pgtype.NummultirangeOID:  pgtype.NumericOID,
+	pgtype.DatemultirangeOID: pgtype.DateOID,
+	pgtype.TsmultirangeOID:   pgtype.TimestampOID,
+	pgtype.TstzmultirangeOID: pgtype.TimestamptzOID,
+}
+
+// rangeToString returns the text form of a range or multirange whose bounds are of type elementOID.
+func rangeToString(elementOID uint32, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case pgtype.Range[interface{}]:
+		return formatRange(elementOID, v)
+	case pgtype.Multirange[pgtype.Range[interface{}]]:
+		ranges := make([]string, 0, len(v))
+		for _, r := range v {
+			rangeStr, err := formatRange(elementOID, r)
+			if err != nil {
+				return "", err
+			}
+			ranges = append(ranges, rangeStr)
+		}
+		return "{" + strings.Join(ranges, ",") + "}", nil
+	default:
+		return "", fmt.Errorf("unexpected range value of type %T", value)
+	}
+}
+
+func formatRange(elementOID uint32, r pgtype.Range[interface{}]) (string, error) {
+	if r.LowerType == pgtype.Empty {
+		return "empty", nil
+	}
+
+	var sb strings.Builder
+	if r.LowerType == pgtype.Inclusive {
+		sb.WriteByte('[')
+	} else {
+		sb.WriteByte('(')
+	}
+	for i, bound := range []struct {
+		boundType pgtype.BoundType
+		value     interface{}
+	}{{r.LowerType, r.Lower}, {r.UpperType, r.Upper}} {
+		if i == 1 {
+			sb.WriteByte(',')
+		}
+		if bound.boundType == pgtype.Unbounded {
+			continue
+		}
+		text, err := encodeText(elementOID, bound.value)
+		if err != nil {
+			return "", err
+		}
+		// bounds with spaces or delimiters are quoted, like timestamps.
+		if text == "" || strings.ContainsAny(text, " \t\",()[]\\") {
+			text = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+		}
+		sb.WriteString(text)
+	}
+	if r.UpperType == pgtype.Inclusive {
+		sb.WriteByte(']')
+	} else {
+		sb.WriteByte(')')
+	}
+	return sb.String(), nil
+}
+
+// textTypeMap decodes arrays of types unknown to pgtype from their text representation and encodes
+// values back to it, a pgtype.Map isn't safe for concurrent use.
+var (
+	textTypeMap     = pgtype.NewMap()
+	textTypeMapLock sync.Mutex
+)
+
+// encodeText returns the text representation of a value decoded from a column of type oid.
+func encodeText(oid uint32, value interface{}) (string, error) {
+	textTypeMapLock.Lock()
+	defer textTypeMapLock.Unlock()
+
+	text, err := textTypeMap.Encode(oid, pgtype.TextFormatCode, value, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(text), nil
+}
+
+func parseTextArray(text string) ([]interface{}, error) {
+	textTypeMapLock.Lock()
+	defer textTypeMapLock.Unlock()
+
+	dt, _ := textTypeMap.TypeForOID(pgtype.TextArrayOID)
+	parsed, err := dt.Codec.DecodeValue(textTypeMap, pgtype.TextArrayOID, pgtype.TextFormatCode, []byte(text))
+	if err != nil {
+		return nil, err
+	}
+	elements, ok := parsed.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected array value %v", parsed)
+	}
+	return elements, nil
+}
+
+// convertArray converts a one dimensional Postgres array to a slice of the array kind's element
+// type. Null elements are dropped as most destinations don't allow them in arrays.
+func convertArray(kind qvalue.QValueKind, value interface{}) (interface{}, error) {
+	var elements []interface{}
+	switch arrayVal := value.(type) {
+	case []interface{}:
+		elements = arrayVal
+	case string:
+		parsed, err := parseTextArray(arrayVal)
+		if err != nil {
+			return nil, err
+		}
+		elements = parsed
+	default:
+		return nil, fmt.Errorf("unexpected array value of type %T", value)
+	}
+
+	switch kind {
+	case qvalue.QValueKindArrayInt32:
+		ret := make([]int32, 0, len(elements))
+		for _, element := range elements {
+			switch v := element.(type) {
+			case int16:
+				ret = append(ret, int32(v))
+			case int32:
+				ret = append(ret, v)
+			case nil:
+			default:
+				return nil, fmt.Errorf("unexpected element %v in int32 array", element)
+			}
+		}
+		return ret, nil
+	case qvalue.QValueKindArrayInt64:
+		ret := make([]int64, 0, len(elements))
+		for _, element := range elements {
+			switch v := element.(type) {
+			case int64:
+				ret = append(ret, v)
+			case nil:
+			default:
+				return nil, fmt.Errorf("unexpected element %v in int64 array", element)
+			}
+		}
+		return ret, nil
+	case qvalue.QValueKindArrayFloat32:
+		ret := make([]float32, 0, len(elements))
+		for _, element := range elements {
+			switch v := element.(type) {
+			case float32:
+				ret = append(ret, v)
+			case nil:
+			default:
+				return nil, fmt.Errorf("unexpected element %v in float32 array", element)
+			}
+		}
+		return ret, nil
+	case qvalue.QValueKindArrayFloat64:
+		ret := make([]float64, 0, len(elements))
+		for _, element := range elements {
+			switch v := element.(type) {
+			case float64:
+				ret = append(ret, v)
+			case nil:
+			default:
+				return nil, fmt.Errorf("unexpected element %v in float64 array", element)
+			}
+		}
+		return ret, nil
+	case qvalue.QValueKindArrayBoolean:
+		ret := make([]bool, 0, len(elements))
+		for _, element := range elements {
+			switch v := element.(type) {
+			case bool:
+				ret = append(ret, v)
+			case nil:
+			default:
+				return nil, fmt.Errorf("unexpected element %v in bool array", element)
+			}
+		}
+		return ret, nil
+	case qvalue.QValueKindArrayString:
+		ret := make([]string, 0, len(elements))
+		for _, element := range elements {
+			if element == nil {
+				continue
+			}
+			str, err := arrayElementToString(element)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, str)
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("%s is not an array kind", kind)
+	}
+}
+
+// arrayElementToString returns the text form of an element of an array without a typed kind.
+func arrayElementToString(element interface{}) (string, error) {
+	switch v := element.(type) {
+	case string:
+		return v, nil
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999-0700"), nil
+	case [16]byte:
+		return uuid.UUID(v).String(), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	case driver.Valuer:
+		textVal, err := v.Value()
+		if err != nil {
+			return "", fmt.Errorf("failed to convert array element %v: %w", element, err)
+		}
+		return fmt.Sprint(textVal), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// hstoreToJSON returns an hstore value as a JSON object of its keys.
+func hstoreToJSON(value interface{}) (string, error) {
+	var hstore pgtype.Hstore
+	switch v := value.(type) {
+	case pgtype.Hstore:
+		hstore = v
+	case map[string]*string:
+		hstore = v
+	case string:
+		parsed, err := pgtype.HstoreCodec{}.DecodeValue(nil, 0, pgtype.TextFormatCode, []byte(v))
+		if err != nil {
+			return "", err
+		}
+		hstore = parsed.(pgtype.Hstore)
+	default:
+		return "", fmt.Errorf("unexpected hstore value of type %T", value)
+	}
+
+	jsonVal, err := json.Marshal(hstore)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonVal), nil
 }
 
 func numericToRat(numVal *pgtype.Numeric) (*big.Rat, error) {
@@ -0,0 +1,156 @@
+package connpostgres
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertArray(t *testing.T) {
+	int32s, err := convertArray(qvalue.QValueKindArrayInt32, []interface{}{int16(1), nil, int32(3)})
+	require.NoError(t, err)
+	require.Equal(t, []int32{1, 3}, int32s)
+
+	float64s, err := convertArray(qvalue.QValueKindArrayFloat64, []interface{}{1.5, 2.25})
+	require.NoError(t, err)
+	require.Equal(t, []float64{1.5, 2.25}, float64s)
+
+	bools, err := convertArray(qvalue.QValueKindArrayBoolean, []interface{}{true, nil, false})
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, false}, bools)
+
+	// arrays of types unknown to pgtype arrive in their text form.
+	strs, err := convertArray(qvalue.QValueKindArrayString, `{a,"b c",NULL,"d,e"}`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b c", "d,e"}, strs)
+
+	ts := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	strs, err = convertArray(qvalue.QValueKindArrayString, []interface{}{
+		ts, [16]byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"2023-05-01 10:00:00+0000", "550e8400-e29b-41d4-a716-446655440000"}, strs)
+
+	_, err = convertArray(qvalue.QValueKindArrayInt64, []interface{}{"1"})
+	require.Error(t, err)
+	_, err = convertArray(qvalue.QValueKindString, []interface{}{"1"})
+	require.Error(t, err)
+	_, err = convertArray(qvalue.QValueKindArrayInt64, 1)
+	require.Error(t, err)
+}
+
+func TestParseTextArray(t *testing.T) {
+	elements, err := parseTextArray(`{"with \"quotes\"",plain,NULL,""}`)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{`with "quotes"`, "plain", nil, ""}, elements)
+
+	elements, err = parseTextArray("{}")
+	require.NoError(t, err)
+	require.Empty(t, elements)
+
+	_, err = parseTextArray("{unterminated")
+	require.Error(t, err)
+}
+
+func TestHstoreToJSON(t *testing.T) {
+	value := "on"
+	for _, hstore := range []interface{}{
+		`"a"=>"1", "b"=>NULL, "c d"=>"on"`,
+		map[string]*string{"a": &[]string{"1"}[0], "b": nil, "c d": &value},
+		pgtype.Hstore{"a": &[]string{"1"}[0], "b": nil, "c d": &value},
+	} {
+		jsonVal, err := hstoreToJSON(hstore)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"a":"1","b":null,"c d":"on"}`, jsonVal)
+	}
+
+	_, err := hstoreToJSON(1)
+	require.Error(t, err)
+}
+
+func TestCustomTypeKind(t *testing.T) {
+	require.Equal(t, qvalue.QValueKindEnum, customTypeKind("mood", "e", ""))
+	require.Equal(t, qvalue.QValueKindHStore, customTypeKind("hstore", "b", ""))
+	require.Equal(t, qvalue.QValueKindGeometry, customTypeKind("geometry", "b", ""))
+	require.Equal(t, qvalue.QValueKindArrayString, customTypeKind("_mood", "b", "mood"))
+	require.Equal(t, qvalue.QValueKindArrayString, customTypeKind("_hstore", "b", "hstore"))
+}
+
+func TestParseNetworkAddresses(t *testing.T) {
+	val, err := parseFieldFromPostgresOID(pgtype.InetOID, netip.MustParsePrefix("192.168.1.5/32"), nil)
+	require.NoError(t, err)
+	require.Equal(t, "192.168.1.5", val.Value)
+
+	val, err = parseFieldFromPostgresOID(pgtype.InetOID, netip.MustParsePrefix("192.168.1.5/24"), nil)
+	require.NoError(t, err)
+	require.Equal(t, "192.168.1.5/24", val.Value)
+
+	// cidr values always keep their netmask.
+	val, err = parseFieldFromPostgresOID(pgtype.CIDROID, netip.MustParsePrefix("10.0.0.1/32"), nil)
+	require.NoError(t, err)
+	require.Equal(t, qvalue.QValueKindCIDR, val.Kind)
+	require.Equal(t, "10.0.0.1/32", val.Value)
+
+	val, err = parseFieldFromPostgresOID(pgtype.InetOID, "::1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "::1", val.Value)
+}
+
+func TestParseInterval(t *testing.T) {
+	val, err := parseFieldFromPostgresOID(pgtype.IntervalOID, pgtype.Interval{
+		Months:       14,
+		Days:         3,
+		Microseconds: int64(4*time.Hour+5*time.Minute+6*time.Second) / 1000,
+		Valid:        true,
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, qvalue.QValueKindInterval, val.Kind)
+	require.Equal(t, "14 mon 3 day 04:05:06.000000", val.Value)
+
+	val, err = parseFieldFromPostgresOID(pgtype.IntervalOID, "1 day", nil)
+	require.NoError(t, err)
+	require.Equal(t, "1 day", val.Value)
+}
+
+func TestParseRanges(t *testing.T) {
+	lower := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	upper := time.Date(2023, 5, 1, 11, 30, 0, 0, time.UTC)
+	val, err := parseFieldFromPostgresOID(pgtype.TstzrangeOID, pgtype.Range[interface{}]{
+		Lower:     lower,
+		Upper:     upper,
+		LowerType: pgtype.Inclusive,
+		UpperType: pgtype.Exclusive,
+		Valid:     true,
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, qvalue.QValueKindString, val.Kind)
+	require.Equal(t, `["2023-05-01 10:00:00Z","2023-05-01 11:30:00Z")`, val.Value)
+
+	val, err = parseFieldFromPostgresOID(pgtype.Int4rangeOID, pgtype.Range[interface{}]{
+		Lower:     int32(1),
+		LowerType: pgtype.Inclusive,
+		UpperType: pgtype.Unbounded,
+		Valid:     true,
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "[1,)", val.Value)
+
+	val, err = parseFieldFromPostgresOID(pgtype.Int4rangeOID, pgtype.Range[interface{}]{
+		LowerType: pgtype.Empty,
+		UpperType: pgtype.Empty,
+		Valid:     true,
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "empty", val.Value)
+
+	val, err = parseFieldFromPostgresOID(pgtype.Int8multirangeOID, pgtype.Multirange[pgtype.Range[interface{}]]{
+		{Lower: int64(1), Upper: int64(3), LowerType: pgtype.Inclusive, UpperType: pgtype.Exclusive, Valid: true},
+		{Lower: int64(5), LowerType: pgtype.Exclusive, UpperType: pgtype.Unbounded, Valid: true},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "{[1,3),(5,)}", val.Value)
+}
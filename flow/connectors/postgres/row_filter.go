@@ -0,0 +1,793 @@
+package connpostgres
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/google/uuid"
+)
+
+// rowFilter evaluates the row filter of a table on decoded rows. Postgres 15 and later apply row
+// filters in the publication, older servers send every change and the filter is applied here.
+// Only a subset of SQL is supported in-process: comparisons, IN lists, IS [NOT] NULL, AND, OR
+// and NOT over columns and string, number, boolean and NULL literals. String literals compared
+// to number, time and uuid columns are read as values of the column's type.
+type rowFilter struct {
+	expression string
+	root       rowFilterNode
+}
+
+// rowFilterNode is a node of a parsed row filter. Values are nil for NULL, bool, string, *big.Rat for
+// exact numbers, float64 for floating point columns, time.Time or uuid.UUID.
+type rowFilterNode interface {
+	eval(items model.RecordItems) (interface{}, error)
+	columns() []string
+}
+
+// errRowFilterColumnMissing is returned while evaluating a filter on a row without a column it references,
+// like the old row of an update that only carries the key columns.
+type errRowFilterColumnMissing struct {
+	column string
+}
+
+func (e *errRowFilterColumnMissing) Error() string {
+	return fmt.Sprintf("row does not have column %s", e.column)
+}
+
+// parseRowFilter parses a row filter expression.
+func parseRowFilter(expression string) (*rowFilter, error) {
+	tokens, err := tokenizeRowFilter(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid row filter %q: %w", expression, err)
+	}
+
+	parser := &rowFilterParser{tokens: tokens}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid row filter %q: %w", expression, err)
+	}
+	if parser.pos != len(parser.tokens) {
+		return nil, fmt.Errorf("invalid row filter %q: unexpected %s", expression, parser.tokens[parser.pos].text)
+	}
+
+	return &rowFilter{
+		expression: expression,
+		root:       root,
+	}, nil
+}
+
+// ValidateRowFilter checks that a row filter is an expression the in-process filter supports, which
+// makes it safe to add to the queries reading the table's rows.
+func ValidateRowFilter(expression string) error {
+	_, err := parseRowFilter(expression)
+	return err
+}
+
+// evaluate returns whether the row satisfies the filter. known is false if the row doesn't have
+// every column the filter references, in which case the result can't be told.
+func (f *rowFilter) evaluate(items model.RecordItems) (matched bool, known bool, err error) {
+	val, err := f.root.eval(items)
+	if err != nil {
+		if _, ok := err.(*errRowFilterColumnMissing); ok {
+			return false, false, nil
+		}
+		return false, false, fmt.Errorf("error evaluating row filter %q: %w", f.expression, err)
+	}
+
+	// as in a WHERE clause, a NULL result doesn't satisfy the filter.
+	boolVal, ok := val.(bool)
+	return ok && boolVal, true, nil
+}
+
+// columns returns the columns referenced by the filter.
+func (f *rowFilter) columns() []string {
+	return f.root.columns()
+}
+
+type rowFilterTokenKind int
+
+const (
+	rowFilterTokenIdentifier rowFilterTokenKind = iota
+	rowFilterTokenKeyword
+	rowFilterTokenString
+	rowFilterTokenNumber
+	rowFilterTokenOperator
+	rowFilterTokenPunctuation
+)
+
+var rowFilterKeywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "IN": true, "IS": true, "NULL": true, "TRUE": true, "FALSE": true,
+}
+
+type rowFilterToken struct {
+	kind rowFilterTokenKind
+	text string
+}
+
+func tokenizeRowFilter(expression string) ([]rowFilterToken, error) {
+	tokens := make([]rowFilterToken, 0)
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, rowFilterToken{kind: rowFilterTokenPunctuation, text: string(r)})
+			i++
+		case r == '=' || r == '<' || r == '>' || r == '!':
+			op := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')) {
+				op += string(runes[i+1])
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected !")
+			}
+			tokens = append(tokens, rowFilterToken{kind: rowFilterTokenOperator, text: op})
+			i += len(op)
+		case r == '\'' || r == '"':
+			// quotes are escaped by doubling them, in string literals and quoted identifiers alike.
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == r {
+					if j+1 < len(runes) && runes[j+1] == r {
+						sb.WriteRune(r)
+						j++
+						continue
+					}
+					break
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			kind := rowFilterTokenString
+			if r == '"' {
+				kind = rowFilterTokenIdentifier
+			}
+			tokens = append(tokens, rowFilterToken{kind: kind, text: sb.String()})
+			i = j + 1
+		case unicode.IsDigit(r) || r == '-' || r == '.':
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, rowFilterToken{kind: rowFilterTokenNumber, text: string(runes[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			word := string(runes[i:j])
+			if rowFilterKeywords[strings.ToUpper(word)] {
+				tokens = append(tokens, rowFilterToken{kind: rowFilterTokenKeyword, text: strings.ToUpper(word)})
+			} else {
+				// unquoted identifiers are folded to lower case, as Postgres does.
+				tokens = append(tokens, rowFilterToken{kind: rowFilterTokenIdentifier, text: strings.ToLower(word)})
+			}
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %c", r)
+		}
+	}
+	return tokens, nil
+}
+
+type rowFilterParser struct {
+	tokens []rowFilterToken
+	pos    int
+}
+
+func (p *rowFilterParser) peekIs(kind rowFilterTokenKind, text string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == kind && p.tokens[p.pos].text == text
+}
+
+func (p *rowFilterParser) accept(kind rowFilterTokenKind, text string) bool {
+	if p.peekIs(kind, text) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *rowFilterParser) expect(kind rowFilterTokenKind, text string) error {
+	if !p.accept(kind, text) {
+		return fmt.Errorf("expected %s", text)
+	}
+	return nil
+}
+
+func (p *rowFilterParser) parseOr() (rowFilterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(rowFilterTokenKeyword, "OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &rowFilterOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *rowFilterParser) parseAnd() (rowFilterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(rowFilterTokenKeyword, "AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &rowFilterAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *rowFilterParser) parseNot() (rowFilterNode, error) {
+	if p.accept(rowFilterTokenKeyword, "NOT") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &rowFilterNot{operand: operand}, nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *rowFilterParser) parsePredicate() (rowFilterNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == rowFilterTokenOperator {
+		op := p.tokens[p.pos].text
+		p.pos++
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &rowFilterComparison{op: op, left: left, right: right}, nil
+	}
+
+	if p.accept(rowFilterTokenKeyword, "IS") {
+		negated := p.accept(rowFilterTokenKeyword, "NOT")
+		if err := p.expect(rowFilterTokenKeyword, "NULL"); err != nil {
+			return nil, err
+		}
+		return &rowFilterIsNull{operand: left, negated: negated}, nil
+	}
+
+	negated := false
+	if p.peekIs(rowFilterTokenKeyword, "NOT") && p.pos+1 < len(p.tokens) &&
+		p.tokens[p.pos+1].kind == rowFilterTokenKeyword && p.tokens[p.pos+1].text == "IN" {
+		p.pos++
+		negated = true
+	}
+	if p.accept(rowFilterTokenKeyword, "IN") {
+		if err := p.expect(rowFilterTokenPunctuation, "("); err != nil {
+			return nil, err
+		}
+		list := make([]rowFilterNode, 0)
+		for {
+			item, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+			if !p.accept(rowFilterTokenPunctuation, ",") {
+				break
+			}
+		}
+		if err := p.expect(rowFilterTokenPunctuation, ")"); err != nil {
+			return nil, err
+		}
+		return &rowFilterIn{operand: left, list: list, negated: negated}, nil
+	}
+
+	return left, nil
+}
+
+func (p *rowFilterParser) parseOperand() (rowFilterNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+	switch token.kind {
+	case rowFilterTokenIdentifier:
+		return &rowFilterColumn{name: token.text}, nil
+	case rowFilterTokenString:
+		return &rowFilterLiteral{value: token.text}, nil
+	case rowFilterTokenNumber:
+		num, ok := new(big.Rat).SetString(token.text)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %s", token.text)
+		}
+		return &rowFilterLiteral{value: num}, nil
+	case rowFilterTokenKeyword:
+		switch token.text {
+		case "NULL":
+			return &rowFilterLiteral{value: nil}, nil
+		case "TRUE":
+			return &rowFilterLiteral{value: true}, nil
+		case "FALSE":
+			return &rowFilterLiteral{value: false}, nil
+		}
+	case rowFilterTokenPunctuation:
+		if token.text == "(" {
+			expr, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(rowFilterTokenPunctuation, ")"); err != nil {
+				return nil, err
+			}
+			return expr, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s", token.text)
+}
+
+type rowFilterLiteral struct {
+	value interface{}
+}
+
+func (n *rowFilterLiteral) eval(items model.RecordItems) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n *rowFilterLiteral) columns() []string {
+	return nil
+}
+
+type rowFilterColumn struct {
+	name string
+}
+
+func (n *rowFilterColumn) eval(items model.RecordItems) (interface{}, error) {
+	val, ok := items[n.name]
+	if !ok {
+		return nil, &errRowFilterColumnMissing{column: n.name}
+	}
+	return qValueToRowFilterValue(val)
+}
+
+func (n *rowFilterColumn) columns() []string {
+	return []string{n.name}
+}
+
+// qValueToRowFilterValue converts a decoded column to the values filters are evaluated on.
+// Integers and numerics are compared exactly, only floating point columns are compared as floats.
+func qValueToRowFilterValue(val qvalue.QValue) (interface{}, error) {
+	if val.Value == nil {
+		return nil, nil
+	}
+
+	switch val.Kind {
+	case qvalue.QValueKindTimestamp, qvalue.QValueKindTimestampTZ, qvalue.QValueKindDate,
+		qvalue.QValueKindTime, qvalue.QValueKindTimeTZ:
+		t, ok := val.Value.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("unexpected value %v for column of type %s", val.Value, val.Kind)
+		}
+		return t, nil
+	case qvalue.QValueKindUUID:
+		switch v := val.Value.(type) {
+		case [16]byte:
+			return uuid.UUID(v), nil
+		case string:
+			u, err := uuid.Parse(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid uuid %q: %w", v, err)
+			}
+			return u, nil
+		}
+		return nil, fmt.Errorf("unexpected value %v for column of type %s", val.Value, val.Kind)
+	}
+
+	switch v := val.Value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return v, nil
+	case int16:
+		return new(big.Rat).SetInt64(int64(v)), nil
+	case int32:
+		return new(big.Rat).SetInt64(int64(v)), nil
+	case int64:
+		return new(big.Rat).SetInt64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case *big.Rat:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("columns of type %s are not supported in row filters", val.Kind)
+	}
+}
+
+type rowFilterComparison struct {
+	op          string
+	left, right rowFilterNode
+}
+
+func (n *rowFilterComparison) eval(items model.RecordItems) (interface{}, error) {
+	left, err := n.left.eval(items)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(items)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	cmp, err := compareRowFilterValues(left, right)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "=":
+		return cmp == 0, nil
+	case "<>", "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	default:
+		return nil, fmt.Errorf("unsupported operator %s", n.op)
+	}
+}
+
+func (n *rowFilterComparison) columns() []string {
+	return append(n.left.columns(), n.right.columns()...)
+}
+
+// compareRowFilterValues compares two non NULL values. A string compared to a value of another
+// type is read as a value of that type, as Postgres does with literals.
+func compareRowFilterValues(left, right interface{}) (int, error) {
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+		cmp, err := compareRowFilterValues(right, l)
+		return -cmp, err
+	}
+
+	switch l := left.(type) {
+	case *big.Rat:
+		if r, ok := right.(float64); ok {
+			lFloat, _ := l.Float64()
+			return compareRowFilterFloats(lFloat, r), nil
+		}
+		r, err := rowFilterRat(right)
+		if err != nil {
+			return 0, err
+		}
+		return l.Cmp(r), nil
+	case float64:
+		r, err := rowFilterFloat(right)
+		if err != nil {
+			return 0, err
+		}
+		return compareRowFilterFloats(l, r), nil
+	case time.Time:
+		r, err := rowFilterTime(right)
+		if err != nil {
+			return 0, err
+		}
+		if l.Before(r) {
+			return -1, nil
+		} else if l.After(r) {
+			return 1, nil
+		}
+		return 0, nil
+	case uuid.UUID:
+		r, err := rowFilterUUID(right)
+		if err != nil {
+			return 0, err
+		}
+		return bytes.Compare(l[:], r[:]), nil
+	case bool:
+		r, ok := right.(bool)
+		if !ok {
+			return 0, fmt.Errorf("can't compare boolean to %v", right)
+		}
+		if l == r {
+			return 0, nil
+		} else if !l {
+			return -1, nil
+		}
+		return 1, nil
+	default:
+		return 0, fmt.Errorf("can't compare %v", left)
+	}
+}
+
+func rowFilterRat(val interface{}) (*big.Rat, error) {
+	switch v := val.(type) {
+	case *big.Rat:
+		return v, nil
+	case string:
+		num, ok := new(big.Rat).SetString(strings.TrimSpace(v))
+		if !ok {
+			return nil, fmt.Errorf("can't compare %q to a number", v)
+		}
+		return num, nil
+	default:
+		return nil, fmt.Errorf("can't compare %v to a number", val)
+	}
+}
+
+func rowFilterFloat(val interface{}) (float64, error) {
+	switch v := val.(type) {
+	case float64:
+		return v, nil
+	case *big.Rat:
+		f, _ := v.Float64()
+		return f, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "nan":
+			return math.NaN(), nil
+		case "infinity", "inf":
+			return math.Inf(1), nil
+		case "-infinity", "-inf":
+			return math.Inf(-1), nil
+		}
+		num, err := rowFilterRat(v)
+		if err != nil {
+			return 0, err
+		}
+		f, _ := num.Float64()
+		return f, nil
+	default:
+		return 0, fmt.Errorf("can't compare %v to a number", val)
+	}
+}
+
+// compareRowFilterFloats orders NaN above every other value and equal to itself, as Postgres does.
+func compareRowFilterFloats(l, r float64) int {
+	switch {
+	case math.IsNaN(l) && math.IsNaN(r):
+		return 0
+	case math.IsNaN(l):
+		return 1
+	case math.IsNaN(r):
+		return -1
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
+
+// rowFilterTimeLayouts are the layouts time literals are read with. Literals without a zone are
+// read as UTC, the zone timestamps are decoded in, and times of day fall on the date times are decoded on.
+var rowFilterTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999Z07:00",
+	"15:04:05.999999999Z07",
+	"15:04:05.999999999",
+	"15:04",
+}
+
+func rowFilterTime(val interface{}) (time.Time, error) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		for _, layout := range rowFilterTimeLayouts {
+			t, err := time.Parse(layout, strings.TrimSpace(v))
+			if err != nil {
+				continue
+			}
+			if t.Year() == 0 {
+				t = t.AddDate(1970, 0, 0)
+			}
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("can't compare %q to a time", v)
+	default:
+		return time.Time{}, fmt.Errorf("can't compare %v to a time", val)
+	}
+}
+
+func rowFilterUUID(val interface{}) (uuid.UUID, error) {
+	switch v := val.(type) {
+	case uuid.UUID:
+		return v, nil
+	case string:
+		u, err := uuid.Parse(strings.TrimSpace(v))
+		if err != nil {
+			return uuid.UUID{}, fmt.Errorf("can't compare %q to a uuid", v)
+		}
+		return u, nil
+	default:
+		return uuid.UUID{}, fmt.Errorf("can't compare %v to a uuid", val)
+	}
+}
+
+type rowFilterIn struct {
+	operand rowFilterNode
+	list    []rowFilterNode
+	negated bool
+}
+
+func (n *rowFilterIn) eval(items model.RecordItems) (interface{}, error) {
+	val, err := n.operand.eval(items)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	sawNull := false
+	for _, item := range n.list {
+		itemVal, err := item.eval(items)
+		if err != nil {
+			return nil, err
+		}
+		if itemVal == nil {
+			sawNull = true
+			continue
+		}
+		cmp, err := compareRowFilterValues(val, itemVal)
+		if err != nil {
+			return nil, err
+		}
+		if cmp == 0 {
+			return !n.negated, nil
+		}
+	}
+	if sawNull {
+		return nil, nil
+	}
+	return n.negated, nil
+}
+
+func (n *rowFilterIn) columns() []string {
+	cols := n.operand.columns()
+	for _, item := range n.list {
+		cols = append(cols, item.columns()...)
+	}
+	return cols
+}
+
+type rowFilterIsNull struct {
+	operand rowFilterNode
+	negated bool
+}
+
+func (n *rowFilterIsNull) eval(items model.RecordItems) (interface{}, error) {
+	val, err := n.operand.eval(items)
+	if err != nil {
+		return nil, err
+	}
+	return (val == nil) != n.negated, nil
+}
+
+func (n *rowFilterIsNull) columns() []string {
+	return n.operand.columns()
+}
+
+type rowFilterNot struct {
+	operand rowFilterNode
+}
+
+func (n *rowFilterNot) eval(items model.RecordItems) (interface{}, error) {
+	val, err := n.operand.eval(items)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	boolVal, ok := val.(bool)
+	if !ok {
+		return nil, fmt.Errorf("argument of NOT must be a boolean")
+	}
+	return !boolVal, nil
+}
+
+func (n *rowFilterNot) columns() []string {
+	return n.operand.columns()
+}
+
+type rowFilterAnd struct {
+	left, right rowFilterNode
+}
+
+// eval follows SQL's three valued logic, false wins over NULL.
+func (n *rowFilterAnd) eval(items model.RecordItems) (interface{}, error) {
+	left, err := evalRowFilterBool(n.left, items)
+	if err != nil {
+		return nil, err
+	}
+	if left != nil && !*left {
+		return false, nil
+	}
+	right, err := evalRowFilterBool(n.right, items)
+	if err != nil {
+		return nil, err
+	}
+	if right != nil && !*right {
+		return false, nil
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	return true, nil
+}
+
+func (n *rowFilterAnd) columns() []string {
+	return append(n.left.columns(), n.right.columns()...)
+}
+
+type rowFilterOr struct {
+	left, right rowFilterNode
+}
+
+// eval follows SQL's three valued logic, true wins over NULL.
+func (n *rowFilterOr) eval(items model.RecordItems) (interface{}, error) {
+	left, err := evalRowFilterBool(n.left, items)
+	if err != nil {
+		return nil, err
+	}
+	if left != nil && *left {
+		return true, nil
+	}
+	right, err := evalRowFilterBool(n.right, items)
+	if err != nil {
+		return nil, err
+	}
+	if right != nil && *right {
+		return true, nil
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	return false, nil
+}
+
+func (n *rowFilterOr) columns() []string {
+	return append(n.left.columns(), n.right.columns()...)
+}
+
+// evalRowFilterBool evaluates a boolean operand, nil stands for NULL.
+func evalRowFilterBool(node rowFilterNode, items model.RecordItems) (*bool, error) {
+	val, err := node.eval(items)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	boolVal, ok := val.(bool)
+	if !ok {
+		return nil, fmt.Errorf("argument of AND/OR must be a boolean")
+	}
+	return &boolVal, nil
+}
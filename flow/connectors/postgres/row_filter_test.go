@@ -0,0 +1,297 @@
+package connpostgres
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/jackc/pglogrepl"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowFilterEvaluate(t *testing.T) {
+	items := model.RecordItems{
+		"id":      qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(42)},
+		"status":  qvalue.QValue{Kind: qvalue.QValueKindString, Value: "active"},
+		"deleted": qvalue.QValue{Kind: qvalue.QValueKindBoolean, Value: false},
+		"note":    qvalue.QValue{Kind: qvalue.QValueKindString, Value: nil},
+		"Region":  qvalue.QValue{Kind: qvalue.QValueKindString, Value: "eu"},
+	}
+
+	testCases := []struct {
+		name       string
+		expression string
+		matched    bool
+		known      bool
+	}{
+		{name: "Equality", expression: "status = 'active'", matched: true, known: true},
+		{name: "Not equal", expression: "status <> 'active'", matched: false, known: true},
+		{name: "Numeric comparison", expression: "id >= 40 AND id < 50", matched: true, known: true},
+		{name: "IN list", expression: "status IN ('inactive', 'active')", matched: true, known: true},
+		{name: "NOT IN list", expression: "id NOT IN (1, 2, 3)", matched: true, known: true},
+		{name: "IS NULL", expression: "note IS NULL", matched: true, known: true},
+		{name: "IS NOT NULL", expression: "note IS NOT NULL", matched: false, known: true},
+		{name: "Boolean column", expression: "NOT deleted", matched: true, known: true},
+		{name: "Comparison with NULL", expression: "note = 'x'", matched: false, known: true},
+		{name: "NULL OR true", expression: "note = 'x' OR id = 42", matched: true, known: true},
+		{name: "Quoted identifier", expression: `"Region" = 'eu'`, matched: true, known: true},
+		{name: "Missing column", expression: "tenant_id = 7", matched: false, known: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := parseRowFilter(tc.expression)
+			if err != nil {
+				t.Fatalf("Error returned by parseRowFilter: %v", err)
+			}
+
+			matched, known, err := filter.evaluate(items)
+			if err != nil {
+				t.Fatalf("Error returned by evaluate: %v", err)
+			}
+			if matched != tc.matched || known != tc.known {
+				t.Fatalf("Unexpected result for %q. Expected: (%v, %v), but got: (%v, %v)",
+					tc.expression, tc.matched, tc.known, matched, known)
+			}
+		})
+	}
+}
+
+func TestRowFilterInvalidExpression(t *testing.T) {
+	expressions := []string{
+		"",
+		"status =",
+		"status = 'active' AND",
+		"id IN ()",
+		"lower(status) = 'active'",
+	}
+
+	for _, expression := range expressions {
+		if _, err := parseRowFilter(expression); err == nil {
+			t.Errorf("Expected an error parsing %q", expression)
+		}
+	}
+}
+
+func TestRowFilterEvaluateTypes(t *testing.T) {
+	updatedAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	items := model.RecordItems{
+		// above 2^53, where float64 can't tell neighbouring integers apart.
+		"big_id":     qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(9007199254740993)},
+		"amount":     qvalue.QValue{Kind: qvalue.QValueKindNumeric, Value: big.NewRat(1, 10)},
+		"ratio":      qvalue.QValue{Kind: qvalue.QValueKindFloat64, Value: 0.1},
+		"score":      qvalue.QValue{Kind: qvalue.QValueKindFloat64, Value: math.NaN()},
+		"updated_at": qvalue.QValue{Kind: qvalue.QValueKindTimestampTZ, Value: updatedAt},
+		"birthday":   qvalue.QValue{Kind: qvalue.QValueKindDate, Value: time.Date(1990, 2, 3, 0, 0, 0, 0, time.UTC)},
+		"opens_at": qvalue.QValue{
+			Kind:  qvalue.QValueKindTime,
+			Value: time.Date(1970, 1, 1, 9, 30, 0, 0, time.UTC),
+		},
+		"uid": qvalue.QValue{Kind: qvalue.QValueKindUUID, Value: [16]byte{
+			0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00,
+		}},
+		"other_uid": qvalue.QValue{Kind: qvalue.QValueKindUUID, Value: "550e8400-e29b-41d4-a716-446655440000"},
+	}
+
+	testCases := []struct {
+		name       string
+		expression string
+		matched    bool
+	}{
+		{name: "Large integer equality", expression: "big_id = 9007199254740993", matched: true},
+		{name: "Large integer neighbour", expression: "big_id = 9007199254740992", matched: false},
+		{name: "Large integer ordering", expression: "big_id > 9007199254740992", matched: true},
+		{name: "Large integer string literal", expression: "big_id = '9007199254740993'", matched: true},
+		{name: "Exact numeric", expression: "amount = 0.1", matched: true},
+		{name: "Exact numeric ordering", expression: "amount < 0.10000000000000001", matched: true},
+		{name: "Float", expression: "ratio = 0.1", matched: true},
+		{name: "NaN above numbers", expression: "score > 1000", matched: true},
+		{name: "NaN literal", expression: "score = 'NaN'", matched: true},
+		{name: "Timestamp", expression: "updated_at = '2023-05-01 10:00:00+00'", matched: true},
+		{name: "Timestamp in other zone", expression: "updated_at = '2023-05-01T12:00:00+02:00'", matched: true},
+		{name: "Timestamp ordering", expression: "updated_at > '2023-05-01 09:59:59.999999'", matched: true},
+		{name: "Date ordering", expression: "birthday < '1990-02-04'", matched: true},
+		{name: "Time of day", expression: "opens_at >= '09:00' AND opens_at < '10:00:00'", matched: true},
+		{name: "UUID", expression: "uid = '550E8400-E29B-41D4-A716-446655440000'", matched: true},
+		{name: "UUID IN list", expression: "other_uid IN ('00000000-0000-0000-0000-000000000000', uid)", matched: true},
+		{name: "UUID ordering", expression: "uid > '00000000-0000-0000-0000-000000000000'", matched: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := parseRowFilter(tc.expression)
+			require.NoError(t, err)
+			matched, known, err := filter.evaluate(items)
+			require.NoError(t, err)
+			require.True(t, known)
+			require.Equal(t, tc.matched, matched, tc.expression)
+		})
+	}
+}
+
+func TestRowFilterTypeMismatch(t *testing.T) {
+	items := model.RecordItems{
+		"updated_at": qvalue.QValue{Kind: qvalue.QValueKindTimestamp, Value: time.Now()},
+		"uid":        qvalue.QValue{Kind: qvalue.QValueKindUUID, Value: "550e8400-e29b-41d4-a716-446655440000"},
+	}
+	for _, expression := range []string{"updated_at = 'yesterday-ish'", "uid = 'not-a-uuid'", "uid = 1"} {
+		filter, err := parseRowFilter(expression)
+		require.NoError(t, err)
+		_, _, err = filter.evaluate(items)
+		require.Error(t, err, expression)
+	}
+}
+
+// newTestRowFilterCDCSource returns a source of a table with REPLICA IDENTITY FULL filtered by the expression.
+func newTestRowFilterCDCSource(t *testing.T, expression string) *PostgresCDCSource {
+	filter, err := parseRowFilter(expression)
+	require.NoError(t, err)
+	source, err := NewPostgresCDCSource(&PostgresCDCConfig{
+		SrcTableIDNameMapping: map[uint32]string{testRelID: "public.docs"},
+		TableNameMapping:      map[string]string{"public.docs": "public.docs_dst"},
+		RelationMessageMapping: map[uint32]*protos.RelationMessage{
+			testRelID: {
+				RelationId:   testRelID,
+				RelationName: "docs",
+				Columns: []*protos.RelationMessageColumn{
+					{Name: "id", DataType: uint32(oid.T_int8), Flags: 1},
+					{Name: "status", DataType: uint32(oid.T_text), Flags: 1},
+					{Name: "body", DataType: uint32(oid.T_text), Flags: 1},
+				},
+			},
+		},
+		RowFilters: map[string]*rowFilter{"public.docs": filter},
+	})
+	require.NoError(t, err)
+	return source
+}
+
+func TestRowFilterUpdates(t *testing.T) {
+	source := newTestRowFilterCDCSource(t, "status = 'published'")
+	update := func(oldStatus, newStatus string) (model.Record, error) {
+		msg := &pglogrepl.UpdateMessage{
+			RelationID: testRelID,
+			NewTuple: &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{
+				{DataType: 't', Data: []byte("1")},
+				{DataType: 't', Data: []byte(newStatus)},
+				{DataType: 'u'},
+			}},
+		}
+		if oldStatus != "" {
+			msg.OldTuple = &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{
+				{DataType: 't', Data: []byte("1")},
+				{DataType: 't', Data: []byte(oldStatus)},
+				{DataType: 't', Data: []byte("text")},
+			}}
+		}
+		return source.processUpdateMessage(0, msg)
+	}
+
+	record, err := update("", "published")
+	require.NoError(t, err)
+	require.IsType(t, &model.UpdateRecord{}, record)
+
+	// a row moving out of the filter is deleted from the destination.
+	record, err = update("published", "draft")
+	require.NoError(t, err)
+	require.IsType(t, &model.DeleteRecord{}, record)
+
+	record, err = update("draft", "draft")
+	require.NoError(t, err)
+	require.Nil(t, record)
+}
+
+func TestRowFilterUpdatesWithKeyReplicaIdentity(t *testing.T) {
+	source := newTestRowFilterCDCSource(t, "status = 'published'")
+	// only the key is the replica identity, the old row is only sent with the key if it changed.
+	for _, col := range source.relations[testRelID].Columns[1:] {
+		col.Flags = 0
+	}
+	newTuple := &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{
+		{DataType: 't', Data: []byte("2")},
+		{DataType: 't', Data: []byte("draft")},
+		{DataType: 't', Data: []byte("text")},
+	}}
+
+	// the old row may or may not have matched the filter, the update is dropped rather than
+	// deleting a row that was never replicated.
+	record, err := source.processUpdateMessage(0, &pglogrepl.UpdateMessage{RelationID: testRelID, NewTuple: newTuple})
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	record, err = source.processUpdateMessage(0, &pglogrepl.UpdateMessage{
+		RelationID: testRelID,
+		OldTuple: &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{
+			{DataType: 't', Data: []byte("1")},
+			{DataType: 'n'},
+			{DataType: 'n'},
+		}},
+		NewTuple: newTuple,
+	})
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	// a filter on the key can be evaluated on the old row.
+	source = newTestRowFilterCDCSource(t, "id = 1")
+	for _, col := range source.relations[testRelID].Columns[1:] {
+		col.Flags = 0
+	}
+	record, err = source.processUpdateMessage(0, &pglogrepl.UpdateMessage{
+		RelationID: testRelID,
+		OldTuple: &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{
+			{DataType: 't', Data: []byte("1")},
+			{DataType: 'n'},
+			{DataType: 'n'},
+		}},
+		NewTuple: newTuple,
+	})
+	require.NoError(t, err)
+	require.IsType(t, &model.DeleteRecord{}, record)
+	require.EqualValues(t, 1, record.(*model.DeleteRecord).Items["id"].Value)
+}
+
+func TestRowFilterOnUnchangedToastColumn(t *testing.T) {
+	source := newTestRowFilterCDCSource(t, "body <> 'secret'")
+	newTuple := &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{
+		{DataType: 't', Data: []byte("1")},
+		{DataType: 't', Data: []byte("draft")},
+		{DataType: 'u'},
+	}}
+
+	// without the old row the filter can't be evaluated, the update mustn't be passed on.
+	_, err := source.processUpdateMessage(0, &pglogrepl.UpdateMessage{RelationID: testRelID, NewTuple: newTuple})
+	require.ErrorContains(t, err, "REPLICA IDENTITY FULL")
+
+	// with REPLICA IDENTITY FULL the unchanged value comes from the old row.
+	updateWithOldBody := func(body string) (model.Record, error) {
+		return source.processUpdateMessage(0, &pglogrepl.UpdateMessage{
+			RelationID: testRelID,
+			OldTuple: &pglogrepl.TupleData{Columns: []*pglogrepl.TupleDataColumn{
+				{DataType: 't', Data: []byte("1")},
+				{DataType: 't', Data: []byte("published")},
+				{DataType: 't', Data: []byte(body)},
+			}},
+			NewTuple: newTuple,
+		})
+	}
+	record, err := updateWithOldBody("hello")
+	require.NoError(t, err)
+	require.IsType(t, &model.UpdateRecord{}, record)
+	require.Equal(t, "hello", record.(*model.UpdateRecord).NewItems["body"].Value)
+
+	record, err = updateWithOldBody("secret")
+	require.NoError(t, err)
+	require.Nil(t, record)
+}
+
+func TestValidateRowFilter(t *testing.T) {
+	require.NoError(t, ValidateRowFilter("id > 10 AND status IN ('a', 'b')"))
+	require.Error(t, ValidateRowFilter("id > 10) OR (1 = 1"))
+	require.Error(t, ValidateRowFilter("id > 10; DROP TABLE users"))
+	require.Error(t, ValidateRowFilter("id > (SELECT max(id) FROM users)"))
+}
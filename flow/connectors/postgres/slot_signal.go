@@ -0,0 +1,25 @@
+package connpostgres
+
+// SlotCreationResult is the replication slot created for a flow job.
+type SlotCreationResult struct {
+	SlotName string
+	// SnapshotName is the exported snapshot of the slot's starting point,
+	// empty if the slot already existed.
+	SnapshotName string
+}
+
+// SlotSignal coordinates creating a replication slot with an exported snapshot.
+// The snapshot stays valid only while the connection that created the slot is open,
+// so the creator keeps it open until CloneComplete is closed.
+type SlotSignal struct {
+	SlotCreated   chan *SlotCreationResult
+	CloneComplete chan struct{}
+}
+
+// NewSlotSignal returns a new SlotSignal.
+func NewSlotSignal() *SlotSignal {
+	return &SlotSignal{
+		SlotCreated:   make(chan *SlotCreationResult, 1),
+		CloneComplete: make(chan struct{}),
+	}
+}
@@ -0,0 +1,360 @@
+package conns3
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	avro "github.com/PeerDB-io/peer-flow/connectors/utils/avro"
+	parquet "github.com/PeerDB-io/peer-flow/connectors/utils/parquet"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	log "github.com/sirupsen/logrus"
+)
+
+// records buffered for each object while it's uploaded.
+const objectStreamBufferSize = 1 << 10
+
+// columns added to the columns of the table in the objects of CDC batches.
+const (
+	opColumnName                    = "_peerdb_op"
+	lsnColumnName                   = "_peerdb_lsn"
+	commitTimeColumnName            = "_peerdb_commit_time"
+	batchIDColumnName               = "_peerdb_batch_id"
+	unchangedToastColumnsColumnName = "_peerdb_unchanged_toast_columns"
+)
+
+// operations of the records, as written to the op column.
+const (
+	opInsert   = "insert"
+	opUpdate   = "update"
+	opDelete   = "delete"
+	opTruncate = "truncate"
+)
+
+// formats of the objects of CDC batches, also their file extensions.
+const (
+	objectFormatAvro    = "avro"
+	objectFormatParquet = "parquet"
+)
+
+// cdcObjectKey returns the key of the object holding the records of a batch for a table that
+// committed on a date, <job>/<table>/dt=YYYY-MM-DD/batch-<id>.<format>. The schema of a table
+// can change part way through a batch, the records with the changed schema go to the next part
+// of the batch, <job>/<table>/dt=YYYY-MM-DD/batch-<id>-<part>.<format>.
+func cdcObjectKey(jobName string, tableName string, commitDate string, batchID int64, part int,
+	format string) string {
+	name := fmt.Sprintf("batch-%d.%s", batchID, format)
+	if part > 0 {
+		name = fmt.Sprintf("batch-%d-%d.%s", batchID, part, format)
+	}
+	return path.Join(jobName, tableName, "dt="+commitDate, name)
+}
+
+// cdcTableSchema returns the schema of the records of a table in the objects of CDC batches, the
+// columns of the table sorted by name followed by the columns describing the change. Every column
+// of the table is nullable, deletes only carry the key of the row and truncates no row at all.
+func cdcTableSchema(tableSchema *protos.TableSchema) *model.QRecordSchema {
+	columnNames := make([]string, 0, len(tableSchema.Columns))
+	for columnName := range tableSchema.Columns {
+		columnNames = append(columnNames, columnName)
+	}
+	sort.Strings(columnNames)
+
+	fields := make([]*model.QField, 0, len(columnNames)+5)
+	for _, columnName := range columnNames {
+		kind := qvalue.QValueKind(tableSchema.Columns[columnName])
+		// JSON values are written as strings.
+		if kind == qvalue.QValueKindJSON {
+			kind = qvalue.QValueKindString
+		}
+		fields = append(fields, &model.QField{Name: columnName, Type: kind, Nullable: true})
+	}
+	fields = append(fields,
+		&model.QField{Name: opColumnName, Type: qvalue.QValueKindString, Nullable: false},
+		&model.QField{Name: lsnColumnName, Type: qvalue.QValueKindInt64, Nullable: false},
+		&model.QField{Name: commitTimeColumnName, Type: qvalue.QValueKindTimestampTZ, Nullable: true},
+		&model.QField{Name: batchIDColumnName, Type: qvalue.QValueKindInt64, Nullable: false},
+		&model.QField{Name: unchangedToastColumnsColumnName, Type: qvalue.QValueKindString, Nullable: true},
+	)
+	return model.NewQRecordSchema(fields)
+}
+
+// cdcRecordToQRecord converts a record to a row of the schema of its table. The commit time of the
+// record is returned to partition the rows by date.
+func cdcRecordToQRecord(record model.Record, schema *model.QRecordSchema,
+	batchID int64) (*model.QRecord, time.Time, error) {
+	var op string
+	var items model.RecordItems
+	var unchangedToastColumns map[string]bool
+	var commitTime time.Time
+	switch r := record.(type) {
+	case *model.InsertRecord:
+		op, items, unchangedToastColumns, commitTime = opInsert, r.Items, r.UnchangedToastColumns, r.CommitTime
+	case *model.UpdateRecord:
+		op, items, unchangedToastColumns, commitTime = opUpdate, r.NewItems, r.UnchangedToastColumns, r.CommitTime
+	case *model.DeleteRecord:
+		op, items, unchangedToastColumns, commitTime = opDelete, r.Items, r.UnchangedToastColumns, r.CommitTime
+	case *model.TruncateRecord:
+		op, commitTime = opTruncate, r.CommitTime
+	default:
+		return nil, time.Time{}, fmt.Errorf("record type %T not supported in S3 flow connector", record)
+	}
+
+	numColumns := len(schema.Fields) - 5
+	qRecord := model.NewQRecord(len(schema.Fields))
+	for i, field := range schema.Fields[:numColumns] {
+		val, ok := items[field.Name]
+		if !ok {
+			val = qvalue.QValue{Kind: field.Type, Value: nil}
+		}
+		qRecord.Set(i, val)
+	}
+
+	var commitTimeValue interface{}
+	if !commitTime.IsZero() {
+		commitTimeValue = commitTime
+	}
+	var unchangedToastColumnsValue interface{}
+	if len(unchangedToastColumns) > 0 {
+		unchangedToastColumnsValue = utils.KeysToString(unchangedToastColumns)
+	}
+	qRecord.Set(numColumns, qvalue.QValue{Kind: qvalue.QValueKindString, Value: op})
+	qRecord.Set(numColumns+1, qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: record.GetCheckPointID()})
+	qRecord.Set(numColumns+2, qvalue.QValue{Kind: qvalue.QValueKindTimestampTZ, Value: commitTimeValue})
+	qRecord.Set(numColumns+3, qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: batchID})
+	qRecord.Set(numColumns+4, qvalue.QValue{Kind: qvalue.QValueKindString, Value: unchangedToastColumnsValue})
+	return qRecord, commitTime, nil
+}
+
+// cdcObjectWriter uploads the rows of one object of a batch while they're read from its stream.
+type cdcObjectWriter struct {
+	key    string
+	stream *model.QRecordStream
+	done   chan struct{}
+	err    error
+}
+
+// startObjectWriter starts the upload of an object, as Parquet if parquetOptions are set and as Avro otherwise.
+func (c *S3Connector) startObjectWriter(key string, schema *model.QRecordSchema,
+	avroSchema *model.QRecordAvroSchemaDefinition, parquetOptions *parquet.WriterOptions) *cdcObjectWriter {
+	w := &cdcObjectWriter{
+		key:    key,
+		stream: model.NewQRecordStream(objectStreamBufferSize),
+		done:   make(chan struct{}),
+	}
+	w.stream.SetSchema(schema)
+	go func() {
+		defer close(w.done)
+		if parquetOptions != nil {
+			_, w.err = parquet.WriteRecordsToStage(c.ctx, w.stream, parquetOptions, c.stage, key)
+		} else {
+			_, w.err = avro.WriteRecordsToStage(c.ctx, w.stream, avroSchema, c.stage, key)
+		}
+		// rows sent after a failed upload are dropped, the error is returned once the writer is finished.
+		w.stream.Abandon()
+	}()
+	return w
+}
+
+// finish ends the object and waits for its upload, an object ended with an error isn't written.
+func (w *cdcObjectWriter) finish(err error) error {
+	w.stream.Close(err)
+	<-w.done
+	if w.err != nil {
+		return fmt.Errorf("failed to write object %s: %w", w.key, w.err)
+	}
+	return nil
+}
+
+// cdcBatchWriter writes the rows of a batch to an object per table and commit date.
+type cdcBatchWriter struct {
+	connector *S3Connector
+	jobName   string
+	batchID   int64
+	manifest  *syncManifest
+	format    string
+	// options of the objects written as Parquet, nil for Avro.
+	parquetOptions *parquet.WriterOptions
+
+	schemas     map[string]*model.QRecordSchema
+	avroSchemas map[string]*model.QRecordAvroSchemaDefinition
+	writers     map[string]*cdcObjectWriter
+	// part of the batch the records of each table are written to, counting the schema changes of the table.
+	parts                  map[string]int
+	numAppliedSchemaDeltas int
+}
+
+// applySchemaDeltas applies the schema changes the source added to the stream so far, before the records
+// with the columns they added are written. Records of a table with a changed schema go to new objects.
+func (b *cdcBatchWriter) applySchemaDeltas(stream *model.CDCRecordStream) error {
+	schemaDeltas := stream.DestinationTableSchemaDeltasSince(b.numAppliedSchemaDeltas)
+	for _, schemaDelta := range schemaDeltas {
+		if err := b.connector.ReplayTableSchemaDelta(b.jobName, schemaDelta); err != nil {
+			return err
+		}
+		if _, ok := b.schemas[schemaDelta.DstTableName]; ok {
+			delete(b.schemas, schemaDelta.DstTableName)
+			delete(b.avroSchemas, schemaDelta.DstTableName)
+			b.parts[schemaDelta.DstTableName]++
+		}
+	}
+	b.numAppliedSchemaDeltas += len(schemaDeltas)
+	return nil
+}
+
+func (b *cdcBatchWriter) write(record model.Record) error {
+	tableName := record.GetTableName()
+	schema, ok := b.schemas[tableName]
+	if !ok {
+		tableSchema, ok := b.connector.tableSchemas[tableName]
+		if !ok {
+			return fmt.Errorf("no schema for destination table %s", tableName)
+		}
+		schema = cdcTableSchema(tableSchema)
+		if b.parquetOptions == nil {
+			avroSchema, err := getAvroSchema(tableName, schema)
+			if err != nil {
+				return err
+			}
+			b.avroSchemas[tableName] = avroSchema
+		}
+		b.schemas[tableName] = schema
+	}
+
+	qRecord, commitTime, err := cdcRecordToQRecord(record, schema, b.batchID)
+	if err != nil {
+		return err
+	}
+	// rows of sources that don't know commit times are partitioned by the time they're synced, objects
+	// of an earlier attempt of the batch are removed before it's written again, whatever their date.
+	if commitTime.IsZero() {
+		commitTime = time.Now()
+	}
+
+	key := cdcObjectKey(b.jobName, tableName, commitTime.UTC().Format("2006-01-02"), b.batchID,
+		b.parts[tableName], b.format)
+	w, ok := b.writers[key]
+	if !ok {
+		b.manifest.PendingBatchID = b.batchID
+		b.manifest.PendingObjects = append(b.manifest.PendingObjects, key)
+		if err := b.connector.writeManifest(b.jobName, b.manifest); err != nil {
+			return err
+		}
+		w = b.connector.startObjectWriter(key, schema, b.avroSchemas[tableName], b.parquetOptions)
+		b.writers[key] = w
+	}
+
+	if !w.stream.Send(qRecord) {
+		if err := w.finish(nil); err != nil {
+			return err
+		}
+		return fmt.Errorf("upload of object %s ended early", key)
+	}
+	return nil
+}
+
+// finish ends every object of the batch, with an error the objects aren't written.
+func (b *cdcBatchWriter) finish(err error) error {
+	var firstErr error
+	for _, w := range b.writers {
+		if finishErr := w.finish(err); finishErr != nil && firstErr == nil {
+			firstErr = finishErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return firstErr
+}
+
+// SyncRecords writes the records of a batch to an Avro or Parquet object per destination table and
+// commit date, with the operation and checkpoint of each record. The checkpoint of the batch is kept
+// in the manifest of the mirror. A batch that is synced again, after a failure part way, replaces the
+// objects written for it before.
+func (c *S3Connector) SyncRecords(req *model.SyncRecordsRequest) (*model.SyncResponse, error) {
+	var parquetOptions *parquet.WriterOptions
+	format := req.ObjectFormat
+	switch format {
+	case "", objectFormatAvro:
+		format = objectFormatAvro
+	case objectFormatParquet:
+		parquetOptions = &parquet.WriterOptions{Compression: req.ParquetCompression}
+	default:
+		return nil, fmt.Errorf("unsupported object format: %s", format)
+	}
+
+	manifest, err := c.readManifest(req.FlowJobName)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.deletePendingObjects(req.FlowJobName, manifest); err != nil {
+		return nil, err
+	}
+	syncBatchID := manifest.LastSyncBatchID + 1
+
+	batch := &cdcBatchWriter{
+		connector:      c,
+		jobName:        req.FlowJobName,
+		batchID:        syncBatchID,
+		manifest:       manifest,
+		format:         format,
+		parquetOptions: parquetOptions,
+		schemas:        make(map[string]*model.QRecordSchema),
+		avroSchemas:    make(map[string]*model.QRecordAvroSchemaDefinition),
+		writers:        make(map[string]*cdcObjectWriter),
+		parts:          make(map[string]int),
+	}
+
+	numRecords := 0
+	var firstCP int64
+	for record := range req.Records.Records() {
+		if numRecords == 0 {
+			firstCP = record.GetCheckPointID()
+		}
+		numRecords++
+
+		if err := batch.applySchemaDeltas(req.Records); err != nil {
+			return nil, batch.finish(err)
+		}
+		if err := batch.write(record); err != nil {
+			return nil, batch.finish(err)
+		}
+	}
+	if err := req.Records.Err(); err != nil {
+		return nil, batch.finish(err)
+	}
+	if err := batch.finish(nil); err != nil {
+		return nil, err
+	}
+
+	if numRecords == 0 {
+		return &model.SyncResponse{
+			FirstSyncedCheckPointID: 0,
+			LastSyncedCheckPointID:  0,
+			NumRecordsSynced:        0,
+		}, nil
+	}
+
+	lastCP := req.Records.LastCheckPointID()
+	manifest.LastSyncBatchID = syncBatchID
+	manifest.LastCheckpointID = lastCP
+	manifest.LastSyncedAt = time.Now()
+	manifest.PendingBatchID = 0
+	manifest.PendingObjects = nil
+	if err := c.writeManifest(req.FlowJobName, manifest); err != nil {
+		return nil, err
+	}
+	log.Infof("wrote %d records of batch %d to %d objects for flow job %s",
+		numRecords, syncBatchID, len(batch.writers), req.FlowJobName)
+
+	return &model.SyncResponse{
+		FirstSyncedCheckPointID: firstCP,
+		LastSyncedCheckPointID:  lastCP,
+		NumRecordsSynced:        int64(numRecords),
+		CurrentSyncBatchID:      syncBatchID,
+	}, nil
+}
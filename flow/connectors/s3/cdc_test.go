@@ -0,0 +1,233 @@
+package conns3
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCDCObjectKey(t *testing.T) {
+	assert.Equal(t, "mirror/public.users/dt=2023-07-01/batch-12.avro",
+		cdcObjectKey("mirror", "public.users", "2023-07-01", 12, 0, objectFormatAvro))
+	assert.Equal(t, "mirror/public.users/dt=2023-07-01/batch-12-1.parquet",
+		cdcObjectKey("mirror", "public.users", "2023-07-01", 12, 1, objectFormatParquet))
+}
+
+func TestCDCRecordToQRecord(t *testing.T) {
+	schema := cdcTableSchema(&protos.TableSchema{
+		TableIdentifier: "public.users",
+		Columns: map[string]string{
+			"id":      string(qvalue.QValueKindInt64),
+			"name":    string(qvalue.QValueKindString),
+			"profile": string(qvalue.QValueKindJSON),
+		},
+		PrimaryKeyColumns: []string{"id"},
+	})
+	assert.Equal(t, []string{"id", "name", "profile", opColumnName, lsnColumnName, commitTimeColumnName,
+		batchIDColumnName, unchangedToastColumnsColumnName}, schema.GetColumnNames())
+	assert.Equal(t, qvalue.QValueKindString, schema.Fields[2].Type)
+
+	commitTime := time.Date(2023, 7, 1, 23, 59, 0, 0, time.UTC)
+	qRecord, recordCommitTime, err := cdcRecordToQRecord(&model.UpdateRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+		CheckPointID:         42,
+		NewItems: model.RecordItems{
+			"id": qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(1)},
+		},
+		UnchangedToastColumns: map[string]bool{"profile": true},
+		CommitTime:            commitTime,
+	}, schema, 3)
+	require.NoError(t, err)
+	assert.Equal(t, commitTime, recordCommitTime)
+	assert.Equal(t, []qvalue.QValue{
+		{Kind: qvalue.QValueKindInt64, Value: int64(1)},
+		{Kind: qvalue.QValueKindString, Value: nil},
+		{Kind: qvalue.QValueKindString, Value: nil},
+		{Kind: qvalue.QValueKindString, Value: opUpdate},
+		{Kind: qvalue.QValueKindInt64, Value: int64(42)},
+		{Kind: qvalue.QValueKindTimestampTZ, Value: commitTime},
+		{Kind: qvalue.QValueKindInt64, Value: int64(3)},
+		{Kind: qvalue.QValueKindString, Value: "profile"},
+	}, qRecord.Entries)
+
+	// a truncate has no row, only the columns describing the change are set.
+	qRecord, _, err = cdcRecordToQRecord(&model.TruncateRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+		CheckPointID:         43,
+	}, schema, 3)
+	require.NoError(t, err)
+	assert.Nil(t, qRecord.Entries[0].Value)
+	assert.Equal(t, opTruncate, qRecord.Entries[3].Value)
+	assert.Nil(t, qRecord.Entries[5].Value)
+
+	// the rows convert to the Avro schema of the table.
+	avroSchema, err := getAvroSchema("public.users", schema)
+	require.NoError(t, err)
+	_, err = model.NewQRecordAvroConverter(qRecord, qvalue.QDWHTypeSnowflake, &avroSchema.NullableFields,
+		schema.GetColumnNames()).Convert()
+	require.NoError(t, err)
+}
+
+func TestSyncRecordsToLocalStage(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewS3Connector(context.Background(), &protos.S3Config{Url: "file://" + dir + "/lake"})
+	require.NoError(t, err)
+	defer c.Close()
+	require.True(t, c.ConnectionActive())
+	require.NoError(t, c.InitializeTableSchema(map[string]*protos.TableSchema{
+		"public.users": {
+			TableIdentifier:   "public.users",
+			Columns:           map[string]string{"id": string(qvalue.QValueKindInt64)},
+			PrimaryKeyColumns: []string{"id"},
+		},
+	}))
+
+	commitTime := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	stream := model.NewCDCRecordStream(4, nil)
+	go func() {
+		for i := int64(1); i <= 3; i++ {
+			_ = stream.Send(&model.InsertRecord{
+				SourceTableName:      "public.users",
+				DestinationTableName: "public.users",
+				CheckPointID:         i,
+				Items: model.RecordItems{
+					"id": qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: i},
+				},
+				CommitTime: commitTime,
+			})
+		}
+		stream.UpdateLastCheckPointID(3)
+		stream.Close(nil)
+	}()
+
+	resp, err := c.SyncRecords(&model.SyncRecordsRequest{Records: stream, FlowJobName: "mirror"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, resp.NumRecordsSynced)
+	assert.EqualValues(t, 1, resp.CurrentSyncBatchID)
+
+	keys, err := c.stage.List(c.ctx, "mirror/")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"mirror/_peerdb_manifest.json",
+		"mirror/public.users/dt=2023-07-01/batch-1.avro"}, keys)
+
+	lastOffset, err := c.GetLastOffset("mirror")
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, lastOffset.Checkpoint)
+
+	require.NoError(t, c.SyncFlowCleanup("mirror"))
+	lastOffset, err = c.GetLastOffset("mirror")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, lastOffset.Checkpoint)
+}
+
+func TestSyncRecordsWithColumnAddedMidBatch(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewS3Connector(context.Background(), &protos.S3Config{Url: "file://" + dir + "/lake"})
+	require.NoError(t, err)
+	defer c.Close()
+	require.NoError(t, c.InitializeTableSchema(map[string]*protos.TableSchema{
+		"public.users": {
+			TableIdentifier:   "public.users",
+			Columns:           map[string]string{"id": string(qvalue.QValueKindInt64)},
+			PrimaryKeyColumns: []string{"id"},
+		},
+	}))
+
+	commitTime := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	newRecord := func(id int64, items model.RecordItems) model.Record {
+		items["id"] = qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: id}
+		return &model.InsertRecord{
+			SourceTableName:      "public.users",
+			DestinationTableName: "public.users",
+			CheckPointID:         id,
+			Items:                items,
+			CommitTime:           commitTime,
+		}
+	}
+	// the stream is unbuffered, once the second record is received the first one is written.
+	stream := model.NewCDCRecordStream(0, nil)
+	go func() {
+		_ = stream.Send(newRecord(1, model.RecordItems{}))
+		_ = stream.Send(newRecord(2, model.RecordItems{}))
+		stream.AddSchemaDelta(&protos.TableSchemaDelta{
+			SrcTableName: "public.users",
+			DstTableName: "public.users",
+			AddedColumns: []*protos.DeltaColumn{{ColumnName: "email", ColumnType: string(qvalue.QValueKindString)}},
+		})
+		_ = stream.Send(newRecord(3, model.RecordItems{
+			"email": qvalue.QValue{Kind: qvalue.QValueKindString, Value: "a@example.com"},
+		}))
+		stream.UpdateLastCheckPointID(3)
+		stream.Close(nil)
+	}()
+
+	resp, err := c.SyncRecords(&model.SyncRecordsRequest{Records: stream, FlowJobName: "mirror"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, resp.NumRecordsSynced)
+	assert.Contains(t, c.tableSchemas["public.users"].Columns, "email")
+
+	// the records with the added column are written to the next part of the batch.
+	keys, err := c.stage.List(c.ctx, "mirror/")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"mirror/_peerdb_manifest.json",
+		"mirror/public.users/dt=2023-07-01/batch-1.avro",
+		"mirror/public.users/dt=2023-07-01/batch-1-1.avro"}, keys)
+}
+
+func TestSyncRecordsAsParquet(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewS3Connector(context.Background(), &protos.S3Config{Url: "file://" + dir + "/lake"})
+	require.NoError(t, err)
+	defer c.Close()
+	require.NoError(t, c.InitializeTableSchema(map[string]*protos.TableSchema{
+		"public.users": {
+			TableIdentifier:   "public.users",
+			Columns:           map[string]string{"id": string(qvalue.QValueKindInt64)},
+			PrimaryKeyColumns: []string{"id"},
+		},
+	}))
+
+	newStream := func() *model.CDCRecordStream {
+		stream := model.NewCDCRecordStream(1, nil)
+		go func() {
+			_ = stream.Send(&model.InsertRecord{
+				SourceTableName:      "public.users",
+				DestinationTableName: "public.users",
+				CheckPointID:         1,
+				Items: model.RecordItems{
+					"id": qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(1)},
+				},
+				CommitTime: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC),
+			})
+			stream.UpdateLastCheckPointID(1)
+			stream.Close(nil)
+		}()
+		return stream
+	}
+
+	_, err = c.SyncRecords(&model.SyncRecordsRequest{Records: newStream(), FlowJobName: "mirror",
+		ObjectFormat: "orc"})
+	require.ErrorContains(t, err, "unsupported object format: orc")
+
+	resp, err := c.SyncRecords(&model.SyncRecordsRequest{Records: newStream(), FlowJobName: "mirror",
+		ObjectFormat: objectFormatParquet, ParquetCompression: "zstd"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.NumRecordsSynced)
+
+	r, err := c.stage.Get(c.ctx, "mirror/public.users/dt=2023-07-01/batch-1.parquet")
+	require.NoError(t, err)
+	defer r.Close()
+	magic := make([]byte, 4)
+	_, err = io.ReadFull(r, magic)
+	require.NoError(t, err)
+	assert.Equal(t, "PAR1", string(magic))
+}
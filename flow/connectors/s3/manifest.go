@@ -0,0 +1,80 @@
+package conns3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	stage "github.com/PeerDB-io/peer-flow/connectors/utils/stage"
+	log "github.com/sirupsen/logrus"
+)
+
+const manifestFileName = "_peerdb_manifest.json"
+
+// syncManifest is the sync state of a CDC mirror, kept next to its objects so no metadata
+// database is needed.
+type syncManifest struct {
+	LastSyncBatchID  int64     `json:"lastSyncBatchId"`
+	LastCheckpointID int64     `json:"lastCheckpointId"`
+	LastSyncedAt     time.Time `json:"lastSyncedAt"`
+	// objects of the batch after the last synced one. They are recorded before they are written,
+	// so the objects of a sync that failed part way can be removed before the batch is written again.
+	PendingBatchID int64    `json:"pendingBatchId,omitempty"`
+	PendingObjects []string `json:"pendingObjects,omitempty"`
+}
+
+func manifestKey(jobName string) string {
+	return path.Join(jobName, manifestFileName)
+}
+
+// readManifest returns the manifest of a mirror, an empty one if nothing was synced yet.
+func (c *S3Connector) readManifest(jobName string) (*syncManifest, error) {
+	r, err := c.stage.Get(c.ctx, manifestKey(jobName))
+	if err != nil {
+		if errors.Is(err, stage.ErrObjectNotFound) {
+			return &syncManifest{}, nil
+		}
+		return nil, fmt.Errorf("failed to read manifest of flow job %s: %w", jobName, err)
+	}
+	defer r.Close()
+
+	manifest := &syncManifest{}
+	if err := json.NewDecoder(r).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest of flow job %s: %w", jobName, err)
+	}
+	return manifest, nil
+}
+
+func (c *S3Connector) writeManifest(jobName string, manifest *syncManifest) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest of flow job %s: %w", jobName, err)
+	}
+
+	if err := c.stage.Put(c.ctx, manifestKey(jobName), bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to write manifest of flow job %s: %w", jobName, err)
+	}
+	return nil
+}
+
+// deletePendingObjects removes the objects a failed sync may have left behind.
+func (c *S3Connector) deletePendingObjects(jobName string, manifest *syncManifest) error {
+	if len(manifest.PendingObjects) == 0 {
+		return nil
+	}
+
+	log.Infof("removing %d objects of unfinished batch %d of flow job %s",
+		len(manifest.PendingObjects), manifest.PendingBatchID, jobName)
+	for _, key := range manifest.PendingObjects {
+		if err := c.stage.Delete(c.ctx, key); err != nil {
+			return fmt.Errorf("failed to delete object %s: %w", key, err)
+		}
+	}
+
+	manifest.PendingBatchID = 0
+	manifest.PendingObjects = nil
+	return c.writeManifest(jobName, manifest)
+}
@@ -2,9 +2,10 @@ package conns3
 
 import (
 	"fmt"
+	"path"
 
-	"github.com/PeerDB-io/peer-flow/connectors/utils"
 	avro "github.com/PeerDB-io/peer-flow/connectors/utils/avro"
+	parquet "github.com/PeerDB-io/peer-flow/connectors/utils/parquet"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/model"
 	log "github.com/sirupsen/logrus"
@@ -18,28 +19,35 @@ func (c *S3Connector) GetQRepPartitions(config *protos.QRepConfig,
 
 func (c *S3Connector) PullQRepRecords(config *protos.QRepConfig,
 	partition *protos.QRepPartition,
-) (*model.QRecordBatch, error) {
+	stream *model.QRecordStream,
+) (int, error) {
 	panic("not implemented for s3")
 }
 
 func (c *S3Connector) SyncQRepRecords(
 	config *protos.QRepConfig,
 	partition *protos.QRepPartition,
-	records *model.QRecordBatch,
+	stream *model.QRecordStream,
 ) (int, error) {
-	if len(records.Records) == 0 {
-		return 0, nil
-	}
-	dstTableName := config.DestinationTableIdentifier
-	avroSchema, err := getAvroSchema(dstTableName, records.Schema)
+	schema, err := stream.Schema()
 	if err != nil {
 		return 0, err
 	}
-	err = c.writeToAvroFile(records, avroSchema, partition.PartitionId, config.FlowJobName)
-	if err != nil {
-		return 0, err
+	switch config.SyncMode {
+	case protos.QRepSyncMode_QREP_SYNC_MODE_STORAGE_PARQUET:
+		options := &parquet.WriterOptions{
+			Compression:    config.ParquetCompression,
+			RowGroupSizeMB: int(config.ParquetRowGroupSizeMb),
+		}
+		return c.writeToParquetFile(stream, options, partition.PartitionId, config.FlowJobName)
+	default:
+		dstTableName := config.DestinationTableIdentifier
+		avroSchema, err := getAvroSchema(dstTableName, schema)
+		if err != nil {
+			return 0, err
+		}
+		return c.writeToAvroFile(stream, avroSchema, partition.PartitionId, config.FlowJobName)
 	}
-	return len(records.Records), nil
 }
 
 func getAvroSchema(
@@ -55,23 +63,33 @@ func getAvroSchema(
 }
 
 func (c *S3Connector) writeToAvroFile(
-	records *model.QRecordBatch,
+	stream *model.QRecordStream,
 	avroSchema *model.QRecordAvroSchemaDefinition,
 	partitionID string,
 	jobName string,
-) error {
-	s3o, err := utils.NewS3BucketAndPrefix(c.url)
+) (int, error) {
+	key := path.Join(jobName, partitionID+".avro")
+	numRecords, err := avro.WriteRecordsToStage(c.ctx, stream, avroSchema, c.stage, key)
 	if err != nil {
-		return fmt.Errorf("failed to parse bucket path: %w", err)
+		return 0, fmt.Errorf("failed to write records to %s: %w", c.url, err)
 	}
 
-	s3Key := fmt.Sprintf("%s/%s/%s.avro", s3o.Prefix, jobName, partitionID)
-	err = avro.WriteRecordsToS3(records, avroSchema, s3o.Bucket, s3Key)
+	return numRecords, nil
+}
+
+func (c *S3Connector) writeToParquetFile(
+	stream *model.QRecordStream,
+	options *parquet.WriterOptions,
+	partitionID string,
+	jobName string,
+) (int, error) {
+	key := path.Join(jobName, partitionID+".parquet")
+	numRecords, err := parquet.WriteRecordsToStage(c.ctx, stream, options, c.stage, key)
 	if err != nil {
-		return fmt.Errorf("failed to write records to S3: %w", err)
+		return 0, fmt.Errorf("failed to write records to %s: %w", c.url, err)
 	}
 
-	return nil
+	return numRecords, nil
 }
 
 // S3 just sets up destination, not metadata tables
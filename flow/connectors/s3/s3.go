@@ -4,94 +4,121 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	stage "github.com/PeerDB-io/peer-flow/connectors/utils/stage"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/model"
-	"github.com/aws/aws-sdk-go/service/s3"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
 )
 
+// S3Connector writes the partitions of QRep flows and the batches of CDC mirrors as objects under
+// the url of the peer, in the stage of its scheme. CDC mirrors keep their sync state in a manifest
+// object, no metadata database is needed.
 type S3Connector struct {
-	url    string
-	client s3.S3
+	ctx   context.Context
+	url   string
+	stage stage.Stage
+	// schemas of the destination tables of a CDC mirror.
+	tableSchemas map[string]*protos.TableSchema
 }
 
 func NewS3Connector(ctx context.Context,
 	s3ProtoConfig *protos.S3Config) (*S3Connector, error) {
-	s3Client, err := utils.CreateS3Client()
+	stg, err := stage.NewStage(ctx, s3ProtoConfig.Url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+		return nil, fmt.Errorf("failed to create stage: %w", err)
 	}
 	return &S3Connector{
-		url:    s3ProtoConfig.Url,
-		client: *s3Client,
+		ctx:          ctx,
+		url:          s3ProtoConfig.Url,
+		stage:        stg,
+		tableSchemas: make(map[string]*protos.TableSchema),
 	}, nil
 }
 
 func (c *S3Connector) Close() error {
-	log.Errorf("Close not supported for S3")
-	return nil
+	return c.stage.Close()
 }
 
+// ConnectionActive lists the objects of a prefix there are none under, to check the stage is reachable.
 func (c *S3Connector) ConnectionActive() bool {
-	_, err := c.client.ListBuckets(nil)
+	_, err := c.stage.List(c.ctx, manifestFileName)
 	return err == nil
 }
 
+// NeedsSetupMetadataTables returns false, the sync state of CDC mirrors is kept in their manifest.
 func (c *S3Connector) NeedsSetupMetadataTables() bool {
-	log.Errorf("NeedsSetupMetadataTables not supported for S3")
 	return false
 }
 
 func (c *S3Connector) SetupMetadataTables() error {
-	log.Errorf("SetupMetadataTables not supported for S3")
-	return fmt.Errorf("cdc based replication is not currently supported for S3 target")
+	return nil
 }
 
+// GetLastOffset returns the checkpoint of the last batch in the manifest of the mirror.
 func (c *S3Connector) GetLastOffset(jobName string) (*protos.LastSyncState, error) {
-	log.Errorf("GetLastOffset not supported for S3")
-	return nil, fmt.Errorf("cdc based replication is not currently supported for S3 target")
+	manifest, err := c.readManifest(jobName)
+	if err != nil {
+		return nil, err
+	}
+	return &protos.LastSyncState{
+		Checkpoint: manifest.LastCheckpointID,
+	}, nil
 }
 
 func (c *S3Connector) GetLastNormalizeBatchID() (int64, error) {
 	log.Errorf("GetLastNormalizeBatchID not supported for S3")
-	return 0, fmt.Errorf("cdc based replication is not currently supported for S3 target")
+	return 0, fmt.Errorf("normalization is not supported for the S3 flow connector")
 }
 
 func (c *S3Connector) GetTableSchema(req *protos.GetTableSchemaInput) (*protos.TableSchema, error) {
 	log.Errorf("GetTableSchema not supported for S3 flow connector")
-	return nil, fmt.Errorf("cdc based replication is not currently supported for S3 target")
+	return nil, fmt.Errorf("GetTableSchema is not supported for the S3 flow connector")
 }
 
+// SetupNormalizedTable is a no-op, the objects of a table are written with the schema of the batch.
 func (c *S3Connector) SetupNormalizedTable(
 	req *protos.SetupNormalizedTableInput) (*protos.SetupNormalizedTableOutput, error) {
-	log.Errorf("SetupNormalizedTable not supported for S3")
-	return nil, fmt.Errorf("cdc based replication is not currently supported for S3 target")
+	log.Infof("normalization for S3 is a no-op")
+	return &protos.SetupNormalizedTableOutput{
+		TableIdentifier: req.TableIdentifier,
+	}, nil
 }
 
+// InitializeTableSchema sets the schemas the objects of the destination tables are written with.
 func (c *S3Connector) InitializeTableSchema(req map[string]*protos.TableSchema) error {
-	log.Errorf("InitializeTableSchema not supported for S3")
-	return fmt.Errorf("cdc based replication is not currently supported for S3 target")
+	for dstTableName, schema := range req {
+		// schema changes are applied to the copy, the request is left as it is.
+		c.tableSchemas[dstTableName] = proto.Clone(schema).(*protos.TableSchema)
+	}
+	return nil
 }
 
-func (c *S3Connector) PullRecords(req *model.PullRecordsRequest) (*model.RecordBatch, error) {
+func (c *S3Connector) PullRecords(req *model.PullRecordsRequest) error {
 	log.Errorf("panicking at call to PullRecords for S3 flow connector")
 	panic("PullRecords is not implemented for the S3 flow connector")
 }
 
-func (c *S3Connector) SyncRecords(req *model.SyncRecordsRequest) (*model.SyncResponse, error) {
-	log.Errorf("SyncRecords not supported for S3")
-	return nil, fmt.Errorf("cdc based replication is not currently supported for S3 target")
-}
-
+// NormalizeRecords is a no-op, there are no normalized tables. No response is returned, so the
+// batches synced before it ran count as normalized.
 func (c *S3Connector) NormalizeRecords(req *model.NormalizeRecordsRequest) (*model.NormalizeResponse, error) {
-	log.Errorf("NormalizeRecords not supported for S3")
-	return nil, fmt.Errorf("cdc based replication is not currently supported for S3 target")
+	log.Infof("normalization for S3 is a no-op")
+	return nil, nil
 }
 
+// CreateRawTable is a no-op, there are no raw tables on S3.
 func (c *S3Connector) CreateRawTable(req *protos.CreateRawTableInput) (*protos.CreateRawTableOutput, error) {
-	log.Errorf("CreateRawTable not supported for S3")
-	return nil, fmt.Errorf("cdc based replication is not currently supported for S3 target")
+	return &protos.CreateRawTableOutput{}, nil
+}
+
+// ReplayTableSchemaDelta adds the new columns to the schema the objects of the table are written with.
+func (c *S3Connector) ReplayTableSchemaDelta(flowJobName string, schemaDelta *protos.TableSchemaDelta) error {
+	schema, ok := c.tableSchemas[schemaDelta.DstTableName]
+	if !ok {
+		return nil
+	}
+	model.ApplyTableSchemaDelta(schema, schemaDelta)
+	return nil
 }
 
 func (c *S3Connector) EnsurePullability(req *protos.EnsurePullabilityInput,
@@ -110,7 +137,17 @@ func (c *S3Connector) PullFlowCleanup(jobName string) error {
 	panic("PullFlowCleanup is not implemented for the S3 flow connector")
 }
 
+// TruncateTables leaves the objects written in place, objects of an initial copy that is started over
+// are written again.
+func (c *S3Connector) TruncateTables(tableIdentifiers []string) error {
+	log.Warnf("objects already written for tables %v are kept", tableIdentifiers)
+	return nil
+}
+
+// SyncFlowCleanup removes the manifest of the mirror, the objects written are left in place.
 func (c *S3Connector) SyncFlowCleanup(jobName string) error {
-	log.Errorf("SyncFlowCleanup not supported for S3")
-	return fmt.Errorf("cdc based replication is not currently supported for S3 target")
+	if err := c.stage.Delete(c.ctx, manifestKey(jobName)); err != nil {
+		return fmt.Errorf("failed to delete manifest of flow job %s: %w", jobName, err)
+	}
+	return nil
 }
@@ -131,8 +131,9 @@ func TestWriteRecordsToAvroFileHappyPath(t *testing.T) {
 	fmt.Printf("[test] avroSchema: %v\n", avroSchema)
 
 	// Call function
-	err = avro.WriteRecordsToAvroFile(records, avroSchema, tmpfile.Name())
+	numRecords, err := avro.WriteRecordsToAvroFile(records.ToQRecordStream(1), avroSchema, tmpfile.Name())
 	require.NoError(t, err, "expected WriteRecordsToAvroFile to complete without errors")
+	require.Equal(t, int(records.NumRecords), numRecords)
 
 	// Check file is not empty
 	info, err := tmpfile.Stat()
@@ -156,8 +157,9 @@ func TestWriteRecordsToAvroFileNonNull(t *testing.T) {
 	fmt.Printf("[test] avroSchema: %v\n", avroSchema)
 
 	// Call function
-	err = avro.WriteRecordsToAvroFile(records, avroSchema, tmpfile.Name())
+	numRecords, err := avro.WriteRecordsToAvroFile(records.ToQRecordStream(1), avroSchema, tmpfile.Name())
 	require.NoError(t, err, "expected WriteRecordsToAvroFile to complete without errors")
+	require.Equal(t, int(records.NumRecords), numRecords)
 
 	// Check file is not empty
 	info, err := tmpfile.Stat()
@@ -188,8 +190,9 @@ func TestWriteRecordsToAvroFileAllNulls(t *testing.T) {
 	}
 
 	// Call function
-	err = avro.WriteRecordsToAvroFile(records, avroSchema, tmpfile.Name())
+	numRecords, err := avro.WriteRecordsToAvroFile(records.ToQRecordStream(1), avroSchema, tmpfile.Name())
 	require.NoError(t, err, "expected WriteRecordsToAvroFile to complete without errors")
+	require.Equal(t, int(records.NumRecords), numRecords)
 
 	// Check file is not empty
 	info, err := tmpfile.Stat()
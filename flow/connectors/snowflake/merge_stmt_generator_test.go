@@ -4,6 +4,9 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
 )
 
 func TestGenerateUpdateStatement_WithUnchangedToastCols(t *testing.T) {
@@ -56,9 +59,63 @@ func TestGenerateUpdateStatement_EmptyColumns(t *testing.T) {
 	}
 }
 
+func TestGenerateMergeStatement_FullRowNetsChanges(t *testing.T) {
+	c := &SnowflakeConnector{
+		tableSchemaMapping: map[string]*protos.TableSchema{
+			"PUBLIC.AUDIT_LOG": {
+				TableIdentifier: "PUBLIC.AUDIT_LOG",
+				Columns:         map[string]string{"msg": "string"},
+				ReplicaIdentity: protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL,
+			},
+		},
+	}
+
+	mergeStatement, mergeArgs := c.generateMergeStatement("PUBLIC.AUDIT_LOG", nil, "_PEERDB_RAW_TEST", 3, 1,
+		model.RawRecordPosition{BatchID: 2, Timestamp: 42})
+	result := removeSpacesTabsNewlines(mergeStatement)
+	// an insert followed by an update of the same row, or a chain of updates, adds and removes the
+	// intermediate row images, so only the last one is inserted and only the first one deleted.
+	for _, expected := range []string{
+		"SELECT _PEERDB_DATA AS _PEERDB_IMAGE,1 AS _PEERDB_DELTA",
+		"SELECT _PEERDB_MATCH_DATA,-1,_PEERDB_TIMESTAMP",
+		"SUM(_PEERDB_DELTA) OVER (PARTITION BY _PEERDB_IMAGE) AS _PEERDB_NET",
+		"WHERE (_PEERDB_DELTA = 1 AND _PEERDB_RANK <= _PEERDB_NET)",
+		"OR (_PEERDB_DELTA = -1 AND _PEERDB_RANK = 1 AND _PEERDB_NET < 0)",
+		"SOURCE ON SOURCE._PEERDB_DELTA = -1 AND EQUAL_NULL(TARGET.msg, SOURCE.msg)",
+		// records up to the last truncate are skipped, by batch and then by timestamp within the batch.
+		"AND (_PEERDB_BATCH_ID > 2 OR (_PEERDB_BATCH_ID = 2 AND _PEERDB_TIMESTAMP > 42))",
+		// a row image removed n times out of m equal rows deletes them all and inserts m-n of them again.
+		"GREATEST(COUNT(*) - ANY_VALUE(SOURCE._PEERDB_COUNT),0) AS _PEERDB_KEPT",
+		"SELECT 1,SOURCE.msg FROM FLATTENED SOURCE JOIN KEPT ON SOURCE._PEERDB_IMAGE = KEPT._PEERDB_IMAGE",
+		"TABLE(FLATTEN(INPUT => ARRAY_GENERATE_RANGE(0,KEPT._PEERDB_KEPT)))",
+	} {
+		if !strings.Contains(result, removeSpacesTabsNewlines(expected)) {
+			t.Errorf("Expected merge statement to contain %v, but got: %v", expected, mergeStatement)
+		}
+	}
+	if strings.Contains(mergeStatement, "UPDATE SET") {
+		t.Errorf("Expected updates to be applied as a delete and an insert, but got: %v", mergeStatement)
+	}
+	if len(mergeArgs) != strings.Count(mergeStatement, "?") {
+		t.Errorf("Expected an argument per placeholder, but got: %v", mergeArgs)
+	}
+}
+
 func removeSpacesTabsNewlines(s string) string {
 	s = strings.ReplaceAll(s, " ", "")
 	s = strings.ReplaceAll(s, "\t", "")
 	s = strings.ReplaceAll(s, "\n", "")
 	return s
 }
+
+func TestGeneratePkeySQL_CompositeKey(t *testing.T) {
+	pkeySelectSQL, pkeyColStr := generatePkeySQL([]string{"tenant_id", "id"})
+
+	if pkeySelectSQL != "tenant_id,id" {
+		t.Errorf("Unexpected partition list. Expected: %v, but got: %v", "tenant_id,id", pkeySelectSQL)
+	}
+	expected := "TARGET.tenant_id = SOURCE.tenant_id AND TARGET.id = SOURCE.id"
+	if pkeyColStr != expected {
+		t.Errorf("Unexpected join condition. Expected: %v, but got: %v", expected, pkeyColStr)
+	}
+}
@@ -1,17 +1,21 @@
 package connsnowflake
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
+	"net/url"
+	"path"
 	"strings"
+	"text/template"
 	"time"
 
+	peersql "github.com/PeerDB-io/peer-flow/connectors/sql"
 	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	stage "github.com/PeerDB-io/peer-flow/connectors/utils/stage"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/model"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -24,16 +28,70 @@ func (c *SnowflakeConnector) GetQRepPartitions(config *protos.QRepConfig,
 	panic("not implemented")
 }
 
+// PullQRepRecords reads the rows of a partition of a query, it's used to read back destination
+// tables when validating a mirror.
 func (c *SnowflakeConnector) PullQRepRecords(config *protos.QRepConfig,
 	partition *protos.QRepPartition,
-) (*model.QRecordBatch, error) {
-	panic("not implemented")
+	stream *model.QRecordStream,
+) (int, error) {
+	var rangeStart interface{}
+	var rangeEnd interface{}
+
+	// Depending on the type of the range, convert the range into the correct type
+	switch x := partition.Range.Range.(type) {
+	case *protos.PartitionRange_IntRange:
+		rangeStart = x.IntRange.Start
+		rangeEnd = x.IntRange.End
+	case *protos.PartitionRange_TimestampRange:
+		rangeStart = x.TimestampRange.Start.AsTime()
+		rangeEnd = x.TimestampRange.End.AsTime()
+	default:
+		return 0, fmt.Errorf("unknown range type: %v", x)
+	}
+
+	query, err := BuildQuery(config.Query)
+	if err != nil {
+		return 0, err
+	}
+
+	rangeParams := map[string]interface{}{
+		"startRange": rangeStart,
+		"endRange":   rangeEnd,
+	}
+
+	// the executor shares the connection of the connector, it's closed with the connector.
+	executor := peersql.NewGenericSQLQueryExecutor(c.ctx, sqlx.NewDb(c.database, "snowflake"),
+		snowflakeTypeToQValueKindMap, qValueKindToSnowflakeTypeMap)
+	return executor.NamedExecuteAndProcessQueryStream(stream, query, rangeParams)
+}
+
+func BuildQuery(query string) (string, error) {
+	tmpl, err := template.New("query").Parse(query)
+	if err != nil {
+		return "", err
+	}
+
+	data := map[string]interface{}{
+		"start": ":startRange",
+		"end":   ":endRange",
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = tmpl.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+	res := buf.String()
+
+	log.Infof("templated query: %s", res)
+	return res, nil
 }
 
 func (c *SnowflakeConnector) SyncQRepRecords(
 	config *protos.QRepConfig,
 	partition *protos.QRepPartition,
-	records *model.QRecordBatch,
+	stream *model.QRecordStream,
 ) (int, error) {
 	// Ensure the destination table is available.
 	destTable := config.DestinationTableIdentifier
@@ -59,7 +117,7 @@ func (c *SnowflakeConnector) SyncQRepRecords(
 		return 0, fmt.Errorf("multi-insert sync mode not supported for snowflake")
 	case protos.QRepSyncMode_QREP_SYNC_MODE_STORAGE_AVRO:
 		avroSync := NewSnowflakeAvroSyncMethod(config, c)
-		return avroSync.SyncQRepRecords(config, partition, tblSchema, records)
+		return avroSync.SyncQRepRecords(config, partition, tblSchema, stream)
 	default:
 		return 0, fmt.Errorf("unsupported sync mode: %s", syncMode)
 	}
@@ -170,7 +228,7 @@ func (c *SnowflakeConnector) createQRepMetadataTable() error {
 
 func (c *SnowflakeConnector) createStage(stageName string, config *protos.QRepConfig) error {
 	var createStageStmt string
-	if strings.HasPrefix(config.StagingPath, "s3://") {
+	if isExternalStagingPath(config.StagingPath) {
 		stmt, err := c.createExternalStage(stageName, config)
 		if err != nil {
 			return err
@@ -195,40 +253,69 @@ func (c *SnowflakeConnector) createStage(stageName string, config *protos.QRepCo
 	return nil
 }
 
-func (c *SnowflakeConnector) createExternalStage(stageName string, config *protos.QRepConfig) (string, error) {
-	awsCreds, err := utils.GetAWSSecrets()
-	if err != nil {
-		log.Errorf("failed to get AWS secrets: %v", err)
-		return "", fmt.Errorf("failed to get AWS secrets: %w", err)
-	}
+// isExternalStagingPath returns true if the files of a staging path are read by an external stage,
+// local files are put to an internal stage.
+func isExternalStagingPath(stagingPath string) bool {
+	return stagingPath != "" && !strings.HasPrefix(stagingPath, "file://")
+}
 
-	s3o, err := utils.NewS3BucketAndPrefix(config.StagingPath)
+// externalStageURL returns the URL of the external stage of a job, in the form Snowflake expects
+// for the storage of the staging path.
+func externalStageURL(stagingPath string, job string) (string, error) {
+	u, err := url.Parse(stagingPath)
 	if err != nil {
-		log.Errorf("failed to extract S3 bucket and prefix: %v", err)
-		return "", fmt.Errorf("failed to extract S3 bucket and prefix: %w", err)
+		return "", fmt.Errorf("failed to parse staging path %s: %w", stagingPath, err)
 	}
 
-	cleanURL := fmt.Sprintf("s3://%s/%s/%s", s3o.Bucket, s3o.Prefix, config.FlowJobName)
+	scheme := u.Scheme
+	switch scheme {
+	case "s3", "azure":
+	case "gs", "gcs":
+		scheme = "gcs"
+	default:
+		return "", fmt.Errorf("unsupported staging path %s for an external stage", stagingPath)
+	}
+	return fmt.Sprintf("%s://%s/%s", scheme, u.Host, path.Join(strings.Trim(u.Path, "/"), job)), nil
+}
 
-	s3Int := config.DestinationPeer.GetSnowflakeConfig().S3Integration
-	if s3Int == "" {
-		credsStr := fmt.Sprintf("CREDENTIALS=(AWS_KEY_ID='%s' AWS_SECRET_KEY='%s')",
-			awsCreds.AccessKeyID, awsCreds.SecretAccessKey)
+func (c *SnowflakeConnector) createExternalStage(stageName string, config *protos.QRepConfig) (string, error) {
+	cleanURL, err := externalStageURL(config.StagingPath, config.FlowJobName)
+	if err != nil {
+		return "", err
+	}
 
+	// the S3 integration of peers set up before storage_integration only applies to S3 stages.
+	sfConfig := config.DestinationPeer.GetSnowflakeConfig()
+	storageInt := sfConfig.GetStorageIntegration()
+	if storageInt == "" && strings.HasPrefix(cleanURL, "s3://") {
+		storageInt = sfConfig.GetS3Integration()
+	}
+	if storageInt != "" {
 		stageStatement := `
 		CREATE OR REPLACE STAGE %s
 		URL = '%s'
-		%s
+		STORAGE_INTEGRATION = %s
 		FILE_FORMAT = (TYPE = AVRO);`
-		return fmt.Sprintf(stageStatement, stageName, cleanURL, credsStr), nil
-	} else {
-		stageStatement := `
+		return fmt.Sprintf(stageStatement, stageName, cleanURL, storageInt), nil
+	}
+
+	if !strings.HasPrefix(cleanURL, "s3://") {
+		return "", fmt.Errorf("the storage_integration of the peer is needed for staging path %s", config.StagingPath)
+	}
+	awsCreds, err := utils.GetAWSSecrets()
+	if err != nil {
+		log.Errorf("failed to get AWS secrets: %v", err)
+		return "", fmt.Errorf("failed to get AWS secrets: %w", err)
+	}
+	credsStr := fmt.Sprintf("CREDENTIALS=(AWS_KEY_ID='%s' AWS_SECRET_KEY='%s')",
+		awsCreds.AccessKeyID, awsCreds.SecretAccessKey)
+
+	stageStatement := `
 		CREATE OR REPLACE STAGE %s
 		URL = '%s'
-		STORAGE_INTEGRATION = %s
+		%s
 		FILE_FORMAT = (TYPE = AVRO);`
-		return fmt.Sprintf(stageStatement, stageName, cleanURL, s3Int), nil
-	}
+	return fmt.Sprintf(stageStatement, stageName, cleanURL, credsStr), nil
 }
 
 func (c *SnowflakeConnector) ConsolidateQRepPartitions(config *protos.QRepConfig) error {
@@ -312,37 +399,24 @@ func (c *SnowflakeConnector) dropStage(stagingPath string, job string) error {
 		return fmt.Errorf("failed to drop stage %s: %w", stageName, err)
 	}
 
-	// if s3 we need to delete the contents of the bucket
-	if strings.HasPrefix(stagingPath, "s3://") {
-		s3o, err := utils.NewS3BucketAndPrefix(stagingPath)
+	// the files of external stages are in the staging path, they're removed with the stage.
+	if isExternalStagingPath(stagingPath) {
+		stg, err := stage.NewStage(c.ctx, stagingPath)
 		if err != nil {
-			log.Errorf("failed to create S3 bucket and prefix: %v", err)
-			return fmt.Errorf("failed to create S3 bucket and prefix: %w", err)
+			return fmt.Errorf("failed to open staging path: %w", err)
 		}
+		defer stg.Close()
 
-		log.Infof("Deleting contents of bucket %s with prefix %s/%s", s3o.Bucket, s3o.Prefix, job)
-
-		// deleting the contents of the bucket with prefix
-		s3svc, err := utils.CreateS3Client()
+		keys, err := stg.List(c.ctx, job+"/")
 		if err != nil {
-			log.Errorf("failed to create S3 client: %v", err)
-			return fmt.Errorf("failed to create S3 client: %w", err)
+			return err
 		}
-
-		// Create a list of all objects with the defined prefix in the bucket
-		iter := s3manager.NewDeleteListIterator(s3svc, &s3.ListObjectsInput{
-			Bucket: aws.String(s3o.Bucket),
-			Prefix: aws.String(fmt.Sprintf("%s/%s", s3o.Prefix, job)),
-		})
-
-		// Iterate through the objects in the bucket with the prefix and delete them
-		s3Client := s3manager.NewBatchDeleteWithClient(s3svc)
-		if err := s3Client.Delete(aws.BackgroundContext(), iter); err != nil {
-			log.Errorf("failed to delete objects from bucket: %v", err)
-			return fmt.Errorf("failed to delete objects from bucket: %w", err)
+		for _, key := range keys {
+			if err := stg.Delete(c.ctx, key); err != nil {
+				return err
+			}
 		}
-
-		log.Infof("Deleted contents of bucket %s with prefix %s/%s", s3o.Bucket, s3o.Prefix, job)
+		log.Infof("Deleted %d files of flow job %s from %s", len(keys), job, stg.URL())
 	}
 
 	log.Infof("Dropped stage %s", stageName)
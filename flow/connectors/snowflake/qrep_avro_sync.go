@@ -4,11 +4,13 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"path"
 	"strings"
 	"time"
 
 	"github.com/PeerDB-io/peer-flow/connectors/utils"
 	avro "github.com/PeerDB-io/peer-flow/connectors/utils/avro"
+	stage "github.com/PeerDB-io/peer-flow/connectors/utils/stage"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/model"
 	util "github.com/PeerDB-io/peer-flow/utils"
@@ -34,16 +36,20 @@ func (s *SnowflakeAvroSyncMethod) SyncQRepRecords(
 	config *protos.QRepConfig,
 	partition *protos.QRepPartition,
 	dstTableSchema []*sql.ColumnType,
-	records *model.QRecordBatch,
+	stream *model.QRecordStream,
 ) (int, error) {
 	startTime := time.Now()
 	dstTableName := config.DestinationTableIdentifier
-	avroSchema, err := s.getAvroSchema(dstTableName, records.Schema)
+	schema, err := stream.Schema()
+	if err != nil {
+		return 0, err
+	}
+	avroSchema, err := s.getAvroSchema(dstTableName, schema)
 	if err != nil {
 		return 0, err
 	}
 
-	localFilePath, err := s.writeToAvroFile(records, avroSchema, partition.PartitionId)
+	numRecords, localFilePath, err := s.writeToAvroFile(stream, avroSchema, partition.PartitionId)
 	if err != nil {
 		return 0, err
 	}
@@ -60,7 +66,7 @@ func (s *SnowflakeAvroSyncMethod) SyncQRepRecords(
 		return -1, err
 	}
 
-	return len(records.Records), nil
+	return numRecords, nil
 }
 
 func (s *SnowflakeAvroSyncMethod) getAvroSchema(
@@ -76,40 +82,39 @@ func (s *SnowflakeAvroSyncMethod) getAvroSchema(
 	return avroSchema, nil
 }
 
+// writeToAvroFile writes the records of a stream to an Avro file in the staging path while they're
+// pulled, in a local temporary directory without one. It returns the number of records written and
+// the path of the file if it's local, the file then still has to be put to the internal stage.
 func (s *SnowflakeAvroSyncMethod) writeToAvroFile(
-	records *model.QRecordBatch,
+	stream *model.QRecordStream,
 	avroSchema *model.QRecordAvroSchemaDefinition,
 	partitionID string,
-) (string, error) {
-	if s.config.StagingPath == "" {
+) (int, string, error) {
+	stagingPath := s.config.StagingPath
+	if stagingPath == "" {
 		tmpDir, err := os.MkdirTemp("", "peerdb-avro")
 		if err != nil {
-			return "", fmt.Errorf("failed to create temp dir: %w", err)
-		}
-
-		localFilePath := fmt.Sprintf("%s/%s.avro", tmpDir, partitionID)
-		err = avro.WriteRecordsToAvroFile(records, avroSchema, localFilePath)
-		if err != nil {
-			return "", fmt.Errorf("failed to write records to Avro file: %w", err)
-		}
-
-		return localFilePath, nil
-	} else if strings.HasPrefix(s.config.StagingPath, "s3://") {
-		s3o, err := utils.NewS3BucketAndPrefix(s.config.StagingPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to parse staging path: %w", err)
+			return 0, "", fmt.Errorf("failed to create temp dir: %w", err)
 		}
+		stagingPath = "file://" + tmpDir
+	}
 
-		s3Key := fmt.Sprintf("%s/%s/%s.avro", s3o.Prefix, s.config.FlowJobName, partitionID)
-		err = avro.WriteRecordsToS3(records, avroSchema, s3o.Bucket, s3Key)
-		if err != nil {
-			return "", fmt.Errorf("failed to write records to S3: %w", err)
-		}
+	stg, err := stage.NewStage(s.connector.ctx, stagingPath)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to open staging path: %w", err)
+	}
+	defer stg.Close()
 
-		return "", nil
+	key := path.Join(s.config.FlowJobName, partitionID+".avro")
+	numRecords, err := avro.WriteRecordsToStage(s.connector.ctx, stream, avroSchema, stg, key)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to write records to Avro file: %w", err)
 	}
 
-	return "", fmt.Errorf("unsupported staging path: %s", s.config.StagingPath)
+	if localStage, ok := stg.(*stage.LocalStage); ok {
+		return numRecords, localStage.Path(key), nil
+	}
+	return numRecords, "", nil
 }
 
 func (s *SnowflakeAvroSyncMethod) putFileToStage(localFilePath string, stage string) error {
@@ -124,6 +129,10 @@ func (s *SnowflakeAvroSyncMethod) putFileToStage(localFilePath string, stage str
 	}
 
 	log.Infof("put file %s to stage %s", localFilePath, stage)
+	// the file is copied to the stage, the local one isn't needed anymore.
+	if err := os.Remove(localFilePath); err != nil {
+		log.Warnf("failed to remove local file %s: %v", localFilePath, err)
+	}
 	return nil
 }
 
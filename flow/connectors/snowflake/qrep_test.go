@@ -0,0 +1,71 @@
+package connsnowflake
+
+import (
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalStageURL(t *testing.T) {
+	tests := []struct {
+		stagingPath string
+		want        string
+	}{
+		{"s3://bucket/staging/", "s3://bucket/staging/job"},
+		{"s3://bucket", "s3://bucket/job"},
+		{"gs://bucket/staging", "gcs://bucket/staging/job"},
+		{"azure://account.blob.core.windows.net/container/staging",
+			"azure://account.blob.core.windows.net/container/staging/job"},
+	}
+	for _, tt := range tests {
+		got, err := externalStageURL(tt.stagingPath, "job")
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := externalStageURL("file:///tmp/staging", "job")
+	assert.Error(t, err)
+
+	assert.False(t, isExternalStagingPath(""))
+	assert.False(t, isExternalStagingPath("file:///tmp/staging"))
+	assert.True(t, isExternalStagingPath("gs://bucket"))
+}
+
+func TestCreateExternalStageIntegration(t *testing.T) {
+	c := &SnowflakeConnector{}
+	newConfig := func(stagingPath string, sfConfig *protos.SnowflakeConfig) *protos.QRepConfig {
+		return &protos.QRepConfig{
+			FlowJobName: "job",
+			StagingPath: stagingPath,
+			DestinationPeer: &protos.Peer{
+				Type:   protos.DBType_SNOWFLAKE,
+				Config: &protos.Peer_SnowflakeConfig{SnowflakeConfig: sfConfig},
+			},
+		}
+	}
+
+	stmt, err := c.createExternalStage("stage", newConfig("gs://bucket/staging",
+		&protos.SnowflakeConfig{StorageIntegration: "gcs_int", S3Integration: "s3_int"}))
+	require.NoError(t, err)
+	assert.Contains(t, stmt, "URL = 'gcs://bucket/staging/job'")
+	assert.Contains(t, stmt, "STORAGE_INTEGRATION = gcs_int")
+
+	// peers set up with only an S3 integration keep using it for S3 stages.
+	stmt, err = c.createExternalStage("stage", newConfig("s3://bucket/staging",
+		&protos.SnowflakeConfig{S3Integration: "s3_int"}))
+	require.NoError(t, err)
+	assert.Contains(t, stmt, "STORAGE_INTEGRATION = s3_int")
+
+	// but not for other storages.
+	_, err = c.createExternalStage("stage", newConfig("azure://account.blob.core.windows.net/container",
+		&protos.SnowflakeConfig{S3Integration: "s3_int"}))
+	assert.ErrorContains(t, err, "the storage_integration of the peer is needed")
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	// normalized tables are created with unquoted, so upper case, column names.
+	assert.Equal(t, `"UPDATED_AT"`, QuoteIdentifier("updated_at"))
+	assert.Equal(t, `"A""B"`, QuoteIdentifier(`a"b`))
+}
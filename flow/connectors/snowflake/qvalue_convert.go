@@ -27,6 +27,21 @@ var qValueKindToSnowflakeTypeMap = map[qvalue.QValueKind]string{
 	qvalue.QValueKindUUID:        "STRING",
 	qvalue.QValueKindTimeTZ:      "STRING",
 	qvalue.QValueKindInvalid:     "STRING",
+	qvalue.QValueKindInterval:    "STRING",
+	qvalue.QValueKindINET:        "STRING",
+	qvalue.QValueKindCIDR:        "STRING",
+	qvalue.QValueKindMacaddr:     "STRING",
+	qvalue.QValueKindEnum:        "STRING",
+	qvalue.QValueKindHStore:      "VARIANT",
+	qvalue.QValueKindGeometry:    "GEOMETRY",
+	qvalue.QValueKindGeography:   "GEOGRAPHY",
+
+	qvalue.QValueKindArrayInt32:   "ARRAY",
+	qvalue.QValueKindArrayInt64:   "ARRAY",
+	qvalue.QValueKindArrayFloat32: "ARRAY",
+	qvalue.QValueKindArrayFloat64: "ARRAY",
+	qvalue.QValueKindArrayBoolean: "ARRAY",
+	qvalue.QValueKindArrayString:  "ARRAY",
 }
 
 var snowflakeTypeToQValueKindMap = map[string]qvalue.QValueKind{
@@ -52,6 +67,8 @@ var snowflakeTypeToQValueKindMap = map[string]qvalue.QValueKind{
 	"NUMBER":        qvalue.QValueKindNumeric,
 	"DECIMAL":       qvalue.QValueKindNumeric,
 	"NUMERIC":       qvalue.QValueKindNumeric,
+	"GEOMETRY":      qvalue.QValueKindGeometry,
+	"GEOGRAPHY":     qvalue.QValueKindGeography,
 }
 
 func qValueKindToSnowflakeType(colType qvalue.QValueKind) string {
@@ -33,15 +33,16 @@ const (
 		_PEERDB_TIMESTAMP INT NOT NULL,_PEERDB_DESTINATION_TABLE_NAME STRING NOT NULL,_PEERDB_DATA STRING NOT NULL,
 		_PEERDB_RECORD_TYPE INTEGER NOT NULL, _PEERDB_MATCH_DATA STRING,_PEERDB_BATCH_ID INT,
 		_PEERDB_UNCHANGED_TOAST_COLUMNS STRING)`
-	rawTableMultiValueInsertSQL = "INSERT INTO %s.%s VALUES%s"
-	createNormalizedTableSQL    = "CREATE TABLE IF NOT EXISTS %s(%s)"
-	toVariantColumnName         = "VAR_COLS"
-	mergeStatementSQL           = `MERGE INTO %s TARGET USING (WITH VARIANT_CONVERTED AS (SELECT _PEERDB_UID,
+	rawTableMultiValueInsertSQL   = "INSERT INTO %s.%s VALUES%s"
+	createNormalizedTableSQL      = "CREATE TABLE IF NOT EXISTS %s(%s)"
+	addColumnToNormalizedTableSQL = "ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s"
+	toVariantColumnName           = "VAR_COLS"
+	mergeStatementSQL             = `MERGE INTO %s TARGET USING (WITH VARIANT_CONVERTED AS (SELECT _PEERDB_UID,
 		_PEERDB_TIMESTAMP,
 		TO_VARIANT(PARSE_JSON(_PEERDB_DATA)) %s,_PEERDB_RECORD_TYPE,_PEERDB_MATCH_DATA,_PEERDB_BATCH_ID,
 		_PEERDB_UNCHANGED_TOAST_COLUMNS FROM
 		 _PEERDB_INTERNAL.%s WHERE _PEERDB_BATCH_ID > %d AND _PEERDB_BATCH_ID <= %d AND
-		 _PEERDB_DESTINATION_TABLE_NAME = ? ), FLATTENED AS
+		 _PEERDB_DESTINATION_TABLE_NAME = ? AND %s), FLATTENED AS
 		 (SELECT _PEERDB_UID,_PEERDB_TIMESTAMP,_PEERDB_RECORD_TYPE,_PEERDB_MATCH_DATA,_PEERDB_BATCH_ID,
 			_PEERDB_UNCHANGED_TOAST_COLUMNS,%s
 		 FROM VARIANT_CONVERTED), DEDUPLICATED_FLATTENED AS (SELECT RANKED.* FROM
@@ -51,8 +52,50 @@ const (
 		 WHEN NOT MATCHED AND (SOURCE._PEERDB_RECORD_TYPE != 2) THEN INSERT (%s) VALUES(%s)
 		 %s
 		 WHEN MATCHED AND (SOURCE._PEERDB_RECORD_TYPE = 2) THEN DELETE`
+	// tables without key columns are multisets of rows: inserts add their row, deletes remove their old row
+	// and updates do both. Changes are netted per row image, so a row inserted and then updated, or updated
+	// several times, is inserted once in its last state whatever the order.
+	mergeStatementFullRowSQL = `MERGE INTO %[1]s TARGET USING (WITH CHANGES AS (
+		SELECT _PEERDB_DATA AS _PEERDB_IMAGE,1 AS _PEERDB_DELTA,_PEERDB_TIMESTAMP FROM _PEERDB_INTERNAL.%[3]s
+		 WHERE _PEERDB_BATCH_ID > %[4]d AND _PEERDB_BATCH_ID <= %[5]d AND
+		 _PEERDB_DESTINATION_TABLE_NAME = ? AND %[6]s AND _PEERDB_RECORD_TYPE IN (0,1)
+		UNION ALL
+		SELECT _PEERDB_MATCH_DATA,-1,_PEERDB_TIMESTAMP FROM _PEERDB_INTERNAL.%[3]s
+		 WHERE _PEERDB_BATCH_ID > %[4]d AND _PEERDB_BATCH_ID <= %[5]d AND
+		 _PEERDB_DESTINATION_TABLE_NAME = ? AND %[6]s AND _PEERDB_RECORD_TYPE IN (1,2)),
+		 NET_CHANGES AS (SELECT _PEERDB_IMAGE,_PEERDB_DELTA,
+		 SUM(_PEERDB_DELTA) OVER (PARTITION BY _PEERDB_IMAGE) AS _PEERDB_NET,
+		 ROW_NUMBER() OVER (PARTITION BY _PEERDB_IMAGE,_PEERDB_DELTA ORDER BY _PEERDB_TIMESTAMP) AS _PEERDB_RANK
+		 FROM CHANGES), VARIANT_CONVERTED AS (SELECT _PEERDB_IMAGE,_PEERDB_DELTA,-_PEERDB_NET AS _PEERDB_COUNT,
+		 TO_VARIANT(PARSE_JSON(_PEERDB_IMAGE)) %[2]s
+		 FROM NET_CHANGES WHERE (_PEERDB_DELTA = 1 AND _PEERDB_RANK <= _PEERDB_NET)
+		 OR (_PEERDB_DELTA = -1 AND _PEERDB_RANK = 1 AND _PEERDB_NET < 0)),
+		 FLATTENED AS (SELECT _PEERDB_IMAGE,_PEERDB_DELTA,_PEERDB_COUNT,%[7]s FROM VARIANT_CONVERTED),
+		 KEPT AS (SELECT SOURCE._PEERDB_IMAGE,GREATEST(COUNT(*) - ANY_VALUE(SOURCE._PEERDB_COUNT),0) AS _PEERDB_KEPT
+		 FROM FLATTENED SOURCE JOIN %[1]s TARGET ON SOURCE._PEERDB_DELTA = -1 AND %[8]s
+		 GROUP BY SOURCE._PEERDB_IMAGE)
+		 SELECT _PEERDB_DELTA,%[9]s FROM FLATTENED
+		 UNION ALL
+		 SELECT 1,%[10]s FROM FLATTENED SOURCE JOIN KEPT ON SOURCE._PEERDB_IMAGE = KEPT._PEERDB_IMAGE,
+		 TABLE(FLATTEN(INPUT => ARRAY_GENERATE_RANGE(0,KEPT._PEERDB_KEPT))))
+		 SOURCE ON SOURCE._PEERDB_DELTA = -1 AND %[8]s
+		 WHEN NOT MATCHED AND (SOURCE._PEERDB_DELTA = 1) THEN INSERT (%[9]s) VALUES(%[10]s)
+		 WHEN MATCHED THEN DELETE`
+	insertAppendOnlySQL = `INSERT INTO %s (%s) SELECT %s FROM (SELECT %s FROM
+		 (SELECT TO_VARIANT(PARSE_JSON(_PEERDB_DATA)) %s FROM _PEERDB_INTERNAL.%s
+		 WHERE _PEERDB_BATCH_ID > %d AND _PEERDB_BATCH_ID <= %d AND
+		 _PEERDB_DESTINATION_TABLE_NAME = ? AND %s AND _PEERDB_RECORD_TYPE = 0))`
 	getDistinctDestinationTableNames = `SELECT DISTINCT _PEERDB_DESTINATION_TABLE_NAME FROM %s.%s WHERE
 	 _PEERDB_BATCH_ID > %d AND _PEERDB_BATCH_ID <= %d`
+	// only the last truncate in a batch range matters, everything before it is discarded.
+	getTableNametoLastTruncateSQL = `SELECT _PEERDB_DESTINATION_TABLE_NAME,_PEERDB_BATCH_ID,_PEERDB_TIMESTAMP
+	 FROM %s.%s WHERE _PEERDB_BATCH_ID > %d AND _PEERDB_BATCH_ID <= %d AND _PEERDB_RECORD_TYPE = 3
+	 QUALIFY ROW_NUMBER() OVER (PARTITION BY _PEERDB_DESTINATION_TABLE_NAME
+	 ORDER BY _PEERDB_BATCH_ID DESC,_PEERDB_TIMESTAMP DESC) = 1`
+	// records of the raw table after a position, ordered by batch and then by timestamp within the batch.
+	rawRecordsAfterSQL = "(_PEERDB_BATCH_ID > %d OR (_PEERDB_BATCH_ID = %d AND _PEERDB_TIMESTAMP > %d))"
+	// DELETE instead of TRUNCATE, which would commit the normalize transaction.
+	deleteAllRowsSQL               = "DELETE FROM %s"
 	getTableNametoUnchangedColsSQL = `SELECT _PEERDB_DESTINATION_TABLE_NAME,
 	 ARRAY_AGG(DISTINCT _PEERDB_UNCHANGED_TOAST_COLUMNS) FROM %s.%s WHERE
 	 _PEERDB_BATCH_ID > %d AND _PEERDB_BATCH_ID <= %d GROUP BY _PEERDB_DESTINATION_TABLE_NAME`
@@ -69,6 +112,7 @@ const (
 	getLastSyncBatchID_SQL      = "SELECT SYNC_BATCH_ID FROM %s.%s WHERE MIRROR_JOB_NAME=?"
 	getLastNormalizeBatchID_SQL = "SELECT NORMALIZE_BATCH_ID FROM %s.%s WHERE MIRROR_JOB_NAME=?"
 	dropTableIfExistsSQL        = "DROP TABLE IF EXISTS %s.%s"
+	truncateTableSQL            = "TRUNCATE TABLE IF EXISTS %s"
 	deleteJobMetadataSQL        = "DELETE FROM %s.%s WHERE MIRROR_JOB_NAME=?"
 
 	syncRecordsChunkSize = 1024
@@ -174,6 +218,12 @@ func (c *SnowflakeConnector) ConnectionActive() bool {
 	return c.database.PingContext(c.ctx) == nil
 }
 
+// QuoteIdentifier quotes an identifier the way normalized tables are created with it, unquoted, so that
+// it's upper case.
+func QuoteIdentifier(identifier string) string {
+	return utils.QuoteIdentifier(strings.ToUpper(identifier))
+}
+
 func (c *SnowflakeConnector) NeedsSetupMetadataTables() bool {
 	result, err := c.checkIfTableExists(peerDBInternalSchema, mirrorJobsTableIdentifier)
 	if err != nil {
@@ -316,6 +366,35 @@ func (c *SnowflakeConnector) getTableNametoUnchangedCols(flowJobName string, syn
 	return resultMap, nil
 }
 
+// getTableNametoLastTruncate returns the position of the last truncate record for each destination table
+// truncated between the two batch IDs.
+func (c *SnowflakeConnector) getTableNametoLastTruncate(flowJobName string, syncBatchID int64,
+	normalizeBatchID int64) (map[string]model.RawRecordPosition, error) {
+	rawTableIdentifier := getRawTableIdentifier(flowJobName)
+
+	rows, err := c.database.QueryContext(c.ctx, fmt.Sprintf(getTableNametoLastTruncateSQL, peerDBInternalSchema,
+		rawTableIdentifier, normalizeBatchID, syncBatchID))
+	if err != nil {
+		return nil, fmt.Errorf("error while retrieving truncated tables for normalization: %w", err)
+	}
+	defer rows.Close()
+
+	resultMap := make(map[string]model.RawRecordPosition)
+	for rows.Next() {
+		var tableName string
+		var truncatePosition model.RawRecordPosition
+		err := rows.Scan(&tableName, &truncatePosition.BatchID, &truncatePosition.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan truncated table row: %w", err)
+		}
+		resultMap[tableName] = truncatePosition
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over truncated table rows: %w", err)
+	}
+	return resultMap, nil
+}
+
 func (c *SnowflakeConnector) GetTableSchema(req *protos.GetTableSchemaInput) (*protos.TableSchema, error) {
 	log.Errorf("panicking at call to GetTableSchema for Snowflake flow connector")
 	panic("GetTableSchema is not implemented for the Snowflake flow connector")
@@ -357,27 +436,59 @@ func (c *SnowflakeConnector) InitializeTableSchema(req map[string]*protos.TableS
 	return nil
 }
 
-func (c *SnowflakeConnector) PullRecords(req *model.PullRecordsRequest) (*model.RecordBatch, error) {
+// ReplayTableSchemaDelta adds the columns added on the source to the normalized table.
+func (c *SnowflakeConnector) ReplayTableSchemaDelta(flowJobName string,
+	schemaDelta *protos.TableSchemaDelta) error {
+	// DDL commits implicitly in Snowflake, so the columns are added one at a time.
+	for _, addedColumn := range schemaDelta.AddedColumns {
+		_, err := c.database.ExecContext(c.ctx, fmt.Sprintf(addColumnToNormalizedTableSQL,
+			schemaDelta.DstTableName, addedColumn.ColumnName,
+			qValueKindToSnowflakeType(qvalue.QValueKind(addedColumn.ColumnType))))
+		if err != nil {
+			return fmt.Errorf("failed to add column %s for table %s: %w", addedColumn.ColumnName,
+				schemaDelta.DstTableName, err)
+		}
+		log.Infof("[%s] added column %s of type %s to table %s", flowJobName, addedColumn.ColumnName,
+			addedColumn.ColumnType, schemaDelta.DstTableName)
+	}
+	return nil
+}
+
+func (c *SnowflakeConnector) PullRecords(req *model.PullRecordsRequest) error {
 	log.Errorf("panicking at call to PullRecords for Snowflake flow connector")
 	panic("PullRecords is not implemented for the Snowflake flow connector")
 }
 
 func (c *SnowflakeConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.SyncResponse, error) {
 	rawTableIdentifier := getRawTableIdentifier(req.FlowJobName)
-	log.Printf("pushing %d records to Snowflake table %s", len(req.Records.Records), rawTableIdentifier)
+	log.Printf("pushing records to Snowflake table %s", rawTableIdentifier)
 
 	syncBatchID, err := c.GetLastSyncBatchID(req.FlowJobName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get previous syncBatchID: %w", err)
 	}
 	syncBatchID = syncBatchID + 1
-	records := make([]snowflakeRawRecord, 0)
 
-	first := true
+	// transaction for SyncRecords
+	syncRecordsTx, err := c.database.BeginTx(c.ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	// in case we return after error, ensure transaction is rolled back
+	defer func() {
+		deferErr := syncRecordsTx.Rollback()
+		if deferErr != sql.ErrTxDone && deferErr != nil {
+			log.Errorf("unexpected error while rolling back transaction for SyncRecords: %v", deferErr)
+		}
+	}()
+
+	// inserting records into raw table in chunks as they're read from the stream.
+	records := make([]snowflakeRawRecord, 0, syncRecordsChunkSize)
+	numRecords := 0
 	var firstCP int64 = 0
-	lastCP := req.Records.LastCheckPointID
+	timestamps := model.NewRawRecordTimestamps()
 
-	for _, record := range req.Records.Records {
+	for record := range req.Records.Records() {
 		switch typedRecord := record.(type) {
 		case *model.InsertRecord:
 			// json.Marshal converts bytes in Hex automatically to BASE64 string.
@@ -389,7 +500,7 @@ func (c *SnowflakeConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.
 			// add insert record to the raw table
 			records = append(records, snowflakeRawRecord{
 				uid:                   uuid.New().String(),
-				timestamp:             time.Now().UnixNano(),
+				timestamp:             timestamps.Next(),
 				destinationTableName:  typedRecord.DestinationTableName,
 				data:                  itemsJSON,
 				recordType:            0,
@@ -410,7 +521,7 @@ func (c *SnowflakeConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.
 			// add update record to the raw table
 			records = append(records, snowflakeRawRecord{
 				uid:                   uuid.New().String(),
-				timestamp:             time.Now().UnixNano(),
+				timestamp:             timestamps.Next(),
 				destinationTableName:  typedRecord.DestinationTableName,
 				data:                  newItemsJSON,
 				recordType:            1,
@@ -427,7 +538,7 @@ func (c *SnowflakeConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.
 			// append delete record to the raw table
 			records = append(records, snowflakeRawRecord{
 				uid:                   uuid.New().String(),
-				timestamp:             time.Now().UnixNano(),
+				timestamp:             timestamps.Next(),
 				destinationTableName:  typedRecord.DestinationTableName,
 				data:                  itemsJSON,
 				recordType:            2,
@@ -435,52 +546,55 @@ func (c *SnowflakeConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.
 				batchID:               syncBatchID,
 				unchangedToastColumns: utils.KeysToString(typedRecord.UnchangedToastColumns),
 			})
+		case *model.TruncateRecord:
+			// append truncate record to the raw table, it carries no data
+			records = append(records, snowflakeRawRecord{
+				uid:                   uuid.New().String(),
+				timestamp:             timestamps.Next(),
+				destinationTableName:  typedRecord.DestinationTableName,
+				data:                  "{}",
+				recordType:            3,
+				matchData:             "",
+				batchID:               syncBatchID,
+				unchangedToastColumns: "",
+			})
 		default:
 			return nil, fmt.Errorf("record type %T not supported in Snowflake flow connector", typedRecord)
 		}
 
-		if first {
+		if numRecords == 0 {
 			firstCP = record.GetCheckPointID()
-			first = false
 		}
+		numRecords++
+
+		if len(records) == syncRecordsChunkSize {
+			err = c.insertRecordsInRawTable(rawTableIdentifier, records, syncRecordsTx)
+			if err != nil {
+				return nil, err
+			}
+			records = records[:0]
+		}
+	}
+	if err := req.Records.Err(); err != nil {
+		return nil, err
 	}
 
-	if len(records) == 0 {
+	if numRecords == 0 {
 		return &model.SyncResponse{
 			FirstSyncedCheckPointID: 0,
 			LastSyncedCheckPointID:  0,
 			NumRecordsSynced:        0,
 		}, nil
 	}
-
-	// transaction for SyncRecords
-	syncRecordsTx, err := c.database.BeginTx(c.ctx, nil)
-	if err != nil {
-		return nil, err
-	}
-	// in case we return after error, ensure transaction is rolled back
-	defer func() {
-		deferErr := syncRecordsTx.Rollback()
-		if deferErr != sql.ErrTxDone && deferErr != nil {
-			log.Errorf("unexpected error while rolling back transaction for SyncRecords: %v", deferErr)
-		}
-	}()
-
-	// inserting records into raw table.
-	numRecords := len(records)
-	for begin := 0; begin < numRecords; begin += syncRecordsChunkSize {
-		end := begin + syncRecordsChunkSize
-
-		if end > numRecords {
-			end = numRecords
-		}
-		err = c.insertRecordsInRawTable(rawTableIdentifier, records[begin:end], syncRecordsTx)
+	if len(records) > 0 {
+		err = c.insertRecordsInRawTable(rawTableIdentifier, records, syncRecordsTx)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	// updating metadata with new offset and syncBatchID
+	lastCP := req.Records.LastCheckPointID()
 	err = c.updateSyncMetadata(req.FlowJobName, lastCP, syncBatchID, syncRecordsTx)
 	if err != nil {
 		return nil, err
@@ -494,7 +608,8 @@ func (c *SnowflakeConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.
 	return &model.SyncResponse{
 		FirstSyncedCheckPointID: firstCP,
 		LastSyncedCheckPointID:  lastCP,
-		NumRecordsSynced:        int64(len(records)),
+		NumRecordsSynced:        int64(numRecords),
+		CurrentSyncBatchID:      syncBatchID,
 	}, nil
 }
 
@@ -536,6 +651,10 @@ func (c *SnowflakeConnector) NormalizeRecords(req *model.NormalizeRecordsRequest
 	if err != nil {
 		return nil, fmt.Errorf("couldn't tablename to unchanged cols mapping: %w", err)
 	}
+	tableNametoLastTruncate, err := c.getTableNametoLastTruncate(req.FlowJobName, syncBatchID, normalizeBatchID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get tablename to last truncate mapping: %w", err)
+	}
 
 	// transaction for NormalizeRecords
 	normalizeRecordsTx, err := c.database.BeginTx(c.ctx, nil)
@@ -551,10 +670,18 @@ func (c *SnowflakeConnector) NormalizeRecords(req *model.NormalizeRecordsRequest
 	}()
 	// execute merge statements per table that uses CTEs to merge data into the normalized table
 	for _, destinationTableName := range destinationTableNames {
+		// a truncate empties the table, only records after the last truncate need to be merged.
+		lastTruncate, truncated := tableNametoLastTruncate[destinationTableName]
+		if truncated {
+			_, err = normalizeRecordsTx.ExecContext(c.ctx, fmt.Sprintf(deleteAllRowsSQL, destinationTableName))
+			if err != nil {
+				return nil, fmt.Errorf("failed to truncate %s: %w", destinationTableName, err)
+			}
+		}
 		err = c.generateAndExecuteMergeStatement(destinationTableName,
 			tableNametoUnchangedToastCols[destinationTableName],
 			getRawTableIdentifier(req.FlowJobName),
-			syncBatchID, normalizeBatchID, normalizeRecordsTx)
+			syncBatchID, normalizeBatchID, lastTruncate, normalizeRecordsTx)
 		if err != nil {
 			return nil, err
 		}
@@ -622,6 +749,16 @@ func (c *SnowflakeConnector) PullFlowCleanup(jobName string) error {
 	panic("PullFlowCleanup is not implemented for the Snowflake flow connector")
 }
 
+// TruncateTables removes the rows of destination tables, one at a time as TRUNCATE commits implicitly.
+func (c *SnowflakeConnector) TruncateTables(tableIdentifiers []string) error {
+	for _, tableIdentifier := range tableIdentifiers {
+		if _, err := c.database.ExecContext(c.ctx, fmt.Sprintf(truncateTableSQL, tableIdentifier)); err != nil {
+			return fmt.Errorf("failed to truncate table %s: %w", tableIdentifier, err)
+		}
+	}
+	return nil
+}
+
 func (c *SnowflakeConnector) SyncFlowCleanup(jobName string) error {
 	syncFlowCleanupTx, err := c.database.BeginTx(c.ctx, nil)
 	if err != nil {
@@ -668,15 +805,14 @@ func (c *SnowflakeConnector) checkIfTableExists(schemaIdentifier string, tableId
 }
 
 func generateCreateTableSQLForNormalizedTable(sourceTableIdentifier string, sourceTableSchema *protos.TableSchema) string {
-	createTableSQLArray := make([]string, 0, len(sourceTableSchema.Columns))
+	createTableSQLArray := make([]string, 0, len(sourceTableSchema.Columns)+1)
 	for columnName, genericColumnType := range sourceTableSchema.Columns {
-		if sourceTableSchema.PrimaryKeyColumn == strings.ToLower(columnName) {
-			createTableSQLArray = append(createTableSQLArray, fmt.Sprintf("%s %s PRIMARY KEY,",
-				columnName, qValueKindToSnowflakeType(qvalue.QValueKind(genericColumnType))))
-		} else {
-			createTableSQLArray = append(createTableSQLArray, fmt.Sprintf("%s %s,", columnName,
-				qValueKindToSnowflakeType(qvalue.QValueKind(genericColumnType))))
-		}
+		createTableSQLArray = append(createTableSQLArray, fmt.Sprintf("%s %s,", columnName,
+			qValueKindToSnowflakeType(qvalue.QValueKind(genericColumnType))))
+	}
+	if len(sourceTableSchema.PrimaryKeyColumns) > 0 {
+		createTableSQLArray = append(createTableSQLArray, fmt.Sprintf("PRIMARY KEY(%s),",
+			strings.Join(sourceTableSchema.PrimaryKeyColumns, ",")))
 	}
 	return fmt.Sprintf(createNormalizedTableSQL, sourceTableIdentifier,
 		strings.TrimSuffix(strings.Join(createTableSQLArray, ""), ","))
@@ -711,11 +847,46 @@ func (c *SnowflakeConnector) insertRecordsInRawTable(rawTableIdentifier string,
 	return nil
 }
 
+// generateVariantColumnCast extracts a column from a variant of the raw table as its Snowflake type.
+func generateVariantColumnCast(variantColumn string, columnName string, genericColumnType qvalue.QValueKind) string {
+	switch genericColumnType {
+	case qvalue.QValueKindBytes, qvalue.QValueKindBit:
+		return fmt.Sprintf("BASE64_DECODE_BINARY(%s:%s)", variantColumn, columnName)
+	case qvalue.QValueKindHStore:
+		// hstore is stored as a JSON string, not as a nested object.
+		return fmt.Sprintf("PARSE_JSON(CAST(%s:%s AS STRING))", variantColumn, columnName)
+	case qvalue.QValueKindGeometry:
+		return fmt.Sprintf("TO_GEOMETRY(CAST(%s:%s AS STRING))", variantColumn, columnName)
+	case qvalue.QValueKindGeography:
+		return fmt.Sprintf("TO_GEOGRAPHY(CAST(%s:%s AS STRING))", variantColumn, columnName)
+	default:
+		return fmt.Sprintf("CAST(%s:%s AS %s)", variantColumn, columnName, qValueKindToSnowflakeType(genericColumnType))
+	}
+}
+
 func (c *SnowflakeConnector) generateAndExecuteMergeStatement(destinationTableIdentifier string,
 	unchangedToastColumns []string,
-	rawTableIdentifier string, syncBatchID int64, normalizeBatchID int64,
+	rawTableIdentifier string, syncBatchID int64, normalizeBatchID int64, lastTruncate model.RawRecordPosition,
 	normalizeRecordsTx *sql.Tx) error {
+	mergeStatement, mergeArgs := c.generateMergeStatement(destinationTableIdentifier, unchangedToastColumns,
+		rawTableIdentifier, syncBatchID, normalizeBatchID, lastTruncate)
+	_, err := normalizeRecordsTx.ExecContext(c.ctx, mergeStatement, mergeArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to merge records into %s: %w", destinationTableIdentifier, err)
+	}
+
+	return nil
+}
+
+// generateMergeStatement returns the statement normalizing the records of a table and its arguments.
+func (c *SnowflakeConnector) generateMergeStatement(destinationTableIdentifier string,
+	unchangedToastColumns []string,
+	rawTableIdentifier string, syncBatchID int64, normalizeBatchID int64,
+	lastTruncate model.RawRecordPosition) (string, []interface{}) {
 	normalizedTableSchema := c.tableSchemaMapping[destinationTableIdentifier]
+	// records up to the last truncate of the table are skipped.
+	afterTruncateSQL := fmt.Sprintf(rawRecordsAfterSQL, lastTruncate.BatchID, lastTruncate.BatchID,
+		lastTruncate.Timestamp)
 	// TODO: switch this to function maps.Keys when it is moved into Go's stdlib
 	columnNames := make([]string, 0, len(normalizedTableSchema.Columns))
 	for columnName := range normalizedTableSchema.Columns {
@@ -724,21 +895,14 @@ func (c *SnowflakeConnector) generateAndExecuteMergeStatement(destinationTableId
 
 	flattenedCastsSQLArray := make([]string, 0, len(normalizedTableSchema.Columns))
 	for columnName, genericColumnType := range normalizedTableSchema.Columns {
-		sfType := qValueKindToSnowflakeType(qvalue.QValueKind(genericColumnType))
-		switch qvalue.QValueKind(genericColumnType) {
-		case qvalue.QValueKindBytes, qvalue.QValueKindBit:
-			flattenedCastsSQLArray = append(flattenedCastsSQLArray, fmt.Sprintf("BASE64_DECODE_BINARY(%s:%s) "+
-				"AS %s,", toVariantColumnName, columnName, columnName))
 		// TODO: https://github.com/PeerDB-io/peerdb/issues/189 - handle time types and interval types
 		// case model.ColumnTypeTime:
 		// 	flattenedCastsSQLArray = append(flattenedCastsSQLArray, fmt.Sprintf("TIME_FROM_PARTS(0,0,0,%s:%s:"+
 		// 		"Microseconds*1000) "+
 		// 		"AS %s,", toVariantColumnName, columnName, columnName))
-		default:
-			flattenedCastsSQLArray = append(flattenedCastsSQLArray, fmt.Sprintf("CAST(%s:%s AS %s) AS %s,",
-				toVariantColumnName,
-				columnName, sfType, columnName))
-		}
+		flattenedCastsSQLArray = append(flattenedCastsSQLArray, fmt.Sprintf("%s AS %s,",
+			generateVariantColumnCast(toVariantColumnName, columnName, qvalue.QValueKind(genericColumnType)),
+			columnName))
 	}
 	flattenedCastsSQL := strings.TrimSuffix(strings.Join(flattenedCastsSQLArray, ""), ",")
 
@@ -749,24 +913,51 @@ func (c *SnowflakeConnector) generateAndExecuteMergeStatement(destinationTableId
 	}
 	insertValuesSQL := strings.TrimSuffix(strings.Join(insertValuesSQLArray, ""), ",")
 
-	updateStatementsforToastCols := c.generateUpdateStatement(columnNames, unchangedToastColumns)
-	updateStringToastCols := strings.Join(updateStatementsforToastCols, " ")
+	var mergeStatement string
+	mergeArgs := []interface{}{destinationTableIdentifier}
+	switch {
+	case len(normalizedTableSchema.PrimaryKeyColumns) > 0:
+		updateStatementsforToastCols := c.generateUpdateStatement(columnNames, unchangedToastColumns)
+		updateStringToastCols := strings.Join(updateStatementsforToastCols, " ")
+
+		// TARGET.<pkey1> = SOURCE.<pkey1> AND TARGET.<pkey2> = SOURCE.<pkey2>...
+		pkeySelectSQL, pkeyColStr := generatePkeySQL(normalizedTableSchema.PrimaryKeyColumns)
+
+		mergeStatement = fmt.Sprintf(mergeStatementSQL, destinationTableIdentifier, toVariantColumnName,
+			rawTableIdentifier, normalizeBatchID, syncBatchID, afterTruncateSQL, flattenedCastsSQL,
+			pkeySelectSQL, pkeyColStr, insertColumnsSQL, insertValuesSQL,
+			updateStringToastCols)
+	case normalizedTableSchema.ReplicaIdentity == protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL:
+		// no key columns, row images to delete match on every column. A MERGE deletes all the equal rows, so the
+		// ones beyond the number of times the image was removed are inserted again.
+		matchConditionsArray := make([]string, 0, len(columnNames))
+		for _, columnName := range columnNames {
+			matchConditionsArray = append(matchConditionsArray, fmt.Sprintf("EQUAL_NULL(TARGET.%s, SOURCE.%s)",
+				columnName, columnName))
+		}
 
-	// TARGET.<pkey> = SOURCE.<pkey>
-	pkeyColStr := fmt.Sprintf("TARGET.%s = SOURCE.%s",
-		normalizedTableSchema.PrimaryKeyColumn, normalizedTableSchema.PrimaryKeyColumn)
+		mergeStatement = fmt.Sprintf(mergeStatementFullRowSQL, destinationTableIdentifier, toVariantColumnName,
+			rawTableIdentifier, normalizeBatchID, syncBatchID, afterTruncateSQL, flattenedCastsSQL,
+			strings.Join(matchConditionsArray, " AND "), insertColumnsSQL, insertValuesSQL)
+		// the destination table name is bound once for the new and once for the old row images.
+		mergeArgs = append(mergeArgs, destinationTableIdentifier)
+	default:
+		// no way to identify rows, only inserts are replicated.
+		mergeStatement = fmt.Sprintf(insertAppendOnlySQL, destinationTableIdentifier, insertColumnsSQL,
+			insertColumnsSQL, flattenedCastsSQL, toVariantColumnName, rawTableIdentifier,
+			normalizeBatchID, syncBatchID, afterTruncateSQL)
+	}
 
-	mergeStatement := fmt.Sprintf(mergeStatementSQL, destinationTableIdentifier, toVariantColumnName,
-		rawTableIdentifier, normalizeBatchID, syncBatchID, flattenedCastsSQL,
-		normalizedTableSchema.PrimaryKeyColumn, pkeyColStr, insertColumnsSQL, insertValuesSQL,
-		updateStringToastCols)
+	return mergeStatement, mergeArgs
+}
 
-	_, err := normalizeRecordsTx.ExecContext(c.ctx, mergeStatement, destinationTableIdentifier)
-	if err != nil {
-		return fmt.Errorf("failed to merge records into %s: %w", destinationTableIdentifier, err)
+// generatePkeySQL returns the PARTITION BY list and the MERGE join condition for the given primary key columns.
+func generatePkeySQL(pkeyCols []string) (string, string) {
+	joinConditions := make([]string, 0, len(pkeyCols))
+	for _, pkeyCol := range pkeyCols {
+		joinConditions = append(joinConditions, fmt.Sprintf("TARGET.%s = SOURCE.%s", pkeyCol, pkeyCol))
 	}
-
-	return nil
+	return strings.Join(pkeyCols, ","), strings.Join(joinConditions, " AND ")
 }
 
 // parseTableName parses a table name into schema and table name.
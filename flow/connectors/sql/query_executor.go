@@ -27,6 +27,7 @@ type SQLQueryExecutor interface {
 
 	ExecuteAndProcessQuery(query string, args ...interface{}) (*model.QRecordBatch, error)
 	NamedExecuteAndProcessQuery(query string, arg interface{}) (*model.QRecordBatch, error)
+	NamedExecuteAndProcessQueryStream(stream *model.QRecordStream, query string, arg interface{}) (int, error)
 	ExecuteQuery(query string, args ...interface{}) error
 	NamedExec(query string, arg interface{}) (sql.Result, error)
 }
@@ -136,28 +137,68 @@ func (g *GenericSQLQueryExecutor) columnTypeToQField(ct *sql.ColumnType) (*model
 }
 
 func (g *GenericSQLQueryExecutor) processRows(rows *sqlx.Rows) (*model.QRecordBatch, error) {
-	dbColTypes, err := rows.ColumnTypes()
+	var schema *model.QRecordSchema
+	var records []*model.QRecord
+	err := g.scanRows(rows, func(s *model.QRecordSchema) {
+		schema = s
+	}, func(record *model.QRecord) error {
+		records = append(records, record)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Return a QRecordBatch
+	return &model.QRecordBatch{
+		NumRecords: uint32(len(records)),
+		Records:    records,
+		Schema:     schema,
+	}, nil
+}
+
+// processRowsToStream sends the rows of a query to a stream as they're read, it returns the number of rows sent.
+func (g *GenericSQLQueryExecutor) processRowsToStream(rows *sqlx.Rows, stream *model.QRecordStream) (int, error) {
+	numRecords := 0
+	err := g.scanRows(rows, stream.SetSchema, func(record *model.QRecord) error {
+		if !stream.Send(record) {
+			return model.ErrRecordStreamAbandoned
+		}
+		numRecords++
+		return nil
+	})
+	return numRecords, err
+}
+
+// scanRows converts the rows of a query to records and passes them to processRecord one by one,
+// the schema of the records is passed to setSchema before the first record.
+func (g *GenericSQLQueryExecutor) scanRows(
+	rows *sqlx.Rows,
+	setSchema func(*model.QRecordSchema),
+	processRecord func(*model.QRecord) error,
+) error {
+	dbColTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+
 	// Convert dbColTypes to QFields
 	qfields := make([]*model.QField, len(dbColTypes))
 	for i, ct := range dbColTypes {
 		qfield, err := g.columnTypeToQField(ct)
 		if err != nil {
 			log.Errorf("failed to convert column type %v: %v", ct, err)
-			return nil, err
+			return err
 		}
 		qfields[i] = qfield
 	}
 
-	var records []*model.QRecord
+	setSchema(model.NewQRecordSchema(qfields))
 
 	for rows.Next() {
 		columns, err := rows.Columns()
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		values := make([]interface{}, len(columns))
@@ -199,7 +240,7 @@ func (g *GenericSQLQueryExecutor) processRows(rows *sqlx.Rows) (*model.QRecordBa
 		}
 
 		if err := rows.Scan(values...); err != nil {
-			return nil, err
+			return err
 		}
 
 		qValues := make([]qvalue.QValue, len(values))
@@ -207,7 +248,7 @@ func (g *GenericSQLQueryExecutor) processRows(rows *sqlx.Rows) (*model.QRecordBa
 			qv, err := toQValue(qfields[i].Type, val)
 			if err != nil {
 				log.Errorf("failed to convert value: %v", err)
-				return nil, err
+				return err
 			}
 			qValues[i] = qv
 		}
@@ -218,20 +259,17 @@ func (g *GenericSQLQueryExecutor) processRows(rows *sqlx.Rows) (*model.QRecordBa
 			record.Set(i, qv)
 		}
 
-		records = append(records, record)
+		if err := processRecord(record); err != nil {
+			return err
+		}
 	}
 
 	if err := rows.Err(); err != nil {
 		log.Errorf("failed to iterate over rows: %v", err)
-		return nil, err
+		return err
 	}
 
-	// Return a QRecordBatch
-	return &model.QRecordBatch{
-		NumRecords: uint32(len(records)),
-		Records:    records,
-		Schema:     model.NewQRecordSchema(qfields),
-	}, nil
+	return nil
 }
 
 func (g *GenericSQLQueryExecutor) ExecuteAndProcessQuery(
@@ -256,6 +294,19 @@ func (g *GenericSQLQueryExecutor) NamedExecuteAndProcessQuery(
 	return g.processRows(rows)
 }
 
+// NamedExecuteAndProcessQueryStream sends the rows of a query to a stream while they're read,
+// it returns the number of rows sent. The stream is left open for the caller to close.
+func (g *GenericSQLQueryExecutor) NamedExecuteAndProcessQueryStream(
+	stream *model.QRecordStream, query string, arg interface{}) (int, error) {
+	rows, err := g.db.NamedQueryContext(g.ctx, query, arg)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	return g.processRowsToStream(rows, stream)
+}
+
 func (g *GenericSQLQueryExecutor) ExecuteQuery(query string, args ...interface{}) error {
 	_, err := g.db.ExecContext(g.ctx, query, args...)
 	return err
@@ -0,0 +1,551 @@
+package connsqlserver
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	log "github.com/sirupsen/logrus"
+)
+
+// interval between reads of the change tables while waiting for changes.
+const changePollInterval = time.Second
+
+// operations of the rows of a change table, as in the __$operation column.
+const (
+	cdcOperationDelete       = 1
+	cdcOperationInsert       = 2
+	cdcOperationUpdateBefore = 3
+	cdcOperationUpdateAfter  = 4
+)
+
+// lsn is a log sequence number, as the cdc functions take and return them.
+type lsn []byte
+
+// Scan copies the LSN, the driver may reuse the buffer it scanned from.
+func (l *lsn) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+	case []byte:
+		*l = append(lsn(nil), v...)
+	default:
+		return fmt.Errorf("invalid lsn %v", src)
+	}
+	return nil
+}
+
+func (l lsn) String() string {
+	return fmt.Sprintf("0x%X", []byte(l))
+}
+
+// change is a row of a change table.
+type change struct {
+	lsn        lsn
+	seqval     []byte
+	operation  int64
+	commitTime time.Time
+	items      model.RecordItems
+}
+
+// changeTable is the change table of a source table the changes of a batch are read from.
+type changeTable struct {
+	srcTableName string
+	dstTableName string
+	// capture instance of the table, its changes are read with fn_cdc_get_all_changes_<instance>.
+	instance string
+	// the LSN changes are read from, the start of the change table if it was created after the last pull.
+	fromLSN     lsn
+	columnNames []string
+	columnKinds []qvalue.QValueKind
+}
+
+// changeBatch is the LSN range of the changes of a pull. Its transactions are those with changes to
+// the tables in the range, in commit order, the last one ends the range.
+type changeBatch struct {
+	tables       []*changeTable
+	transactions []lsn
+}
+
+// sqlServerCDCSource reads the changes of the source tables from their change tables.
+type sqlServerCDCSource struct {
+	connector *SQLServerConnector
+	req       *model.PullRecordsRequest
+	// whether the pull starts where replication was set up.
+	fromSetup bool
+}
+
+// PullRecords reads the changes committed after the checkpoint the destination has synced from the
+// change tables, and records the LSN of the batch's last checkpoint for the next pull.
+// Checkpoint ids number the transactions read, so a batch always ends at a commit.
+func (c *SQLServerConnector) PullRecords(req *model.PullRecordsRequest) error {
+	if c.pgMetadata == nil {
+		return errNoMetadataDB
+	}
+
+	var startCheckpointID int64
+	if req.LastSyncState != nil {
+		startCheckpointID = req.LastSyncState.Checkpoint
+	}
+	startLSN, err := c.getCheckpointLSN(req.FlowJobName, startCheckpointID)
+	if err != nil {
+		return err
+	}
+
+	source := &sqlServerCDCSource{
+		connector: c,
+		req:       req,
+		fromSetup: startCheckpointID == 0,
+	}
+
+	// the change tables are filled by the capture job, so they are polled until changes show up.
+	deadline := time.Now().Add(req.IdleTimeout)
+	var batch *changeBatch
+	for {
+		batch, err = source.planBatch(startLSN)
+		if err != nil {
+			return err
+		}
+		if len(batch.transactions) > 0 || !time.Now().Before(deadline) {
+			break
+		}
+		select {
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		case <-time.After(changePollInterval):
+		}
+	}
+	if len(batch.transactions) == 0 {
+		log.Infof("Idle timeout reached, returning currently accumulated records")
+		return nil
+	}
+
+	numRecords, err := source.sendBatch(startCheckpointID, batch)
+	if err != nil {
+		return err
+	}
+
+	if numRecords > 0 {
+		lastLSN := batch.transactions[len(batch.transactions)-1]
+		err = c.saveCheckpoint(req.FlowJobName, startCheckpointID, req.RecordStream.LastCheckPointID(), lastLSN)
+		if err != nil {
+			return fmt.Errorf("failed to save checkpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+// planBatch returns the LSN range of the changes of all tables committed after an LSN, capped at the
+// batch size. Only the LSNs of the first changes are read: when there are more changes than the batch
+// size, the transactions before the one the last of them belongs to are in the batch, so that every
+// table is complete up to there. A single transaction larger than the batch size is read whole.
+func (s *sqlServerCDCSource) planBatch(afterLSN lsn) (*changeBatch, error) {
+	c := s.connector
+
+	var fromLSN, maxLSN lsn
+	err := c.db.QueryRowContext(c.ctx,
+		"SELECT sys.fn_cdc_increment_lsn(@p1), sys.fn_cdc_get_max_lsn()", []byte(afterLSN)).Scan(&fromLSN, &maxLSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lsn range: %w", err)
+	}
+	batch := &changeBatch{}
+	if maxLSN == nil || bytes.Compare(fromLSN, maxLSN) > 0 {
+		return batch, nil
+	}
+
+	srcTableNames := make([]string, 0, len(s.req.TableNameMapping))
+	for srcTableName := range s.req.TableNameMapping {
+		srcTableNames = append(srcTableNames, srcTableName)
+	}
+	sort.Strings(srcTableNames)
+	for _, srcTableName := range srcTableNames {
+		table, err := s.changeTable(srcTableName, fromLSN)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Compare(table.fromLSN, maxLSN) <= 0 {
+			batch.tables = append(batch.tables, table)
+		}
+	}
+	if len(batch.tables) == 0 {
+		return batch, nil
+	}
+
+	limit := int64(s.req.MaxBatchSize)
+	if limit <= 0 {
+		limit = 1
+	}
+	lsnQueries := make([]string, 0, len(batch.tables))
+	args := make([]interface{}, 0, 2*len(batch.tables))
+	for _, table := range batch.tables {
+		lsnQueries = append(lsnQueries, fmt.Sprintf("SELECT __$start_lsn FROM cdc.%s(@p%d, @p%d, N'all update old')",
+			QuoteIdentifier("fn_cdc_get_all_changes_"+table.instance), len(args)+1, len(args)+2))
+		args = append(args, []byte(table.fromLSN), []byte(maxLSN))
+	}
+	//nolint:gosec
+	query := fmt.Sprintf("SELECT TOP (%d) __$start_lsn FROM (%s) changes ORDER BY __$start_lsn",
+		limit, strings.Join(lsnQueries, " UNION ALL "))
+	rows, err := c.db.QueryContext(c.ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lsns of changes: %w", err)
+	}
+	defer rows.Close()
+
+	var numChanges int64
+	for rows.Next() {
+		var changeLSN lsn
+		if err := rows.Scan(&changeLSN); err != nil {
+			return nil, fmt.Errorf("failed to scan lsn of change: %w", err)
+		}
+		numChanges++
+		if len(batch.transactions) == 0 || !bytes.Equal(batch.transactions[len(batch.transactions)-1], changeLSN) {
+			batch.transactions = append(batch.transactions, changeLSN)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over lsns of changes: %w", err)
+	}
+
+	// the changes of the last transaction may not all have been counted.
+	if numChanges == limit && len(batch.transactions) > 1 {
+		batch.transactions = batch.transactions[:len(batch.transactions)-1]
+	}
+	return batch, nil
+}
+
+// changeTable returns the change table of a source table and the columns its changes are read with.
+func (s *sqlServerCDCSource) changeTable(srcTableName string, fromLSN lsn) (*changeTable, error) {
+	table, err := parseSchemaTable(srcTableName)
+	if err != nil {
+		return nil, err
+	}
+	dstTableName := s.req.TableNameMapping[srcTableName]
+	tableSchema, ok := s.req.TableNameSchemaMapping[dstTableName]
+	if !ok {
+		return nil, fmt.Errorf("no schema known for table %s", dstTableName)
+	}
+
+	instance, minLSN, err := s.connector.captureInstance(table)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Compare(fromLSN, minLSN) < 0 {
+		// nothing before the capture instance was created was captured, but after that changes
+		// missing from the change table were cleaned up before they were replicated.
+		if !s.fromSetup {
+			return nil, fmt.Errorf("changes of table %s before lsn %s have been cleaned up", srcTableName, minLSN)
+		}
+		fromLSN = minLSN
+	}
+
+	columnNames := make([]string, 0, len(tableSchema.Columns))
+	for name := range tableSchema.Columns {
+		columnNames = append(columnNames, name)
+	}
+	sort.Strings(columnNames)
+	columnKinds := make([]qvalue.QValueKind, len(columnNames))
+	for i, name := range columnNames {
+		columnKinds[i] = qvalue.QValueKind(tableSchema.Columns[name])
+	}
+
+	return &changeTable{
+		srcTableName: srcTableName,
+		dstTableName: dstTableName,
+		instance:     instance,
+		fromLSN:      fromLSN,
+		columnNames:  columnNames,
+		columnKinds:  columnKinds,
+	}, nil
+}
+
+// captureInstance returns the newest capture instance of a table and the lowest LSN its change
+// table still has changes from.
+func (c *SQLServerConnector) captureInstance(table *SchemaTable) (string, lsn, error) {
+	var name string
+	var minLSN lsn
+	err := c.db.QueryRowContext(c.ctx, `
+		SELECT TOP 1 capture_instance, sys.fn_cdc_get_min_lsn(capture_instance)
+		FROM cdc.change_tables
+		WHERE source_object_id = OBJECT_ID(@p1)
+		ORDER BY create_date DESC`, table.String()).Scan(&name, &minLSN)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil, fmt.Errorf("change data capture is not enabled for table %s", table)
+		}
+		return "", nil, fmt.Errorf("failed to get capture instance of table %s: %w", table, err)
+	}
+	return name, minLSN, nil
+}
+
+// sendBatch sends the changes of a batch to the stream of the request, table by table and in the order
+// they were made within a table. Transactions are numbered from the checkpoint the pull started at,
+// the number of records sent is returned.
+func (s *sqlServerCDCSource) sendBatch(startCheckpointID int64, batch *changeBatch) (int, error) {
+	checkpointIDs := transactionCheckpointIDs(startCheckpointID, batch.transactions)
+	toLSN := batch.transactions[len(batch.transactions)-1]
+
+	numRecords := 0
+	for _, table := range batch.tables {
+		if bytes.Compare(table.fromLSN, toLSN) > 0 {
+			continue
+		}
+		sender := newChangeSender(s.req.RecordStream, table, checkpointIDs)
+		if err := s.readTableChanges(table, toLSN, sender.send); err != nil {
+			return 0, err
+		}
+		if err := sender.finish(); err != nil {
+			return 0, err
+		}
+		numRecords += sender.numRecords
+	}
+
+	if numRecords > 0 {
+		s.req.RecordStream.UpdateLastCheckPointID(startCheckpointID + int64(len(batch.transactions)))
+	}
+	return numRecords, nil
+}
+
+// transactionCheckpointIDs numbers transactions in commit order from the checkpoint after a start one.
+func transactionCheckpointIDs(startCheckpointID int64, transactions []lsn) map[string]int64 {
+	checkpointIDs := make(map[string]int64, len(transactions))
+	for i, transaction := range transactions {
+		checkpointIDs[string(transaction)] = startCheckpointID + int64(i) + 1
+	}
+	return checkpointIDs
+}
+
+// readTableChanges reads the changes of a table up to an LSN and hands each one to send as it is scanned.
+func (s *sqlServerCDCSource) readTableChanges(table *changeTable, toLSN lsn, send func(*change) error) error {
+	c := s.connector
+	quotedColumns := make([]string, len(table.columnNames))
+	for i, name := range table.columnNames {
+		quotedColumns[i] = QuoteIdentifier(name)
+	}
+
+	//nolint:gosec
+	query := fmt.Sprintf(`SELECT __$start_lsn, __$seqval, __$operation,
+			sys.fn_cdc_map_lsn_to_time(__$start_lsn), %s
+		FROM cdc.%s(@p1, @p2, N'all update old')
+		ORDER BY __$start_lsn, __$seqval, __$operation`,
+		strings.Join(quotedColumns, ", "), QuoteIdentifier("fn_cdc_get_all_changes_"+table.instance))
+	rows, err := c.db.QueryContext(c.ctx, query, []byte(table.fromLSN), []byte(toLSN))
+	if err != nil {
+		return fmt.Errorf("failed to read changes of table %s: %w", table.srcTableName, err)
+	}
+	defer rows.Close()
+
+	values := make([]interface{}, len(table.columnNames))
+	for rows.Next() {
+		ch := &change{}
+		var commitTime sql.NullTime
+		dest := []interface{}{&ch.lsn, &ch.seqval, &ch.operation, &commitTime}
+		for i := range values {
+			values[i] = nil
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("failed to scan change of table %s: %w", table.srcTableName, err)
+		}
+		ch.commitTime = commitTime.Time
+
+		ch.items = make(model.RecordItems, len(table.columnNames))
+		for i, name := range table.columnNames {
+			val, err := changeValueToQValue(table.columnKinds[i], values[i])
+			if err != nil {
+				return fmt.Errorf("column %s of table %s: %w", name, table.srcTableName, err)
+			}
+			ch.items[name] = val
+		}
+		if err := send(ch); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate over changes of table %s: %w", table.srcTableName, err)
+	}
+	return nil
+}
+
+// changeSender turns the changes of a table into records and sends them to a stream, in the order they
+// were made. The row before an update is held until the row after it.
+type changeSender struct {
+	stream        *model.CDCRecordStream
+	table         *changeTable
+	checkpointIDs map[string]int64
+	updateBefore  *change
+	numRecords    int
+}
+
+func newChangeSender(stream *model.CDCRecordStream, table *changeTable,
+	checkpointIDs map[string]int64) *changeSender {
+	return &changeSender{
+		stream:        stream,
+		table:         table,
+		checkpointIDs: checkpointIDs,
+	}
+}
+
+func (s *changeSender) send(ch *change) error {
+	checkpointID, ok := s.checkpointIDs[string(ch.lsn)]
+	if !ok {
+		return fmt.Errorf("change of table %s at lsn %s is not in the batch", s.table.srcTableName, ch.lsn)
+	}
+	if s.updateBefore != nil && (ch.operation != cdcOperationUpdateAfter ||
+		!bytes.Equal(ch.lsn, s.updateBefore.lsn) || !bytes.Equal(ch.seqval, s.updateBefore.seqval)) {
+		return fmt.Errorf("update of table %s at lsn %s has no row after the update",
+			s.table.srcTableName, s.updateBefore.lsn)
+	}
+
+	var record model.Record
+	switch ch.operation {
+	case cdcOperationInsert:
+		record = &model.InsertRecord{
+			SourceTableName:       s.table.srcTableName,
+			DestinationTableName:  s.table.dstTableName,
+			CheckPointID:          checkpointID,
+			Items:                 ch.items,
+			UnchangedToastColumns: make(map[string]bool),
+			CommitTime:            ch.commitTime,
+		}
+	case cdcOperationDelete:
+		record = &model.DeleteRecord{
+			SourceTableName:       s.table.srcTableName,
+			DestinationTableName:  s.table.dstTableName,
+			CheckPointID:          checkpointID,
+			Items:                 ch.items,
+			UnchangedToastColumns: make(map[string]bool),
+			CommitTime:            ch.commitTime,
+		}
+	case cdcOperationUpdateBefore:
+		s.updateBefore = ch
+		return nil
+	case cdcOperationUpdateAfter:
+		if s.updateBefore == nil {
+			return fmt.Errorf("update of table %s at lsn %s has no row before the update", s.table.srcTableName, ch.lsn)
+		}
+		record = &model.UpdateRecord{
+			SourceTableName:       s.table.srcTableName,
+			DestinationTableName:  s.table.dstTableName,
+			CheckPointID:          checkpointID,
+			OldItems:              s.updateBefore.items,
+			NewItems:              ch.items,
+			UnchangedToastColumns: make(map[string]bool),
+			CommitTime:            s.updateBefore.commitTime,
+		}
+		s.updateBefore = nil
+	default:
+		return fmt.Errorf("unexpected operation %d in changes of table %s", ch.operation, s.table.srcTableName)
+	}
+
+	if err := s.stream.Send(record); err != nil {
+		return err
+	}
+	s.numRecords++
+	return nil
+}
+
+// finish checks that the changes of the table didn't end with the row before an update.
+func (s *changeSender) finish() error {
+	if s.updateBefore != nil {
+		return fmt.Errorf("update of table %s at lsn %s has no row after the update",
+			s.table.srcTableName, s.updateBefore.lsn)
+	}
+	return nil
+}
+
+// EnsurePullability checks that the table exists and that change data capture is available on the
+// database. SQL Server tables have no identifier like Postgres relation ids, so the output identifies none.
+func (c *SQLServerConnector) EnsurePullability(req *protos.EnsurePullabilityInput,
+) (*protos.EnsurePullabilityOutput, error) {
+	table, err := parseSchemaTable(req.SourceTableIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.getTableColumns(table); err != nil {
+		return nil, err
+	}
+
+	// change data capture is only available on editions with the SQL Server Agent.
+	var edition string
+	err = c.db.QueryRowContext(c.ctx, "SELECT CAST(SERVERPROPERTY('Edition') AS NVARCHAR(128))").Scan(&edition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sql server edition: %w", err)
+	}
+	if strings.HasPrefix(edition, "Express") {
+		return nil, fmt.Errorf("change data capture is not supported on sql server %s", edition)
+	}
+
+	return &protos.EnsurePullabilityOutput{TableIdentifier: &protos.TableIdentifier{}}, nil
+}
+
+// SetupReplication enables change data capture on the database and the tables of the mirror, and
+// records the LSN changes are read after. Replication is set up again without effect.
+func (c *SQLServerConnector) SetupReplication(req *protos.SetupReplicationInput) error {
+	if c.pgMetadata == nil {
+		return errNoMetadataDB
+	}
+	if len(req.RowFilters) > 0 {
+		return fmt.Errorf("row filters are not supported for sql server sources")
+	}
+
+	if c.NeedsSetupMetadataTables() {
+		if err := c.SetupMetadataTables(); err != nil {
+			return fmt.Errorf("failed to setup metadata tables: %w", err)
+		}
+	}
+
+	_, err := c.db.ExecContext(c.ctx, `IF (SELECT is_cdc_enabled FROM sys.databases WHERE name = DB_NAME()) = 0
+		EXEC sys.sp_cdc_enable_db`)
+	if err != nil {
+		return fmt.Errorf("failed to enable change data capture on database %s: %w", c.config.Database, err)
+	}
+
+	for srcTableName := range req.TableNameMapping {
+		table, err := parseSchemaTable(srcTableName)
+		if err != nil {
+			return err
+		}
+		// all columns are captured, filtered columns are left out when changes are read.
+		_, err = c.db.ExecContext(c.ctx, `IF (SELECT is_tracked_by_cdc FROM sys.tables
+				WHERE object_id = OBJECT_ID(@p1)) = 0
+			EXEC sys.sp_cdc_enable_table @source_schema = @p2, @source_name = @p3, @role_name = NULL`,
+			table.String(), table.Schema, table.Table)
+		if err != nil {
+			return fmt.Errorf("failed to enable change data capture on table %s: %w", srcTableName, err)
+		}
+	}
+
+	var maxLSN lsn
+	err = c.db.QueryRowContext(c.ctx, "SELECT sys.fn_cdc_get_max_lsn()").Scan(&maxLSN)
+	if err != nil {
+		return fmt.Errorf("failed to read max lsn: %w", err)
+	}
+	// the capture job hasn't run yet, changes are read from the start of the change tables.
+	if maxLSN == nil {
+		maxLSN = make(lsn, 10)
+	}
+
+	err = c.insertCheckpoint(req.FlowJobName, 0, maxLSN)
+	if err != nil {
+		return fmt.Errorf("failed to record starting lsn: %w", err)
+	}
+	log.Infof("replication for flow job %s starts after lsn %s", req.FlowJobName, maxLSN)
+
+	return nil
+}
+
+// PullFlowCleanup removes the checkpoints of a job. Change data capture is left enabled on the
+// tables, other mirrors may read their changes.
+func (c *SQLServerConnector) PullFlowCleanup(jobName string) error {
+	if c.pgMetadata == nil || c.NeedsSetupMetadataTables() {
+		return nil
+	}
+	return c.pgMetadata.DropMetadata(jobName)
+}
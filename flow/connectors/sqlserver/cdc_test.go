@@ -0,0 +1,112 @@
+package connsqlserver
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLSN(n byte) lsn {
+	return lsn{0, 0, 0, 0x2a, 0, 0, 0x01, 0x10, 0, n}
+}
+
+func testChange(n byte, seq byte, operation int64, id int32) *change {
+	return &change{
+		lsn:       testLSN(n),
+		seqval:    []byte{0, seq},
+		operation: operation,
+		items: model.RecordItems{
+			"id": qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: id},
+		},
+	}
+}
+
+func TestChangeSender(t *testing.T) {
+	changes := []*change{
+		testChange(1, 1, cdcOperationInsert, 1),
+		testChange(1, 2, cdcOperationInsert, 2),
+		testChange(2, 1, cdcOperationUpdateBefore, 1),
+		testChange(2, 1, cdcOperationUpdateAfter, 1),
+		testChange(3, 1, cdcOperationDelete, 2),
+	}
+	table := &changeTable{srcTableName: "dbo.accounts", dstTableName: "public.accounts"}
+	stream := model.NewCDCRecordStream(len(changes), nil)
+	checkpointIDs := transactionCheckpointIDs(10, []lsn{testLSN(1), testLSN(2), testLSN(3)})
+
+	sender := newChangeSender(stream, table, checkpointIDs)
+	for _, ch := range changes {
+		require.NoError(t, sender.send(ch))
+	}
+	require.NoError(t, sender.finish())
+	assert.Equal(t, 4, sender.numRecords)
+	stream.UpdateLastCheckPointID(13)
+	stream.Close(nil)
+	batch, err := stream.ToRecordBatch()
+	require.NoError(t, err)
+	require.Len(t, batch.Records, 4)
+	assert.Equal(t, int64(11), batch.FirstCheckPointID)
+	assert.Equal(t, int64(13), batch.LastCheckPointID)
+
+	// records of a transaction share its checkpoint.
+	assert.IsType(t, &model.InsertRecord{}, batch.Records[0])
+	assert.Equal(t, int32(1), batch.Records[0].GetItems()["id"].Value)
+	assert.Equal(t, int64(11), batch.Records[1].GetCheckPointID())
+	assert.IsType(t, &model.UpdateRecord{}, batch.Records[2])
+	assert.Equal(t, int64(12), batch.Records[2].GetCheckPointID())
+	assert.IsType(t, &model.DeleteRecord{}, batch.Records[3])
+	assert.Equal(t, "public.accounts", batch.Records[3].GetTableName())
+
+	// an update without the row after it, or a change past the end of the batch, fails.
+	sender = newChangeSender(model.NewCDCRecordStream(1, nil), table, checkpointIDs)
+	require.NoError(t, sender.send(testChange(1, 1, cdcOperationUpdateBefore, 1)))
+	assert.Error(t, sender.finish())
+	sender = newChangeSender(model.NewCDCRecordStream(1, nil), table, checkpointIDs)
+	assert.Error(t, sender.send(testChange(4, 1, cdcOperationInsert, 1)))
+}
+
+func TestChangeValueToQValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		dataType string
+		val      interface{}
+		expected interface{}
+	}{
+		{"tinyint", "tinyint", int64(200), int16(200)},
+		{"int", "int", int64(-7), int32(-7)},
+		{"decimal", "decimal", []byte("12.50"), big.NewRat(25, 2)},
+		{"real", "real", float64(1.5), float32(1.5)},
+		{"nvarchar", "nvarchar", "héllo", "héllo"},
+		{"datetime2", "datetime2", time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC),
+			time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)},
+		{"uniqueidentifier", "uniqueidentifier",
+			[]byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+			"00112233-4455-6677-8899-aabbccddeeff"},
+		{"null", "int", nil, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := sourceColumnKind(tc.dataType)
+			require.NoError(t, err)
+			qv, err := changeValueToQValue(kind, tc.val)
+			require.NoError(t, err)
+			assert.Equal(t, kind, qv.Kind)
+			assert.Equal(t, tc.expected, qv.Value)
+		})
+	}
+
+	_, err := sourceColumnKind("geography")
+	assert.Error(t, err)
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, "[id]", QuoteIdentifier("id"))
+	assert.Equal(t, "[first name]", QuoteIdentifier("first name"))
+	assert.Equal(t, "[a]]b]", QuoteIdentifier("a]b"))
+	assert.Equal(t, `[fn_cdc_get_all_changes_dbo_"x"]`, QuoteIdentifier(`fn_cdc_get_all_changes_dbo_"x"`))
+}
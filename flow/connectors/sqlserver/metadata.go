@@ -0,0 +1,63 @@
+package connsqlserver
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+)
+
+const (
+	// schema for the peerdb metadata. LSNs are 10 bytes and don't fit a checkpoint id, so checkpoint ids
+	// count the transactions pulled since replication was set up and the LSN of each checkpoint is kept.
+	metadataSchema = "peerdb_sqlserver_metadata"
+)
+
+var errNoMetadataDB = errors.New("a metadata database is needed to replicate changes from sql server")
+
+func (c *SQLServerConnector) NeedsSetupMetadataTables() bool {
+	if c.pgMetadata == nil {
+		return false
+	}
+	return c.pgMetadata.NeedsSetupMetadata()
+}
+
+func (c *SQLServerConnector) SetupMetadataTables() error {
+	if c.pgMetadata == nil {
+		return errNoMetadataDB
+	}
+	return c.pgMetadata.SetupMetadata()
+}
+
+// GetLastOffset returns the last checkpoint pulled for a job.
+func (c *SQLServerConnector) GetLastOffset(jobName string) (*protos.LastSyncState, error) {
+	if c.pgMetadata == nil {
+		return nil, errNoMetadataDB
+	}
+	return c.pgMetadata.FetchLastCheckpoint(jobName)
+}
+
+// getCheckpointLSN returns the LSN of the last transaction up to a checkpoint of a job.
+func (c *SQLServerConnector) getCheckpointLSN(jobName string, checkpointID int64) (lsn, error) {
+	position, err := c.pgMetadata.GetCheckpointPosition(jobName, checkpointID)
+	if err != nil {
+		return nil, err
+	}
+	l, err := hex.DecodeString(position)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lsn %s recorded for checkpoint %d: %w", position, checkpointID, err)
+	}
+	return l, nil
+}
+
+// insertCheckpoint records the LSN of a checkpoint, keeping the one already recorded.
+func (c *SQLServerConnector) insertCheckpoint(jobName string, checkpointID int64, l lsn) error {
+	return c.pgMetadata.InsertCheckpoint(jobName, checkpointID, hex.EncodeToString(l))
+}
+
+// saveCheckpoint records the LSN of a pulled batch's last checkpoint.
+func (c *SQLServerConnector) saveCheckpoint(jobName string, startCheckpointID int64, checkpointID int64,
+	l lsn) error {
+	return c.pgMetadata.SaveCheckpoint(jobName, startCheckpointID, checkpointID, hex.EncodeToString(l))
+}
@@ -12,11 +12,6 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func (c *SQLServerConnector) SetupQRepMetadataTables(config *protos.QRepConfig) error {
-	log.Infof("Setting up metadata tables for query replication on sql server is a no-op")
-	return nil
-}
-
 func (c *SQLServerConnector) GetQRepPartitions(
 	config *protos.QRepConfig, last *protos.QRepPartition) ([]*protos.QRepPartition, error) {
 	if config.NumRowsPerPartition <= 0 {
@@ -130,7 +125,8 @@ func (c *SQLServerConnector) GetQRepPartitions(
 }
 
 func (c *SQLServerConnector) PullQRepRecords(
-	config *protos.QRepConfig, partition *protos.QRepPartition) (*model.QRecordBatch, error) {
+	config *protos.QRepConfig, partition *protos.QRepPartition,
+	stream *model.QRecordStream) (int, error) {
 	var rangeStart interface{}
 	var rangeEnd interface{}
 
@@ -143,14 +139,14 @@ func (c *SQLServerConnector) PullQRepRecords(
 		rangeStart = x.TimestampRange.Start.AsTime()
 		rangeEnd = x.TimestampRange.End.AsTime()
 	default:
-		return nil, fmt.Errorf("unknown range type: %v", x)
+		return 0, fmt.Errorf("unknown range type: %v", x)
 	}
 
 	// Build the query to pull records within the range from the source table
 	// Be sure to order the results by the watermark column to ensure consistency across pulls
 	query, err := BuildQuery(config.Query)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
 	rangeParams := map[string]interface{}{
@@ -158,7 +154,7 @@ func (c *SQLServerConnector) PullQRepRecords(
 		"endRange":   rangeEnd,
 	}
 
-	return c.NamedExecuteAndProcessQuery(query, rangeParams)
+	return c.NamedExecuteAndProcessQueryStream(stream, query, rangeParams)
 }
 
 func BuildQuery(query string) (string, error) {
@@ -183,19 +179,3 @@ func BuildQuery(query string) (string, error) {
 	log.Infof("templated query: %s", res)
 	return res, nil
 }
-
-func (c *SQLServerConnector) SyncQRepRecords(
-	config *protos.QRepConfig,
-	partition *protos.QRepPartition,
-	records *model.QRecordBatch,
-) (int, error) {
-	panic("not implemented")
-}
-
-func (c *SQLServerConnector) ConsolidateQRepPartitions(config *protos.QRepConfig) error {
-	panic("not implemented")
-}
-
-func (c *SQLServerConnector) CleanupQRepFlow(config *protos.QRepConfig) error {
-	panic("not implemented")
-}
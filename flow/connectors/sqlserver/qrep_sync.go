@@ -0,0 +1,312 @@
+package connsqlserver
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	util "github.com/PeerDB-io/peer-flow/utils"
+	mssql "github.com/microsoft/go-mssqldb"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const qRepMetadataTableName = "_peerdb_query_replication_metadata"
+
+type SchemaTable struct {
+	Schema string
+	Table  string
+}
+
+func (t *SchemaTable) String() string {
+	return fmt.Sprintf(`"%s"."%s"`, t.Schema, t.Table)
+}
+
+// parseSchemaTable parses a table identifier of the form schema.table, tables without a schema
+// are in dbo.
+func parseSchemaTable(tableName string) (*SchemaTable, error) {
+	parts := strings.Split(tableName, ".")
+	switch len(parts) {
+	case 1:
+		return &SchemaTable{Schema: "dbo", Table: parts[0]}, nil
+	case 2:
+		return &SchemaTable{Schema: parts[0], Table: parts[1]}, nil
+	default:
+		return nil, fmt.Errorf("invalid table name: %s", tableName)
+	}
+}
+
+func (c *SQLServerConnector) SetupQRepMetadataTables(config *protos.QRepConfig) error {
+	//nolint:gosec
+	qRepMetadataSchema := fmt.Sprintf(`IF OBJECT_ID(N'%s', N'U') IS NULL
+		CREATE TABLE %s (
+			flowJobName NVARCHAR(255),
+			partitionID NVARCHAR(255),
+			syncPartition NVARCHAR(MAX),
+			syncStartTime DATETIME2,
+			syncFinishTime DATETIME2 DEFAULT SYSUTCDATETIME()
+		)`, qRepMetadataTableName, qRepMetadataTableName)
+
+	_, err := c.db.ExecContext(c.ctx, qRepMetadataSchema)
+	if err != nil {
+		return fmt.Errorf("failed to create table %s: %w", qRepMetadataTableName, err)
+	}
+
+	return nil
+}
+
+// isPartitionSynced checks whether a specific partition is synced
+func (c *SQLServerConnector) isPartitionSynced(partitionID string) (bool, error) {
+	//nolint:gosec
+	queryString := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE partitionID = @p1", qRepMetadataTableName)
+
+	var count int
+	err := c.db.QueryRowContext(c.ctx, queryString, partitionID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (c *SQLServerConnector) tableExists(table *SchemaTable) (bool, error) {
+	var count int
+	err := c.db.QueryRowContext(c.ctx,
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = @p1 AND table_name = @p2",
+		table.Schema, table.Table).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if table %s exists: %w", table, err)
+	}
+
+	return count > 0, nil
+}
+
+// SyncQRepRecords bulk copies the records of a partition into the destination table, creating the
+// table from the records' schema if it doesn't exist yet. In upsert mode the records are copied to
+// a staging table first and merged into the destination table on the upsert key columns.
+func (c *SQLServerConnector) SyncQRepRecords(
+	config *protos.QRepConfig,
+	partition *protos.QRepPartition,
+	stream *model.QRecordStream,
+) (int, error) {
+	dstTable, err := parseSchemaTable(config.DestinationTableIdentifier)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse destination table identifier: %w", err)
+	}
+
+	done, err := c.isPartitionSynced(partition.PartitionId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check if partition is synced: %w", err)
+	}
+
+	if done {
+		log.Infof("partition %s already synced", partition.PartitionId)
+		return 0, nil
+	}
+
+	schema, err := stream.Schema()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema from stream: %w", err)
+	}
+
+	exists, err := c.tableExists(dstTable)
+	if err != nil {
+		return 0, err
+	}
+
+	if !exists {
+		log.Infof("creating destination table %s for flow job %s", dstTable, config.FlowJobName)
+		err = c.CreateTable(schema, dstTable.Schema, dstTable.Table)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create destination table %s: %w", dstTable, err)
+		}
+	}
+
+	startTime := time.Now()
+
+	tx, err := c.db.BeginTx(c.ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Errorf("failed to rollback transaction: %v", err)
+		}
+	}()
+
+	colNames := schema.GetColumnNames()
+	var numRecords int
+	writeMode := config.WriteMode
+	if writeMode != nil && writeMode.WriteType == protos.QRepWriteType_QREP_WRITE_MODE_UPSERT {
+		if len(writeMode.UpsertKeyColumns) == 0 {
+			return 0, fmt.Errorf("upsert key columns are required for upsert mode")
+		}
+
+		runID, err := util.RandomUInt64()
+		if err != nil {
+			return 0, fmt.Errorf("failed to generate random runID: %w", err)
+		}
+
+		// local temporary tables live as long as the session, which the transaction holds on to.
+		stagingTable := fmt.Sprintf("#_peerdb_staging_%d", runID)
+		//nolint:gosec
+		_, err = tx.ExecContext(c.ctx,
+			fmt.Sprintf("SELECT * INTO %s FROM %s WHERE 1 = 0", stagingTable, dstTable))
+		if err != nil {
+			return 0, fmt.Errorf("failed to create staging table %s: %w", stagingTable, err)
+		}
+
+		numRecords, err = c.bulkCopy(tx, stagingTable, colNames, stream)
+		if err != nil {
+			return 0, err
+		}
+
+		mergeStmt := generateMergeStatement(dstTable.String(), stagingTable, colNames,
+			writeMode.UpsertKeyColumns, config.WatermarkColumn)
+		_, err = tx.ExecContext(c.ctx, mergeStmt)
+		if err != nil {
+			return 0, fmt.Errorf("failed to merge staging table into %s: %w", dstTable, err)
+		}
+	} else {
+		numRecords, err = c.bulkCopy(tx, dstTable.String(), colNames, stream)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// marshal the partition to json using protojson
+	pbytes, err := protojson.Marshal(partition)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal partition to json: %w", err)
+	}
+
+	//nolint:gosec
+	insertMetadataStmt := fmt.Sprintf("INSERT INTO %s VALUES (@p1, @p2, @p3, @p4, @p5)", qRepMetadataTableName)
+	_, err = tx.ExecContext(c.ctx, insertMetadataStmt,
+		config.FlowJobName, partition.PartitionId, string(pbytes), startTime, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert metadata for partition %s: %w", partition.PartitionId, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	log.Infof("pushed %d records to %s", numRecords, dstTable)
+	return numRecords, nil
+}
+
+// bulkCopy inserts the records of a stream into the table with the TDS bulk copy protocol while
+// they're pulled, it returns the number of records copied.
+func (c *SQLServerConnector) bulkCopy(tx *sql.Tx, table string, colNames []string,
+	stream *model.QRecordStream) (int, error) {
+	stmt, err := tx.PrepareContext(c.ctx, mssql.CopyIn(table, mssql.BulkOptions{KeepNulls: true}, colNames...))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare bulk copy into %s: %w", table, err)
+	}
+	defer stmt.Close()
+
+	numRecords := 0
+	values := make([]interface{}, len(colNames))
+	for record := range stream.Records() {
+		for i, qv := range record.Entries {
+			values[i], err = qValueToBulkValue(qv)
+			if err != nil {
+				return 0, fmt.Errorf("column %s: %w", colNames[i], err)
+			}
+		}
+
+		_, err = stmt.ExecContext(c.ctx, values...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to add row to bulk copy into %s: %w", table, err)
+		}
+		numRecords++
+	}
+	if err := stream.Err(); err != nil {
+		return 0, fmt.Errorf("failed to pull records: %w", err)
+	}
+
+	// executing the statement without arguments flushes the rows.
+	_, err = stmt.ExecContext(c.ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bulk copy into %s: %w", table, err)
+	}
+
+	return numRecords, nil
+}
+
+// generateMergeStatement merges the staging table into the destination table, keeping only the latest
+// row by watermark column for each upsert key in the staging table.
+func generateMergeStatement(
+	dstTable string,
+	stagingTable string,
+	allCols []string,
+	upsertKeyCols []string,
+	watermarkCol string,
+) string {
+	upsertKeys := make([]string, 0, len(upsertKeyCols))
+	partitionKeyCols := make([]string, 0, len(upsertKeyCols))
+	for _, key := range upsertKeyCols {
+		quotedKey := utils.QuoteIdentifier(key)
+		upsertKeys = append(upsertKeys, fmt.Sprintf("dst.%s = src.%s", quotedKey, quotedKey))
+		partitionKeyCols = append(partitionKeyCols, quotedKey)
+	}
+
+	hasWatermark := false
+	updateSetClauses := make([]string, 0, len(allCols))
+	insertColumnsClauses := make([]string, 0, len(allCols))
+	insertValuesClauses := make([]string, 0, len(allCols))
+	for _, column := range allCols {
+		if strings.EqualFold(column, watermarkCol) {
+			hasWatermark = true
+		}
+		quotedColumn := utils.QuoteIdentifier(column)
+		updateSetClauses = append(updateSetClauses, fmt.Sprintf("%s = src.%s", quotedColumn, quotedColumn))
+		insertColumnsClauses = append(insertColumnsClauses, quotedColumn)
+		insertValuesClauses = append(insertValuesClauses, fmt.Sprintf("src.%s", quotedColumn))
+	}
+
+	// without the watermark column among the records' columns, any row of a key may win.
+	orderBy := "(SELECT NULL)"
+	matchedClause := "WHEN MATCHED"
+	if hasWatermark {
+		quotedWMC := utils.QuoteIdentifier(watermarkCol)
+		orderBy = quotedWMC + " DESC"
+		matchedClause = fmt.Sprintf("WHEN MATCHED AND src.%s > dst.%s", quotedWMC, quotedWMC)
+	}
+
+	selectCmd := fmt.Sprintf(`
+		SELECT %s FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s) AS _peerdb_rank
+			FROM %s
+		) ranked WHERE _peerdb_rank = 1
+	`, strings.Join(insertColumnsClauses, ", "), strings.Join(partitionKeyCols, ", "), orderBy, stagingTable)
+
+	return fmt.Sprintf(`
+		MERGE INTO %s AS dst
+		USING (%s) AS src
+		ON %s
+		%s THEN UPDATE SET %s
+		WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s);
+	`, dstTable, selectCmd, strings.Join(upsertKeys, " AND "), matchedClause,
+		strings.Join(updateSetClauses, ", "), strings.Join(insertColumnsClauses, ", "),
+		strings.Join(insertValuesClauses, ", "))
+}
+
+func (c *SQLServerConnector) ConsolidateQRepPartitions(config *protos.QRepConfig) error {
+	log.Infof("Consolidating partitions for flow job %s", config.FlowJobName)
+	log.Infof("This is a no-op for SQL Server")
+	return nil
+}
+
+// CleanupQRepFlow function for sql server connector
+func (c *SQLServerConnector) CleanupQRepFlow(config *protos.QRepConfig) error {
+	log.Infof("Cleaning up QRep flow for flow job %s", config.FlowJobName)
+	log.Infof("This is a no-op for SQL Server")
+	return nil
+}
@@ -0,0 +1,83 @@
+package connsqlserver
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func normalizeSQL(query string) string {
+	return strings.TrimSpace(regexp.MustCompile(`\s+`).ReplaceAllString(query, " "))
+}
+
+func TestParseSchemaTable(t *testing.T) {
+	table, err := parseSchemaTable("finance.accounts")
+	require.NoError(t, err)
+	assert.Equal(t, `"finance"."accounts"`, table.String())
+
+	table, err = parseSchemaTable("accounts")
+	require.NoError(t, err)
+	assert.Equal(t, "dbo", table.Schema)
+
+	_, err = parseSchemaTable("db.finance.accounts")
+	assert.Error(t, err)
+}
+
+func TestGenerateMergeStatement(t *testing.T) {
+	mergeStmt := generateMergeStatement(`"dbo"."accounts"`, "#staging",
+		[]string{"id", "balance", "updated_at"}, []string{"id"}, "updated_at")
+
+	expected := `MERGE INTO "dbo"."accounts" AS dst USING (` +
+		` SELECT "id", "balance", "updated_at" FROM (` +
+		` SELECT *, ROW_NUMBER() OVER (PARTITION BY "id" ORDER BY "updated_at" DESC) AS _peerdb_rank` +
+		` FROM #staging ) ranked WHERE _peerdb_rank = 1 ) AS src ON dst."id" = src."id"` +
+		` WHEN MATCHED AND src."updated_at" > dst."updated_at"` +
+		` THEN UPDATE SET "id" = src."id", "balance" = src."balance", "updated_at" = src."updated_at"` +
+		` WHEN NOT MATCHED THEN INSERT ("id", "balance", "updated_at")` +
+		` VALUES (src."id", src."balance", src."updated_at");`
+	assert.Equal(t, expected, normalizeSQL(mergeStmt))
+
+	// without the watermark column, matched rows are always updated.
+	mergeStmt = generateMergeStatement(`"dbo"."accounts"`, "#staging",
+		[]string{"id", "balance"}, []string{"id"}, "updated_at")
+	assert.Contains(t, normalizeSQL(mergeStmt), "ORDER BY (SELECT NULL)")
+	assert.Contains(t, normalizeSQL(mergeStmt), "WHEN MATCHED THEN UPDATE SET")
+}
+
+func TestQValueToBulkValue(t *testing.T) {
+	ts := time.Date(2023, 7, 4, 10, 11, 12, 0, time.FixedZone("", -7*3600))
+
+	tests := []struct {
+		name string
+		qv   qvalue.QValue
+		want interface{}
+	}{
+		{"int16", qvalue.QValue{Kind: qvalue.QValueKindInt16, Value: int16(7)}, int64(7)},
+		{"float32", qvalue.QValue{Kind: qvalue.QValueKindFloat32, Value: float32(1.5)}, 1.5},
+		{"numeric", qvalue.QValue{Kind: qvalue.QValueKindNumeric, Value: big.NewRat(25, 2)}, "12.500000000"},
+		{"timestamptz", qvalue.QValue{Kind: qvalue.QValueKindTimestampTZ, Value: ts}, ts},
+		{"timetz", qvalue.QValue{Kind: qvalue.QValueKindTimeTZ, Value: ts}, "10:11:12-07:00"},
+		{"uuid", qvalue.QValue{Kind: qvalue.QValueKindUUID, Value: "00010203-0405-0607-0809-0a0b0c0d0e0f"},
+			[]byte{3, 2, 1, 0, 5, 4, 7, 6, 8, 9, 10, 11, 12, 13, 14, 15}},
+		{"array", qvalue.QValue{Kind: qvalue.QValueKindArrayInt32, Value: []int32{1, 2}}, "[1,2]"},
+		{"inet", qvalue.QValue{Kind: qvalue.QValueKindINET, Value: "10.0.0.1"}, "10.0.0.1"},
+		{"null", qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: nil}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := qValueToBulkValue(tt.qv)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := qValueToBulkValue(qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: "7"})
+	assert.Error(t, err)
+}
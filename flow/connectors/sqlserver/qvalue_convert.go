@@ -1,6 +1,16 @@
 package connsqlserver
 
-import "github.com/PeerDB-io/peer-flow/model/qvalue"
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/google/uuid"
+	mssql "github.com/microsoft/go-mssqldb"
+)
 
 var qValueKindToSQLServerTypeMap = map[qvalue.QValueKind]string{
 	qvalue.QValueKindBoolean:     "BIT",
@@ -22,7 +32,22 @@ var qValueKindToSQLServerTypeMap = map[qvalue.QValueKind]string{
 	qvalue.QValueKindStruct:      "NTEXT", // SQL Server doesn't support struct type
 	qvalue.QValueKindUUID:        "UNIQUEIDENTIFIER",
 	qvalue.QValueKindTimeTZ:      "NTEXT", // SQL Server doesn't have a time with timezone type
+	qvalue.QValueKindInterval:    "NTEXT",
+	qvalue.QValueKindINET:        "NTEXT",
+	qvalue.QValueKindCIDR:        "NTEXT",
+	qvalue.QValueKindMacaddr:     "NTEXT",
+	qvalue.QValueKindEnum:        "NTEXT",
+	qvalue.QValueKindHStore:      "NTEXT",
+	qvalue.QValueKindGeometry:    "NTEXT",
+	qvalue.QValueKindGeography:   "NTEXT",
 	qvalue.QValueKindInvalid:     "NTEXT",
+
+	qvalue.QValueKindArrayInt32:   "NTEXT",
+	qvalue.QValueKindArrayInt64:   "NTEXT",
+	qvalue.QValueKindArrayFloat32: "NTEXT",
+	qvalue.QValueKindArrayFloat64: "NTEXT",
+	qvalue.QValueKindArrayBoolean: "NTEXT",
+	qvalue.QValueKindArrayString:  "NTEXT",
 }
 
 var sqlServerTypeToQValueKindMap = map[string]qvalue.QValueKind{
@@ -41,3 +66,221 @@ var sqlServerTypeToQValueKindMap = map[string]qvalue.QValueKind{
 	"DECIMAL":          qvalue.QValueKindNumeric,
 	"UNIQUEIDENTIFIER": qvalue.QValueKindUUID,
 }
+
+// qValueToBulkValue converts a QValue to a value the bulk copy protocol accepts for the column type
+// the QValue's kind maps to.
+func qValueToBulkValue(qv qvalue.QValue) (interface{}, error) {
+	if qv.Value == nil {
+		return nil, nil
+	}
+
+	switch qv.Kind {
+	case qvalue.QValueKindInt16, qvalue.QValueKindInt32, qvalue.QValueKindInt64:
+		switch v := qv.Value.(type) {
+		case int16:
+			return int64(v), nil
+		case int32:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		}
+	case qvalue.QValueKindFloat32, qvalue.QValueKindFloat64:
+		switch v := qv.Value.(type) {
+		case float32:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case qvalue.QValueKindBoolean:
+		if v, ok := qv.Value.(bool); ok {
+			return v, nil
+		}
+	case qvalue.QValueKindNumeric:
+		if v, ok := qv.Value.(*big.Rat); ok {
+			// the scale of the DECIMAL(38, 9) columns created for numerics.
+			return v.FloatString(9), nil
+		}
+	case qvalue.QValueKindTimestamp, qvalue.QValueKindTimestampTZ, qvalue.QValueKindDate, qvalue.QValueKindTime:
+		if v, ok := qv.Value.(time.Time); ok {
+			return v, nil
+		}
+	case qvalue.QValueKindTimeTZ:
+		if v, ok := qv.Value.(time.Time); ok {
+			return v.Format("15:04:05.999999-07:00"), nil
+		}
+	case qvalue.QValueKindBytes, qvalue.QValueKindBit:
+		if v, ok := qv.Value.([]byte); ok {
+			return v, nil
+		}
+	case qvalue.QValueKindUUID:
+		var id uuid.UUID
+		switch v := qv.Value.(type) {
+		case [16]byte:
+			id = v
+		case string:
+			parsed, err := uuid.Parse(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid uuid value %s: %w", v, err)
+			}
+			id = parsed
+		default:
+			return nil, fmt.Errorf("invalid uuid value %v", qv.Value)
+		}
+		// UNIQUEIDENTIFIER stores the first three groups little endian.
+		return mssql.UniqueIdentifier(id).Value()
+	case qvalue.QValueKindArrayInt32, qvalue.QValueKindArrayInt64, qvalue.QValueKindArrayFloat32,
+		qvalue.QValueKindArrayFloat64, qvalue.QValueKindArrayBoolean, qvalue.QValueKindArrayString,
+		qvalue.QValueKindArray, qvalue.QValueKindStruct:
+		encoded, err := json.Marshal(qv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s value: %w", qv.Kind, err)
+		}
+		return string(encoded), nil
+	default:
+		// everything else is stored as text.
+		if v, ok := qv.Value.(string); ok {
+			return v, nil
+		}
+		return fmt.Sprint(qv.Value), nil
+	}
+
+	return nil, fmt.Errorf("invalid %s value %v", qv.Kind, qv.Value)
+}
+
+// sourceColumnKinds maps the data types of source table columns, as INFORMATION_SCHEMA names them,
+// to the kinds their changes are replicated as.
+var sourceColumnKinds = map[string]qvalue.QValueKind{
+	"bit":              qvalue.QValueKindBoolean,
+	"tinyint":          qvalue.QValueKindInt16,
+	"smallint":         qvalue.QValueKindInt16,
+	"int":              qvalue.QValueKindInt32,
+	"bigint":           qvalue.QValueKindInt64,
+	"real":             qvalue.QValueKindFloat32,
+	"float":            qvalue.QValueKindFloat64,
+	"decimal":          qvalue.QValueKindNumeric,
+	"numeric":          qvalue.QValueKindNumeric,
+	"money":            qvalue.QValueKindNumeric,
+	"smallmoney":       qvalue.QValueKindNumeric,
+	"date":             qvalue.QValueKindDate,
+	"time":             qvalue.QValueKindTime,
+	"datetime":         qvalue.QValueKindTimestamp,
+	"datetime2":        qvalue.QValueKindTimestamp,
+	"smalldatetime":    qvalue.QValueKindTimestamp,
+	"datetimeoffset":   qvalue.QValueKindTimestampTZ,
+	"char":             qvalue.QValueKindString,
+	"varchar":          qvalue.QValueKindString,
+	"nchar":            qvalue.QValueKindString,
+	"nvarchar":         qvalue.QValueKindString,
+	"text":             qvalue.QValueKindString,
+	"ntext":            qvalue.QValueKindString,
+	"xml":              qvalue.QValueKindString,
+	"sysname":          qvalue.QValueKindString,
+	"binary":           qvalue.QValueKindBytes,
+	"varbinary":        qvalue.QValueKindBytes,
+	"image":            qvalue.QValueKindBytes,
+	"timestamp":        qvalue.QValueKindBytes,
+	"rowversion":       qvalue.QValueKindBytes,
+	"uniqueidentifier": qvalue.QValueKindUUID,
+}
+
+// sourceColumnKind returns the kind the changes of a source column are replicated as.
+func sourceColumnKind(dataType string) (qvalue.QValueKind, error) {
+	kind, ok := sourceColumnKinds[strings.ToLower(dataType)]
+	if !ok {
+		return qvalue.QValueKindInvalid, fmt.Errorf("unsupported sql server data type %s", dataType)
+	}
+	return kind, nil
+}
+
+// changeValueToQValue converts a value read from a change table, as the driver scans it, to a QValue
+// of the kind of its source column.
+func changeValueToQValue(kind qvalue.QValueKind, val interface{}) (qvalue.QValue, error) {
+	if val == nil {
+		return qvalue.QValue{Kind: kind, Value: nil}, nil
+	}
+
+	switch kind {
+	case qvalue.QValueKindBoolean:
+		if v, ok := val.(bool); ok {
+			return qvalue.QValue{Kind: kind, Value: v}, nil
+		}
+	case qvalue.QValueKindInt16, qvalue.QValueKindInt32, qvalue.QValueKindInt64:
+		var n int64
+		switch v := val.(type) {
+		case int64:
+			n = v
+		case int32:
+			n = int64(v)
+		case int16:
+			n = int64(v)
+		case uint8:
+			n = int64(v)
+		default:
+			return qvalue.QValue{}, fmt.Errorf("invalid %s value %v", kind, val)
+		}
+		switch kind {
+		case qvalue.QValueKindInt16:
+			return qvalue.QValue{Kind: kind, Value: int16(n)}, nil
+		case qvalue.QValueKindInt32:
+			return qvalue.QValue{Kind: kind, Value: int32(n)}, nil
+		default:
+			return qvalue.QValue{Kind: kind, Value: n}, nil
+		}
+	case qvalue.QValueKindFloat32:
+		switch v := val.(type) {
+		case float32:
+			return qvalue.QValue{Kind: kind, Value: v}, nil
+		case float64:
+			return qvalue.QValue{Kind: kind, Value: float32(v)}, nil
+		}
+	case qvalue.QValueKindFloat64:
+		switch v := val.(type) {
+		case float64:
+			return qvalue.QValue{Kind: kind, Value: v}, nil
+		case float32:
+			return qvalue.QValue{Kind: kind, Value: float64(v)}, nil
+		}
+	case qvalue.QValueKindNumeric:
+		// decimals and money come as their text representation.
+		var s string
+		switch v := val.(type) {
+		case []byte:
+			s = string(v)
+		case string:
+			s = v
+		case float64:
+			return qvalue.QValue{Kind: kind, Value: new(big.Rat).SetFloat64(v)}, nil
+		default:
+			return qvalue.QValue{}, fmt.Errorf("invalid %s value %v", kind, val)
+		}
+		numeric, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return qvalue.QValue{}, fmt.Errorf("failed to parse numeric %s", s)
+		}
+		return qvalue.QValue{Kind: kind, Value: numeric}, nil
+	case qvalue.QValueKindDate, qvalue.QValueKindTime, qvalue.QValueKindTimestamp, qvalue.QValueKindTimestampTZ:
+		if v, ok := val.(time.Time); ok {
+			return qvalue.QValue{Kind: kind, Value: v}, nil
+		}
+	case qvalue.QValueKindString:
+		switch v := val.(type) {
+		case string:
+			return qvalue.QValue{Kind: kind, Value: v}, nil
+		case []byte:
+			return qvalue.QValue{Kind: kind, Value: string(v)}, nil
+		}
+	case qvalue.QValueKindBytes:
+		if v, ok := val.([]byte); ok {
+			return qvalue.QValue{Kind: kind, Value: v}, nil
+		}
+	case qvalue.QValueKindUUID:
+		// UNIQUEIDENTIFIER stores the first three groups little endian.
+		var id mssql.UniqueIdentifier
+		if err := id.Scan(val); err != nil {
+			return qvalue.QValue{}, fmt.Errorf("invalid uuid value %v: %w", val, err)
+		}
+		return qvalue.QValue{Kind: kind, Value: strings.ToLower(id.String())}, nil
+	}
+
+	return qvalue.QValue{}, fmt.Errorf("invalid %s value %v", kind, val)
+}
@@ -3,21 +3,28 @@ package connsqlserver
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	connmetadata "github.com/PeerDB-io/peer-flow/connectors/external_metadata"
 	peersql "github.com/PeerDB-io/peer-flow/connectors/sql"
+	"github.com/PeerDB-io/peer-flow/connectors/utils"
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/microsoft/go-mssqldb"
 	log "github.com/sirupsen/logrus"
 )
 
+// SQLServerConnector copies SQL Server tables with query replication in both directions, and
+// streams the changes of source tables from their change data capture tables.
 type SQLServerConnector struct {
 	peersql.GenericSQLQueryExecutor
 
-	ctx    context.Context
-	config *protos.SqlServerConfig
-	db     *sqlx.DB
+	ctx        context.Context
+	config     *protos.SqlServerConfig
+	db         *sqlx.DB
+	pgMetadata *connmetadata.PostgresMetadataStore
 }
 
 // NewSQLServerConnector creates a new SQL Server connection
@@ -32,9 +39,21 @@ func NewSQLServerConnector(ctx context.Context, config *protos.SqlServerConfig)
 
 	err = db.PingContext(ctx)
 	if err != nil {
+		db.Close()
 		return nil, err
 	}
 
+	// the metadata database is only needed to replicate changes.
+	var pgMetadata *connmetadata.PostgresMetadataStore
+	if config.MetadataDb != nil {
+		pgMetadata, err = connmetadata.NewPostgresMetadataStore(ctx, config.MetadataDb, metadataSchema)
+		if err != nil {
+			log.Errorf("failed to create postgres metadata store: %v", err)
+			db.Close()
+			return nil, err
+		}
+	}
+
 	genericExecutor := *peersql.NewGenericSQLQueryExecutor(
 		ctx, db, sqlServerTypeToQValueKindMap, qValueKindToSQLServerTypeMap)
 
@@ -43,11 +62,15 @@ func NewSQLServerConnector(ctx context.Context, config *protos.SqlServerConfig)
 		ctx:                     ctx,
 		config:                  config,
 		db:                      db,
+		pgMetadata:              pgMetadata,
 	}, nil
 }
 
 // Close closes the database connection
 func (c *SQLServerConnector) Close() error {
+	if c.pgMetadata != nil {
+		c.pgMetadata.Close()
+	}
 	if c.db != nil {
 		return c.db.Close()
 	}
@@ -62,29 +85,125 @@ func (c *SQLServerConnector) ConnectionActive() bool {
 	return true
 }
 
-func (c *SQLServerConnector) NeedsSetupMetadataTables() bool {
-	log.Errorf("NeedsSetupMetadataTables not supported for SQLServer")
-	return false
+// QuoteIdentifier quotes an identifier with brackets, escaping the closing brackets it contains.
+func QuoteIdentifier(identifier string) string {
+	return "[" + strings.ReplaceAll(identifier, "]", "]]") + "]"
 }
 
-func (c *SQLServerConnector) SetupMetadataTables() error {
-	log.Errorf("SetupMetadataTables not supported for SQLServer")
-	return fmt.Errorf("cdc based replication is not currently supported for SQLServer target")
+// sqlServerColumn is a column of a source table.
+type sqlServerColumn struct {
+	name string
+	kind qvalue.QValueKind
 }
 
-func (c *SQLServerConnector) GetLastOffset(jobName string) (*protos.LastSyncState, error) {
-	log.Errorf("GetLastOffset not supported for SQLServer")
-	return nil, fmt.Errorf("cdc based replication is not currently supported for SQLServer target")
+// getTableColumns returns the columns of a table in their order in the table.
+func (c *SQLServerConnector) getTableColumns(table *SchemaTable) ([]*sqlServerColumn, error) {
+	rows, err := c.db.QueryContext(c.ctx, `
+		SELECT COLUMN_NAME, DATA_TYPE FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = @p1 AND TABLE_NAME = @p2
+		ORDER BY ORDINAL_POSITION`, table.Schema, table.Table)
+	if err != nil {
+		return nil, fmt.Errorf("error getting columns of table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var columns []*sqlServerColumn
+	for rows.Next() {
+		var name, dataType string
+		if err := rows.Scan(&name, &dataType); err != nil {
+			return nil, fmt.Errorf("error scanning columns of table %s: %w", table, err)
+		}
+		kind, err := sourceColumnKind(dataType)
+		if err != nil {
+			return nil, fmt.Errorf("column %s of table %s: %w", name, table, err)
+		}
+		columns = append(columns, &sqlServerColumn{name: name, kind: kind})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over columns of table %s: %w", table, err)
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s does not exist", table)
+	}
+	return columns, nil
 }
 
-func (c *SQLServerConnector) GetLastNormalizeBatchID() (int64, error) {
-	log.Errorf("GetLastNormalizeBatchID not supported for SQLServer")
-	return 0, fmt.Errorf("cdc based replication is not currently supported for SQLServer target")
+func (c *SQLServerConnector) getPrimaryKeyColumns(table *SchemaTable) ([]string, error) {
+	rows, err := c.db.QueryContext(c.ctx, `
+		SELECT kcu.COLUMN_NAME
+		FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+		JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+			ON kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		WHERE tc.TABLE_SCHEMA = @p1 AND tc.TABLE_NAME = @p2 AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+		ORDER BY kcu.ORDINAL_POSITION`, table.Schema, table.Table)
+	if err != nil {
+		return nil, fmt.Errorf("error getting primary key columns of table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var pkeyCols []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("error scanning primary key columns of table %s: %w", table, err)
+		}
+		pkeyCols = append(pkeyCols, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over primary key columns of table %s: %w", table, err)
+	}
+	return pkeyCols, nil
 }
 
+// GetTableSchema returns the schema of a table. Change tables carry the full row image, so rows of
+// tables without a primary key are matched on all of their columns.
 func (c *SQLServerConnector) GetTableSchema(req *protos.GetTableSchemaInput) (*protos.TableSchema, error) {
-	log.Errorf("GetTableSchema not supported for SQLServer flow connector")
-	return nil, fmt.Errorf("cdc based replication is not currently supported for SQLServer target")
+	table, err := parseSchemaTable(req.TableIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := c.getTableColumns(table)
+	if err != nil {
+		return nil, err
+	}
+
+	pkeyCols, err := c.getPrimaryKeyColumns(table)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &protos.TableSchema{
+		TableIdentifier:   req.TableIdentifier,
+		Columns:           make(map[string]string),
+		PrimaryKeyColumns: pkeyCols,
+	}
+	if len(pkeyCols) == 0 {
+		log.Infof("table %s has no primary key, rows will be matched on all columns", table)
+		res.ReplicaIdentity = protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL
+	}
+
+	for _, col := range columns {
+		if !utils.IsColumnReplicated(req.ColumnFilter, col.name) {
+			continue
+		}
+		res.Columns[col.name] = string(col.kind)
+	}
+
+	// rows can't be matched on the destination without their key columns.
+	for _, pkeyCol := range pkeyCols {
+		if !utils.IsColumnReplicated(req.ColumnFilter, pkeyCol) {
+			return nil, fmt.Errorf("key column %s of table %s can't be filtered out", pkeyCol, table)
+		}
+	}
+
+	return res, nil
+}
+
+func (c *SQLServerConnector) GetLastNormalizeBatchID() (int64, error) {
+	log.Errorf("GetLastNormalizeBatchID not supported for SQLServer")
+	return 0, fmt.Errorf("cdc based replication is not currently supported for SQLServer target")
 }
 
 func (c *SQLServerConnector) SetupNormalizedTable(
@@ -98,11 +217,6 @@ func (c *SQLServerConnector) InitializeTableSchema(req map[string]*protos.TableS
 	return fmt.Errorf("cdc based replication is not currently supported for SQLServer target")
 }
 
-func (c *SQLServerConnector) PullRecords(req *model.PullRecordsRequest) (*model.RecordBatch, error) {
-	log.Errorf("panicking at call to PullRecords for SQLServer flow connector")
-	panic("PullRecords is not implemented for the SQLServer flow connector")
-}
-
 func (c *SQLServerConnector) SyncRecords(req *model.SyncRecordsRequest) (*model.SyncResponse, error) {
 	log.Errorf("SyncRecords not supported for SQLServer")
 	return nil, fmt.Errorf("cdc based replication is not currently supported for SQLServer target")
@@ -118,20 +232,14 @@ func (c *SQLServerConnector) CreateRawTable(req *protos.CreateRawTableInput) (*p
 	return nil, fmt.Errorf("cdc based replication is not currently supported for SQLServer target")
 }
 
-func (c *SQLServerConnector) EnsurePullability(req *protos.EnsurePullabilityInput,
-) (*protos.EnsurePullabilityOutput, error) {
-	log.Errorf("panicking at call to EnsurePullability for SQLServer flow connector")
-	panic("EnsurePullability is not implemented for the SQLServer flow connector")
-}
-
-func (c *SQLServerConnector) SetupReplication(req *protos.SetupReplicationInput) error {
-	log.Errorf("panicking at call to SetupReplication for SQLServer flow connector")
-	panic("SetupReplication is not implemented for the SQLServer flow connector")
+func (c *SQLServerConnector) ReplayTableSchemaDelta(flowJobName string, schemaDelta *protos.TableSchemaDelta) error {
+	log.Errorf("ReplayTableSchemaDelta not supported for SQLServer")
+	return fmt.Errorf("cdc based replication is not currently supported for SQLServer target")
 }
 
-func (c *SQLServerConnector) PullFlowCleanup(jobName string) error {
-	log.Errorf("panicking at call to PullFlowCleanup for SQLServer flow connector")
-	panic("PullFlowCleanup is not implemented for the SQLServer flow connector")
+func (c *SQLServerConnector) TruncateTables(tableIdentifiers []string) error {
+	log.Errorf("TruncateTables not supported for SQLServer")
+	return fmt.Errorf("cdc based replication is not currently supported for SQLServer target")
 }
 
 func (c *SQLServerConnector) SyncFlowCleanup(jobName string) error {
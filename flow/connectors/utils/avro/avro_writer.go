@@ -1,16 +1,15 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 
-	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	stage "github.com/PeerDB-io/peer-flow/connectors/utils/stage"
 	"github.com/PeerDB-io/peer-flow/model"
 	"github.com/PeerDB-io/peer-flow/model/qvalue"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/linkedin/goavro/v2"
 	log "github.com/sirupsen/logrus"
 )
@@ -27,13 +26,19 @@ func createOCFWriter(w io.Writer, avroSchema *model.QRecordAvroSchemaDefinition)
 	return ocfWriter, nil
 }
 
+// writeRecordsToOCFWriter writes the records of a stream as they're read, returning the number written.
 func writeRecordsToOCFWriter(
 	ocfWriter *goavro.OCFWriter,
-	records *model.QRecordBatch,
-	avroSchema *model.QRecordAvroSchemaDefinition) error {
-	colNames := records.Schema.GetColumnNames()
+	stream *model.QRecordStream,
+	avroSchema *model.QRecordAvroSchemaDefinition) (int, error) {
+	schema, err := stream.Schema()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema from stream: %w", err)
+	}
+	colNames := schema.GetColumnNames()
 
-	for _, qRecord := range records.Records {
+	numRecords := 0
+	for qRecord := range stream.Records() {
 		avroConverter := model.NewQRecordAvroConverter(
 			qRecord,
 			qvalue.QDWHTypeSnowflake,
@@ -43,85 +48,77 @@ func writeRecordsToOCFWriter(
 		avroMap, err := avroConverter.Convert()
 		if err != nil {
 			log.Errorf("failed to convert QRecord to Avro compatible map: %v", err)
-			return fmt.Errorf("failed to convert QRecord to Avro compatible map: %w", err)
+			return numRecords, fmt.Errorf("failed to convert QRecord to Avro compatible map: %w", err)
 		}
 
 		err = ocfWriter.Append([]interface{}{avroMap})
 		if err != nil {
 			log.Errorf("failed to write record to OCF: %v", err)
-			return fmt.Errorf("failed to write record to OCF: %w", err)
+			return numRecords, fmt.Errorf("failed to write record to OCF: %w", err)
 		}
+		numRecords++
 	}
 
-	return nil
+	if err := stream.Err(); err != nil {
+		return numRecords, fmt.Errorf("failed to pull records: %w", err)
+	}
+	return numRecords, nil
 }
 
-func WriteRecordsToS3(
-	records *model.QRecordBatch,
+// WriteRecordsToStage writes the records of a stream as an Avro file to a stage while they're pulled,
+// returning the number of records written.
+func WriteRecordsToStage(
+	ctx context.Context,
+	stream *model.QRecordStream,
 	avroSchema *model.QRecordAvroSchemaDefinition,
-	bucketName, key string) error {
-	r, w := io.Pipe()
-
-	go func() {
-		defer w.Close()
-
+	stg stage.Stage,
+	key string) (int, error) {
+	numRecords := 0
+	err := stage.PutStream(ctx, stg, key, func(w io.Writer) error {
 		ocfWriter, err := createOCFWriter(w, avroSchema)
 		if err != nil {
-			log.Fatalf("failed to create OCF writer: %v", err)
+			return err
 		}
 
-		if err := writeRecordsToOCFWriter(ocfWriter, records, avroSchema); err != nil {
-			log.Fatalf("failed to write records to OCF writer: %v", err)
+		numRecords, err = writeRecordsToOCFWriter(ocfWriter, stream, avroSchema)
+		if err != nil {
+			return fmt.Errorf("failed to write records to OCF writer: %w", err)
 		}
-	}()
-
-	s3svc, err := utils.CreateS3Client()
-	if err != nil {
-		log.Errorf("failed to create S3 client: %v", err)
-		return fmt.Errorf("failed to create S3 client: %w", err)
-	}
-
-	// Create an uploader with the session and default options
-	uploader := s3manager.NewUploaderWithClient(s3svc)
-
-	// Upload the file to S3.
-	result, err := uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(bucketName),
-		Key:    aws.String(key),
-		Body:   r,
+		return nil
 	})
-
 	if err != nil {
-		log.Errorf("failed to upload file: %v", err)
-		return fmt.Errorf("failed to upload file: %w", err)
+		log.Errorf("failed to write Avro file %s to stage %s: %v", key, stg.URL(), err)
+		return 0, err
 	}
 
-	log.Infof("file uploaded to, %s", result.Location)
-
-	return nil
+	log.Infof("file written to %s/%s", stg.URL(), key)
+	return numRecords, nil
 }
 
+// WriteRecordsToAvroFile writes the records of a stream to a local Avro file,
+// returning the number of records written.
 func WriteRecordsToAvroFile(
-	records *model.QRecordBatch,
+	stream *model.QRecordStream,
 	avroSchema *model.QRecordAvroSchemaDefinition,
-	filePath string) error {
+	filePath string) (int, error) {
 	file, err := os.Create(filePath)
 	if err != nil {
 		log.Errorf("failed to create file: %v", err)
-		return fmt.Errorf("failed to create file: %w", err)
+		return 0, fmt.Errorf("failed to create file: %w", err)
 	}
 	defer file.Close()
 
 	ocfWriter, err := createOCFWriter(file, avroSchema)
 	if err != nil {
 		log.Errorf("failed to create OCF writer: %v", err)
-		return err
+		return 0, err
 	}
 
-	if err := writeRecordsToOCFWriter(ocfWriter, records, avroSchema); err != nil {
+	numRecords, err := writeRecordsToOCFWriter(ocfWriter, stream, avroSchema)
+	if err != nil {
 		log.Errorf("failed to write records to OCF writer: %v", err)
-		return err
+		return 0, err
 	}
 
-	return nil
+	return numRecords, nil
 }
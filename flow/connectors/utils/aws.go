@@ -15,6 +15,8 @@ type AWSSecrets struct {
 	SecretAccessKey string
 	AwsRoleArn      string
 	Region          string
+	// Endpoint is set for S3 compatible stores like MinIO, requests then use path-style addressing.
+	Endpoint string
 }
 
 func GetAWSSecrets() (*AWSSecrets, error) {
@@ -37,6 +39,7 @@ func GetAWSSecrets() (*AWSSecrets, error) {
 		SecretAccessKey: awsSecret,
 		AwsRoleArn:      awsRoleArn,
 		Region:          awsRegion,
+		Endpoint:        os.Getenv("AWS_ENDPOINT_URL_S3"),
 	}, nil
 }
 
@@ -72,9 +75,14 @@ func CreateS3Client() (*s3.S3, error) {
 		return nil, fmt.Errorf("failed to get AWS secrets: %w", err)
 	}
 
-	sess := session.Must(session.NewSession(&aws.Config{
+	config := &aws.Config{
 		Region: aws.String(awsSecrets.Region),
-	}))
+	}
+	if awsSecrets.Endpoint != "" {
+		config.Endpoint = aws.String(awsSecrets.Endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess := session.Must(session.NewSession(config))
 
 	s3svc := s3.New(sess)
 	return s3svc, nil
@@ -0,0 +1,27 @@
+package utils
+
+import "github.com/PeerDB-io/peer-flow/generated/protos"
+
+// IsColumnReplicated returns true if the column passes the column filter of its table.
+// Every column is replicated if the filter is nil.
+func IsColumnReplicated(filter *protos.ColumnFilter, column string) bool {
+	if filter == nil {
+		return true
+	}
+
+	for _, excluded := range filter.ExcludeColumns {
+		if excluded == column {
+			return false
+		}
+	}
+
+	if len(filter.IncludeColumns) == 0 {
+		return true
+	}
+	for _, included := range filter.IncludeColumns {
+		if included == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsColumnReplicated(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filter   *protos.ColumnFilter
+		column   string
+		expected bool
+	}{
+		{"no filter", nil, "email", true},
+		{"empty filter", &protos.ColumnFilter{}, "email", true},
+		{"excluded", &protos.ColumnFilter{ExcludeColumns: []string{"email"}}, "email", false},
+		{"not excluded", &protos.ColumnFilter{ExcludeColumns: []string{"email"}}, "name", true},
+		{"included", &protos.ColumnFilter{IncludeColumns: []string{"id", "name"}}, "name", true},
+		{"not included", &protos.ColumnFilter{IncludeColumns: []string{"id", "name"}}, "email", false},
+		{"names are case sensitive", &protos.ColumnFilter{IncludeColumns: []string{"Name"}}, "name", false},
+		{
+			"excluded wins over included",
+			&protos.ColumnFilter{IncludeColumns: []string{"id", "email"}, ExcludeColumns: []string{"email"}},
+			"email",
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsColumnReplicated(tc.filter, tc.column))
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/google/uuid"
+)
+
+const (
+	// Debezium operation codes.
+	opCreate   = "c"
+	opUpdate   = "u"
+	opDelete   = "d"
+	opTruncate = "t"
+
+	// value Debezium sends for unchanged toast columns.
+	unavailableValuePlaceholder = "__debezium_unavailable_value"
+
+	secondsPerDay = 24 * 60 * 60
+	// scale of values that have no exact decimal form, the largest scale of the destinations' decimals.
+	maxDecimalScale = 38
+)
+
+// schemaField is a Kafka Connect schema, as written by Debezium's JSON converter with schemas enabled.
+type schemaField struct {
+	Type     string         `json:"type"`
+	Optional bool           `json:"optional"`
+	Name     string         `json:"name,omitempty"`
+	Version  int            `json:"version,omitempty"`
+	Field    string         `json:"field,omitempty"`
+	Fields   []*schemaField `json:"fields,omitempty"`
+	Items    *schemaField   `json:"items,omitempty"`
+}
+
+type envelope struct {
+	Schema  *schemaField    `json:"schema"`
+	Payload envelopePayload `json:"payload"`
+}
+
+type envelopePayload struct {
+	Before map[string]interface{} `json:"before"`
+	After  map[string]interface{} `json:"after"`
+	Source envelopeSource         `json:"source"`
+	Op     string                 `json:"op"`
+	TsMs   int64                  `json:"ts_ms"`
+}
+
+type envelopeSource struct {
+	Version   string `json:"version"`
+	Connector string `json:"connector"`
+	Name      string `json:"name"`
+	TsMs      int64  `json:"ts_ms"`
+	Snapshot  string `json:"snapshot"`
+	Schema    string `json:"schema"`
+	Table     string `json:"table"`
+	Lsn       int64  `json:"lsn"`
+}
+
+// EncodeEnvelope returns the change event of a record in the layout of Debezium's Postgres
+// connector, so consumers written against Debezium can read the changes of streaming destinations.
+// serverName takes the place of Debezium's logical server name. The columns of the event are those
+// of the destination table's schema, which may be nil, and any other columns the record carries.
+func EncodeEnvelope(serverName string, record model.Record, tableSchema *protos.TableSchema) ([]byte, error) {
+	var op, sourceTableName string
+	var commitTime time.Time
+	var before, after model.RecordItems
+	var unchangedToastColumns map[string]bool
+	switch r := record.(type) {
+	case *model.InsertRecord:
+		op, sourceTableName, commitTime = opCreate, r.SourceTableName, r.CommitTime
+		after, unchangedToastColumns = r.Items, r.UnchangedToastColumns
+	case *model.UpdateRecord:
+		op, sourceTableName, commitTime = opUpdate, r.SourceTableName, r.CommitTime
+		after, unchangedToastColumns = r.NewItems, r.UnchangedToastColumns
+		// the old row is only sent if the key changed or with REPLICA IDENTITY FULL.
+		if len(r.OldItems) > 0 {
+			before = r.OldItems
+		}
+	case *model.DeleteRecord:
+		op, sourceTableName, commitTime = opDelete, r.SourceTableName, r.CommitTime
+		before = r.Items
+	case *model.TruncateRecord:
+		op, sourceTableName, commitTime = opTruncate, r.SourceTableName, r.CommitTime
+	default:
+		return nil, fmt.Errorf("unsupported record type %T", record)
+	}
+
+	columns := envelopeColumns(tableSchema, before, after)
+	schemaName, tableName := splitTableName(sourceTableName)
+	now := time.Now()
+	if commitTime.IsZero() {
+		commitTime = now
+	}
+
+	env := envelope{
+		Schema: envelopeSchema(serverName, schemaName, tableName, tableSchema, columns),
+		Payload: envelopePayload{
+			Source: envelopeSource{
+				Version:   "peerdb",
+				Connector: "postgresql",
+				Name:      serverName,
+				TsMs:      commitTime.UnixMilli(),
+				Snapshot:  "false",
+				Schema:    schemaName,
+				Table:     tableName,
+				Lsn:       record.GetCheckPointID(),
+			},
+			Op:   op,
+			TsMs: now.UnixMilli(),
+		},
+	}
+	var err error
+	if env.Payload.Before, err = envelopeRow(before); err != nil {
+		return nil, fmt.Errorf("failed to convert old row of %s: %w", sourceTableName, err)
+	}
+	if env.Payload.After, err = envelopeRow(after); err != nil {
+		return nil, fmt.Errorf("failed to convert new row of %s: %w", sourceTableName, err)
+	}
+	// unchanged toast columns are not in the row, Debezium fills them with a placeholder.
+	for col := range unchangedToastColumns {
+		if isStringKind(columns[col]) {
+			env.Payload.After[col] = unavailableValuePlaceholder
+		}
+	}
+
+	return json.Marshal(env)
+}
+
+// envelopeColumns returns the kinds of the columns of an event, the columns of the table schema
+// followed by the columns of the rows the schema doesn't know of yet.
+func envelopeColumns(
+	tableSchema *protos.TableSchema,
+	rows ...model.RecordItems,
+) map[string]qvalue.QValueKind {
+	columns := make(map[string]qvalue.QValueKind)
+	if tableSchema != nil {
+		for col, kind := range tableSchema.Columns {
+			columns[col] = qvalue.QValueKind(kind)
+		}
+	}
+	for _, row := range rows {
+		for col, val := range row {
+			if _, ok := columns[col]; !ok {
+				columns[col] = val.Kind
+			}
+		}
+	}
+	return columns
+}
+
+func envelopeSchema(
+	serverName string,
+	schemaName string,
+	tableName string,
+	tableSchema *protos.TableSchema,
+	columns map[string]qvalue.QValueKind,
+) *schemaField {
+	keyColumns := make(map[string]bool)
+	if tableSchema != nil {
+		for _, col := range tableSchema.PrimaryKeyColumns {
+			keyColumns[col] = true
+		}
+	}
+
+	names := make([]string, 0, len(columns))
+	for col := range columns {
+		names = append(names, col)
+	}
+	sort.Strings(names)
+
+	valueFields := make([]*schemaField, 0, len(names))
+	for _, col := range names {
+		field := kindSchema(columns[col])
+		field.Field = col
+		field.Optional = !keyColumns[col]
+		valueFields = append(valueFields, field)
+	}
+
+	prefix := strings.Join([]string{serverName, schemaName, tableName}, ".")
+	valueSchema := func(field string) *schemaField {
+		return &schemaField{
+			Type:     "struct",
+			Optional: true,
+			Name:     prefix + ".Value",
+			Field:    field,
+			Fields:   valueFields,
+		}
+	}
+
+	return &schemaField{
+		Type: "struct",
+		Name: prefix + ".Envelope",
+		Fields: []*schemaField{
+			valueSchema("before"),
+			valueSchema("after"),
+			{
+				Type:  "struct",
+				Name:  "io.debezium.connector.postgresql.Source",
+				Field: "source",
+				Fields: []*schemaField{
+					{Type: "string", Field: "version"},
+					{Type: "string", Field: "connector"},
+					{Type: "string", Field: "name"},
+					{Type: "int64", Field: "ts_ms"},
+					{Type: "string", Optional: true, Field: "snapshot"},
+					{Type: "string", Field: "schema"},
+					{Type: "string", Field: "table"},
+					{Type: "int64", Optional: true, Field: "lsn"},
+				},
+			},
+			{Type: "string", Field: "op"},
+			{Type: "int64", Optional: true, Field: "ts_ms"},
+		},
+	}
+}
+
+// kindSchema returns the schema Debezium uses for a column kind. Numerics are strings, as with
+// decimal.handling.mode=string, and intervals are strings, as with interval.handling.mode=string.
+func kindSchema(kind qvalue.QValueKind) *schemaField {
+	if kind.IsArray() {
+		return &schemaField{Type: "array", Items: kindSchema(kind.ArrayElementKind())}
+	}
+
+	switch kind {
+	case qvalue.QValueKindInt16:
+		return &schemaField{Type: "int16"}
+	case qvalue.QValueKindInt32:
+		return &schemaField{Type: "int32"}
+	case qvalue.QValueKindInt64:
+		return &schemaField{Type: "int64"}
+	case qvalue.QValueKindFloat32:
+		return &schemaField{Type: "float"}
+	case qvalue.QValueKindFloat64:
+		return &schemaField{Type: "double"}
+	case qvalue.QValueKindBoolean:
+		return &schemaField{Type: "boolean"}
+	case qvalue.QValueKindBytes, qvalue.QValueKindBit:
+		return &schemaField{Type: "bytes"}
+	case qvalue.QValueKindTimestamp:
+		return &schemaField{Type: "int64", Name: "io.debezium.time.MicroTimestamp", Version: 1}
+	case qvalue.QValueKindTimestampTZ:
+		return &schemaField{Type: "string", Name: "io.debezium.time.ZonedTimestamp", Version: 1}
+	case qvalue.QValueKindDate:
+		return &schemaField{Type: "int32", Name: "io.debezium.time.Date", Version: 1}
+	case qvalue.QValueKindTime:
+		return &schemaField{Type: "int64", Name: "io.debezium.time.MicroTime", Version: 1}
+	case qvalue.QValueKindTimeTZ:
+		return &schemaField{Type: "string", Name: "io.debezium.time.ZonedTime", Version: 1}
+	case qvalue.QValueKindUUID:
+		return &schemaField{Type: "string", Name: "io.debezium.data.Uuid", Version: 1}
+	case qvalue.QValueKindJSON, qvalue.QValueKindHStore:
+		return &schemaField{Type: "string", Name: "io.debezium.data.Json", Version: 1}
+	default:
+		return &schemaField{Type: "string"}
+	}
+}
+
+func isStringKind(kind qvalue.QValueKind) bool {
+	return kindSchema(kind).Type == "string"
+}
+
+// envelopeRow converts a row to the values of an event, nil rows stay nil.
+func envelopeRow(items model.RecordItems) (map[string]interface{}, error) {
+	if items == nil {
+		return nil, nil
+	}
+
+	row := make(map[string]interface{}, len(items))
+	for col, val := range items {
+		converted, err := envelopeValue(val)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", col, err)
+		}
+		row[col] = converted
+	}
+	return row, nil
+}
+
+// envelopeValue converts a value to its representation in the schema of its kind.
+func envelopeValue(val qvalue.QValue) (interface{}, error) {
+	if val.Value == nil {
+		return nil, nil
+	}
+
+	switch val.Kind {
+	case qvalue.QValueKindTimestamp, qvalue.QValueKindTimestampTZ, qvalue.QValueKindDate,
+		qvalue.QValueKindTime, qvalue.QValueKindTimeTZ:
+		t, ok := val.Value.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("invalid time value %v", val.Value)
+		}
+		switch val.Kind {
+		case qvalue.QValueKindTimestamp:
+			return t.UnixMicro(), nil
+		case qvalue.QValueKindTimestampTZ:
+			return t.UTC().Format("2006-01-02T15:04:05.999999Z"), nil
+		case qvalue.QValueKindDate:
+			days := t.Unix() / secondsPerDay
+			if t.Unix()%secondsPerDay < 0 {
+				days--
+			}
+			return int32(days), nil
+		case qvalue.QValueKindTime:
+			midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+			return t.Sub(midnight).Microseconds(), nil
+		default:
+			return t.UTC().Format("15:04:05.999999Z"), nil
+		}
+	case qvalue.QValueKindNumeric:
+		num, ok := val.Value.(*big.Rat)
+		if !ok {
+			return nil, fmt.Errorf("invalid numeric value %v", val.Value)
+		}
+		return decimalString(num), nil
+	case qvalue.QValueKindUUID:
+		switch u := val.Value.(type) {
+		case [16]byte:
+			return uuid.UUID(u).String(), nil
+		case uuid.UUID:
+			return u.String(), nil
+		default:
+			return fmt.Sprint(val.Value), nil
+		}
+	default:
+		if isStringKind(val.Kind) {
+			if s, ok := val.Value.(string); ok {
+				return s, nil
+			}
+			return fmt.Sprint(val.Value), nil
+		}
+		return val.Value, nil
+	}
+}
+
+// splitTableName splits a schema qualified table name, tables without a schema are in public.
+func splitTableName(tableName string) (string, string) {
+	if schemaName, table, ok := strings.Cut(tableName, "."); ok {
+		return schemaName, table
+	}
+	return "public", tableName
+}
+
+// decimalString returns the exact decimal form of a numeric. A numeric has a finite decimal expansion,
+// with as many digits after the point as it takes for a power of ten to be a multiple of its denominator.
+func decimalString(num *big.Rat) string {
+	denom := new(big.Int).Set(num.Denom())
+	twos := denom.TrailingZeroBits()
+	denom.Rsh(denom, twos)
+
+	fives := uint(0)
+	five := big.NewInt(5)
+	quo, rem := new(big.Int), new(big.Int)
+	for {
+		quo.QuoRem(denom, five, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		denom.Set(quo)
+		fives++
+	}
+
+	// values that aren't numerics, like 1/3, are rounded.
+	if !denom.IsInt64() || denom.Int64() != 1 {
+		return num.FloatString(maxDecimalScale)
+	}
+	if twos > fives {
+		return num.FloatString(int(twos))
+	}
+	return num.FloatString(int(fives))
+}
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTableSchema = &protos.TableSchema{
+	TableIdentifier: "public.users",
+	Columns: map[string]string{
+		"id":         string(qvalue.QValueKindInt64),
+		"name":       string(qvalue.QValueKindString),
+		"bio":        string(qvalue.QValueKindString),
+		"born_on":    string(qvalue.QValueKindDate),
+		"created_at": string(qvalue.QValueKindTimestamp),
+	},
+	PrimaryKeyColumns: []string{"id"},
+}
+
+type testEvent struct {
+	Schema struct {
+		Name   string `json:"name"`
+		Fields []struct {
+			Field  string `json:"field"`
+			Fields []struct {
+				Field    string `json:"field"`
+				Type     string `json:"type"`
+				Name     string `json:"name"`
+				Optional bool   `json:"optional"`
+			} `json:"fields"`
+		} `json:"fields"`
+	} `json:"schema"`
+	Payload struct {
+		Before map[string]interface{} `json:"before"`
+		After  map[string]interface{} `json:"after"`
+		Source struct {
+			Name   string `json:"name"`
+			TsMs   int64  `json:"ts_ms"`
+			Schema string `json:"schema"`
+			Table  string `json:"table"`
+			Lsn    int64  `json:"lsn"`
+		} `json:"source"`
+		Op string `json:"op"`
+	} `json:"payload"`
+}
+
+func decodeEnvelope(t *testing.T, record model.Record) *testEvent {
+	encoded, err := EncodeEnvelope("test_flow", record, testTableSchema)
+	require.NoError(t, err)
+
+	event := &testEvent{}
+	require.NoError(t, json.Unmarshal(encoded, event))
+	return event
+}
+
+func TestEncodeEnvelopeUpdate(t *testing.T) {
+	commitTime := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	event := decodeEnvelope(t, &model.UpdateRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+		CheckPointID:         42,
+		CommitTime:           commitTime,
+		OldItems: model.RecordItems{
+			"id":   qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(1)},
+			"name": qvalue.QValue{Kind: qvalue.QValueKindString, Value: "ada"},
+		},
+		NewItems: model.RecordItems{
+			"id":         qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(1)},
+			"name":       qvalue.QValue{Kind: qvalue.QValueKindString, Value: "grace"},
+			"born_on":    qvalue.QValue{Kind: qvalue.QValueKindDate, Value: time.Date(1970, 1, 11, 0, 0, 0, 0, time.UTC)},
+			"created_at": qvalue.QValue{Kind: qvalue.QValueKindTimestamp, Value: commitTime},
+		},
+		UnchangedToastColumns: map[string]bool{"bio": true},
+	})
+
+	assert.Equal(t, "u", event.Payload.Op)
+	assert.Equal(t, map[string]interface{}{"id": float64(1), "name": "ada"}, event.Payload.Before)
+	assert.Equal(t, map[string]interface{}{
+		"id":         float64(1),
+		"name":       "grace",
+		"bio":        unavailableValuePlaceholder,
+		"born_on":    float64(10),
+		"created_at": float64(commitTime.UnixMicro()),
+	}, event.Payload.After)
+
+	assert.Equal(t, "test_flow", event.Payload.Source.Name)
+	assert.Equal(t, commitTime.UnixMilli(), event.Payload.Source.TsMs)
+	assert.Equal(t, "public", event.Payload.Source.Schema)
+	assert.Equal(t, "users", event.Payload.Source.Table)
+	assert.Equal(t, int64(42), event.Payload.Source.Lsn)
+
+	assert.Equal(t, "test_flow.public.users.Envelope", event.Schema.Name)
+	require.Equal(t, "before", event.Schema.Fields[0].Field)
+	for _, field := range event.Schema.Fields[0].Fields {
+		switch field.Field {
+		case "id":
+			assert.Equal(t, "int64", field.Type)
+			assert.False(t, field.Optional)
+		case "born_on":
+			assert.Equal(t, "int32", field.Type)
+			assert.Equal(t, "io.debezium.time.Date", field.Name)
+		case "created_at":
+			assert.Equal(t, "io.debezium.time.MicroTimestamp", field.Name)
+		}
+	}
+}
+
+func TestEncodeEnvelopeDeleteAndTruncate(t *testing.T) {
+	event := decodeEnvelope(t, &model.DeleteRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+		Items: model.RecordItems{
+			"id": qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(1)},
+		},
+	})
+	assert.Equal(t, "d", event.Payload.Op)
+	assert.Equal(t, map[string]interface{}{"id": float64(1)}, event.Payload.Before)
+	assert.Nil(t, event.Payload.After)
+
+	event = decodeEnvelope(t, &model.TruncateRecord{
+		SourceTableName:      "public.users",
+		DestinationTableName: "public.users",
+	})
+	assert.Equal(t, "t", event.Payload.Op)
+	assert.Nil(t, event.Payload.Before)
+	assert.Nil(t, event.Payload.After)
+}
+
+func TestEnvelopeNumericsAreExact(t *testing.T) {
+	for value, expected := range map[string]string{
+		"12345678901234567890.123456789012345": "12345678901234567890.123456789012345",
+		"0.1":                                  "0.1",
+		"-0.125":                               "-0.125",
+		"42":                                   "42",
+		"1e-20":                                "0.00000000000000000001",
+	} {
+		num, ok := new(big.Rat).SetString(value)
+		require.True(t, ok)
+		envelopeVal, err := envelopeValue(qvalue.QValue{Kind: qvalue.QValueKindNumeric, Value: num})
+		require.NoError(t, err)
+		assert.Equal(t, expected, envelopeVal, value)
+	}
+
+	// values without a finite decimal form are rounded.
+	envelopeVal, err := envelopeValue(qvalue.QValue{Kind: qvalue.QValueKindNumeric, Value: big.NewRat(1, 3)})
+	require.NoError(t, err)
+	assert.Equal(t, "0."+strings.Repeat("3", maxDecimalScale), envelopeVal)
+}
@@ -1,7 +1,11 @@
 package utils
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
+// QuoteIdentifier quotes an identifier with double quotes, escaping the double quotes in it.
 func QuoteIdentifier(identifier string) string {
-	return fmt.Sprintf(`"%s"`, identifier)
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(identifier, `"`, `""`))
 }
@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	require.Equal(t, `"id"`, QuoteIdentifier("id"))
+	require.Equal(t, `"Mixed Case"`, QuoteIdentifier("Mixed Case"))
+	require.Equal(t, `"say ""hi"""`, QuoteIdentifier(`say "hi"`))
+}
@@ -0,0 +1,242 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/schema"
+	"github.com/google/uuid"
+)
+
+// numerics are written as decimal(38, 9), like the Avro files.
+const (
+	numericPrecision = 38
+	numericScale     = 9
+	// bytes of a decimal of numericPrecision digits.
+	numericByteLength = 16
+)
+
+var (
+	numericScaleFactor = new(big.Int).Exp(big.NewInt(10), big.NewInt(numericScale), nil)
+	maxNumericUnscaled = new(big.Int).Exp(big.NewInt(10), big.NewInt(numericPrecision), nil)
+)
+
+// getParquetSchema returns the schema of the Parquet files holding records of a schema.
+func getParquetSchema(qRecordSchema *model.QRecordSchema) (*schema.GroupNode, error) {
+	fields := make(schema.FieldList, 0, len(qRecordSchema.Fields))
+	for _, field := range qRecordSchema.Fields {
+		node, err := getParquetNode(field.Name, field.Type, field.Nullable)
+		if err != nil {
+			return nil, fmt.Errorf("failed to define Parquet column %s: %w", field.Name, err)
+		}
+		fields = append(fields, node)
+	}
+	return schema.NewGroupNode("schema", parquet.Repetitions.Required, fields, -1)
+}
+
+// getParquetNode returns the column of a kind. Values are written with the logical type of their
+// kind so engines reading the files see numerics, timestamps, dates, times, uuids and JSON as such.
+// Array kinds are lists of their element kind, intervals, network addresses, enums, hstore and
+// geospatial kinds are written as strings.
+func getParquetNode(name string, kind qvalue.QValueKind, nullable bool) (schema.Node, error) {
+	repetition := parquet.Repetitions.Required
+	if nullable {
+		repetition = parquet.Repetitions.Optional
+	}
+
+	if kind.IsArray() {
+		// nulls are dropped from arrays, the elements are never null.
+		element, err := getParquetNode(name, kind.ArrayElementKind(), false)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ListOf(element, repetition, -1)
+	}
+
+	var logicalType schema.LogicalType = schema.NoLogicalType{}
+	var physicalType parquet.Type
+	typeLength := -1
+	switch kind {
+	case qvalue.QValueKindBoolean:
+		physicalType = parquet.Types.Boolean
+	case qvalue.QValueKindInt16:
+		logicalType, physicalType = schema.NewIntLogicalType(16, true), parquet.Types.Int32
+	case qvalue.QValueKindInt32:
+		physicalType = parquet.Types.Int32
+	case qvalue.QValueKindInt64:
+		physicalType = parquet.Types.Int64
+	case qvalue.QValueKindFloat32:
+		physicalType = parquet.Types.Float
+	case qvalue.QValueKindFloat64:
+		physicalType = parquet.Types.Double
+	case qvalue.QValueKindString, qvalue.QValueKindInterval, qvalue.QValueKindINET, qvalue.QValueKindCIDR,
+		qvalue.QValueKindMacaddr, qvalue.QValueKindEnum, qvalue.QValueKindHStore, qvalue.QValueKindGeometry,
+		qvalue.QValueKindGeography:
+		logicalType, physicalType = schema.StringLogicalType{}, parquet.Types.ByteArray
+	case qvalue.QValueKindJSON:
+		logicalType, physicalType = schema.JSONLogicalType{}, parquet.Types.ByteArray
+	case qvalue.QValueKindBytes, qvalue.QValueKindBit:
+		physicalType = parquet.Types.ByteArray
+	case qvalue.QValueKindUUID:
+		logicalType, physicalType, typeLength = schema.UUIDLogicalType{}, parquet.Types.FixedLenByteArray, 16
+	case qvalue.QValueKindNumeric:
+		logicalType = schema.NewDecimalLogicalType(numericPrecision, numericScale)
+		physicalType, typeLength = parquet.Types.FixedLenByteArray, numericByteLength
+	case qvalue.QValueKindTimestamp:
+		logicalType, physicalType = schema.NewTimestampLogicalType(false, schema.TimeUnitMicros), parquet.Types.Int64
+	case qvalue.QValueKindTimestampTZ:
+		logicalType, physicalType = schema.NewTimestampLogicalType(true, schema.TimeUnitMicros), parquet.Types.Int64
+	case qvalue.QValueKindDate:
+		logicalType, physicalType = schema.DateLogicalType{}, parquet.Types.Int32
+	case qvalue.QValueKindTime:
+		logicalType, physicalType = schema.NewTimeLogicalType(false, schema.TimeUnitMicros), parquet.Types.Int64
+	case qvalue.QValueKindTimeTZ:
+		logicalType, physicalType = schema.NewTimeLogicalType(true, schema.TimeUnitMicros), parquet.Types.Int64
+	default:
+		return nil, fmt.Errorf("unsupported QValueKind for Parquet: %s", kind)
+	}
+
+	return schema.NewPrimitiveNodeLogical(name, repetition, logicalType, physicalType, typeLength, -1)
+}
+
+// toParquetValue converts a non-null value of a kind to the Go type of its physical Parquet type,
+// bool, int32, int64, float32, float64, parquet.ByteArray or parquet.FixedLenByteArray.
+func toParquetValue(kind qvalue.QValueKind, value interface{}) (interface{}, error) {
+	switch kind {
+	case qvalue.QValueKindBoolean:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case qvalue.QValueKindInt16, qvalue.QValueKindInt32:
+		switch v := value.(type) {
+		case int16:
+			return int32(v), nil
+		case int32:
+			return v, nil
+		}
+	case qvalue.QValueKindInt64:
+		switch v := value.(type) {
+		case int16:
+			return int64(v), nil
+		case int32:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		}
+	case qvalue.QValueKindFloat32:
+		switch v := value.(type) {
+		case float32:
+			return v, nil
+		case float64:
+			return float32(v), nil
+		}
+	case qvalue.QValueKindFloat64:
+		switch v := value.(type) {
+		case float32:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case qvalue.QValueKindString, qvalue.QValueKindInterval, qvalue.QValueKindINET, qvalue.QValueKindCIDR,
+		qvalue.QValueKindMacaddr, qvalue.QValueKindEnum, qvalue.QValueKindHStore, qvalue.QValueKindGeometry,
+		qvalue.QValueKindGeography, qvalue.QValueKindJSON:
+		if v, ok := value.(string); ok {
+			return parquet.ByteArray(v), nil
+		}
+	case qvalue.QValueKindBytes, qvalue.QValueKindBit:
+		if v, ok := value.([]byte); ok {
+			return parquet.ByteArray(v), nil
+		}
+	case qvalue.QValueKindUUID:
+		return uuidToParquet(value)
+	case qvalue.QValueKindNumeric:
+		if v, ok := value.(*big.Rat); ok {
+			return numericToParquet(v)
+		}
+	case qvalue.QValueKindTimestamp, qvalue.QValueKindTimestampTZ:
+		if v, ok := value.(time.Time); ok {
+			return v.UnixMicro(), nil
+		}
+	case qvalue.QValueKindDate:
+		if v, ok := value.(time.Time); ok {
+			days := time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+			return int32(days), nil
+		}
+	case qvalue.QValueKindTime, qvalue.QValueKindTimeTZ:
+		if v, ok := value.(time.Time); ok {
+			if kind == qvalue.QValueKindTimeTZ {
+				v = v.UTC()
+			}
+			midnight := time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, v.Location())
+			return v.Sub(midnight).Microseconds(), nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported QValueKind for Parquet: %s", kind)
+	}
+	return nil, fmt.Errorf("invalid %s value: unexpected %T", kind, value)
+}
+
+// toParquetList converts a non-null array of an array kind to the values of its elements.
+func toParquetList(kind qvalue.QValueKind, value interface{}) ([]interface{}, error) {
+	arrayVal := reflect.ValueOf(value)
+	if arrayVal.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("invalid %s value: expected a slice, got %T", kind, value)
+	}
+
+	elementKind := kind.ArrayElementKind()
+	elements := make([]interface{}, 0, arrayVal.Len())
+	for i := 0; i < arrayVal.Len(); i++ {
+		element, err := toParquetValue(elementKind, arrayVal.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+func uuidToParquet(value interface{}) (interface{}, error) {
+	var u uuid.UUID
+	switch v := value.(type) {
+	case [16]byte:
+		u = v
+	case uuid.UUID:
+		u = v
+	case string:
+		parsed, err := uuid.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uuid value %s: %w", v, err)
+		}
+		u = parsed
+	default:
+		return nil, fmt.Errorf("invalid uuid value: unexpected %T", value)
+	}
+	return parquet.FixedLenByteArray(u[:]), nil
+}
+
+// numericToParquet returns the unscaled value of a numeric as a big-endian two's complement integer,
+// digits past the scale are truncated like they are in Avro files.
+func numericToParquet(num *big.Rat) (interface{}, error) {
+	unscaled := new(big.Int).Mul(num.Num(), numericScaleFactor)
+	unscaled.Quo(unscaled, num.Denom())
+	if new(big.Int).Abs(unscaled).Cmp(maxNumericUnscaled) >= 0 {
+		return nil, fmt.Errorf("numeric value %s exceeds decimal(%d, %d)",
+			num.FloatString(numericScale), numericPrecision, numericScale)
+	}
+
+	buf := make([]byte, numericByteLength)
+	if unscaled.Sign() >= 0 {
+		unscaled.FillBytes(buf)
+		return parquet.FixedLenByteArray(buf), nil
+	}
+	// two's complement of a negative value, 2^128 + value.
+	twosComplement := new(big.Int).Lsh(big.NewInt(1), numericByteLength*8)
+	twosComplement.Add(twosComplement, unscaled)
+	twosComplement.FillBytes(buf)
+	return parquet.FixedLenByteArray(buf), nil
+}
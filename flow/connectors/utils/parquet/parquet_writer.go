@@ -0,0 +1,342 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	stage "github.com/PeerDB-io/peer-flow/connectors/utils/stage"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/schema"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultRowGroupSizeMB is the size of the values buffered for a row group if none is configured.
+// The values of a row group are held in memory until it's written, so row groups are sized by the
+// bytes of their values rather than their rows, which vary in width between tables.
+const DefaultRowGroupSizeMB = 64
+
+var compressionCodecs = map[string]compress.Compression{
+	"uncompressed": compress.Codecs.Uncompressed,
+	"snappy":       compress.Codecs.Snappy,
+	"gzip":         compress.Codecs.Gzip,
+	"zstd":         compress.Codecs.Zstd,
+	"brotli":       compress.Codecs.Brotli,
+}
+
+// WriterOptions are the options of the Parquet files written.
+type WriterOptions struct {
+	// Compression is the codec of the column chunks, snappy if empty.
+	Compression string
+	// RowGroupSizeMB is the size in MiB of the values buffered per row group, DefaultRowGroupSizeMB if 0.
+	RowGroupSizeMB int
+}
+
+// ValidateCompression returns an error for a compression codec the writer doesn't support, empty is snappy.
+func ValidateCompression(compression string) error {
+	if _, ok := compressionCodecs[compression]; !ok && compression != "" {
+		return fmt.Errorf("unsupported Parquet compression: %s", compression)
+	}
+	return nil
+}
+
+func (o *WriterOptions) writerProperties() (*parquet.WriterProperties, error) {
+	if err := ValidateCompression(o.Compression); err != nil {
+		return nil, err
+	}
+	codec := compress.Codecs.Snappy
+	if o.Compression != "" {
+		codec = compressionCodecs[o.Compression]
+	}
+	return parquet.NewWriterProperties(
+		parquet.WithCompression(codec),
+		parquet.WithCreatedBy("peerdb"),
+	), nil
+}
+
+// rowGroupBytes returns the size of the values a row group is written at.
+func (o *WriterOptions) rowGroupBytes() int {
+	if o.RowGroupSizeMB <= 0 {
+		return DefaultRowGroupSizeMB << 20
+	}
+	return o.RowGroupSizeMB << 20
+}
+
+// parquetColumn buffers the values and levels of a column for the row group being written.
+type parquetColumn struct {
+	field       *model.QField
+	isList      bool
+	maxDefLevel int16
+
+	values    []interface{}
+	defLevels []int16
+	repLevels []int16
+	// size of the buffered values and levels, as they're written.
+	numBytes int
+}
+
+// append adds the value of a row, lists add a value per element.
+func (c *parquetColumn) append(value interface{}) error {
+	if value == nil {
+		if !c.field.Nullable {
+			return fmt.Errorf("null value in non-nullable column %s", c.field.Name)
+		}
+		c.defLevels = append(c.defLevels, 0)
+		c.numBytes += 2
+		if c.isList {
+			c.repLevels = append(c.repLevels, 0)
+			c.numBytes += 2
+		}
+		return nil
+	}
+
+	if !c.isList {
+		v, err := toParquetValue(c.field.Type, value)
+		if err != nil {
+			return fmt.Errorf("failed to convert value of column %s: %w", c.field.Name, err)
+		}
+		c.values = append(c.values, v)
+		c.numBytes += parquetValueSize(v)
+		if c.field.Nullable {
+			c.defLevels = append(c.defLevels, c.maxDefLevel)
+			c.numBytes += 2
+		}
+		return nil
+	}
+
+	elements, err := toParquetList(c.field.Type, value)
+	if err != nil {
+		return fmt.Errorf("failed to convert value of column %s: %w", c.field.Name, err)
+	}
+	if len(elements) == 0 {
+		// an empty list is defined up to the repeated group.
+		c.defLevels = append(c.defLevels, c.maxDefLevel-1)
+		c.repLevels = append(c.repLevels, 0)
+		c.numBytes += 4
+		return nil
+	}
+	for i, element := range elements {
+		c.values = append(c.values, element)
+		c.numBytes += parquetValueSize(element) + 4
+		c.defLevels = append(c.defLevels, c.maxDefLevel)
+		if i == 0 {
+			c.repLevels = append(c.repLevels, 0)
+		} else {
+			c.repLevels = append(c.repLevels, 1)
+		}
+	}
+	return nil
+}
+
+// write writes the buffered values to the chunk of the column in a row group and resets the buffers.
+func (c *parquetColumn) write(cw file.ColumnChunkWriter) error {
+	var err error
+	switch w := cw.(type) {
+	case *file.BooleanColumnChunkWriter:
+		values := make([]bool, len(c.values))
+		for i, v := range c.values {
+			values[i] = v.(bool)
+		}
+		_, err = w.WriteBatch(values, c.defLevels, c.repLevels)
+	case *file.Int32ColumnChunkWriter:
+		values := make([]int32, len(c.values))
+		for i, v := range c.values {
+			values[i] = v.(int32)
+		}
+		_, err = w.WriteBatch(values, c.defLevels, c.repLevels)
+	case *file.Int64ColumnChunkWriter:
+		values := make([]int64, len(c.values))
+		for i, v := range c.values {
+			values[i] = v.(int64)
+		}
+		_, err = w.WriteBatch(values, c.defLevels, c.repLevels)
+	case *file.Float32ColumnChunkWriter:
+		values := make([]float32, len(c.values))
+		for i, v := range c.values {
+			values[i] = v.(float32)
+		}
+		_, err = w.WriteBatch(values, c.defLevels, c.repLevels)
+	case *file.Float64ColumnChunkWriter:
+		values := make([]float64, len(c.values))
+		for i, v := range c.values {
+			values[i] = v.(float64)
+		}
+		_, err = w.WriteBatch(values, c.defLevels, c.repLevels)
+	case *file.ByteArrayColumnChunkWriter:
+		values := make([]parquet.ByteArray, len(c.values))
+		for i, v := range c.values {
+			values[i] = v.(parquet.ByteArray)
+		}
+		_, err = w.WriteBatch(values, c.defLevels, c.repLevels)
+	case *file.FixedLenByteArrayColumnChunkWriter:
+		values := make([]parquet.FixedLenByteArray, len(c.values))
+		for i, v := range c.values {
+			values[i] = v.(parquet.FixedLenByteArray)
+		}
+		_, err = w.WriteBatch(values, c.defLevels, c.repLevels)
+	default:
+		err = fmt.Errorf("unexpected column writer %T", cw)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write column %s: %w", c.field.Name, err)
+	}
+	if err := cw.Close(); err != nil {
+		return fmt.Errorf("failed to close column %s: %w", c.field.Name, err)
+	}
+
+	c.values = c.values[:0]
+	c.defLevels = c.defLevels[:0]
+	c.repLevels = c.repLevels[:0]
+	c.numBytes = 0
+	return nil
+}
+
+// parquetValueSize returns the size of a converted value as it's written, before encoding and compression.
+func parquetValueSize(value interface{}) int {
+	switch v := value.(type) {
+	case bool:
+		return 1
+	case int32, float32:
+		return 4
+	case parquet.ByteArray:
+		return len(v) + 4
+	case parquet.FixedLenByteArray:
+		return len(v)
+	default:
+		return 8
+	}
+}
+
+// writeRecordsToParquet writes the records of a stream as a Parquet file while they're read,
+// returning the number written.
+func writeRecordsToParquet(w io.Writer, stream *model.QRecordStream, options *WriterOptions) (int, error) {
+	qRecordSchema, err := stream.Schema()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema from stream: %w", err)
+	}
+	parquetSchema, err := getParquetSchema(qRecordSchema)
+	if err != nil {
+		return 0, err
+	}
+	props, err := options.writerProperties()
+	if err != nil {
+		return 0, err
+	}
+
+	fileWriter := file.NewParquetWriter(w, parquetSchema, file.WithWriterProps(props))
+	columnDescriptors := schema.NewSchema(parquetSchema)
+	columns := make([]*parquetColumn, 0, len(qRecordSchema.Fields))
+	for i, field := range qRecordSchema.Fields {
+		descriptor := columnDescriptors.Column(i)
+		columns = append(columns, &parquetColumn{
+			field:       field,
+			isList:      descriptor.MaxRepetitionLevel() > 0,
+			maxDefLevel: descriptor.MaxDefinitionLevel(),
+		})
+	}
+
+	flushRowGroup := func() error {
+		rowGroupWriter := fileWriter.AppendRowGroup()
+		for _, column := range columns {
+			cw, err := rowGroupWriter.NextColumn()
+			if err != nil {
+				return fmt.Errorf("failed to start column %s: %w", column.field.Name, err)
+			}
+			if err := column.write(cw); err != nil {
+				return err
+			}
+		}
+		if err := rowGroupWriter.Close(); err != nil {
+			return fmt.Errorf("failed to close row group: %w", err)
+		}
+		return nil
+	}
+
+	rowGroupBytes := options.rowGroupBytes()
+	numRecords := 0
+	numBufferedRecords := 0
+	for qRecord := range stream.Records() {
+		numBufferedBytes := 0
+		for i, column := range columns {
+			if err := column.append(qRecord.Entries[i].Value); err != nil {
+				return numRecords, err
+			}
+			numBufferedBytes += column.numBytes
+		}
+		numRecords++
+		numBufferedRecords++
+
+		if numBufferedBytes >= rowGroupBytes {
+			if err := flushRowGroup(); err != nil {
+				return numRecords, err
+			}
+			numBufferedRecords = 0
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return numRecords, fmt.Errorf("failed to pull records: %w", err)
+	}
+
+	if numBufferedRecords > 0 {
+		if err := flushRowGroup(); err != nil {
+			return numRecords, err
+		}
+	}
+	if err := fileWriter.Close(); err != nil {
+		return numRecords, fmt.Errorf("failed to close Parquet writer: %w", err)
+	}
+	return numRecords, nil
+}
+
+// WriteRecordsToStage writes the records of a stream as a Parquet file to a stage while they're
+// pulled, returning the number of records written.
+func WriteRecordsToStage(
+	ctx context.Context,
+	stream *model.QRecordStream,
+	options *WriterOptions,
+	stg stage.Stage,
+	key string) (int, error) {
+	numRecords := 0
+	err := stage.PutStream(ctx, stg, key, func(w io.Writer) error {
+		var err error
+		numRecords, err = writeRecordsToParquet(w, stream, options)
+		if err != nil {
+			return fmt.Errorf("failed to write records to Parquet writer: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("failed to write Parquet file %s to stage %s: %v", key, stg.URL(), err)
+		return 0, err
+	}
+
+	log.Infof("file written to %s/%s", stg.URL(), key)
+	return numRecords, nil
+}
+
+// WriteRecordsToParquetFile writes the records of a stream to a local Parquet file,
+// returning the number of records written.
+func WriteRecordsToParquetFile(
+	stream *model.QRecordStream,
+	options *WriterOptions,
+	filePath string) (int, error) {
+	f, err := os.Create(filePath)
+	if err != nil {
+		log.Errorf("failed to create file: %v", err)
+		return 0, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	numRecords, err := writeRecordsToParquet(f, stream, options)
+	if err != nil {
+		log.Errorf("failed to write records to Parquet file: %v", err)
+		return 0, err
+	}
+
+	return numRecords, nil
+}
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/schema"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumericToParquet(t *testing.T) {
+	v, err := numericToParquet(big.NewRat(25, 2))
+	require.NoError(t, err)
+	// 12.5 is 12500000000 unscaled.
+	assert.Equal(t, parquet.FixedLenByteArray{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x02, 0xe9, 0x0e, 0xdd, 0x00},
+		v.(parquet.FixedLenByteArray))
+
+	v, err = numericToParquet(big.NewRat(-1, 1000000000))
+	require.NoError(t, err)
+	assert.Equal(t, parquet.FixedLenByteArray{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, v.(parquet.FixedLenByteArray))
+
+	_, err = numericToParquet(new(big.Rat).SetInt(maxNumericUnscaled))
+	assert.Error(t, err)
+}
+
+func TestWriteRecordsToParquetFile(t *testing.T) {
+	qRecordSchema := model.NewQRecordSchema([]*model.QField{
+		{Name: "id", Type: qvalue.QValueKindInt64, Nullable: false},
+		{Name: "amount", Type: qvalue.QValueKindNumeric, Nullable: true},
+		{Name: "created_at", Type: qvalue.QValueKindTimestampTZ, Nullable: true},
+		{Name: "ref", Type: qvalue.QValueKindUUID, Nullable: true},
+		{Name: "payload", Type: qvalue.QValueKindJSON, Nullable: true},
+		{Name: "tags", Type: qvalue.QValueKindArrayString, Nullable: true},
+	})
+	createdAt := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	ref := uuid.New()
+
+	records := []*model.QRecord{
+		{NumEntries: 6, Entries: []qvalue.QValue{
+			{Kind: qvalue.QValueKindInt64, Value: int64(1)},
+			{Kind: qvalue.QValueKindNumeric, Value: big.NewRat(25, 2)},
+			{Kind: qvalue.QValueKindTimestampTZ, Value: createdAt},
+			{Kind: qvalue.QValueKindUUID, Value: [16]byte(ref)},
+			{Kind: qvalue.QValueKindJSON, Value: `{"a":1}`},
+			{Kind: qvalue.QValueKindArrayString, Value: []string{"x", "y"}},
+		}},
+		{NumEntries: 6, Entries: []qvalue.QValue{
+			{Kind: qvalue.QValueKindInt64, Value: int64(2)},
+			{Kind: qvalue.QValueKindNumeric, Value: nil},
+			{Kind: qvalue.QValueKindTimestampTZ, Value: nil},
+			{Kind: qvalue.QValueKindUUID, Value: nil},
+			{Kind: qvalue.QValueKindJSON, Value: nil},
+			{Kind: qvalue.QValueKindArrayString, Value: []string{}},
+		}},
+		{NumEntries: 6, Entries: []qvalue.QValue{
+			{Kind: qvalue.QValueKindInt64, Value: int64(3)},
+			{Kind: qvalue.QValueKindNumeric, Value: nil},
+			{Kind: qvalue.QValueKindTimestampTZ, Value: nil},
+			{Kind: qvalue.QValueKindUUID, Value: ref.String()},
+			{Kind: qvalue.QValueKindJSON, Value: nil},
+			{Kind: qvalue.QValueKindArrayString, Value: nil},
+		}},
+	}
+	batch := &model.QRecordBatch{NumRecords: uint32(len(records)), Records: records, Schema: qRecordSchema}
+
+	filePath := filepath.Join(t.TempDir(), "partition.parquet")
+	numRecords, err := WriteRecordsToParquetFile(batch.ToQRecordStream(len(records)),
+		&WriterOptions{Compression: "zstd"}, filePath)
+	require.NoError(t, err)
+	assert.Equal(t, 3, numRecords)
+
+	reader, err := file.OpenParquetFile(filePath, false)
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.EqualValues(t, 3, reader.NumRows())
+	assert.Equal(t, 1, reader.NumRowGroups())
+
+	fileSchema := reader.MetaData().Schema
+	assert.Equal(t, parquet.Repetitions.Required, fileSchema.Column(0).SchemaNode().RepetitionType())
+	assert.True(t, fileSchema.Column(1).LogicalType().Equals(schema.NewDecimalLogicalType(38, 9)))
+	assert.True(t, fileSchema.Column(2).LogicalType().Equals(schema.NewTimestampLogicalType(true, schema.TimeUnitMicros)))
+	assert.True(t, fileSchema.Column(3).LogicalType().Equals(schema.UUIDLogicalType{}))
+	assert.True(t, fileSchema.Column(4).LogicalType().Equals(schema.JSONLogicalType{}))
+	assert.Equal(t, "tags.list.element", fileSchema.Column(5).Path())
+
+	rowGroup := reader.RowGroup(0)
+	col, err := rowGroup.Column(2)
+	require.NoError(t, err)
+	timestamps := make([]int64, 2)
+	defLevels := make([]int16, 2)
+	_, valuesRead, err := col.(*file.Int64ColumnChunkReader).ReadBatch(2, timestamps, defLevels, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, valuesRead)
+	assert.Equal(t, createdAt.UnixMicro(), timestamps[0])
+	assert.Equal(t, []int16{1, 0}, defLevels)
+
+	col, err = rowGroup.Column(5)
+	require.NoError(t, err)
+	tags := make([]parquet.ByteArray, 3)
+	defLevels = make([]int16, 3)
+	repLevels := make([]int16, 3)
+	_, valuesRead, err = col.(*file.ByteArrayColumnChunkReader).ReadBatch(3, tags, defLevels, repLevels)
+	require.NoError(t, err)
+	assert.Equal(t, 2, valuesRead)
+	assert.Equal(t, "x", tags[0].String())
+	assert.Equal(t, "y", tags[1].String())
+	// two elements of the first row, then the empty list of the second.
+	assert.Equal(t, []int16{2, 2, 1}, defLevels)
+	assert.Equal(t, []int16{0, 1, 0}, repLevels)
+
+	col, err = rowGroup.Column(3)
+	require.NoError(t, err)
+	refs := make([]parquet.FixedLenByteArray, 3)
+	_, valuesRead, err = col.(*file.FixedLenByteArrayColumnChunkReader).ReadBatch(3, refs, make([]int16, 3), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, valuesRead)
+	assert.Equal(t, ref[:], []byte(refs[0]))
+	assert.Equal(t, ref[:], []byte(refs[1]))
+}
+
+func TestWriteRecordsToParquetFileRowGroupSize(t *testing.T) {
+	qRecordSchema := model.NewQRecordSchema([]*model.QField{
+		{Name: "id", Type: qvalue.QValueKindInt64, Nullable: false},
+		{Name: "body", Type: qvalue.QValueKindString, Nullable: true},
+	})
+	body := strings.Repeat("x", 300<<10)
+	records := make([]*model.QRecord, 0, 10)
+	for i := int64(0); i < 10; i++ {
+		records = append(records, &model.QRecord{NumEntries: 2, Entries: []qvalue.QValue{
+			{Kind: qvalue.QValueKindInt64, Value: i},
+			{Kind: qvalue.QValueKindString, Value: body},
+		}})
+	}
+	batch := &model.QRecordBatch{NumRecords: uint32(len(records)), Records: records, Schema: qRecordSchema}
+
+	// row groups are written once their values reach 1 MiB, after 4 rows of 300 KiB.
+	filePath := filepath.Join(t.TempDir(), "partition.parquet")
+	numRecords, err := WriteRecordsToParquetFile(batch.ToQRecordStream(len(records)),
+		&WriterOptions{RowGroupSizeMB: 1}, filePath)
+	require.NoError(t, err)
+	assert.Equal(t, 10, numRecords)
+
+	reader, err := file.OpenParquetFile(filePath, false)
+	require.NoError(t, err)
+	defer reader.Close()
+	require.Equal(t, 3, reader.NumRowGroups())
+	assert.EqualValues(t, 4, reader.RowGroup(0).NumRows())
+	assert.EqualValues(t, 4, reader.RowGroup(1).NumRows())
+	assert.EqualValues(t, 2, reader.RowGroup(2).NumRows())
+}
+
+func TestWriteRecordsToParquetFileErrors(t *testing.T) {
+	qRecordSchema := model.NewQRecordSchema([]*model.QField{
+		{Name: "id", Type: qvalue.QValueKindInt64, Nullable: false},
+	})
+	newStream := func() *model.QRecordStream {
+		batch := &model.QRecordBatch{NumRecords: 1, Schema: qRecordSchema, Records: []*model.QRecord{
+			{NumEntries: 1, Entries: []qvalue.QValue{{Kind: qvalue.QValueKindInt64, Value: nil}}},
+		}}
+		return batch.ToQRecordStream(1)
+	}
+
+	_, err := WriteRecordsToParquetFile(newStream(), &WriterOptions{Compression: "lzo"},
+		filepath.Join(t.TempDir(), "partition.parquet"))
+	assert.ErrorContains(t, err, "unsupported Parquet compression")
+
+	_, err = WriteRecordsToParquetFile(newStream(), &WriterOptions{},
+		filepath.Join(t.TempDir(), "partition.parquet"))
+	assert.ErrorContains(t, err, "null value in non-nullable column id")
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+)
+
+// AzureBlobStage keeps objects in an Azure Blob Storage container, with the default Azure
+// credentials of the environment.
+type AzureBlobStage struct {
+	client    *azblob.Client
+	account   string
+	container string
+	prefix    string
+}
+
+// NewAzureBlobStage returns the stage of a URL like azure://<account>.blob.core.windows.net/<container>/<prefix>.
+func NewAzureBlobStage(stageURL string) (*AzureBlobStage, error) {
+	u, err := url.Parse(stageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stage url %s: %w", stageURL, err)
+	}
+	container, prefix := splitBucketURL(u.Path)
+	if u.Host == "" || container == "" {
+		return nil, fmt.Errorf("stage url %s has no account or container", stageURL)
+	}
+
+	creds, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default Azure credentials: %w", err)
+	}
+	client, err := azblob.NewClient(fmt.Sprintf("https://%s/", u.Host), creds, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure Blob client: %w", err)
+	}
+	return &AzureBlobStage{
+		client:    client,
+		account:   u.Host,
+		container: container,
+		prefix:    prefix,
+	}, nil
+}
+
+func (s *AzureBlobStage) URL() string {
+	return stageURL("azure", s.account+"/"+s.container, s.prefix)
+}
+
+func (s *AzureBlobStage) Put(ctx context.Context, key string, r io.Reader) error {
+	// the blocks of a stream are only committed once it's read to the end.
+	_, err := s.client.UploadStream(ctx, s.container, joinKey(s.prefix, key), r, nil)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to Azure Blob Storage: %w", key, err)
+	}
+	return nil
+}
+
+func (s *AzureBlobStage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.client.DownloadStream(ctx, s.container, joinKey(s.prefix, key), nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to read %s from Azure Blob Storage: %w", key, err)
+	}
+	return resp.Body, nil
+}
+
+func (s *AzureBlobStage) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	containerPrefix := joinKey(s.prefix, "")
+	listPrefix := containerPrefix + prefix
+	pager := s.client.NewListBlobsFlatPager(s.container, &azblob.ListBlobsFlatOptions{
+		Prefix: &listPrefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects with prefix %s in Azure Blob Storage: %w", prefix, err)
+		}
+		for _, blob := range page.Segment.BlobItems {
+			keys = append(keys, (*blob.Name)[len(containerPrefix):])
+		}
+	}
+	return keys, nil
+}
+
+func (s *AzureBlobStage) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteBlob(ctx, s.container, joinKey(s.prefix, key), nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return fmt.Errorf("failed to delete %s from Azure Blob Storage: %w", key, err)
+	}
+	return nil
+}
+
+func (s *AzureBlobStage) Close() error {
+	return nil
+}
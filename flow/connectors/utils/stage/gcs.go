@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// GCSStage keeps objects in a Google Cloud Storage bucket.
+type GCSStage struct {
+	client *storage.Client
+	// the client is closed with the stage if it was created for it.
+	ownsClient bool
+	bucket     string
+	prefix     string
+}
+
+// NewGCSStage returns a stage with the application default credentials.
+func NewGCSStage(ctx context.Context, stageURL string) (*GCSStage, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	stage, err := NewGCSStageWithClient(client, stageURL)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	stage.ownsClient = true
+	return stage, nil
+}
+
+// NewGCSStageWithClient returns a stage using a client of the caller, it isn't closed with the stage.
+func NewGCSStageWithClient(client *storage.Client, stageURL string) (*GCSStage, error) {
+	u, err := url.Parse(stageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stage url %s: %w", stageURL, err)
+	}
+	_, prefix := splitBucketURL(u.Path)
+	return &GCSStage{
+		client: client,
+		bucket: u.Host,
+		prefix: prefix,
+	}, nil
+}
+
+func (s *GCSStage) URL() string {
+	return stageURL("gs", s.bucket, s.prefix)
+}
+
+func (s *GCSStage) Put(ctx context.Context, key string, r io.Reader) error {
+	// the upload is aborted if the writer's context is canceled before it's closed.
+	writeCtx, cancelWrite := context.WithCancel(ctx)
+	defer cancelWrite()
+
+	w := s.client.Bucket(s.bucket).Object(joinKey(s.prefix, key)).NewWriter(writeCtx)
+	if _, err := io.Copy(w, r); err != nil {
+		return fmt.Errorf("failed to upload %s to GCS: %w", key, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to upload %s to GCS: %w", key, err)
+	}
+	return nil
+}
+
+func (s *GCSStage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	r, err := s.client.Bucket(s.bucket).Object(joinKey(s.prefix, key)).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to read %s from GCS: %w", key, err)
+	}
+	return r, nil
+}
+
+func (s *GCSStage) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	bucketPrefix := joinKey(s.prefix, "")
+	it := s.client.Bucket(s.bucket).Objects(ctx, &storage.Query{Prefix: bucketPrefix + prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects with prefix %s in GCS: %w", prefix, err)
+		}
+		keys = append(keys, attrs.Name[len(bucketPrefix):])
+	}
+	return keys, nil
+}
+
+func (s *GCSStage) Delete(ctx context.Context, key string) error {
+	err := s.client.Bucket(s.bucket).Object(joinKey(s.prefix, key)).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete %s from GCS: %w", key, err)
+	}
+	return nil
+}
+
+func (s *GCSStage) Close() error {
+	if s.ownsClient {
+		return s.client.Close()
+	}
+	return nil
+}
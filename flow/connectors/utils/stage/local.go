@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStage keeps objects as files under a local directory, keys are their paths in it.
+type LocalStage struct {
+	dir string
+}
+
+// NewLocalStage returns the stage of a URL like file:///<directory>, the directory is created if needed.
+func NewLocalStage(stageURL string) (*LocalStage, error) {
+	u, err := url.Parse(stageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stage url %s: %w", stageURL, err)
+	}
+	if u.Host != "" || !filepath.IsAbs(u.Path) {
+		return nil, fmt.Errorf("stage url %s must be an absolute file:/// url", stageURL)
+	}
+
+	dir := filepath.Clean(u.Path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create stage directory %s: %w", dir, err)
+	}
+	return &LocalStage{dir: dir}, nil
+}
+
+func (s *LocalStage) URL() string {
+	return "file://" + filepath.ToSlash(s.dir)
+}
+
+// Path returns the path of the file of an object.
+func (s *LocalStage) Path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+func (s *LocalStage) Put(ctx context.Context, key string, r io.Reader) error {
+	filePath := s.Path(key)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory of %s: %w", key, err)
+	}
+
+	// the object is written to a temporary file first, so it's only there once it's complete.
+	f, err := os.CreateTemp(filepath.Dir(filePath), ".tmp-"+filepath.Base(filePath))
+	if err != nil {
+		return fmt.Errorf("failed to create file for %s: %w", key, err)
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filePath)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(s.Path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return f, nil
+}
+
+func (s *LocalStage) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	err := filepath.WalkDir(s.dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, filePath)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects with prefix %s: %w", prefix, err)
+	}
+	return keys, nil
+}
+
+func (s *LocalStage) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(s.Path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStage) Close() error {
+	return nil
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3Stage keeps objects in an S3 bucket, with the credentials of the environment.
+type S3Stage struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+func NewS3Stage(stageURL string) (*S3Stage, error) {
+	u, err := url.Parse(stageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stage url %s: %w", stageURL, err)
+	}
+	client, err := utils.CreateS3Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	_, prefix := splitBucketURL(u.Path)
+	return &S3Stage{
+		client: client,
+		bucket: u.Host,
+		prefix: prefix,
+	}, nil
+}
+
+func (s *S3Stage) URL() string {
+	return stageURL("s3", s.bucket, s.prefix)
+}
+
+func (s *S3Stage) Put(ctx context.Context, key string, r io.Reader) error {
+	uploader := s3manager.NewUploaderWithClient(s.client)
+	_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(joinKey(s.prefix, key)),
+		Body:   r,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to S3: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3Stage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(joinKey(s.prefix, key)),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to read %s from S3: %w", key, err)
+	}
+	return out.Body, nil
+}
+
+func (s *S3Stage) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	bucketPrefix := joinKey(s.prefix, "")
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(bucketPrefix + prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, (*object.Key)[len(bucketPrefix):])
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects with prefix %s in S3: %w", prefix, err)
+	}
+	return keys, nil
+}
+
+func (s *S3Stage) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(joinKey(s.prefix, key)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete %s from S3: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3Stage) Close() error {
+	return nil
+}
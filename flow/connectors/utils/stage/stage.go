@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// ErrObjectNotFound is returned by Get for keys without an object.
+var ErrObjectNotFound = errors.New("object not found in stage")
+
+// Stage is a location objects are staged in, or kept, by connectors. Keys are relative to the URL
+// of the stage and separated by slashes.
+type Stage interface {
+	// URL returns the URL of the stage, without a trailing slash.
+	URL() string
+	// Put writes the object read from r, it isn't written if reading fails.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get returns the content of an object, ErrObjectNotFound if there is none.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// List returns the keys of the objects with a prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+	// Delete removes an object, removing a missing object isn't an error.
+	Delete(ctx context.Context, key string) error
+	Close() error
+}
+
+// NewStage returns the stage of a URL, picked by its scheme:
+//   - s3://<bucket>/<prefix>
+//   - gs://<bucket>/<prefix>, or gcs://
+//   - azure://<account>.blob.core.windows.net/<container>/<prefix>
+//   - file:///<directory>
+func NewStage(ctx context.Context, stageURL string) (Stage, error) {
+	u, err := url.Parse(stageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stage url %s: %w", stageURL, err)
+	}
+
+	switch u.Scheme {
+	case "s3":
+		return NewS3Stage(stageURL)
+	case "gs", "gcs":
+		return NewGCSStage(ctx, stageURL)
+	case "azure":
+		return NewAzureBlobStage(stageURL)
+	case "file":
+		return NewLocalStage(stageURL)
+	default:
+		return nil, fmt.Errorf("unsupported stage url %s, expected s3://, gs://, azure:// or file://", stageURL)
+	}
+}
+
+// PutStream writes an object while write writes its content, the object isn't written if write fails.
+func PutStream(ctx context.Context, stage Stage, key string, write func(w io.Writer) error) error {
+	r, w := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.CloseWithError(write(w))
+	}()
+
+	if err := stage.Put(ctx, key, r); err != nil {
+		// unblock the writer, it stops at its next write.
+		r.CloseWithError(err)
+		return err
+	}
+	<-done
+	return nil
+}
+
+// splitBucketURL returns the bucket, or container, of a URL path and the prefix of the keys in it.
+func splitBucketURL(bucketPath string) (string, string) {
+	parts := strings.SplitN(strings.Trim(bucketPath, "/"), "/", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], strings.Trim(parts[1], "/")
+}
+
+// joinKey returns the key of an object in a bucket from its key in the stage.
+func joinKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "/" + key
+}
+
+// stageURL returns the URL of a stage in a bucket.
+func stageURL(scheme string, bucket string, prefix string) string {
+	return strings.TrimSuffix(fmt.Sprintf("%s://%s/%s", scheme, bucket, prefix), "/")
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBucketURL(t *testing.T) {
+	bucket, prefix := splitBucketURL("/bucket/staging/peerdb/")
+	assert.Equal(t, "bucket", bucket)
+	assert.Equal(t, "staging/peerdb", prefix)
+
+	bucket, prefix = splitBucketURL("/bucket")
+	assert.Equal(t, "bucket", bucket)
+	assert.Equal(t, "", prefix)
+
+	assert.Equal(t, "gs://bucket", stageURL("gs", "bucket", ""))
+	assert.Equal(t, "s3://bucket/staging", stageURL("s3", "bucket", "staging"))
+}
+
+func TestNewStageUnsupportedScheme(t *testing.T) {
+	_, err := NewStage(context.Background(), "ftp://host/dir")
+	assert.ErrorContains(t, err, "unsupported stage url")
+
+	_, err = NewStage(context.Background(), "file://relative/dir")
+	assert.Error(t, err)
+}
+
+func TestLocalStage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	stage, err := NewStage(ctx, "file://"+dir+"/staging")
+	require.NoError(t, err)
+	defer stage.Close()
+	assert.Equal(t, "file://"+dir+"/staging", stage.URL())
+
+	require.NoError(t, stage.Put(ctx, "job/1.avro", strings.NewReader("one")))
+	require.NoError(t, stage.Put(ctx, "job/2.avro", strings.NewReader("two")))
+	require.NoError(t, stage.Put(ctx, "other/1.avro", strings.NewReader("other")))
+
+	keys, err := stage.List(ctx, "job/")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"job/1.avro", "job/2.avro"}, keys)
+
+	r, err := stage.Get(ctx, "job/2.avro")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	r.Close()
+	assert.Equal(t, "two", string(content))
+
+	require.NoError(t, stage.Delete(ctx, "job/2.avro"))
+	// deleting a missing object isn't an error.
+	require.NoError(t, stage.Delete(ctx, "job/2.avro"))
+	_, err = stage.Get(ctx, "job/2.avro")
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+
+	keys, err = stage.List(ctx, "")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"job/1.avro", "other/1.avro"}, keys)
+}
+
+func TestPutStream(t *testing.T) {
+	ctx := context.Background()
+	stage, err := NewLocalStage("file://" + t.TempDir())
+	require.NoError(t, err)
+
+	err = PutStream(ctx, stage, "job/1.avro", func(w io.Writer) error {
+		_, err := w.Write([]byte("streamed"))
+		return err
+	})
+	require.NoError(t, err)
+	r, err := stage.Get(ctx, "job/1.avro")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	r.Close()
+	assert.Equal(t, "streamed", string(content))
+
+	// an object whose writer fails isn't written, and no partial file is left behind.
+	err = PutStream(ctx, stage, "job/2.avro", func(w io.Writer) error {
+		if _, err := w.Write([]byte("partial")); err != nil {
+			return err
+		}
+		return errors.New("source failed")
+	})
+	assert.ErrorContains(t, err, "source failed")
+	keys, err := stage.List(ctx, "job/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job/1.avro"}, keys)
+}
@@ -0,0 +1,68 @@
+package e2e
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type KafkaTestHelper struct {
+	kafkaConfig *protos.KafkaConfig
+}
+
+// NewKafkaTestHelper connects to the brokers in KAFKA_SERVERS, a local Redpanda container by default.
+func NewKafkaTestHelper(pgConf *protos.PostgresConfig) *KafkaTestHelper {
+	servers := os.Getenv("KAFKA_SERVERS")
+	if servers == "" {
+		servers = "localhost:9092"
+	}
+
+	return &KafkaTestHelper{
+		kafkaConfig: &protos.KafkaConfig{
+			Servers:    strings.Split(servers, ","),
+			DisableTls: true,
+			MetadataDb: pgConf,
+		},
+	}
+}
+
+func (h *KafkaTestHelper) GetPeer() *protos.Peer {
+	return &protos.Peer{
+		Name: "test_kafka_peer",
+		Type: protos.DBType_KAFKA,
+		Config: &protos.Peer_KafkaConfig{
+			KafkaConfig: h.kafkaConfig,
+		},
+	}
+}
+
+// ConsumeMessages reads the first expectedNum records of a topic.
+func (h *KafkaTestHelper) ConsumeMessages(
+	ctx context.Context,
+	topic string,
+	expectedNum int,
+) ([]*kgo.Record, error) {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(h.kafkaConfig.Servers...),
+		kgo.ConsumeTopics(topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	records := make([]*kgo.Record, 0, expectedNum)
+	for len(records) < expectedNum {
+		fetches := client.PollFetches(ctx)
+		if err := fetches.Err(); err != nil {
+			return nil, err
+		}
+		records = append(records, fetches.Records()...)
+	}
+
+	return records, nil
+}
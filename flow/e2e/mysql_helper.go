@@ -0,0 +1,90 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	connmysql "github.com/PeerDB-io/peer-flow/connectors/mysql"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	util "github.com/PeerDB-io/peer-flow/utils"
+)
+
+type MySQLTestHelper struct {
+	config    *protos.MySqlConfig
+	connector *connmysql.MySQLConnector
+
+	Database string
+}
+
+// NewMySQLTestHelper connects to the server at MYSQL_HOST and MYSQL_PORT, a local mysqld container
+// started with --gtid-mode=ON --enforce-gtid-consistency=ON by default, and creates a test database.
+func NewMySQLTestHelper(pgConf *protos.PostgresConfig) (*MySQLTestHelper, error) {
+	host := os.Getenv("MYSQL_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+	port := 3306
+	if portEnv := os.Getenv("MYSQL_PORT"); portEnv != "" {
+		var err error
+		port, err = strconv.Atoi(portEnv)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MYSQL_PORT: %s", portEnv)
+		}
+	}
+	user := os.Getenv("MYSQL_USER")
+	if user == "" {
+		user = "root"
+	}
+
+	config := &protos.MySqlConfig{
+		Host:       host,
+		Port:       uint32(port),
+		User:       user,
+		Password:   os.Getenv("MYSQL_PASSWORD"),
+		MetadataDb: pgConf,
+	}
+
+	connector, err := connmysql.NewMySQLConnector(context.Background(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	rndNum, err := util.RandomUInt64()
+	if err != nil {
+		return nil, err
+	}
+
+	database := fmt.Sprintf("e2e_test_%d", rndNum)
+	err = connector.ExecuteQuery("CREATE DATABASE " + database)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MySQLTestHelper{
+		config:    config,
+		connector: connector,
+		Database:  database,
+	}, nil
+}
+
+func (h *MySQLTestHelper) GetPeer() *protos.Peer {
+	return &protos.Peer{
+		Name: "test_mysql_peer",
+		Type: protos.DBType_MYSQL,
+		Config: &protos.Peer_MysqlConfig{
+			MysqlConfig: h.config,
+		},
+	}
+}
+
+// Exec runs a statement on the test server.
+func (h *MySQLTestHelper) Exec(query string, args ...interface{}) error {
+	return h.connector.ExecuteQuery(query, args...)
+}
+
+func (h *MySQLTestHelper) CleanUp() error {
+	defer h.connector.Close()
+	return h.connector.ExecuteQuery("DROP DATABASE IF EXISTS " + h.Database)
+}
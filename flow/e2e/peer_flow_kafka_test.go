@@ -0,0 +1,97 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	util "github.com/PeerDB-io/peer-flow/utils"
+	peerflow "github.com/PeerDB-io/peer-flow/workflows"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *E2EPeerFlowTestSuite) setupKafka() {
+	if os.Getenv("ENABLE_KAFKA_TESTS") == "" {
+		return
+	}
+
+	s.kafkaHelper = NewKafkaTestHelper(GetTestPostgresConf())
+}
+
+func (s *E2EPeerFlowTestSuite) Test_Complete_Simple_Flow_Kafka() {
+	if s.kafkaHelper == nil {
+		s.T().Skip("Skipping Kafka test")
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	registerWorkflowsAndActivities(env)
+
+	ru, err := util.RandomUInt64()
+	s.NoError(err)
+
+	jobName := fmt.Sprintf("test_complete_simple_flow_kafka_%d", ru)
+	schemaQualifiedName := fmt.Sprintf("e2e_test.%s", jobName)
+	_, err = s.pool.Exec(context.Background(), `
+		CREATE TABLE `+schemaQualifiedName+` (
+			id SERIAL PRIMARY KEY,
+			key TEXT NOT NULL,
+			value TEXT NOT NULL
+		);
+	`)
+	s.NoError(err)
+
+	connectionGen := FlowConnectionGenerationConfig{
+		FlowJobName:      jobName,
+		TableNameMapping: map[string]string{schemaQualifiedName: jobName},
+		PostgresPort:     postgresPort,
+		Destination:      s.kafkaHelper.GetPeer(),
+	}
+
+	flowConnConfig, err := connectionGen.GenerateFlowConnectionConfigs()
+	s.NoError(err)
+
+	peerFlowInput := peerflow.PeerFlowLimits{
+		TotalSyncFlows: 2,
+		MaxBatchSize:   100,
+	}
+
+	// in a separate goroutine, wait for PeerFlowStatusQuery to finish setup
+	// and then insert 10 rows into the source table
+	go func() {
+		s.SetupPeerFlowStatusQuery(env, connectionGen)
+		// insert 10 rows into the source table
+		for i := 0; i < 10; i++ {
+			testKey := fmt.Sprintf("test_key_%d", i)
+			testValue := fmt.Sprintf("test_value_%d", i)
+			_, err = s.pool.Exec(context.Background(), `
+			INSERT INTO `+schemaQualifiedName+` (key, value) VALUES ($1, $2)
+		`, testKey, testValue)
+			s.NoError(err)
+		}
+		fmt.Println("Inserted 10 rows into the source table")
+	}()
+
+	env.ExecuteWorkflow(peerflow.PeerFlowWorkflowWithConfig, flowConnConfig, &peerFlowInput, nil)
+
+	// Verify workflow completes without error
+	s.True(env.IsWorkflowCompleted())
+	err = env.GetWorkflowError()
+
+	// allow only continue as new error
+	s.Error(err)
+	s.Contains(err.Error(), "continue as new")
+
+	// Verify that the topic of the table has 10 records, keyed by the primary key
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	records, err := s.kafkaHelper.ConsumeMessages(ctx, jobName, 10)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 10, len(records))
+	for i, record := range records {
+		require.Equal(s.T(), fmt.Sprintf(`{"id":%d}`, i+1), string(record.Key))
+	}
+
+	env.AssertExpectations(s.T())
+}
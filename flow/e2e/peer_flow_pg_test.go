@@ -0,0 +1,166 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	util "github.com/PeerDB-io/peer-flow/utils"
+	peerflow "github.com/PeerDB-io/peer-flow/workflows"
+)
+
+func (s *E2EPeerFlowTestSuite) Test_Complete_Simple_Flow_PG_Direct_Apply() {
+	env := s.NewTestWorkflowEnvironment()
+	registerWorkflowsAndActivities(env)
+
+	ru, err := util.RandomUInt64()
+	s.NoError(err)
+
+	jobName := fmt.Sprintf("test_direct_apply_pg_%d", ru)
+	srcTableName := fmt.Sprintf("e2e_test.%s", jobName)
+	dstTableName := fmt.Sprintf("e2e_test.%s_dst", jobName)
+	_, err = s.pool.Exec(context.Background(), `
+		CREATE TABLE `+srcTableName+` (
+			id SERIAL PRIMARY KEY,
+			key TEXT NOT NULL,
+			value TEXT NOT NULL
+		);
+	`)
+	s.NoError(err)
+
+	connectionGen := FlowConnectionGenerationConfig{
+		FlowJobName:      jobName,
+		TableNameMapping: map[string]string{srcTableName: dstTableName},
+		PostgresPort:     postgresPort,
+		Destination:      GeneratePostgresPeer(postgresPort),
+	}
+
+	flowConnConfig, err := connectionGen.GenerateFlowConnectionConfigs()
+	s.NoError(err)
+	flowConnConfig.CdcSyncMode = protos.CDCSyncMode_CDC_SYNC_MODE_DIRECT_APPLY
+
+	limits := peerflow.PeerFlowLimits{
+		TotalSyncFlows: 2,
+		MaxBatchSize:   100,
+	}
+
+	// in a separate goroutine, wait for PeerFlowStatusQuery to finish setup
+	// and then insert 10 rows, update one and delete another.
+	go func() {
+		s.SetupPeerFlowStatusQuery(env, connectionGen)
+		for i := 0; i < 10; i++ {
+			testKey := fmt.Sprintf("test_key_%d", i)
+			testValue := fmt.Sprintf("test_value_%d", i)
+			_, err = s.pool.Exec(context.Background(), `
+			INSERT INTO `+srcTableName+` (key, value) VALUES ($1, $2)
+		`, testKey, testValue)
+			s.NoError(err)
+		}
+		_, err = s.pool.Exec(context.Background(), `UPDATE `+srcTableName+` SET value='updated' WHERE id=1`)
+		s.NoError(err)
+		_, err = s.pool.Exec(context.Background(), `DELETE FROM `+srcTableName+` WHERE id=2`)
+		s.NoError(err)
+		fmt.Println("Changed 12 rows in the source table")
+	}()
+
+	env.ExecuteWorkflow(peerflow.PeerFlowWorkflowWithConfig, flowConnConfig, &limits, nil)
+
+	// Verify workflow completes without error
+	s.True(env.IsWorkflowCompleted())
+	err = env.GetWorkflowError()
+
+	// allow only continue as new error
+	s.Error(err)
+	s.Contains(err.Error(), "continue as new")
+
+	// the destination has the rows of the source, without any raw table in between.
+	for _, query := range []string{
+		fmt.Sprintf("SELECT id,key,value FROM %s EXCEPT SELECT id,key,value FROM %s", srcTableName, dstTableName),
+		fmt.Sprintf("SELECT id,key,value FROM %s EXCEPT SELECT id,key,value FROM %s", dstTableName, srcTableName),
+	} {
+		var count int64
+		err = s.pool.QueryRow(context.Background(), fmt.Sprintf("SELECT COUNT(*) FROM (%s) diff", query)).Scan(&count)
+		s.NoError(err)
+		s.Equal(int64(0), count)
+	}
+
+	env.AssertExpectations(s.T())
+}
+
+func (s *E2EPeerFlowTestSuite) Test_Full_Row_Changes_PG() {
+	env := s.NewTestWorkflowEnvironment()
+	registerWorkflowsAndActivities(env)
+
+	ru, err := util.RandomUInt64()
+	s.NoError(err)
+
+	jobName := fmt.Sprintf("test_full_row_pg_%d", ru)
+	srcTableName := fmt.Sprintf("e2e_test.%s", jobName)
+	dstTableName := fmt.Sprintf("e2e_test.%s_dst", jobName)
+	_, err = s.pool.Exec(context.Background(), `
+		CREATE TABLE `+srcTableName+` (
+			key TEXT NOT NULL,
+			value TEXT NOT NULL
+		);
+		ALTER TABLE `+srcTableName+` REPLICA IDENTITY FULL;
+	`)
+	s.NoError(err)
+
+	connectionGen := FlowConnectionGenerationConfig{
+		FlowJobName:      jobName,
+		TableNameMapping: map[string]string{srcTableName: dstTableName},
+		PostgresPort:     postgresPort,
+		Destination:      GeneratePostgresPeer(postgresPort),
+	}
+
+	flowConnConfig, err := connectionGen.GenerateFlowConnectionConfigs()
+	s.NoError(err)
+
+	limits := peerflow.PeerFlowLimits{
+		TotalSyncFlows: 2,
+		MaxBatchSize:   100,
+	}
+
+	// in a separate goroutine, wait for PeerFlowStatusQuery to finish setup and then, in a single
+	// transaction so that they're synced and normalized together, insert a row and update it, and
+	// update another row twice in a row.
+	go func() {
+		s.SetupPeerFlowStatusQuery(env, connectionGen)
+		_, err = s.pool.Exec(context.Background(), `
+			BEGIN;
+			INSERT INTO `+srcTableName+` (key, value) VALUES ('inserted', 'v1'), ('chained', 'v1'), ('kept', 'v1');
+			UPDATE `+srcTableName+` SET value='v2' WHERE key='inserted';
+			UPDATE `+srcTableName+` SET value='v2' WHERE key='chained';
+			UPDATE `+srcTableName+` SET value='v3' WHERE key='chained';
+			COMMIT;
+		`)
+		s.NoError(err)
+		fmt.Println("Changed 3 rows in the source table")
+	}()
+
+	env.ExecuteWorkflow(peerflow.PeerFlowWorkflowWithConfig, flowConnConfig, &limits, nil)
+
+	// Verify workflow completes without error
+	s.True(env.IsWorkflowCompleted())
+	err = env.GetWorkflowError()
+
+	// allow only continue as new error
+	s.Error(err)
+	s.Contains(err.Error(), "continue as new")
+
+	// every row is in the destination once, in its last state.
+	rows, err := s.pool.Query(context.Background(),
+		fmt.Sprintf("SELECT key,value FROM %s ORDER BY key", dstTableName))
+	s.NoError(err)
+	defer rows.Close()
+	var dstRows []string
+	for rows.Next() {
+		var key, value string
+		s.NoError(rows.Scan(&key, &value))
+		dstRows = append(dstRows, key+"="+value)
+	}
+	s.NoError(rows.Err())
+	s.Equal([]string{"chained=v3", "inserted=v2", "kept=v1"}, dstRows)
+
+	env.AssertExpectations(s.T())
+}
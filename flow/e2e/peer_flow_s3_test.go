@@ -3,10 +3,14 @@ package e2e
 import (
 	"context"
 	"fmt"
+	"os"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/PeerDB-io/peer-flow/generated/protos"
 	util "github.com/PeerDB-io/peer-flow/utils"
+	peerflow "github.com/PeerDB-io/peer-flow/workflows"
 	"github.com/stretchr/testify/require"
 )
 
@@ -89,3 +93,146 @@ func (s *E2EPeerFlowTestSuite) Test_Complete_QRep_Flow_S3() {
 
 	env.AssertExpectations(s.T())
 }
+
+func (s *E2EPeerFlowTestSuite) Test_Complete_QRep_Flow_S3_Parquet() {
+	if s.s3Helper == nil {
+		s.T().Skip("Skipping S3 test")
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	registerWorkflowsAndActivities(env)
+
+	ru, err := util.RandomUInt64()
+	s.NoError(err)
+
+	jobName := fmt.Sprintf("test_complete_flow_s3_parquet_%d", ru)
+
+	tblName := "test_qrep_flow_s3_parquet_1"
+	s.setupSourceTable(tblName, 10)
+	query := fmt.Sprintf("SELECT * FROM e2e_test.%s WHERE updated_at >= {{.start}} AND updated_at < {{.end}}", tblName)
+	qrepConfig := s.createQRepWorkflowConfig(
+		jobName,
+		"e2e_test."+tblName,
+		"e2e_dest_1",
+		query,
+		protos.QRepSyncMode_QREP_SYNC_MODE_STORAGE_PARQUET,
+		s.s3Helper.GetPeer(),
+	)
+	qrepConfig.ParquetCompression = "zstd"
+
+	runQrepFlowWorkflow(env, qrepConfig)
+
+	// Verify workflow completes without error
+	s.True(env.IsWorkflowCompleted())
+	err = env.GetWorkflowError()
+
+	s.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	files, err := s.s3Helper.ListAllFiles(ctx, jobName)
+
+	require.NoError(s.T(), err)
+
+	require.Equal(s.T(), 1, len(files))
+	require.True(s.T(), strings.HasSuffix(*files[0].Key, ".parquet"))
+
+	env.AssertExpectations(s.T())
+}
+
+// Test_Complete_Simple_Flow_S3_MinIO mirrors changes to an S3 compatible store, it runs when
+// AWS_ENDPOINT_URL_S3 points at one, e.g. a local MinIO server with
+// AWS_ENDPOINT_URL_S3=http://localhost:9000 and its root user as the access key.
+func (s *E2EPeerFlowTestSuite) Test_Complete_Simple_Flow_S3_MinIO() {
+	if s.s3Helper == nil || os.Getenv("AWS_ENDPOINT_URL_S3") == "" {
+		s.T().Skip("Skipping S3 CDC test, AWS_ENDPOINT_URL_S3 is not set")
+	}
+	require.NoError(s.T(), s.s3Helper.EnsureBucket())
+
+	env := s.NewTestWorkflowEnvironment()
+	registerWorkflowsAndActivities(env)
+
+	ru, err := util.RandomUInt64()
+	s.NoError(err)
+
+	jobName := fmt.Sprintf("test_simple_flow_s3_%d", ru)
+	srcTableName := fmt.Sprintf("e2e_test.%s", jobName)
+	dstTableName := "users"
+	_, err = s.pool.Exec(context.Background(), `
+		CREATE TABLE `+srcTableName+` (
+			id SERIAL PRIMARY KEY,
+			key TEXT NOT NULL,
+			value TEXT NOT NULL
+		);
+	`)
+	s.NoError(err)
+
+	connectionGen := FlowConnectionGenerationConfig{
+		FlowJobName:      jobName,
+		TableNameMapping: map[string]string{srcTableName: dstTableName},
+		PostgresPort:     postgresPort,
+		Destination:      s.s3Helper.GetPeer(),
+	}
+
+	flowConnConfig, err := connectionGen.GenerateFlowConnectionConfigs()
+	s.NoError(err)
+	flowConnConfig.CdcObjectFormat = "parquet"
+	flowConnConfig.ParquetCompression = "zstd"
+
+	limits := peerflow.PeerFlowLimits{
+		TotalSyncFlows: 2,
+		MaxBatchSize:   100,
+	}
+
+	// in a separate goroutine, wait for PeerFlowStatusQuery to finish setup
+	// and then insert 10 rows, update one and delete another.
+	go func() {
+		s.SetupPeerFlowStatusQuery(env, connectionGen)
+		for i := 0; i < 10; i++ {
+			testKey := fmt.Sprintf("test_key_%d", i)
+			testValue := fmt.Sprintf("test_value_%d", i)
+			_, err = s.pool.Exec(context.Background(), `
+			INSERT INTO `+srcTableName+` (key, value) VALUES ($1, $2)
+		`, testKey, testValue)
+			s.NoError(err)
+		}
+		_, err = s.pool.Exec(context.Background(), `UPDATE `+srcTableName+` SET value='updated' WHERE id=1`)
+		s.NoError(err)
+		_, err = s.pool.Exec(context.Background(), `DELETE FROM `+srcTableName+` WHERE id=2`)
+		s.NoError(err)
+		fmt.Println("Changed 12 rows in the source table")
+	}()
+
+	env.ExecuteWorkflow(peerflow.PeerFlowWorkflowWithConfig, flowConnConfig, &limits, nil)
+
+	// Verify workflow completes without error
+	s.True(env.IsWorkflowCompleted())
+	err = env.GetWorkflowError()
+
+	// allow only continue as new error
+	s.Error(err)
+	s.Contains(err.Error(), "continue as new")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// the manifest and the parquet objects of the batches, partitioned by table and commit date.
+	files, err := s.s3Helper.ListAllFiles(ctx, jobName)
+	require.NoError(s.T(), err)
+	numObjects := 0
+	hasManifest := false
+	for _, file := range files {
+		key := strings.TrimPrefix(*file.Key, path.Join(prefixName, jobName)+"/")
+		if key == "_peerdb_manifest.json" {
+			hasManifest = true
+			continue
+		}
+		require.Regexp(s.T(), `^users/dt=\d{4}-\d{2}-\d{2}/batch-\d+\.parquet$`, key)
+		numObjects++
+	}
+	require.True(s.T(), hasManifest)
+	require.Positive(s.T(), numObjects)
+
+	env.AssertExpectations(s.T())
+}
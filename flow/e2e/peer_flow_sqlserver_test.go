@@ -0,0 +1,92 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	util "github.com/PeerDB-io/peer-flow/utils"
+	peerflow "github.com/PeerDB-io/peer-flow/workflows"
+)
+
+func (s *E2EPeerFlowTestSuite) Test_Complete_Simple_Flow_SQLServer_PG() {
+	if s.sqlsHelper == nil {
+		s.T().Skip("Skipping SQL Server test")
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	registerWorkflowsAndActivities(env)
+
+	ru, err := util.RandomUInt64()
+	s.NoError(err)
+
+	jobName := fmt.Sprintf("test_simple_flow_sqlserver_%d", ru)
+	srcTableName := fmt.Sprintf("%s.%s", s.sqlsHelper.SchemaName, jobName)
+	dstTableName := fmt.Sprintf("e2e_test.%s", jobName)
+	err = s.sqlsHelper.E.ExecuteQuery(`
+		CREATE TABLE ` + srcTableName + ` (
+			id INT IDENTITY(1, 1) PRIMARY KEY,
+			"key" NVARCHAR(64) NOT NULL,
+			value NVARCHAR(MAX) NOT NULL
+		)
+	`)
+	s.NoError(err)
+	s.sqlsHelper.TrackTable(jobName)
+
+	connectionGen := FlowConnectionGenerationConfig{
+		FlowJobName:      jobName,
+		TableNameMapping: map[string]string{srcTableName: dstTableName},
+		PostgresPort:     postgresPort,
+		Destination:      GeneratePostgresPeer(postgresPort),
+	}
+
+	flowConnConfig, err := connectionGen.GenerateFlowConnectionConfigs()
+	s.NoError(err)
+	flowConnConfig.Source = s.sqlsHelper.GetPeer()
+	flowConnConfig.CdcSyncMode = protos.CDCSyncMode_CDC_SYNC_MODE_DIRECT_APPLY
+
+	limits := peerflow.PeerFlowLimits{
+		TotalSyncFlows: 2,
+		MaxBatchSize:   100,
+	}
+
+	// in a separate goroutine, wait for PeerFlowStatusQuery to finish setup
+	// and then insert 10 rows, update one and delete another.
+	go func() {
+		s.SetupPeerFlowStatusQuery(env, connectionGen)
+		for i := 0; i < 10; i++ {
+			testKey := fmt.Sprintf("test_key_%d", i)
+			testValue := fmt.Sprintf("test_value_%d", i)
+			err = s.sqlsHelper.E.ExecuteQuery(
+				`INSERT INTO `+srcTableName+` ("key", value) VALUES (@p1, @p2)`, testKey, testValue)
+			s.NoError(err)
+		}
+		err = s.sqlsHelper.E.ExecuteQuery(`UPDATE ` + srcTableName + ` SET value='updated' WHERE id=1`)
+		s.NoError(err)
+		err = s.sqlsHelper.E.ExecuteQuery(`DELETE FROM ` + srcTableName + ` WHERE id=2`)
+		s.NoError(err)
+		fmt.Println("Changed 12 rows in the source table")
+	}()
+
+	env.ExecuteWorkflow(peerflow.PeerFlowWorkflowWithConfig, flowConnConfig, &limits, nil)
+
+	// Verify workflow completes without error
+	s.True(env.IsWorkflowCompleted())
+	err = env.GetWorkflowError()
+
+	// allow only continue as new error
+	s.Error(err)
+	s.Contains(err.Error(), "continue as new")
+
+	var count int64
+	err = s.pool.QueryRow(context.Background(), "SELECT COUNT(*) FROM "+dstTableName).Scan(&count)
+	s.NoError(err)
+	s.Equal(int64(9), count)
+
+	var value string
+	err = s.pool.QueryRow(context.Background(), "SELECT value FROM "+dstTableName+" WHERE id=1").Scan(&value)
+	s.NoError(err)
+	s.Equal("updated", value)
+
+	env.AssertExpectations(s.T())
+}
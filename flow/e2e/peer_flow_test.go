@@ -28,11 +28,13 @@ type E2EPeerFlowTestSuite struct {
 	pgConnStr string
 	pool      *pgxpool.Pool
 
-	bqHelper   *BigQueryTestHelper
-	sfHelper   *SnowflakeTestHelper
-	ehHelper   *EventHubTestHelper
-	s3Helper   *S3TestHelper
-	sqlsHelper *SQLServerHelper
+	bqHelper    *BigQueryTestHelper
+	sfHelper    *SnowflakeTestHelper
+	ehHelper    *EventHubTestHelper
+	s3Helper    *S3TestHelper
+	sqlsHelper  *SQLServerHelper
+	kafkaHelper *KafkaTestHelper
+	mysqlHelper *MySQLTestHelper
 }
 
 func TestE2EPeerFlowTestSuite(t *testing.T) {
@@ -162,7 +164,7 @@ func (s *E2EPeerFlowTestSuite) setupSQLServer() {
 		return
 	}
 
-	sqlsHelper, err := NewSQLServerHelper("test_sqlserver_peer")
+	sqlsHelper, err := NewSQLServerHelper("test_sqlserver_peer", GetTestPostgresConf())
 	require.NoError(s.T(), err)
 	s.sqlsHelper = sqlsHelper
 }
@@ -207,6 +209,8 @@ func (s *E2EPeerFlowTestSuite) SetupSuite() {
 	}
 
 	s.setupSQLServer()
+	s.setupKafka()
+	s.setupMySQL()
 }
 
 // Implement TearDownAllSuite interface to tear down the test suite
@@ -255,6 +259,13 @@ func (s *E2EPeerFlowTestSuite) TearDownSuite() {
 			s.Fail("failed to clean up sqlserver", err)
 		}
 	}
+
+	if s.mysqlHelper != nil {
+		err = s.mysqlHelper.CleanUp()
+		if err != nil {
+			s.Fail("failed to clean up mysql", err)
+		}
+	}
 }
 
 func (s *E2EPeerFlowTestSuite) TearDownTest() {
@@ -116,3 +116,93 @@ func (s *E2EPeerFlowTestSuite) Test_Complete_QRep_Flow_SqlServer_Append() {
 
 	s.Equal(numRows, numRowsInDest)
 }
+
+func (s *E2EPeerFlowTestSuite) Test_Complete_QRep_Flow_PG_SqlServer_Append() {
+	if s.sqlsHelper == nil {
+		s.T().Skip("Skipping SQL Server test")
+	}
+
+	env := s.NewTestWorkflowEnvironment()
+	registerWorkflowsAndActivities(env)
+
+	numRows := 10
+	tblName := "test_qrep_flow_pg_ss_append"
+	s.setupSourceTable(tblName, numRows)
+
+	// the destination table is created from the schema of the first partition.
+	s.sqlsHelper.TrackTable(tblName)
+	dstTableName := fmt.Sprintf("%s.%s", s.sqlsHelper.SchemaName, tblName)
+
+	query := fmt.Sprintf("SELECT * FROM e2e_test.%s WHERE updated_at BETWEEN {{.start}} AND {{.end}}", tblName)
+
+	qrepConfig := s.createQRepWorkflowConfig(
+		"test_qrep_flow_pg_ss_append",
+		"e2e_test."+tblName,
+		dstTableName,
+		query,
+		protos.QRepSyncMode_QREP_SYNC_MODE_MULTI_INSERT,
+		s.sqlsHelper.GetPeer(),
+	)
+
+	runQrepFlowWorkflow(env, qrepConfig)
+
+	// Verify workflow completes without error
+	s.True(env.IsWorkflowCompleted())
+
+	err := env.GetWorkflowError()
+	s.NoError(err)
+
+	numRowsInDest, err := s.sqlsHelper.E.CountRows(s.sqlsHelper.SchemaName, tblName)
+	s.NoError(err)
+	s.Equal(int64(numRows), numRowsInDest)
+
+	env.AssertExpectations(s.T())
+}
+
+func (s *E2EPeerFlowTestSuite) Test_Complete_QRep_Flow_PG_SqlServer_Upsert() {
+	if s.sqlsHelper == nil {
+		s.T().Skip("Skipping SQL Server test")
+	}
+
+	numRows := 10
+	tblName := "test_qrep_flow_pg_ss_upsert"
+	s.setupSourceTable(tblName, numRows)
+
+	s.sqlsHelper.TrackTable(tblName)
+	dstTableName := fmt.Sprintf("%s.%s", s.sqlsHelper.SchemaName, tblName)
+
+	query := fmt.Sprintf("SELECT * FROM e2e_test.%s WHERE updated_at BETWEEN {{.start}} AND {{.end}}", tblName)
+
+	// replicating the same rows twice leaves a single copy of each row.
+	for i := 0; i < 2; i++ {
+		env := s.NewTestWorkflowEnvironment()
+		registerWorkflowsAndActivities(env)
+
+		qrepConfig := s.createQRepWorkflowConfig(
+			fmt.Sprintf("test_qrep_flow_pg_ss_upsert_%d", i),
+			"e2e_test."+tblName,
+			dstTableName,
+			query,
+			protos.QRepSyncMode_QREP_SYNC_MODE_MULTI_INSERT,
+			s.sqlsHelper.GetPeer(),
+		)
+		qrepConfig.WriteMode = &protos.QRepWriteMode{
+			WriteType:        protos.QRepWriteType_QREP_WRITE_MODE_UPSERT,
+			UpsertKeyColumns: []string{"id"},
+		}
+
+		runQrepFlowWorkflow(env, qrepConfig)
+
+		// Verify workflow completes without error
+		s.True(env.IsWorkflowCompleted())
+
+		err := env.GetWorkflowError()
+		s.NoError(err)
+
+		env.AssertExpectations(s.T())
+	}
+
+	numRowsInDest, err := s.sqlsHelper.E.CountRows(s.sqlsHelper.SchemaName, tblName)
+	s.NoError(err)
+	s.Equal(int64(numRows), numRowsInDest)
+}
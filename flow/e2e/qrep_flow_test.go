@@ -522,7 +522,7 @@ func (s *E2EPeerFlowTestSuite) Test_Complete_QRep_Flow_Avro_SF_S3_Integration()
 	query := fmt.Sprintf("SELECT * FROM e2e_test.%s WHERE updated_at >= {{.start}} AND updated_at < {{.end}}", tblName)
 
 	sfPeer := s.sfHelper.Peer
-	sfPeer.GetSnowflakeConfig().S3Integration = "peerdb_s3_integration"
+	sfPeer.GetSnowflakeConfig().StorageIntegration = "peerdb_s3_integration"
 
 	qrepConfig := s.createQRepWorkflowConfig(
 		"test_qrep_flow_avro_sf_int",
@@ -46,6 +46,19 @@ func (h *S3TestHelper) GetPeer() *protos.Peer {
 	}
 }
 
+// EnsureBucket creates the test bucket if it doesn't exist yet, as in a fresh MinIO server.
+func (h *S3TestHelper) EnsureBucket() error {
+	_, err := h.client.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String(bucketName)})
+	if err == nil {
+		return nil
+	}
+	_, err = h.client.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", bucketName, err)
+	}
+	return nil
+}
+
 // List all files from the S3 bucket.
 // returns as a list of S3Objects.
 func (h *S3TestHelper) ListAllFiles(
@@ -22,7 +22,7 @@ type SQLServerHelper struct {
 	tables     []string
 }
 
-func NewSQLServerHelper(name string) (*SQLServerHelper, error) {
+func NewSQLServerHelper(name string, pgConf *protos.PostgresConfig) (*SQLServerHelper, error) {
 	port, err := strconv.Atoi(os.Getenv("SQLSERVER_PORT"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid SQLSERVER_PORT: %s", os.Getenv("SQLSERVER_PORT"))
@@ -34,6 +34,8 @@ func NewSQLServerHelper(name string) (*SQLServerHelper, error) {
 		User:     os.Getenv("SQLSERVER_USER"),
 		Password: os.Getenv("SQLSERVER_PASSWORD"),
 		Database: os.Getenv("SQLSERVER_DATABASE"),
+		// the checkpoints of CDC mirrors are kept in the catalog.
+		MetadataDb: pgConf,
 	}
 
 	connector, err := connsqlserver.NewSQLServerConnector(context.Background(), config)
@@ -75,6 +77,11 @@ func (h *SQLServerHelper) CreateTable(schema *model.QRecordSchema, tableName str
 	return nil
 }
 
+// TrackTable drops a table created outside of the helper on clean up.
+func (h *SQLServerHelper) TrackTable(tableName string) {
+	h.tables = append(h.tables, tableName)
+}
+
 func (h *SQLServerHelper) GetPeer() *protos.Peer {
 	return &protos.Peer{
 		Name: h.peerName,
@@ -19,10 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	FlowService_CreatePeerFlow_FullMethodName = "/peerdb_route.FlowService/CreatePeerFlow"
-	FlowService_CreateQRepFlow_FullMethodName = "/peerdb_route.FlowService/CreateQRepFlow"
-	FlowService_HealthCheck_FullMethodName    = "/peerdb_route.FlowService/HealthCheck"
-	FlowService_ShutdownFlow_FullMethodName   = "/peerdb_route.FlowService/ShutdownFlow"
+	FlowService_CreatePeerFlow_FullMethodName    = "/peerdb_route.FlowService/CreatePeerFlow"
+	FlowService_CreateQRepFlow_FullMethodName    = "/peerdb_route.FlowService/CreateQRepFlow"
+	FlowService_HealthCheck_FullMethodName       = "/peerdb_route.FlowService/HealthCheck"
+	FlowService_ShutdownFlow_FullMethodName      = "/peerdb_route.FlowService/ShutdownFlow"
+	FlowService_PauseFlow_FullMethodName         = "/peerdb_route.FlowService/PauseFlow"
+	FlowService_ResumeFlow_FullMethodName        = "/peerdb_route.FlowService/ResumeFlow"
+	FlowService_GetFlowStatus_FullMethodName     = "/peerdb_route.FlowService/GetFlowStatus"
+	FlowService_GetQRepFlowStatus_FullMethodName = "/peerdb_route.FlowService/GetQRepFlowStatus"
+	FlowService_ValidateFlow_FullMethodName      = "/peerdb_route.FlowService/ValidateFlow"
 )
 
 // FlowServiceClient is the client API for FlowService service.
@@ -33,6 +38,11 @@ type FlowServiceClient interface {
 	CreateQRepFlow(ctx context.Context, in *CreateQRepFlowRequest, opts ...grpc.CallOption) (*CreateQRepFlowResponse, error)
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 	ShutdownFlow(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	PauseFlow(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error)
+	ResumeFlow(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error)
+	GetFlowStatus(ctx context.Context, in *FlowStatusRequest, opts ...grpc.CallOption) (*FlowStatusResponse, error)
+	GetQRepFlowStatus(ctx context.Context, in *QRepFlowStatusRequest, opts ...grpc.CallOption) (*QRepFlowStatusResponse, error)
+	ValidateFlow(ctx context.Context, in *ValidateFlowRequest, opts ...grpc.CallOption) (*ValidateFlowResponse, error)
 }
 
 type flowServiceClient struct {
@@ -79,6 +89,51 @@ func (c *flowServiceClient) ShutdownFlow(ctx context.Context, in *ShutdownReques
 	return out, nil
 }
 
+func (c *flowServiceClient) PauseFlow(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error) {
+	out := new(PauseResponse)
+	err := c.cc.Invoke(ctx, FlowService_PauseFlow_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowServiceClient) ResumeFlow(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error) {
+	out := new(ResumeResponse)
+	err := c.cc.Invoke(ctx, FlowService_ResumeFlow_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowServiceClient) GetFlowStatus(ctx context.Context, in *FlowStatusRequest, opts ...grpc.CallOption) (*FlowStatusResponse, error) {
+	out := new(FlowStatusResponse)
+	err := c.cc.Invoke(ctx, FlowService_GetFlowStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowServiceClient) GetQRepFlowStatus(ctx context.Context, in *QRepFlowStatusRequest, opts ...grpc.CallOption) (*QRepFlowStatusResponse, error) {
+	out := new(QRepFlowStatusResponse)
+	err := c.cc.Invoke(ctx, FlowService_GetQRepFlowStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowServiceClient) ValidateFlow(ctx context.Context, in *ValidateFlowRequest, opts ...grpc.CallOption) (*ValidateFlowResponse, error) {
+	out := new(ValidateFlowResponse)
+	err := c.cc.Invoke(ctx, FlowService_ValidateFlow_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FlowServiceServer is the server API for FlowService service.
 // All implementations must embed UnimplementedFlowServiceServer
 // for forward compatibility
@@ -87,6 +142,11 @@ type FlowServiceServer interface {
 	CreateQRepFlow(context.Context, *CreateQRepFlowRequest) (*CreateQRepFlowResponse, error)
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	ShutdownFlow(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	PauseFlow(context.Context, *PauseRequest) (*PauseResponse, error)
+	ResumeFlow(context.Context, *ResumeRequest) (*ResumeResponse, error)
+	GetFlowStatus(context.Context, *FlowStatusRequest) (*FlowStatusResponse, error)
+	GetQRepFlowStatus(context.Context, *QRepFlowStatusRequest) (*QRepFlowStatusResponse, error)
+	ValidateFlow(context.Context, *ValidateFlowRequest) (*ValidateFlowResponse, error)
 	mustEmbedUnimplementedFlowServiceServer()
 }
 
@@ -106,6 +166,21 @@ func (UnimplementedFlowServiceServer) HealthCheck(context.Context, *HealthCheckR
 func (UnimplementedFlowServiceServer) ShutdownFlow(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShutdownFlow not implemented")
 }
+func (UnimplementedFlowServiceServer) PauseFlow(context.Context, *PauseRequest) (*PauseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseFlow not implemented")
+}
+func (UnimplementedFlowServiceServer) ResumeFlow(context.Context, *ResumeRequest) (*ResumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeFlow not implemented")
+}
+func (UnimplementedFlowServiceServer) GetFlowStatus(context.Context, *FlowStatusRequest) (*FlowStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlowStatus not implemented")
+}
+func (UnimplementedFlowServiceServer) GetQRepFlowStatus(context.Context, *QRepFlowStatusRequest) (*QRepFlowStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQRepFlowStatus not implemented")
+}
+func (UnimplementedFlowServiceServer) ValidateFlow(context.Context, *ValidateFlowRequest) (*ValidateFlowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateFlow not implemented")
+}
 func (UnimplementedFlowServiceServer) mustEmbedUnimplementedFlowServiceServer() {}
 
 // UnsafeFlowServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -191,6 +266,96 @@ func _FlowService_ShutdownFlow_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FlowService_PauseFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowServiceServer).PauseFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowService_PauseFlow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowServiceServer).PauseFlow(ctx, req.(*PauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowService_ResumeFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowServiceServer).ResumeFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowService_ResumeFlow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowServiceServer).ResumeFlow(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowService_GetFlowStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlowStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowServiceServer).GetFlowStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowService_GetFlowStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowServiceServer).GetFlowStatus(ctx, req.(*FlowStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowService_GetQRepFlowStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QRepFlowStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowServiceServer).GetQRepFlowStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowService_GetQRepFlowStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowServiceServer).GetQRepFlowStatus(ctx, req.(*QRepFlowStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowService_ValidateFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateFlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowServiceServer).ValidateFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowService_ValidateFlow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowServiceServer).ValidateFlow(ctx, req.(*ValidateFlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FlowService_ServiceDesc is the grpc.ServiceDesc for FlowService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -214,6 +379,26 @@ var FlowService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ShutdownFlow",
 			Handler:    _FlowService_ShutdownFlow_Handler,
 		},
+		{
+			MethodName: "PauseFlow",
+			Handler:    _FlowService_PauseFlow_Handler,
+		},
+		{
+			MethodName: "ResumeFlow",
+			Handler:    _FlowService_ResumeFlow_Handler,
+		},
+		{
+			MethodName: "GetFlowStatus",
+			Handler:    _FlowService_GetFlowStatus_Handler,
+		},
+		{
+			MethodName: "GetQRepFlowStatus",
+			Handler:    _FlowService_GetQRepFlowStatus_Handler,
+		},
+		{
+			MethodName: "ValidateFlow",
+			Handler:    _FlowService_ValidateFlow_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "route.proto",
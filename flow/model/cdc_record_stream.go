@@ -0,0 +1,214 @@
+package model
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+)
+
+// CDCRecordStream carries the records of a sync batch from the source to the destination while
+// they're pulled, so that only as many records as the stream buffers are held in memory at a time.
+// The source sends the records, sets the checkpoint and schema changes of the batch and the stream
+// is closed once it returns; the destination reads the records until the stream is closed and
+// checks Err before committing the batch.
+type CDCRecordStream struct {
+	records chan Record
+	// transformers applied to records as they're sent, keyed by source table name.
+	transformers map[string]*RecordTransformer
+
+	// closed once the first record is sent or the stream is closed.
+	ready      chan struct{}
+	readyOnce  sync.Once
+	hasRecords bool
+
+	closeOnce   sync.Once
+	abandoned   chan struct{}
+	abandonOnce sync.Once
+
+	// set by the source while pulling, only read once the stream is closed.
+	err                    error
+	lastCheckPointID       int64
+	relationMessageMapping map[uint32]*protos.RelationMessage
+
+	// schema changes can be read while pulling, the source adds them before it sends the records
+	// with the changed columns.
+	tableSchemaDeltasMu sync.Mutex
+	tableSchemaDeltas   []*protos.TableSchemaDelta
+}
+
+// NewCDCRecordStream returns a stream buffering up to bufferSize records, the records are
+// transformed by the transformers of their source table as they're sent.
+func NewCDCRecordStream(bufferSize int, transformers map[string]*RecordTransformer) *CDCRecordStream {
+	return &CDCRecordStream{
+		records:      make(chan Record, bufferSize),
+		transformers: transformers,
+		ready:        make(chan struct{}),
+		abandoned:    make(chan struct{}),
+	}
+}
+
+// Send transforms a record, waits for room in the stream and sends it. It returns
+// ErrRecordStreamAbandoned once the destination has abandoned the stream, the source
+// should stop pulling then.
+func (s *CDCRecordStream) Send(record Record) error {
+	if err := TransformRecord(record, s.transformers); err != nil {
+		return err
+	}
+
+	s.readyOnce.Do(func() {
+		s.hasRecords = true
+		close(s.ready)
+	})
+
+	select {
+	case s.records <- record:
+		return nil
+	case <-s.abandoned:
+		return ErrRecordStreamAbandoned
+	}
+}
+
+// UpdateLastCheckPointID sets the checkpoint the batch ends at.
+func (s *CDCRecordStream) UpdateLastCheckPointID(checkpointID int64) {
+	s.lastCheckPointID = checkpointID
+}
+
+// AddSchemaDelta adds a schema change of a source table seen while pulling, before the records
+// with the changed columns are sent.
+func (s *CDCRecordStream) AddSchemaDelta(delta *protos.TableSchemaDelta) {
+	s.tableSchemaDeltasMu.Lock()
+	defer s.tableSchemaDeltasMu.Unlock()
+	s.tableSchemaDeltas = append(s.tableSchemaDeltas, delta)
+}
+
+// SetRelationMessageMapping sets the relations known after pulling, to be passed on to the next pull.
+func (s *CDCRecordStream) SetRelationMessageMapping(relations map[uint32]*protos.RelationMessage) {
+	s.relationMessageMapping = relations
+}
+
+// Close ends the stream with the error of the source, nil if the whole batch was sent.
+func (s *CDCRecordStream) Close(err error) {
+	s.closeOnce.Do(func() {
+		s.err = err
+		s.readyOnce.Do(func() {
+			close(s.ready)
+		})
+		close(s.records)
+	})
+}
+
+// Abandon tells the source that the destination won't read any more records.
+func (s *CDCRecordStream) Abandon() {
+	s.abandonOnce.Do(func() {
+		close(s.abandoned)
+	})
+}
+
+// WaitAndCheckEmpty waits for the first record or the end of the stream,
+// it returns true if the stream was closed without records.
+func (s *CDCRecordStream) WaitAndCheckEmpty() bool {
+	<-s.ready
+	return !s.hasRecords
+}
+
+// Records returns the records of the stream, the channel is closed with the stream.
+func (s *CDCRecordStream) Records() <-chan Record {
+	return s.records
+}
+
+// Err returns the error the stream was closed with. It must only be called once Records is drained,
+// the records of a stream closed with an error are incomplete and must not be committed.
+func (s *CDCRecordStream) Err() error {
+	return s.err
+}
+
+// LastCheckPointID returns the checkpoint the batch ends at, once Records is drained.
+func (s *CDCRecordStream) LastCheckPointID() int64 {
+	return s.lastCheckPointID
+}
+
+// TableSchemaDeltas returns the schema changes of source tables seen while pulling the batch, as seen
+// on the source. They're only complete once the source is done, but cover the records read so far.
+func (s *CDCRecordStream) TableSchemaDeltas() []*protos.TableSchemaDelta {
+	s.tableSchemaDeltasMu.Lock()
+	defer s.tableSchemaDeltasMu.Unlock()
+	return append([]*protos.TableSchemaDelta(nil), s.tableSchemaDeltas...)
+}
+
+// DestinationTableSchemaDeltas returns the schema changes of the batch transformed like its records,
+// as they apply to the destination tables. They're only complete once the source is done, but cover
+// the records read so far.
+func (s *CDCRecordStream) DestinationTableSchemaDeltas() []*protos.TableSchemaDelta {
+	return TransformTableSchemaDeltas(s.TableSchemaDeltas(), s.transformers)
+}
+
+// DestinationTableSchemaDeltasSince returns the schema changes of the batch added after the first n, transformed
+// like its records. Destinations encoding records with the schema of their table apply these before each record,
+// the source adds schema changes before the records with the columns they added.
+func (s *CDCRecordStream) DestinationTableSchemaDeltasSince(n int) []*protos.TableSchemaDelta {
+	s.tableSchemaDeltasMu.Lock()
+	if len(s.tableSchemaDeltas) <= n {
+		s.tableSchemaDeltasMu.Unlock()
+		return nil
+	}
+	deltas := append([]*protos.TableSchemaDelta(nil), s.tableSchemaDeltas[n:]...)
+	s.tableSchemaDeltasMu.Unlock()
+	return TransformTableSchemaDeltas(deltas, s.transformers)
+}
+
+// RelationMessageMapping returns the relations known after pulling the batch, once the source is done.
+func (s *CDCRecordStream) RelationMessageMapping() map[uint32]*protos.RelationMessage {
+	return s.relationMessageMapping
+}
+
+// ToRecordBatch reads the remaining records of a stream into a batch.
+func (s *CDCRecordStream) ToRecordBatch() (*RecordBatch, error) {
+	batch := &RecordBatch{
+		Records: make([]Record, 0),
+	}
+	for record := range s.Records() {
+		batch.Records = append(batch.Records, record)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(batch.Records) > 0 {
+		batch.FirstCheckPointID = batch.Records[0].GetCheckPointID()
+	}
+	batch.LastCheckPointID = s.LastCheckPointID()
+	batch.TableSchemaDeltas = s.TableSchemaDeltas()
+	batch.RelationMessageMapping = s.RelationMessageMapping()
+	return batch, nil
+}
+
+// TransformRecord applies the transformers, keyed by source table name, to a record.
+func TransformRecord(record Record, transformers map[string]*RecordTransformer) error {
+	if len(transformers) == 0 {
+		return nil
+	}
+
+	var err error
+	switch r := record.(type) {
+	case *InsertRecord:
+		if t, ok := transformers[r.SourceTableName]; ok {
+			r.Items, r.UnchangedToastColumns, err = t.TransformItems(r.Items, r.UnchangedToastColumns)
+		}
+	case *UpdateRecord:
+		if t, ok := transformers[r.SourceTableName]; ok {
+			r.OldItems, _, err = t.TransformItems(r.OldItems, nil)
+			if err == nil {
+				r.NewItems, r.UnchangedToastColumns, err = t.TransformItems(r.NewItems, r.UnchangedToastColumns)
+			}
+		}
+	case *DeleteRecord:
+		if t, ok := transformers[r.SourceTableName]; ok {
+			r.Items, r.UnchangedToastColumns, err = t.TransformItems(r.Items, r.UnchangedToastColumns)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to transform record of table %s: %w", record.GetTableName(), err)
+	}
+	return nil
+}
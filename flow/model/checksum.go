@@ -0,0 +1,153 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/google/uuid"
+)
+
+// QRecordChecksum is the number of records of a set and a checksum of their values. The checksum
+// doesn't depend on the order of the records, and values are normalized before they're hashed so
+// that a row read back from a destination matches the row pulled from its source, like an int32
+// and the numeric it's stored as.
+type QRecordChecksum struct {
+	NumRecords int64
+	sum        uint64
+}
+
+// Add adds a record to the checksum.
+func (c *QRecordChecksum) Add(record *QRecord) error {
+	buf := make([]byte, 0, 64)
+	for i, entry := range record.Entries {
+		var err error
+		buf, err = appendChecksumValue(buf, entry.Kind, entry.Value)
+		if err != nil {
+			return fmt.Errorf("failed to checksum value %d: %w", i, err)
+		}
+	}
+
+	// the sum of the record hashes is the same whatever order the records are added in.
+	hash := sha256.Sum256(buf)
+	c.sum += binary.LittleEndian.Uint64(hash[:8])
+	c.NumRecords++
+	return nil
+}
+
+// String returns the checksum as hex.
+func (c *QRecordChecksum) String() string {
+	return fmt.Sprintf("%016x", c.sum)
+}
+
+// ChecksumQRecordStream reads the records of a stream until it's closed and returns their checksum.
+func ChecksumQRecordStream(stream *QRecordStream) (*QRecordChecksum, error) {
+	checksum := &QRecordChecksum{}
+	for record := range stream.Records() {
+		if err := checksum.Add(record); err != nil {
+			return nil, err
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, fmt.Errorf("failed to pull records: %w", err)
+	}
+	return checksum, nil
+}
+
+// appendChecksumValue appends a tag for the family of a value and its normalized encoding.
+func appendChecksumValue(buf []byte, kind qvalue.QValueKind, value interface{}) ([]byte, error) {
+	if value == nil {
+		return append(buf, 'n'), nil
+	}
+
+	switch kind {
+	case qvalue.QValueKindTime, qvalue.QValueKindTimeTZ:
+		if t, ok := value.(time.Time); ok {
+			return appendChecksumBytes(buf, 's', []byte(t.UTC().Format("15:04:05.999999"))), nil
+		}
+	case qvalue.QValueKindDate:
+		if t, ok := value.(time.Time); ok {
+			return appendChecksumBytes(buf, 's', []byte(t.Format("2006-01-02"))), nil
+		}
+	case qvalue.QValueKindUUID:
+		switch v := value.(type) {
+		case [16]byte:
+			return appendChecksumBytes(buf, 's', []byte(uuid.UUID(v).String())), nil
+		case uuid.UUID:
+			return appendChecksumBytes(buf, 's', []byte(v.String())), nil
+		}
+	}
+
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return append(buf, 'b', 1), nil
+		}
+		return append(buf, 'b', 0), nil
+	case int:
+		return appendChecksumNumber(buf, new(big.Rat).SetInt64(int64(v))), nil
+	case int16:
+		return appendChecksumNumber(buf, new(big.Rat).SetInt64(int64(v))), nil
+	case int32:
+		return appendChecksumNumber(buf, new(big.Rat).SetInt64(int64(v))), nil
+	case int64:
+		return appendChecksumNumber(buf, new(big.Rat).SetInt64(v)), nil
+	case *big.Rat:
+		return appendChecksumNumber(buf, v), nil
+	case big.Rat:
+		return appendChecksumNumber(buf, &v), nil
+	case float32:
+		return appendChecksumBytes(buf, 'f', []byte(formatChecksumFloat(float64(v)))), nil
+	case float64:
+		return appendChecksumBytes(buf, 'f', []byte(formatChecksumFloat(v))), nil
+	case string:
+		return appendChecksumBytes(buf, 's', []byte(v)), nil
+	case []byte:
+		return appendChecksumBytes(buf, 'x', v), nil
+	case time.Time:
+		return appendChecksumBytes(buf, 't', []byte(strconv.FormatInt(v.UnixMicro(), 10))), nil
+	}
+
+	// arrays hash their elements in order.
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		buf = append(buf, 'a')
+		buf = binary.AppendUvarint(buf, uint64(rv.Len()))
+		elementKind := kind.ArrayElementKind()
+		for i := 0; i < rv.Len(); i++ {
+			var err error
+			buf, err = appendChecksumValue(buf, elementKind, rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value %T of kind %s", value, kind)
+}
+
+func appendChecksumBytes(buf []byte, tag byte, value []byte) []byte {
+	buf = append(buf, tag)
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+// appendChecksumNumber encodes integers and numerics alike, destinations often widen one to the other.
+func appendChecksumNumber(buf []byte, value *big.Rat) []byte {
+	return appendChecksumBytes(buf, 'd', []byte(value.RatString()))
+}
+
+// formatChecksumFloat formats floats that are exact float32s at float32 precision, so a real
+// stored as a double by a destination keeps its checksum.
+func formatChecksumFloat(value float64) string {
+	if float64(float32(value)) == value {
+		return strconv.FormatFloat(value, 'g', -1, 32)
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
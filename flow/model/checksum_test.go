@@ -0,0 +1,106 @@
+package model
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newChecksumRecord(values ...qvalue.QValue) *QRecord {
+	record := NewQRecord(len(values))
+	for i, value := range values {
+		record.Set(i, value)
+	}
+	return record
+}
+
+func checksumOf(t *testing.T, records ...*QRecord) *QRecordChecksum {
+	checksum := &QRecordChecksum{}
+	for _, record := range records {
+		require.NoError(t, checksum.Add(record))
+	}
+	return checksum
+}
+
+func TestChecksumNormalizesValues(t *testing.T) {
+	id := uuid.New()
+	ts := time.Date(2023, 7, 1, 12, 30, 0, 123456000, time.UTC)
+
+	// a row as pulled from a Postgres source.
+	source := newChecksumRecord(
+		qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(42)},
+		qvalue.QValue{Kind: qvalue.QValueKindNumeric, Value: big.NewRat(3, 2)},
+		qvalue.QValue{Kind: qvalue.QValueKindFloat32, Value: float32(0.1)},
+		qvalue.QValue{Kind: qvalue.QValueKindUUID, Value: [16]byte(id)},
+		qvalue.QValue{Kind: qvalue.QValueKindTimestamp, Value: ts},
+		qvalue.QValue{Kind: qvalue.QValueKindDate, Value: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+		qvalue.QValue{Kind: qvalue.QValueKindArrayInt32, Value: []int32{1, 2}},
+		qvalue.QValue{Kind: qvalue.QValueKindString, Value: nil},
+	)
+	// the same row as read back from a warehouse.
+	destination := newChecksumRecord(
+		qvalue.QValue{Kind: qvalue.QValueKindNumeric, Value: big.NewRat(42, 1)},
+		qvalue.QValue{Kind: qvalue.QValueKindNumeric, Value: big.NewRat(15, 10)},
+		qvalue.QValue{Kind: qvalue.QValueKindFloat64, Value: float64(float32(0.1))},
+		qvalue.QValue{Kind: qvalue.QValueKindString, Value: id.String()},
+		qvalue.QValue{Kind: qvalue.QValueKindTimestamp, Value: ts.In(time.FixedZone("IST", 19800))},
+		qvalue.QValue{Kind: qvalue.QValueKindDate, Value: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+		qvalue.QValue{Kind: qvalue.QValueKindArrayInt64, Value: []interface{}{int64(1), int64(2)}},
+		qvalue.QValue{Kind: qvalue.QValueKindString, Value: nil},
+	)
+
+	assert.Equal(t, checksumOf(t, source).String(), checksumOf(t, destination).String())
+}
+
+func TestChecksumDetectsDifferences(t *testing.T) {
+	row := func(id int64, name interface{}) *QRecord {
+		return newChecksumRecord(
+			qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: id},
+			qvalue.QValue{Kind: qvalue.QValueKindString, Value: name},
+		)
+	}
+
+	expected := checksumOf(t, row(1, "a"), row(2, "b"))
+	// the order of the rows doesn't matter.
+	assert.Equal(t, expected.String(), checksumOf(t, row(2, "b"), row(1, "a")).String())
+	assert.Equal(t, int64(2), expected.NumRecords)
+
+	assert.NotEqual(t, expected.String(), checksumOf(t, row(1, "a"), row(2, "c")).String())
+	assert.NotEqual(t, expected.String(), checksumOf(t, row(1, "a"), row(2, nil)).String())
+	assert.NotEqual(t, expected.String(), checksumOf(t, row(1, "a")).String())
+	// values don't run into each other.
+	assert.NotEqual(t,
+		checksumOf(t, newChecksumRecord(
+			qvalue.QValue{Kind: qvalue.QValueKindString, Value: "ab"},
+			qvalue.QValue{Kind: qvalue.QValueKindString, Value: "c"},
+		)).String(),
+		checksumOf(t, newChecksumRecord(
+			qvalue.QValue{Kind: qvalue.QValueKindString, Value: "a"},
+			qvalue.QValue{Kind: qvalue.QValueKindString, Value: "bc"},
+		)).String())
+}
+
+func TestChecksumQRecordStream(t *testing.T) {
+	stream := NewQRecordStream(2)
+	go func() {
+		for i := int64(0); i < 5; i++ {
+			stream.Send(newChecksumRecord(qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: i}))
+		}
+		stream.Close(nil)
+	}()
+
+	checksum, err := ChecksumQRecordStream(stream)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), checksum.NumRecords)
+
+	stream = NewQRecordStream(1)
+	stream.Close(errors.New("connection reset"))
+	_, err = ChecksumQRecordStream(stream)
+	assert.ErrorContains(t, err, "connection reset")
+}
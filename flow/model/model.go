@@ -1,7 +1,9 @@
 package model
 
 import (
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -24,12 +26,25 @@ type PullRecordsRequest struct {
 	TableNameMapping map[string]string
 	// tablename to schema mapping
 	TableNameSchemaMapping map[string]*protos.TableSchema
+	// relations seen by earlier pulls, keyed by relation id.
+	RelationMessageMapping map[uint32]*protos.RelationMessage
+	// columns to replicate, keyed by source table name.
+	ColumnFilters map[string]*protos.ColumnFilter
+	// row filter expressions, keyed by source table name.
+	RowFilters map[string]string
+	// TransactionalBatches ends batches between source transactions only,
+	// so that a batch can be applied to the destination atomically.
+	TransactionalBatches bool
+	// RecordStream is where the pulled records are sent, along with the checkpoint
+	// and schema changes of the batch.
+	RecordStream *CDCRecordStream
 }
 
 type Record interface {
 	// GetCheckPointID returns the ID of the record.
 	GetCheckPointID() int64
-	// get table name
+	// GetTableName returns the destination table of the record, changes are routed to
+	// the raw table rows, topics and tables of their destination table.
 	GetTableName() string
 	// get columns and values for the record
 	GetItems() RecordItems
@@ -75,6 +90,8 @@ type InsertRecord struct {
 	Items RecordItems
 	// unchanged toast columns
 	UnchangedToastColumns map[string]bool
+	// CommitTime is when the transaction of the change committed on the source.
+	CommitTime time.Time
 }
 
 // Implement Record interface for InsertRecord.
@@ -103,6 +120,8 @@ type UpdateRecord struct {
 	NewItems RecordItems
 	// unchanged toast columns
 	UnchangedToastColumns map[string]bool
+	// CommitTime is when the transaction of the change committed on the source.
+	CommitTime time.Time
 }
 
 // Implement Record interface for UpdateRecord.
@@ -130,6 +149,8 @@ type DeleteRecord struct {
 	Items RecordItems
 	// unchanged toast columns
 	UnchangedToastColumns map[string]bool
+	// CommitTime is when the transaction of the change committed on the source.
+	CommitTime time.Time
 }
 
 // Implement Record interface for DeleteRecord.
@@ -138,16 +159,81 @@ func (r *DeleteRecord) GetCheckPointID() int64 {
 }
 
 func (r *DeleteRecord) GetTableName() string {
-	return r.SourceTableName
+	return r.DestinationTableName
 }
 
 func (r *DeleteRecord) GetItems() RecordItems {
 	return r.Items
 }
 
+type TruncateRecord struct {
+	// Name of the source table
+	SourceTableName string
+	// Name of the destination table
+	DestinationTableName string
+	// CheckPointID is the ID of the record.
+	CheckPointID int64
+	// CommitTime is when the transaction of the change committed on the source.
+	CommitTime time.Time
+}
+
+// Implement Record interface for TruncateRecord.
+func (r *TruncateRecord) GetCheckPointID() int64 {
+	return r.CheckPointID
+}
+
+func (r *TruncateRecord) GetTableName() string {
+	return r.DestinationTableName
+}
+
+// a truncate carries no row data.
+func (r *TruncateRecord) GetItems() RecordItems {
+	return RecordItems{}
+}
+
+// RawRecordPosition is the position of a record in the raw table of a mirror, records are ordered by the
+// batch they were synced in and then by their timestamp within the batch.
+type RawRecordPosition struct {
+	BatchID   int64
+	Timestamp int64
+}
+
+// RawRecordTimestamps hands out the timestamps, in nanoseconds, of the records of a sync batch. They start
+// at the time of the sync and strictly increase in the order the records are read, whatever the clock does,
+// so the records of a batch keep the order of the source.
+type RawRecordTimestamps struct {
+	next int64
+}
+
+func NewRawRecordTimestamps() *RawRecordTimestamps {
+	return &RawRecordTimestamps{next: time.Now().UnixNano()}
+}
+
+// Next returns the timestamp of the next record of the batch.
+func (t *RawRecordTimestamps) Next() int64 {
+	timestamp := t.next
+	t.next++
+	return timestamp
+}
+
 type TableWithPkey struct {
-	TableName  string
-	PkeyColVal interface{}
+	TableName string
+	// SHA256 hash of the primary key column values, in primary key order.
+	PkeyColVal [32]byte
+}
+
+// RecToTablePKey returns the TableWithPkey for a record, keyed on all of its primary key columns.
+func RecToTablePKey(tableName string, pkeyCols []string, items RecordItems) TableWithPkey {
+	hasher := sha256.New()
+	for _, pkeyCol := range pkeyCols {
+		pkeyColVal := items[pkeyCol]
+		// NUL separator so that adjacent values can't run into each other.
+		fmt.Fprintf(hasher, "%v\x00", pkeyColVal.Value)
+	}
+
+	tablePkey := TableWithPkey{TableName: tableName}
+	copy(tablePkey.PkeyColVal[:], hasher.Sum(nil))
+	return tablePkey
 }
 
 type RecordBatch struct {
@@ -157,14 +243,23 @@ type RecordBatch struct {
 	FirstCheckPointID int64
 	// LastCheckPointID is the last ID of the commit that corresponds to this batch.
 	LastCheckPointID int64
-	//TablePkey to record index mapping
-	TablePKeyLastSeen map[TableWithPkey]int
+	// schema changes of source tables seen while pulling this batch.
+	TableSchemaDeltas []*protos.TableSchemaDelta
+	// all relations known after pulling this batch, to be passed on to the next pull.
+	RelationMessageMapping map[uint32]*protos.RelationMessage
 }
 
 type SyncRecordsRequest struct {
-	Records *RecordBatch
+	// Records are read from the stream while they're pulled.
+	Records *CDCRecordStream
 	// FlowJobName is the name of the flow job.
 	FlowJobName string
+	// SyncMode is how the records are written to the destination.
+	SyncMode protos.CDCSyncMode
+	// ObjectFormat is the format of the objects object store destinations write the records as.
+	ObjectFormat string
+	// ParquetCompression is the compression codec of Parquet objects.
+	ParquetCompression string
 }
 
 type NormalizeRecordsRequest struct {
@@ -178,6 +273,12 @@ type SyncResponse struct {
 	LastSyncedCheckPointID int64
 	// NumRecordsSynced is the number of records that were synced.
 	NumRecordsSynced int64
+	// CurrentSyncBatchID is the batch ID the records were synced under, 0 if nothing was synced.
+	CurrentSyncBatchID int64
+	// TableSchemaDeltas are the schema changes applied to the destination tables in this sync.
+	TableSchemaDeltas []*protos.TableSchemaDelta
+	// RelationMessageMapping are the relations known to the source after this sync.
+	RelationMessageMapping map[uint32]*protos.RelationMessage
 }
 
 type NormalizeResponse struct {
@@ -186,3 +287,24 @@ type NormalizeResponse struct {
 	StartBatchID int64
 	EndBatchID   int64
 }
+
+// ApplyTableSchemaDelta updates the schema of a destination table with the columns
+// added and dropped on the source. Retyped columns keep their type, since destinations
+// only add columns and the existing column still has the type it was created with.
+func ApplyTableSchemaDelta(schema *protos.TableSchema, delta *protos.TableSchemaDelta) {
+	for _, col := range delta.AddedColumns {
+		schema.Columns[col.ColumnName] = col.ColumnType
+	}
+
+	pkeyCols := make(map[string]bool)
+	for _, colName := range schema.PrimaryKeyColumns {
+		pkeyCols[colName] = true
+	}
+	for _, colName := range delta.DroppedColumns {
+		// key columns are still needed to merge the records synced before the drop.
+		if pkeyCols[colName] {
+			continue
+		}
+		delete(schema.Columns, colName)
+	}
+}
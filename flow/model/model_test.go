@@ -0,0 +1,47 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyTableSchemaDelta(t *testing.T) {
+	schema := &protos.TableSchema{
+		TableIdentifier:   "public.users",
+		Columns:           map[string]string{"id": "int64", "name": "string", "age": "int32"},
+		PrimaryKeyColumns: []string{"id"},
+	}
+
+	ApplyTableSchemaDelta(schema, &protos.TableSchemaDelta{
+		SrcTableName:   "public.users",
+		DstTableName:   "public.users",
+		AddedColumns:   []*protos.DeltaColumn{{ColumnName: "email", ColumnType: "string"}},
+		DroppedColumns: []string{"id", "name"},
+		RetypedColumns: []*protos.DeltaColumn{{ColumnName: "age", ColumnType: "int64"}},
+	})
+
+	// key columns are kept and retyped columns keep the type of the destination column.
+	assert.Equal(t, map[string]string{"id": "int64", "age": "int32", "email": "string"}, schema.Columns)
+}
+
+func TestRecordGetTableName(t *testing.T) {
+	// deletes used to return their source table, sending them where no other change of the table went.
+	for _, record := range []Record{
+		&InsertRecord{SourceTableName: "public.users", DestinationTableName: "users_dst"},
+		&UpdateRecord{SourceTableName: "public.users", DestinationTableName: "users_dst"},
+		&DeleteRecord{SourceTableName: "public.users", DestinationTableName: "users_dst"},
+		&TruncateRecord{SourceTableName: "public.users", DestinationTableName: "users_dst"},
+	} {
+		assert.Equal(t, "users_dst", record.GetTableName(), "%T", record)
+	}
+}
+
+func TestRawRecordTimestamps(t *testing.T) {
+	// records read within the same clock tick still get increasing timestamps.
+	timestamps := NewRawRecordTimestamps()
+	first := timestamps.Next()
+	assert.Equal(t, first+1, timestamps.Next())
+	assert.Equal(t, first+2, timestamps.Next())
+}
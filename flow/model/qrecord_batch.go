@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"math/big"
+	"net/netip"
 	"time"
 
 	"github.com/PeerDB-io/peer-flow/model/qvalue"
@@ -47,28 +48,53 @@ func (q *QRecordBatch) Equals(other *QRecordBatch) bool {
 	return true
 }
 
-type QRecordBatchCopyFromSource struct {
-	currentIndex int
-	records      *QRecordBatch
-	err          error
+// QRecordStreamCopyFromSource is a pgx.CopyFromSource reading the records of a stream.
+type QRecordStreamCopyFromSource struct {
+	numRecords    int
+	stream        *QRecordStream
+	currentRecord *QRecord
+	err           error
 }
 
-func NewQRecordBatchCopyFromSource(
-	records *QRecordBatch,
-) *QRecordBatchCopyFromSource {
-	return &QRecordBatchCopyFromSource{
-		records: records,
+func NewQRecordStreamCopyFromSource(
+	stream *QRecordStream,
+) *QRecordStreamCopyFromSource {
+	return &QRecordStreamCopyFromSource{
+		stream: stream,
 	}
 }
 
-func (src *QRecordBatchCopyFromSource) Next() bool {
-	return src.currentIndex < len(src.records.Records)
+func (src *QRecordStreamCopyFromSource) Next() bool {
+	record, ok := <-src.stream.Records()
+	if !ok {
+		src.err = src.stream.Err()
+		return false
+	}
+	src.currentRecord = record
+	src.numRecords++
+	return true
 }
 
-func (src *QRecordBatchCopyFromSource) Values() ([]interface{}, error) {
-	record := src.records.Records[src.currentIndex]
-	src.currentIndex++
+func (src *QRecordStreamCopyFromSource) Values() ([]interface{}, error) {
+	values, err := recordToCopyValues(src.currentRecord)
+	if err != nil {
+		src.err = err
+		return nil, err
+	}
+	return values, nil
+}
 
+// NumRecords returns the number of records read from the stream so far.
+func (src *QRecordStreamCopyFromSource) NumRecords() int {
+	return src.numRecords
+}
+
+func (src *QRecordStreamCopyFromSource) Err() error {
+	return src.err
+}
+
+// recordToCopyValues converts the values of a record to the types pgx copies them as.
+func recordToCopyValues(record *QRecord) ([]interface{}, error) {
 	numEntries := len(record.Entries)
 
 	values := make([]interface{}, numEntries)
@@ -77,32 +103,28 @@ func (src *QRecordBatchCopyFromSource) Values() ([]interface{}, error) {
 		case qvalue.QValueKindFloat32:
 			v, ok := qValue.Value.(float32)
 			if !ok {
-				src.err = fmt.Errorf("invalid float32 value")
-				return nil, src.err
+				return nil, fmt.Errorf("invalid float32 value")
 			}
 			values[i] = v
 
 		case qvalue.QValueKindFloat64:
 			v, ok := qValue.Value.(float64)
 			if !ok {
-				src.err = fmt.Errorf("invalid float64 value")
-				return nil, src.err
+				return nil, fmt.Errorf("invalid float64 value")
 			}
 			values[i] = v
 
 		case qvalue.QValueKindInt16, qvalue.QValueKindInt32:
 			v, ok := qValue.Value.(int32)
 			if !ok {
-				src.err = fmt.Errorf("invalid int32 value")
-				return nil, src.err
+				return nil, fmt.Errorf("invalid int32 value")
 			}
 			values[i] = v
 
 		case qvalue.QValueKindInt64:
 			v, ok := qValue.Value.(int64)
 			if !ok {
-				src.err = fmt.Errorf("invalid int64 value")
-				return nil, src.err
+				return nil, fmt.Errorf("invalid int64 value")
 			}
 			values[i] = v
 
@@ -115,8 +137,7 @@ func (src *QRecordBatchCopyFromSource) Values() ([]interface{}, error) {
 		case qvalue.QValueKindTimestamp:
 			t, ok := qValue.Value.(time.Time)
 			if !ok {
-				src.err = fmt.Errorf("invalid ExtendedTime value")
-				return nil, src.err
+				return nil, fmt.Errorf("invalid ExtendedTime value")
 			}
 			timestamp := pgtype.Timestamp{Time: t, Valid: true}
 			values[i] = timestamp
@@ -124,8 +145,7 @@ func (src *QRecordBatchCopyFromSource) Values() ([]interface{}, error) {
 		case qvalue.QValueKindTimestampTZ:
 			t, ok := qValue.Value.(time.Time)
 			if !ok {
-				src.err = fmt.Errorf("invalid ExtendedTime value")
-				return nil, src.err
+				return nil, fmt.Errorf("invalid ExtendedTime value")
 			}
 			timestampTZ := pgtype.Timestamptz{Time: t, Valid: true}
 			values[i] = timestampTZ
@@ -137,16 +157,14 @@ func (src *QRecordBatchCopyFromSource) Values() ([]interface{}, error) {
 
 			v, ok := qValue.Value.([16]byte) // treat it as byte slice
 			if !ok {
-				src.err = fmt.Errorf("invalid UUID value %v", qValue.Value)
-				return nil, src.err
+				return nil, fmt.Errorf("invalid UUID value %v", qValue.Value)
 			}
 			values[i] = uuid.UUID(v)
 
 		case qvalue.QValueKindNumeric:
 			v, ok := qValue.Value.(*big.Rat)
 			if !ok {
-				src.err = fmt.Errorf("invalid Numeric value %v", qValue.Value)
-				return nil, src.err
+				return nil, fmt.Errorf("invalid Numeric value %v", qValue.Value)
 			}
 			// TODO: account for precision and scale issues.
 			values[i] = v.FloatString(38)
@@ -154,20 +172,57 @@ func (src *QRecordBatchCopyFromSource) Values() ([]interface{}, error) {
 		case qvalue.QValueKindBytes, qvalue.QValueKindBit:
 			v, ok := qValue.Value.([]byte)
 			if !ok {
-				src.err = fmt.Errorf("invalid Bytes value")
-				return nil, src.err
+				return nil, fmt.Errorf("invalid Bytes value")
 			}
 			values[i] = v
 
+		case qvalue.QValueKindInterval:
+			v, ok := qValue.Value.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid Interval value %v", qValue.Value)
+			}
+			var interval pgtype.Interval
+			if err := interval.Scan(v); err != nil {
+				return nil, fmt.Errorf("invalid Interval value %v: %w", qValue.Value, err)
+			}
+			values[i] = interval
+
+		case qvalue.QValueKindINET, qvalue.QValueKindCIDR:
+			v, ok := qValue.Value.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid network address value %v", qValue.Value)
+			}
+			prefix, err := parseNetworkAddress(v)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = prefix
+
+		case qvalue.QValueKindMacaddr, qvalue.QValueKindEnum, qvalue.QValueKindHStore,
+			qvalue.QValueKindGeometry, qvalue.QValueKindGeography:
+			values[i] = qValue.Value
+
+		case qvalue.QValueKindArrayInt32, qvalue.QValueKindArrayInt64, qvalue.QValueKindArrayFloat32,
+			qvalue.QValueKindArrayFloat64, qvalue.QValueKindArrayBoolean, qvalue.QValueKindArrayString:
+			values[i] = qValue.Value
+
 		// And so on for the other types...
 		default:
-			src.err = fmt.Errorf("unsupported value type %s", qValue.Kind)
-			return nil, src.err
+			return nil, fmt.Errorf("unsupported value type %s", qValue.Kind)
 		}
 	}
 	return values, nil
 }
 
-func (src *QRecordBatchCopyFromSource) Err() error {
-	return src.err
+// parseNetworkAddress parses an inet or cidr value, host addresses may come without a netmask.
+func parseNetworkAddress(addr string) (netip.Prefix, error) {
+	if prefix, err := netip.ParsePrefix(addr); err == nil {
+		return prefix, nil
+	}
+
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid network address value %s: %w", addr, err)
+	}
+	return netip.PrefixFrom(ip, ip.BitLen()), nil
 }
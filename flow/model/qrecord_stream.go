@@ -0,0 +1,134 @@
+package model
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrRecordStreamAbandoned is returned by sources when the destination stopped reading their stream.
+var ErrRecordStreamAbandoned = errors.New("record stream abandoned by destination")
+
+// QRecordStream carries the records of a QRep partition from the source to the destination while
+// they're pulled, so that only as many records as the stream buffers are held in memory at a time.
+// The source sets the schema, sends the records and closes the stream; the destination waits for
+// the schema, reads the records until the stream is closed and checks Err before committing.
+type QRecordStream struct {
+	schema     *QRecordSchema
+	schemaSet  chan struct{}
+	schemaOnce sync.Once
+
+	records   chan *QRecord
+	closeOnce sync.Once
+	// only read once records is closed, or the schema wasn't set before the stream was closed.
+	err error
+
+	abandoned   chan struct{}
+	abandonOnce sync.Once
+}
+
+// NewQRecordStream returns a stream buffering up to bufferSize records.
+func NewQRecordStream(bufferSize int) *QRecordStream {
+	return &QRecordStream{
+		schemaSet: make(chan struct{}),
+		records:   make(chan *QRecord, bufferSize),
+		abandoned: make(chan struct{}),
+	}
+}
+
+// SetSchema sets the schema of the records, it must be called before the first record is sent.
+func (s *QRecordStream) SetSchema(schema *QRecordSchema) {
+	s.schemaOnce.Do(func() {
+		s.schema = schema
+		close(s.schemaSet)
+	})
+}
+
+// Schema waits for the schema of the records. It returns the error of the source
+// if the stream was closed without a schema.
+func (s *QRecordStream) Schema() (*QRecordSchema, error) {
+	<-s.schemaSet
+	if s.schema == nil {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, errors.New("record stream closed without a schema")
+	}
+	return s.schema, nil
+}
+
+// Send waits for room in the stream and sends a record. It returns false once the destination has
+// abandoned the stream, the source should stop pulling then.
+func (s *QRecordStream) Send(record *QRecord) bool {
+	select {
+	case s.records <- record:
+		return true
+	case <-s.abandoned:
+		return false
+	}
+}
+
+// Close ends the stream with the error of the source, nil if every record was sent.
+func (s *QRecordStream) Close(err error) {
+	s.closeOnce.Do(func() {
+		s.err = err
+		s.schemaOnce.Do(func() {
+			close(s.schemaSet)
+		})
+		close(s.records)
+	})
+}
+
+// Abandon tells the source that the destination won't read any more records.
+func (s *QRecordStream) Abandon() {
+	s.abandonOnce.Do(func() {
+		close(s.abandoned)
+	})
+}
+
+// Records returns the records of the stream, the channel is closed with the stream.
+func (s *QRecordStream) Records() <-chan *QRecord {
+	return s.records
+}
+
+// Err returns the error the stream was closed with. It must only be called once Records is drained,
+// the records of a stream closed with an error are incomplete and must not be committed.
+func (s *QRecordStream) Err() error {
+	return s.err
+}
+
+// ToQRecordStream sends the records of a batch to a new stream and closes it.
+func (q *QRecordBatch) ToQRecordStream(bufferSize int) *QRecordStream {
+	stream := NewQRecordStream(bufferSize)
+	stream.SetSchema(q.Schema)
+	go func() {
+		for _, record := range q.Records {
+			if !stream.Send(record) {
+				break
+			}
+		}
+		stream.Close(nil)
+	}()
+	return stream
+}
+
+// ToQRecordBatch reads the remaining records of a stream into a batch.
+func (s *QRecordStream) ToQRecordBatch() (*QRecordBatch, error) {
+	schema, err := s.Schema()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*QRecord, 0)
+	for record := range s.Records() {
+		records = append(records, record)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return &QRecordBatch{
+		NumRecords: uint32(len(records)),
+		Records:    records,
+		Schema:     schema,
+	}, nil
+}
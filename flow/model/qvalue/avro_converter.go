@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,7 +23,8 @@ type QValueKindAvroSchema struct {
 // representing the Avro schema and an error if the QValueKind is unsupported.
 //
 // For example, QValueKindInt64 would return an AvroLogicalSchema of "long". Unsupported QValueKinds
-// will return an error.
+// will return an error. Array kinds map to an Avro array of their element's schema, while
+// intervals, network addresses, enums, hstore and geospatial kinds are written as strings.
 //
 // The function currently does not support the following QValueKinds:
 // - QValueKindJSON
@@ -34,7 +36,8 @@ type QValueKindAvroSchema struct {
 // set to false, regardless of the nullable value passed in.
 func GetAvroSchemaFromQValueKind(kind QValueKind, nullable bool) (*QValueKindAvroSchema, error) {
 	switch kind {
-	case QValueKindString, QValueKindUUID:
+	case QValueKindString, QValueKindUUID, QValueKindInterval, QValueKindINET, QValueKindCIDR,
+		QValueKindMacaddr, QValueKindEnum, QValueKindHStore, QValueKindGeometry, QValueKindGeography:
 		return &QValueKindAvroSchema{
 			AvroLogicalSchema: "string",
 		}, nil
@@ -73,6 +76,18 @@ func GetAvroSchemaFromQValueKind(kind QValueKind, nullable bool) (*QValueKindAvr
 				"type": "string",
 			},
 		}, nil
+	case QValueKindArrayInt32, QValueKindArrayInt64, QValueKindArrayFloat32, QValueKindArrayFloat64,
+		QValueKindArrayBoolean, QValueKindArrayString:
+		items, err := GetAvroSchemaFromQValueKind(kind.ArrayElementKind(), false)
+		if err != nil {
+			return nil, err
+		}
+		return &QValueKindAvroSchema{
+			AvroLogicalSchema: map[string]interface{}{
+				"type":  "array",
+				"items": items.AvroLogicalSchema,
+			},
+		}, nil
 	case QValueKindJSON, QValueKindArray, QValueKindStruct:
 		return nil, fmt.Errorf("complex or unsupported types: %s", kind)
 	default:
@@ -115,8 +130,12 @@ func (c *QValueAvroConverter) ToAvroValue() (interface{}, error) {
 		} else {
 			return t.(int64), nil
 		}
-	case QValueKindString:
+	case QValueKindString, QValueKindInterval, QValueKindINET, QValueKindCIDR, QValueKindMacaddr,
+		QValueKindEnum, QValueKindHStore, QValueKindGeometry, QValueKindGeography:
 		return c.processNullableUnion("string", c.Value.Value)
+	case QValueKindArrayInt32, QValueKindArrayInt64, QValueKindArrayFloat32, QValueKindArrayFloat64,
+		QValueKindArrayBoolean, QValueKindArrayString:
+		return c.processArray()
 	case QValueKindFloat32:
 		return c.processNullableUnion("float", c.Value.Value)
 	case QValueKindFloat64:
@@ -219,6 +238,28 @@ func (c *QValueAvroConverter) processBytes() (interface{}, error) {
 	return byteData, nil
 }
 
+func (c *QValueAvroConverter) processArray() (interface{}, error) {
+	if c.Value.Value == nil && c.Nullable {
+		return nil, nil
+	}
+
+	arrayVal := reflect.ValueOf(c.Value.Value)
+	if arrayVal.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("invalid Array value: expected a slice, got %T", c.Value.Value)
+	}
+
+	items := make([]interface{}, 0, arrayVal.Len())
+	for i := 0; i < arrayVal.Len(); i++ {
+		items = append(items, arrayVal.Index(i).Interface())
+	}
+
+	if c.Nullable {
+		return goavro.Union("array", items), nil
+	}
+
+	return items, nil
+}
+
 func (c *QValueAvroConverter) processUUID() (interface{}, error) {
 	if c.Value.Value == nil {
 		return nil, nil
@@ -23,4 +23,46 @@ const (
 	QValueKindUUID        QValueKind = "uuid"
 	QValueKindJSON        QValueKind = "json"
 	QValueKindBit         QValueKind = "bit"
+	QValueKindInterval    QValueKind = "interval"
+	QValueKindINET        QValueKind = "inet"
+	QValueKindCIDR        QValueKind = "cidr"
+	QValueKindMacaddr     QValueKind = "macaddr"
+	QValueKindEnum        QValueKind = "enum"
+	QValueKindHStore      QValueKind = "hstore"
+	QValueKindGeometry    QValueKind = "geometry"
+	QValueKindGeography   QValueKind = "geography"
+
+	// array kinds hold one dimensional arrays of their element kind, nulls are dropped.
+	QValueKindArrayInt32   QValueKind = "array_int32"
+	QValueKindArrayInt64   QValueKind = "array_int64"
+	QValueKindArrayFloat32 QValueKind = "array_float32"
+	QValueKindArrayFloat64 QValueKind = "array_float64"
+	QValueKindArrayBoolean QValueKind = "array_bool"
+	QValueKindArrayString  QValueKind = "array_string"
 )
+
+// IsArray returns true if the kind holds an array of values.
+func (kind QValueKind) IsArray() bool {
+	return kind.ArrayElementKind() != QValueKindInvalid
+}
+
+// ArrayElementKind returns the kind of the elements of an array kind,
+// or QValueKindInvalid if the kind is not an array kind.
+func (kind QValueKind) ArrayElementKind() QValueKind {
+	switch kind {
+	case QValueKindArrayInt32:
+		return QValueKindInt32
+	case QValueKindArrayInt64:
+		return QValueKindInt64
+	case QValueKindArrayFloat32:
+		return QValueKindFloat32
+	case QValueKindArrayFloat64:
+		return QValueKindFloat64
+	case QValueKindArrayBoolean:
+		return QValueKindBoolean
+	case QValueKindArrayString:
+		return QValueKindString
+	default:
+		return QValueKindInvalid
+	}
+}
@@ -37,8 +37,12 @@ func (q *QValue) Equals(other *QValue) bool {
 		return compareArray(q.Value, other.Value)
 	case QValueKindStruct:
 		return compareStruct(q.Value, other.Value)
-	case QValueKindString:
+	case QValueKindString, QValueKindInterval, QValueKindINET, QValueKindCIDR, QValueKindMacaddr,
+		QValueKindEnum, QValueKindHStore, QValueKindGeometry, QValueKindGeography:
 		return compareString(q.Value, other.Value)
+	case QValueKindArrayInt32, QValueKindArrayInt64, QValueKindArrayFloat32, QValueKindArrayFloat64,
+		QValueKindArrayBoolean, QValueKindArrayString:
+		return compareTypedArray(q.Value, other.Value)
 	// all internally represented as a Golang time.Time
 	case QValueKindTime, QValueKindTimeTZ, QValueKindDate,
 		QValueKindTimestamp, QValueKindTimestampTZ:
@@ -190,6 +194,15 @@ func compareString(value1, value2 interface{}) bool {
 	return ok1 && ok2 && str1 == str2
 }
 
+// compareTypedArray compares the typed slices held by the array kinds.
+func compareTypedArray(value1, value2 interface{}) bool {
+	if value1 == nil && value2 == nil {
+		return true
+	}
+
+	return reflect.DeepEqual(value1, value2)
+}
+
 func compareArray(value1, value2 interface{}) bool {
 	array1, ok1 := value1.([]interface{})
 	array2, ok2 := value2.([]interface{})
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRecordStreamClosedWithError(t *testing.T) {
+	stream := NewQRecordStream(1)
+	pullErr := errors.New("connection reset")
+	stream.Close(pullErr)
+
+	_, err := stream.Schema()
+	assert.ErrorIs(t, err, pullErr)
+	_, err = stream.ToQRecordBatch()
+	assert.ErrorIs(t, err, pullErr)
+}
+
+func TestQRecordStreamAbandon(t *testing.T) {
+	stream := NewQRecordStream(1)
+	stream.SetSchema(NewQRecordSchema([]*QField{{Name: "id", Type: qvalue.QValueKindInt64}}))
+
+	record := NewQRecord(1)
+	record.Set(0, qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(1)})
+	assert.True(t, stream.Send(record))
+	// the buffer is full, sends return once the destination abandons the stream.
+	stream.Abandon()
+	assert.False(t, stream.Send(record))
+}
+
+func TestCDCRecordStream(t *testing.T) {
+	transformers, err := NewRecordTransformers(map[string]*protos.TableTransforms{
+		"public.users": {
+			Transforms: []*protos.RecordTransform{
+				{Type: protos.RecordTransformType_RECORD_TRANSFORM_DROP, Column: "password"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	stream := NewCDCRecordStream(1, transformers)
+	go func() {
+		for i := 1; i <= 3; i++ {
+			err := stream.Send(&InsertRecord{
+				SourceTableName:      "public.users",
+				DestinationTableName: "users",
+				CheckPointID:         int64(i),
+				Items: RecordItems{
+					"id":       qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(i)},
+					"password": qvalue.QValue{Kind: qvalue.QValueKindString, Value: "secret"},
+				},
+				UnchangedToastColumns: make(map[string]bool),
+			})
+			if err != nil {
+				stream.Close(err)
+				return
+			}
+		}
+		stream.UpdateLastCheckPointID(4)
+		stream.AddSchemaDelta(&protos.TableSchemaDelta{
+			SrcTableName: "public.users",
+			DstTableName: "users",
+			AddedColumns: []*protos.DeltaColumn{
+				{ColumnName: "email", ColumnType: string(qvalue.QValueKindString)},
+				{ColumnName: "password", ColumnType: string(qvalue.QValueKindString)},
+			},
+		})
+		stream.Close(nil)
+	}()
+
+	assert.False(t, stream.WaitAndCheckEmpty())
+	batch, err := stream.ToRecordBatch()
+	require.NoError(t, err)
+	require.Len(t, batch.Records, 3)
+	assert.Equal(t, int64(1), batch.FirstCheckPointID)
+	assert.Equal(t, int64(4), batch.LastCheckPointID)
+	assert.Len(t, batch.TableSchemaDeltas, 1)
+	// records are transformed as they're sent.
+	_, ok := batch.Records[0].GetItems()["password"]
+	assert.False(t, ok)
+	// and so are the schema changes applying to the destination.
+	dstDeltas := stream.DestinationTableSchemaDeltas()
+	require.Len(t, dstDeltas, 1)
+	assert.Equal(t, []*protos.DeltaColumn{{ColumnName: "email", ColumnType: string(qvalue.QValueKindString)}},
+		dstDeltas[0].AddedColumns)
+}
+
+func TestCDCRecordStreamEmpty(t *testing.T) {
+	stream := NewCDCRecordStream(1, nil)
+	stream.Close(nil)
+	assert.True(t, stream.WaitAndCheckEmpty())
+	assert.NoError(t, stream.Err())
+}
+
+func TestCDCRecordStreamAbandon(t *testing.T) {
+	stream := NewCDCRecordStream(0, nil)
+	stream.Abandon()
+	err := stream.Send(&DeleteRecord{
+		SourceTableName: "public.users",
+		Items:           RecordItems{},
+	})
+	assert.ErrorIs(t, err, ErrRecordStreamAbandoned)
+}
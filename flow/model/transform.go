@@ -0,0 +1,713 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+)
+
+// RecordTransformer applies the transforms of a table to its records and its schema. Transforms run
+// between pulling and syncing records, so every destination gets the same transformed rows.
+type RecordTransformer struct {
+	transforms []*protos.RecordTransform
+	// parsed templates of computed columns and the columns they reference, by transform index.
+	templates       map[int]*template.Template
+	templateColumns map[int][]string
+	// converted values of constant columns, by transform index.
+	constants map[int]qvalue.QValue
+}
+
+// kinds columns can be cast to and constants can be given as.
+var transformKinds = map[qvalue.QValueKind]bool{
+	qvalue.QValueKindString:  true,
+	qvalue.QValueKindInt32:   true,
+	qvalue.QValueKindInt64:   true,
+	qvalue.QValueKindFloat32: true,
+	qvalue.QValueKindFloat64: true,
+	qvalue.QValueKindBoolean: true,
+}
+
+// NewRecordTransformer validates the transforms of a table and prepares them to be applied.
+func NewRecordTransformer(transforms *protos.TableTransforms) (*RecordTransformer, error) {
+	t := &RecordTransformer{
+		transforms:      transforms.GetTransforms(),
+		templates:       make(map[int]*template.Template),
+		templateColumns: make(map[int][]string),
+		constants:       make(map[int]qvalue.QValue),
+	}
+
+	for i, transform := range t.transforms {
+		switch transform.Type {
+		case protos.RecordTransformType_RECORD_TRANSFORM_UNSPECIFIED:
+			return nil, fmt.Errorf("transform %d: no transform type given", i)
+		case protos.RecordTransformType_RECORD_TRANSFORM_RENAME:
+			if transform.Column == "" || transform.NewColumn == "" {
+				return nil, fmt.Errorf("transform %d: rename needs a column and a new column", i)
+			}
+		case protos.RecordTransformType_RECORD_TRANSFORM_HASH, protos.RecordTransformType_RECORD_TRANSFORM_DROP:
+			if transform.Column == "" {
+				return nil, fmt.Errorf("transform %d: %s needs a column", i, transform.Type)
+			}
+		case protos.RecordTransformType_RECORD_TRANSFORM_MASK:
+			if transform.Column == "" {
+				return nil, fmt.Errorf("transform %d: mask needs a column", i)
+			}
+			if _, err := maskVisibleChars(transform); err != nil {
+				return nil, fmt.Errorf("transform %d: %w", i, err)
+			}
+		case protos.RecordTransformType_RECORD_TRANSFORM_CAST:
+			if transform.Column == "" {
+				return nil, fmt.Errorf("transform %d: cast needs a column", i)
+			}
+			if !transformKinds[qvalue.QValueKind(transform.NewType)] {
+				return nil, fmt.Errorf("transform %d: unsupported cast to %q", i, transform.NewType)
+			}
+		case protos.RecordTransformType_RECORD_TRANSFORM_COMPUTED:
+			if transform.NewColumn == "" {
+				return nil, fmt.Errorf("transform %d: computed column needs a new column", i)
+			}
+			tmpl, err := template.New(transform.NewColumn).Option("missingkey=error").Parse(transform.Value)
+			if err != nil {
+				return nil, fmt.Errorf("transform %d: invalid template for computed column: %w", i, err)
+			}
+			columns := make(map[string]bool)
+			collectTemplateColumns(tmpl.Tree.Root, columns)
+			t.templates[i] = tmpl
+			t.templateColumns[i] = sortedKeys(columns)
+		case protos.RecordTransformType_RECORD_TRANSFORM_CONSTANT:
+			if transform.NewColumn == "" {
+				return nil, fmt.Errorf("transform %d: constant column needs a new column", i)
+			}
+			kind := constantKind(transform)
+			if !transformKinds[kind] {
+				return nil, fmt.Errorf("transform %d: unsupported constant type %q", i, transform.NewType)
+			}
+			val, err := castQValue(qvalue.QValue{Kind: qvalue.QValueKindString, Value: transform.Value}, kind)
+			if err != nil {
+				return nil, fmt.Errorf("transform %d: %w", i, err)
+			}
+			t.constants[i] = val
+		default:
+			return nil, fmt.Errorf("transform %d: unknown transform type %s", i, transform.Type)
+		}
+	}
+
+	return t, nil
+}
+
+// NewRecordTransformers prepares the transforms of every table, keyed by source table name.
+func NewRecordTransformers(transforms map[string]*protos.TableTransforms) (map[string]*RecordTransformer, error) {
+	transformers := make(map[string]*RecordTransformer, len(transforms))
+	for srcTableName, tableTransforms := range transforms {
+		if len(tableTransforms.GetTransforms()) == 0 {
+			continue
+		}
+		transformer, err := NewRecordTransformer(tableTransforms)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transforms for table %s: %w", srcTableName, err)
+		}
+		transformers[srcTableName] = transformer
+	}
+	return transformers, nil
+}
+
+// TransformItems applies the transforms to the columns of a row. Rows of updates and deletes may
+// only carry some columns, transforms of missing columns are skipped and computed columns left out.
+// Columns computed from unchanged toast columns are unchanged toast columns themselves.
+func (t *RecordTransformer) TransformItems(
+	items RecordItems,
+	unchangedToastColumns map[string]bool,
+) (RecordItems, map[string]bool, error) {
+	newItems := make(RecordItems, len(items))
+	for col, val := range items {
+		newItems[col] = val
+	}
+	newUnchangedToastColumns := make(map[string]bool, len(unchangedToastColumns))
+	for col, unchanged := range unchangedToastColumns {
+		newUnchangedToastColumns[col] = unchanged
+	}
+
+	for i, transform := range t.transforms {
+		val, ok := newItems[transform.Column]
+		switch transform.Type {
+		case protos.RecordTransformType_RECORD_TRANSFORM_RENAME:
+			if ok {
+				delete(newItems, transform.Column)
+				newItems[transform.NewColumn] = val
+			}
+			if newUnchangedToastColumns[transform.Column] {
+				delete(newUnchangedToastColumns, transform.Column)
+				newUnchangedToastColumns[transform.NewColumn] = true
+			}
+		case protos.RecordTransformType_RECORD_TRANSFORM_DROP:
+			delete(newItems, transform.Column)
+			delete(newUnchangedToastColumns, transform.Column)
+		case protos.RecordTransformType_RECORD_TRANSFORM_HASH:
+			if ok {
+				hashed, err := hashQValue(val, transform.Value)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to hash column %s: %w", transform.Column, err)
+				}
+				newItems[transform.Column] = hashed
+			}
+		case protos.RecordTransformType_RECORD_TRANSFORM_MASK:
+			if ok {
+				masked, err := maskQValue(val, transform)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to mask column %s: %w", transform.Column, err)
+				}
+				newItems[transform.Column] = masked
+			}
+		case protos.RecordTransformType_RECORD_TRANSFORM_CAST:
+			if ok {
+				cast, err := castQValue(val, qvalue.QValueKind(transform.NewType))
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to cast column %s: %w", transform.Column, err)
+				}
+				newItems[transform.Column] = cast
+			}
+		case protos.RecordTransformType_RECORD_TRANSFORM_CONSTANT:
+			newItems[transform.NewColumn] = t.constants[i]
+		case protos.RecordTransformType_RECORD_TRANSFORM_COMPUTED:
+			computed, unchangedToast, err := t.computeColumn(i, newItems, newUnchangedToastColumns)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to compute column %s: %w", transform.NewColumn, err)
+			}
+			if computed != nil {
+				newItems[transform.NewColumn] = *computed
+			} else if unchangedToast {
+				newUnchangedToastColumns[transform.NewColumn] = true
+			}
+		}
+	}
+
+	return newItems, newUnchangedToastColumns, nil
+}
+
+// computeColumn renders the template of a computed column. It returns nil if the row doesn't carry
+// every referenced column, with unchangedToast set if one of them is an unchanged toast column.
+func (t *RecordTransformer) computeColumn(
+	idx int,
+	items RecordItems,
+	unchangedToastColumns map[string]bool,
+) (computed *qvalue.QValue, unchangedToast bool, err error) {
+	data := make(map[string]string)
+	for _, col := range t.templateColumns[idx] {
+		if unchangedToastColumns[col] {
+			return nil, true, nil
+		}
+		val, ok := items[col]
+		if !ok {
+			return nil, false, nil
+		}
+		str, _, err := qValueToString(val)
+		if err != nil {
+			return nil, false, err
+		}
+		data[col] = str
+	}
+
+	var sb strings.Builder
+	if err := t.templates[idx].Execute(&sb, data); err != nil {
+		return nil, false, err
+	}
+	return &qvalue.QValue{Kind: qvalue.QValueKindString, Value: sb.String()}, false, nil
+}
+
+// transformedColumn is a column of a schema the transforms are applied to.
+type transformedColumn struct {
+	name     string
+	kind     qvalue.QValueKind
+	nullable bool
+}
+
+// transformColumns applies the transforms to the columns of a schema, keeping their order and
+// adding computed and constant columns at the end. For partial schemas, like the columns added
+// in a schema change, transforms of missing columns are skipped and no columns are added.
+func (t *RecordTransformer) transformColumns(
+	columns []*transformedColumn,
+	partial bool,
+) ([]*transformedColumn, error) {
+	findColumn := func(name string) int {
+		for i, col := range columns {
+			if col.name == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for i, transform := range t.transforms {
+		switch transform.Type {
+		case protos.RecordTransformType_RECORD_TRANSFORM_CONSTANT,
+			protos.RecordTransformType_RECORD_TRANSFORM_COMPUTED:
+			if partial {
+				continue
+			}
+			if findColumn(transform.NewColumn) >= 0 {
+				return nil, fmt.Errorf("transform %d: column %s already exists", i, transform.NewColumn)
+			}
+			if transform.Type == protos.RecordTransformType_RECORD_TRANSFORM_CONSTANT {
+				columns = append(columns, &transformedColumn{name: transform.NewColumn, kind: constantKind(transform)})
+				continue
+			}
+			for _, col := range t.templateColumns[i] {
+				if findColumn(col) < 0 {
+					return nil, fmt.Errorf("transform %d: computed column references unknown column %s", i, col)
+				}
+			}
+			columns = append(columns, &transformedColumn{
+				name:     transform.NewColumn,
+				kind:     qvalue.QValueKindString,
+				nullable: true,
+			})
+			continue
+		}
+
+		idx := findColumn(transform.Column)
+		if idx < 0 {
+			if partial {
+				continue
+			}
+			return nil, fmt.Errorf("transform %d: unknown column %s", i, transform.Column)
+		}
+
+		switch transform.Type {
+		case protos.RecordTransformType_RECORD_TRANSFORM_RENAME:
+			if findColumn(transform.NewColumn) >= 0 {
+				return nil, fmt.Errorf("transform %d: column %s already exists", i, transform.NewColumn)
+			}
+			columns[idx].name = transform.NewColumn
+		case protos.RecordTransformType_RECORD_TRANSFORM_DROP:
+			columns = append(columns[:idx], columns[idx+1:]...)
+		case protos.RecordTransformType_RECORD_TRANSFORM_HASH, protos.RecordTransformType_RECORD_TRANSFORM_MASK:
+			columns[idx].kind = qvalue.QValueKindString
+		case protos.RecordTransformType_RECORD_TRANSFORM_CAST:
+			columns[idx].kind = qvalue.QValueKind(transform.NewType)
+		}
+	}
+
+	return columns, nil
+}
+
+// TransformTableSchema returns the schema of the destination table of a source table. Key columns
+// can be renamed, hashed and cast, but not masked or dropped as rows could no longer be told apart.
+func (t *RecordTransformer) TransformTableSchema(schema *protos.TableSchema) (*protos.TableSchema, error) {
+	columnNames := make([]string, 0, len(schema.Columns))
+	for colName := range schema.Columns {
+		columnNames = append(columnNames, colName)
+	}
+	sort.Strings(columnNames)
+	columns := make([]*transformedColumn, 0, len(columnNames))
+	for _, colName := range columnNames {
+		columns = append(columns, &transformedColumn{name: colName, kind: qvalue.QValueKind(schema.Columns[colName])})
+	}
+
+	columns, err := t.transformColumns(columns, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pkeyCols := make([]string, len(schema.PrimaryKeyColumns))
+	copy(pkeyCols, schema.PrimaryKeyColumns)
+	for i, transform := range t.transforms {
+		for j, pkeyCol := range pkeyCols {
+			if pkeyCol != transform.Column {
+				continue
+			}
+			switch transform.Type {
+			case protos.RecordTransformType_RECORD_TRANSFORM_RENAME:
+				pkeyCols[j] = transform.NewColumn
+			case protos.RecordTransformType_RECORD_TRANSFORM_DROP, protos.RecordTransformType_RECORD_TRANSFORM_MASK:
+				return nil, fmt.Errorf("transform %d: key column %s can't be dropped or masked", i, pkeyCol)
+			}
+		}
+	}
+
+	transformed := &protos.TableSchema{
+		TableIdentifier:   schema.TableIdentifier,
+		Columns:           make(map[string]string, len(columns)),
+		PrimaryKeyColumns: pkeyCols,
+		ReplicaIdentity:   schema.ReplicaIdentity,
+	}
+	for _, col := range columns {
+		transformed.Columns[col.name] = string(col.kind)
+	}
+	return transformed, nil
+}
+
+// DestinationColumn returns the name a column of the source table has in the destination table.
+// Columns that are dropped, or whose values are changed, have no destination column that holds
+// their source values.
+func (t *RecordTransformer) DestinationColumn(column string) (string, error) {
+	for i, transform := range t.transforms {
+		if transform.Column != column {
+			continue
+		}
+		switch transform.Type {
+		case protos.RecordTransformType_RECORD_TRANSFORM_RENAME:
+			column = transform.NewColumn
+		case protos.RecordTransformType_RECORD_TRANSFORM_DROP, protos.RecordTransformType_RECORD_TRANSFORM_HASH,
+			protos.RecordTransformType_RECORD_TRANSFORM_MASK, protos.RecordTransformType_RECORD_TRANSFORM_CAST:
+			return "", fmt.Errorf("transform %d: column %s is dropped or its values are changed", i, column)
+		}
+	}
+	return column, nil
+}
+
+// TransformTableSchemaDelta returns the changes to the destination table for a schema change of
+// its source table. Added columns are renamed, retyped or left out as the transforms say.
+func (t *RecordTransformer) TransformTableSchemaDelta(delta *protos.TableSchemaDelta) *protos.TableSchemaDelta {
+	transformDeltaColumns := func(deltaColumns []*protos.DeltaColumn) []*protos.DeltaColumn {
+		transformed := make([]*protos.DeltaColumn, 0, len(deltaColumns))
+		for _, deltaColumn := range deltaColumns {
+			// partial schemas can't fail, unknown columns are skipped.
+			columns, _ := t.transformColumns([]*transformedColumn{{
+				name: deltaColumn.ColumnName,
+				kind: qvalue.QValueKind(deltaColumn.ColumnType),
+			}}, true)
+			for _, col := range columns {
+				transformed = append(transformed, &protos.DeltaColumn{ColumnName: col.name, ColumnType: string(col.kind)})
+			}
+		}
+		return transformed
+	}
+
+	droppedColumns := make([]string, 0, len(delta.DroppedColumns))
+	for _, colName := range delta.DroppedColumns {
+		columns, _ := t.transformColumns([]*transformedColumn{{name: colName}}, true)
+		for _, col := range columns {
+			droppedColumns = append(droppedColumns, col.name)
+		}
+	}
+
+	return &protos.TableSchemaDelta{
+		SrcTableName:   delta.SrcTableName,
+		DstTableName:   delta.DstTableName,
+		AddedColumns:   transformDeltaColumns(delta.AddedColumns),
+		DroppedColumns: droppedColumns,
+		RetypedColumns: transformDeltaColumns(delta.RetypedColumns),
+	}
+}
+
+// TransformQRecordBatch applies the transforms to a batch of a QRep flow.
+func (t *RecordTransformer) TransformQRecordBatch(batch *QRecordBatch) (*QRecordBatch, error) {
+	schema, err := t.transformQRecordSchema(batch.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*QRecord, 0, len(batch.Records))
+	for _, record := range batch.Records {
+		transformed, err := t.transformQRecord(batch.Schema, schema, record)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, transformed)
+	}
+
+	return &QRecordBatch{
+		NumRecords: uint32(len(records)),
+		Records:    records,
+		Schema:     schema,
+	}, nil
+}
+
+// TransformQRecordStream applies the transforms to the records of a QRep flow while they're pulled,
+// it returns the stream of transformed records.
+func (t *RecordTransformer) TransformQRecordStream(stream *QRecordStream, bufferSize int) *QRecordStream {
+	transformedStream := NewQRecordStream(bufferSize)
+	go func() {
+		transformedStream.Close(t.transformQRecordStream(stream, transformedStream))
+	}()
+	return transformedStream
+}
+
+func (t *RecordTransformer) transformQRecordStream(stream *QRecordStream, transformedStream *QRecordStream) error {
+	// the source stops pulling if the transforms fail or the destination is done.
+	defer stream.Abandon()
+
+	srcSchema, err := stream.Schema()
+	if err != nil {
+		return err
+	}
+	schema, err := t.transformQRecordSchema(srcSchema)
+	if err != nil {
+		return err
+	}
+	transformedStream.SetSchema(schema)
+
+	for record := range stream.Records() {
+		transformed, err := t.transformQRecord(srcSchema, schema, record)
+		if err != nil {
+			return err
+		}
+		if !transformedStream.Send(transformed) {
+			return ErrRecordStreamAbandoned
+		}
+	}
+	return stream.Err()
+}
+
+func (t *RecordTransformer) transformQRecordSchema(schema *QRecordSchema) (*QRecordSchema, error) {
+	columns := make([]*transformedColumn, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		columns = append(columns, &transformedColumn{name: field.Name, kind: field.Type, nullable: field.Nullable})
+	}
+	columns, err := t.transformColumns(columns, false)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]*QField, 0, len(columns))
+	for _, col := range columns {
+		fields = append(fields, &QField{Name: col.name, Type: col.kind, Nullable: col.nullable})
+	}
+	return NewQRecordSchema(fields), nil
+}
+
+func (t *RecordTransformer) transformQRecord(
+	srcSchema *QRecordSchema,
+	schema *QRecordSchema,
+	record *QRecord,
+) (*QRecord, error) {
+	items := make(RecordItems, len(srcSchema.Fields))
+	for i, field := range srcSchema.Fields {
+		items[field.Name] = record.Entries[i]
+	}
+
+	items, _, err := t.TransformItems(items, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	transformed := NewQRecord(len(schema.Fields))
+	for i, field := range schema.Fields {
+		val, ok := items[field.Name]
+		if !ok {
+			val = qvalue.QValue{Kind: field.Type}
+		}
+		transformed.Set(i, val)
+	}
+	return transformed, nil
+}
+
+// TransformTableSchemaDeltas returns the changes to the destination tables for the schema changes
+// of their source tables, the transformers are keyed by source table name.
+func TransformTableSchemaDeltas(
+	deltas []*protos.TableSchemaDelta,
+	transformers map[string]*RecordTransformer,
+) []*protos.TableSchemaDelta {
+	transformed := make([]*protos.TableSchemaDelta, 0, len(deltas))
+	for _, delta := range deltas {
+		if t, ok := transformers[delta.SrcTableName]; ok {
+			delta = t.TransformTableSchemaDelta(delta)
+		}
+		transformed = append(transformed, delta)
+	}
+	return transformed
+}
+
+// TransformTableSchemaMapping returns the schemas of the destination tables from the schemas of
+// their source tables, both keyed by destination table name.
+func TransformTableSchemaMapping(
+	tableNameSchemaMapping map[string]*protos.TableSchema,
+	tableNameMapping map[string]string,
+	transformers map[string]*RecordTransformer,
+) (map[string]*protos.TableSchema, error) {
+	if len(transformers) == 0 {
+		return tableNameSchemaMapping, nil
+	}
+
+	transformed := make(map[string]*protos.TableSchema, len(tableNameSchemaMapping))
+	for dstTableName, schema := range tableNameSchemaMapping {
+		transformed[dstTableName] = schema
+	}
+	for srcTableName, t := range transformers {
+		dstTableName := tableNameMapping[srcTableName]
+		schema, ok := tableNameSchemaMapping[dstTableName]
+		if !ok {
+			continue
+		}
+		dstSchema, err := t.TransformTableSchema(schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform schema of table %s: %w", srcTableName, err)
+		}
+		transformed[dstTableName] = dstSchema
+	}
+	return transformed, nil
+}
+
+func constantKind(transform *protos.RecordTransform) qvalue.QValueKind {
+	if transform.NewType == "" {
+		return qvalue.QValueKindString
+	}
+	return qvalue.QValueKind(transform.NewType)
+}
+
+func maskVisibleChars(transform *protos.RecordTransform) (int, error) {
+	if transform.Value == "" {
+		return 0, nil
+	}
+	visible, err := strconv.Atoi(transform.Value)
+	if err != nil || visible < 0 {
+		return 0, fmt.Errorf("mask needs a non-negative number of visible characters, got %q", transform.Value)
+	}
+	return visible, nil
+}
+
+// qValueToString returns the text of a value hashes, masks, casts and computed columns work on.
+func qValueToString(val qvalue.QValue) (string, bool, error) {
+	if val.Value == nil {
+		return "", false, nil
+	}
+
+	switch val.Kind {
+	case qvalue.QValueKindTimestamp, qvalue.QValueKindTimestampTZ, qvalue.QValueKindDate,
+		qvalue.QValueKindTime, qvalue.QValueKindTimeTZ:
+		str, err := val.GoTimeConvert()
+		return str, true, err
+	}
+
+	switch v := val.Value.(type) {
+	case string:
+		return v, true, nil
+	case []byte:
+		return hex.EncodeToString(v), true, nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true, nil
+	case *big.Rat:
+		return v.FloatString(9), true, nil
+	default:
+		return fmt.Sprint(v), true, nil
+	}
+}
+
+func hashQValue(val qvalue.QValue, salt string) (qvalue.QValue, error) {
+	str, valid, err := qValueToString(val)
+	if err != nil || !valid {
+		return qvalue.QValue{Kind: qvalue.QValueKindString}, err
+	}
+	hash := sha256.Sum256([]byte(salt + str))
+	return qvalue.QValue{Kind: qvalue.QValueKindString, Value: hex.EncodeToString(hash[:])}, nil
+}
+
+func maskQValue(val qvalue.QValue, transform *protos.RecordTransform) (qvalue.QValue, error) {
+	str, valid, err := qValueToString(val)
+	if err != nil || !valid {
+		return qvalue.QValue{Kind: qvalue.QValueKindString}, err
+	}
+	visible, err := maskVisibleChars(transform)
+	if err != nil {
+		return qvalue.QValue{}, err
+	}
+
+	runes := []rune(str)
+	for i := 0; i < len(runes)-visible; i++ {
+		runes[i] = '*'
+	}
+	return qvalue.QValue{Kind: qvalue.QValueKindString, Value: string(runes)}, nil
+}
+
+// castQValue converts a value to one of the kinds of transformKinds, NULL stays NULL.
+func castQValue(val qvalue.QValue, kind qvalue.QValueKind) (qvalue.QValue, error) {
+	str, valid, err := qValueToString(val)
+	if err != nil || !valid {
+		return qvalue.QValue{Kind: kind}, err
+	}
+
+	switch kind {
+	case qvalue.QValueKindString:
+		return qvalue.QValue{Kind: kind, Value: str}, nil
+	case qvalue.QValueKindInt32, qvalue.QValueKindInt64:
+		bitSize := 64
+		if kind == qvalue.QValueKindInt32 {
+			bitSize = 32
+		}
+		i, err := strconv.ParseInt(strings.TrimSpace(str), 10, bitSize)
+		if err != nil {
+			// whole numbers of float and numeric values, like 3.000000000.
+			f, ferr := strconv.ParseFloat(strings.TrimSpace(str), 64)
+			if ferr != nil || f != math.Trunc(f) {
+				return qvalue.QValue{}, fmt.Errorf("can't cast %q to %s", str, kind)
+			}
+			i = int64(f)
+		}
+		if kind == qvalue.QValueKindInt32 {
+			return qvalue.QValue{Kind: kind, Value: int32(i)}, nil
+		}
+		return qvalue.QValue{Kind: kind, Value: i}, nil
+	case qvalue.QValueKindFloat32, qvalue.QValueKindFloat64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+		if err != nil {
+			return qvalue.QValue{}, fmt.Errorf("can't cast %q to %s", str, kind)
+		}
+		if kind == qvalue.QValueKindFloat32 {
+			return qvalue.QValue{Kind: kind, Value: float32(f)}, nil
+		}
+		return qvalue.QValue{Kind: kind, Value: f}, nil
+	case qvalue.QValueKindBoolean:
+		b, err := strconv.ParseBool(strings.TrimSpace(str))
+		if err != nil {
+			return qvalue.QValue{}, fmt.Errorf("can't cast %q to %s", str, kind)
+		}
+		return qvalue.QValue{Kind: kind, Value: b}, nil
+	default:
+		return qvalue.QValue{}, fmt.Errorf("unsupported cast to %s", kind)
+	}
+}
+
+// collectTemplateColumns adds the columns referenced as {{.column}} in a template to columns.
+func collectTemplateColumns(node parse.Node, columns map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateColumns(child, columns)
+		}
+	case *parse.ActionNode:
+		collectTemplateColumns(n.Pipe, columns)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectTemplateColumns(cmd, columns)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectTemplateColumns(arg, columns)
+		}
+	case *parse.FieldNode:
+		if len(n.Ident) > 0 {
+			columns[n.Ident[0]] = true
+		}
+	case *parse.IfNode:
+		collectTemplateColumns(n.Pipe, columns)
+		collectTemplateColumns(n.List, columns)
+		collectTemplateColumns(n.ElseList, columns)
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,227 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTransforms() *protos.TableTransforms {
+	return &protos.TableTransforms{
+		Transforms: []*protos.RecordTransform{
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_RENAME, Column: "id", NewColumn: "user_id"},
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_HASH, Column: "email"},
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_MASK, Column: "phone", Value: "4"},
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_DROP, Column: "password"},
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_CAST, Column: "age", NewType: "string"},
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_COMPUTED, NewColumn: "full_name", Value: "{{.first}} {{.last}}"},
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_CONSTANT, NewColumn: "region", Value: "eu"},
+		},
+	}
+}
+
+func TestTransformItems(t *testing.T) {
+	transformer, err := NewRecordTransformer(testTransforms())
+	require.NoError(t, err)
+
+	items, unchanged, err := transformer.TransformItems(RecordItems{
+		"id":       qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(7)},
+		"email":    qvalue.QValue{Kind: qvalue.QValueKindString, Value: "a@example.com"},
+		"phone":    qvalue.QValue{Kind: qvalue.QValueKindString, Value: "5551234567"},
+		"password": qvalue.QValue{Kind: qvalue.QValueKindString, Value: "secret"},
+		"age":      qvalue.QValue{Kind: qvalue.QValueKindInt32, Value: int32(42)},
+		"first":    qvalue.QValue{Kind: qvalue.QValueKindString, Value: "Ada"},
+		"last":     qvalue.QValue{Kind: qvalue.QValueKindString, Value: "Lovelace"},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, unchanged)
+	assert.Equal(t, RecordItems{
+		"user_id": qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(7)},
+		"email": qvalue.QValue{
+			Kind:  qvalue.QValueKindString,
+			Value: "08168cd80dfd534ab0f10af10f1303fe00af2d43ab5c1432360d137f8197e17a",
+		},
+		"phone":     qvalue.QValue{Kind: qvalue.QValueKindString, Value: "******4567"},
+		"age":       qvalue.QValue{Kind: qvalue.QValueKindString, Value: "42"},
+		"first":     qvalue.QValue{Kind: qvalue.QValueKindString, Value: "Ada"},
+		"last":      qvalue.QValue{Kind: qvalue.QValueKindString, Value: "Lovelace"},
+		"full_name": qvalue.QValue{Kind: qvalue.QValueKindString, Value: "Ada Lovelace"},
+		"region":    qvalue.QValue{Kind: qvalue.QValueKindString, Value: "eu"},
+	}, items)
+}
+
+func TestTransformItemsPartialRow(t *testing.T) {
+	transformer, err := NewRecordTransformer(testTransforms())
+	require.NoError(t, err)
+
+	// an update leaving a toasted column unchanged, computed columns depending on it stay unchanged too.
+	items, unchanged, err := transformer.TransformItems(RecordItems{
+		"id":    qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(7)},
+		"first": qvalue.QValue{Kind: qvalue.QValueKindString, Value: "Ada"},
+		"email": qvalue.QValue{Kind: qvalue.QValueKindString, Value: nil},
+	}, map[string]bool{"last": true, "password": true})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{"last": true, "full_name": true}, unchanged)
+	assert.Equal(t, RecordItems{
+		"user_id": qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(7)},
+		"first":   qvalue.QValue{Kind: qvalue.QValueKindString, Value: "Ada"},
+		"email":   qvalue.QValue{Kind: qvalue.QValueKindString, Value: nil},
+		"region":  qvalue.QValue{Kind: qvalue.QValueKindString, Value: "eu"},
+	}, items)
+}
+
+func TestTransformTableSchema(t *testing.T) {
+	transformer, err := NewRecordTransformer(testTransforms())
+	require.NoError(t, err)
+
+	schema, err := transformer.TransformTableSchema(&protos.TableSchema{
+		TableIdentifier: "public.users",
+		Columns: map[string]string{
+			"id": "int64", "email": "string", "phone": "string", "password": "string",
+			"age": "int32", "first": "string", "last": "string",
+		},
+		PrimaryKeyColumns: []string{"id"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"user_id": "int64", "email": "string", "phone": "string", "age": "string",
+		"first": "string", "last": "string", "full_name": "string", "region": "string",
+	}, schema.Columns)
+	assert.Equal(t, []string{"user_id"}, schema.PrimaryKeyColumns)
+
+	// key columns can't be dropped.
+	_, err = transformer.TransformTableSchema(&protos.TableSchema{
+		TableIdentifier:   "public.users",
+		Columns:           map[string]string{"id": "int64", "password": "string"},
+		PrimaryKeyColumns: []string{"password"},
+	})
+	assert.Error(t, err)
+}
+
+func TestTransformTableSchemaDelta(t *testing.T) {
+	transformer, err := NewRecordTransformer(testTransforms())
+	require.NoError(t, err)
+
+	delta := transformer.TransformTableSchemaDelta(&protos.TableSchemaDelta{
+		SrcTableName: "public.users",
+		DstTableName: "public.users",
+		AddedColumns: []*protos.DeltaColumn{
+			{ColumnName: "password", ColumnType: "string"},
+			{ColumnName: "age", ColumnType: "int64"},
+			{ColumnName: "nickname", ColumnType: "string"},
+		},
+		DroppedColumns: []string{"id"},
+	})
+
+	assert.Equal(t, []*protos.DeltaColumn{
+		{ColumnName: "age", ColumnType: "string"},
+		{ColumnName: "nickname", ColumnType: "string"},
+	}, delta.AddedColumns)
+	assert.Equal(t, []string{"user_id"}, delta.DroppedColumns)
+}
+
+func TestDestinationColumn(t *testing.T) {
+	transformer, err := NewRecordTransformer(testTransforms())
+	require.NoError(t, err)
+
+	column, err := transformer.DestinationColumn("id")
+	require.NoError(t, err)
+	assert.Equal(t, "user_id", column)
+	column, err = transformer.DestinationColumn("created_at")
+	require.NoError(t, err)
+	assert.Equal(t, "created_at", column)
+
+	// the destination doesn't have the source values of dropped and changed columns.
+	for _, column := range []string{"email", "phone", "password", "age"} {
+		_, err = transformer.DestinationColumn(column)
+		assert.ErrorContains(t, err, "column "+column+" is dropped or its values are changed")
+	}
+}
+
+func TestTransformQRecordBatch(t *testing.T) {
+	transformer, err := NewRecordTransformer(&protos.TableTransforms{
+		Transforms: []*protos.RecordTransform{
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_DROP, Column: "password"},
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_CAST, Column: "score", NewType: "int64"},
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_CONSTANT, NewColumn: "version", NewType: "int32", Value: "2"},
+		},
+	})
+	require.NoError(t, err)
+
+	record := NewQRecord(3)
+	record.Set(0, qvalue.QValue{Kind: qvalue.QValueKindString, Value: "ada"})
+	record.Set(1, qvalue.QValue{Kind: qvalue.QValueKindString, Value: "secret"})
+	record.Set(2, qvalue.QValue{Kind: qvalue.QValueKindFloat64, Value: float64(12)})
+
+	batch, err := transformer.TransformQRecordBatch(&QRecordBatch{
+		NumRecords: 1,
+		Records:    []*QRecord{record},
+		Schema: NewQRecordSchema([]*QField{
+			{Name: "name", Type: qvalue.QValueKindString, Nullable: true},
+			{Name: "password", Type: qvalue.QValueKindString, Nullable: true},
+			{Name: "score", Type: qvalue.QValueKindFloat64, Nullable: true},
+		}),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"name", "score", "version"}, batch.Schema.GetColumnNames())
+	assert.Equal(t, []qvalue.QValue{
+		{Kind: qvalue.QValueKindString, Value: "ada"},
+		{Kind: qvalue.QValueKindInt64, Value: int64(12)},
+		{Kind: qvalue.QValueKindInt32, Value: int32(2)},
+	}, batch.Records[0].Entries)
+}
+
+func TestTransformQRecordStream(t *testing.T) {
+	transformer, err := NewRecordTransformer(&protos.TableTransforms{
+		Transforms: []*protos.RecordTransform{
+			{Type: protos.RecordTransformType_RECORD_TRANSFORM_DROP, Column: "password"},
+		},
+	})
+	require.NoError(t, err)
+
+	records := make([]*QRecord, 0, 3)
+	for i := 0; i < 3; i++ {
+		record := NewQRecord(2)
+		record.Set(0, qvalue.QValue{Kind: qvalue.QValueKindInt64, Value: int64(i)})
+		record.Set(1, qvalue.QValue{Kind: qvalue.QValueKindString, Value: "secret"})
+		records = append(records, record)
+	}
+	stream := (&QRecordBatch{
+		NumRecords: 3,
+		Records:    records,
+		Schema: NewQRecordSchema([]*QField{
+			{Name: "id", Type: qvalue.QValueKindInt64, Nullable: false},
+			{Name: "password", Type: qvalue.QValueKindString, Nullable: true},
+		}),
+	}).ToQRecordStream(1)
+
+	batch, err := transformer.TransformQRecordStream(stream, 1).ToQRecordBatch()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id"}, batch.Schema.GetColumnNames())
+	require.Len(t, batch.Records, 3)
+	assert.Equal(t, []qvalue.QValue{{Kind: qvalue.QValueKindInt64, Value: int64(2)}}, batch.Records[2].Entries)
+}
+
+func TestNewRecordTransformerInvalid(t *testing.T) {
+	invalid := []*protos.RecordTransform{
+		// a transform without a type mustn't be taken for a rename.
+		{Column: "id", NewColumn: "user_id"},
+		{Type: protos.RecordTransformType_RECORD_TRANSFORM_RENAME, Column: "id"},
+		{Type: protos.RecordTransformType_RECORD_TRANSFORM_MASK, Column: "phone", Value: "-1"},
+		{Type: protos.RecordTransformType_RECORD_TRANSFORM_CAST, Column: "age", NewType: "geometry"},
+		{Type: protos.RecordTransformType_RECORD_TRANSFORM_COMPUTED, NewColumn: "full_name", Value: "{{.first"},
+		{Type: protos.RecordTransformType_RECORD_TRANSFORM_CONSTANT, NewColumn: "n", NewType: "int64", Value: "x"},
+	}
+
+	for _, transform := range invalid {
+		_, err := NewRecordTransformer(&protos.TableTransforms{Transforms: []*protos.RecordTransform{transform}})
+		assert.Error(t, err, "transform %v", transform)
+	}
+}
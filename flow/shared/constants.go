@@ -1,13 +1,39 @@
 package shared
 
+import "fmt"
+
 const (
-	PeerFlowTaskQueue  = "peer-flow-task-queue"
-	PeerFlowSignalName = "peer-flow-signal"
+	PeerFlowTaskQueue              = "peer-flow-task-queue"
+	PeerFlowSignalName             = "peer-flow-signal"
+	PeerFlowConfigUpdateSignalName = "peer-flow-config-update-signal"
 )
 
+// CTIDWatermarkColumn is the watermark column of QRep flows reading postgres tables without a key
+// to partition on, partitions are ranges of the blocks the rows of the table are stored in.
+const CTIDWatermarkColumn = "ctid"
+
 type PeerFlowSignal int64
 
 const (
 	NoopSignal PeerFlowSignal = iota
 	ShutdownSignal
+	// PauseSignal stops the peer flow before its next sync flow, keeping the slot and raw tables.
+	PauseSignal
+	// ResumeSignal continues a paused peer flow.
+	ResumeSignal
 )
+
+func (s PeerFlowSignal) String() string {
+	switch s {
+	case NoopSignal:
+		return "noop"
+	case ShutdownSignal:
+		return "shutdown"
+	case PauseSignal:
+		return "pause"
+	case ResumeSignal:
+		return "resume"
+	default:
+		return fmt.Sprintf("unknown(%d)", int64(s))
+	}
+}
@@ -5,4 +5,5 @@ import "github.com/PeerDB-io/peer-flow/activities"
 var (
 	fetchConfig *activities.FetchConfigActivity
 	flowable    *activities.FlowableActivity
+	snapshot    *activities.SnapshotActivity
 )
@@ -2,6 +2,7 @@ package peerflow
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/PeerDB-io/peer-flow/activities"
@@ -14,12 +15,16 @@ import (
 	"go.temporal.io/sdk/log"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
 	PeerFlowStatusQuery          = "q-peer-flow-status"
 	maxSyncFlowsPerPeerFlow      = 32
 	maxNormalizeFlowsPerPeerFlow = 32
+	// statuses, error messages and progress events carried over to the next run of a peer flow,
+	// older ones are dropped so that the state doesn't grow with every run.
+	maxCarriedOverStatuses = 16
 )
 
 type PeerFlowLimits struct {
@@ -65,21 +70,105 @@ type PeerFlowState struct {
 	// SetupComplete indicates whether the peer flow setup has completed.
 	SetupComplete bool
 	// Errors encountered during child sync flow executions.
-	SyncFlowErrors error
+	// multierror can't be serialized, queries and continue-as-new only see SyncFlowErrorMessages.
+	SyncFlowErrors error `json:"-"`
 	// Errors encountered during child sync flow executions.
-	NormalizeFlowErrors error
+	NormalizeFlowErrors error `json:"-"`
+	// Messages of the errors encountered during child sync flow executions.
+	SyncFlowErrorMessages []string
+	// Messages of the errors encountered during child normalize flow executions.
+	NormalizeFlowErrorMessages []string
+	// Number of errors encountered during child sync flow executions, across runs.
+	NumSyncFlowErrors int64
+	// Number of errors encountered during child normalize flow executions, across runs.
+	NumNormalizeFlowErrors int64
+	// Messages of the errors of config updates that couldn't be applied.
+	ConfigUpdateErrorMessages []string
+	// Relations known to the source, passed on to every sync flow so that
+	// changes can be decoded when the slot doesn't send the relation again.
+	RelationMessageMapping map[uint32]*protos.RelationMessage
+	// Last batch ID synced to the raw table of the destination.
+	LastSyncedBatchID int64
+	// Last batch ID normalized into the destination tables, normalize is
+	// behind sync by LastSyncedBatchID - LastNormalizedBatchID batches.
+	LastNormalizedBatchID int64
+}
+
+func (s *PeerFlowState) addSyncFlowError(err error) {
+	s.SyncFlowErrors = multierror.Append(s.SyncFlowErrors, err)
+	s.SyncFlowErrorMessages = append(s.SyncFlowErrorMessages, err.Error())
+	s.NumSyncFlowErrors++
+}
+
+func (s *PeerFlowState) addNormalizeFlowError(err error) {
+	s.NormalizeFlowErrors = multierror.Append(s.NormalizeFlowErrors, err)
+	s.NormalizeFlowErrorMessages = append(s.NormalizeFlowErrorMessages, err.Error())
+	s.NumNormalizeFlowErrors++
+}
+
+func (s *PeerFlowState) addConfigUpdateError(err error) {
+	s.ConfigUpdateErrorMessages = append(s.ConfigUpdateErrorMessages, err.Error())
+}
+
+// addNormalizeFlowStatus records the status of a normalize flow, only the latest statuses are kept.
+func (s *PeerFlowState) addNormalizeFlowStatus(status *model.NormalizeResponse) {
+	s.NormalizeFlowStatuses = append(s.NormalizeFlowStatuses, status)
+	if len(s.NormalizeFlowStatuses) > maxCarriedOverStatuses {
+		s.NormalizeFlowStatuses = s.NormalizeFlowStatuses[len(s.NormalizeFlowStatuses)-maxCarriedOverStatuses:]
+	}
+}
+
+// trimForContinueAsNew keeps the latest statuses, error messages and progress events of the state,
+// sync flows that had nothing to sync are dropped. Batch IDs and error counts are kept as they are.
+func (s *PeerFlowState) trimForContinueAsNew() {
+	syncFlowStatuses := make([]*model.SyncResponse, 0, len(s.SyncFlowStatuses))
+	for _, status := range s.SyncFlowStatuses {
+		if status != nil && status.NumRecordsSynced > 0 {
+			syncFlowStatuses = append(syncFlowStatuses, status)
+		}
+	}
+	if len(syncFlowStatuses) > maxCarriedOverStatuses {
+		syncFlowStatuses = syncFlowStatuses[len(syncFlowStatuses)-maxCarriedOverStatuses:]
+	}
+	s.SyncFlowStatuses = syncFlowStatuses
+
+	if len(s.NormalizeFlowStatuses) > maxCarriedOverStatuses {
+		s.NormalizeFlowStatuses = s.NormalizeFlowStatuses[len(s.NormalizeFlowStatuses)-maxCarriedOverStatuses:]
+	}
+	if len(s.SyncFlowErrorMessages) > maxCarriedOverStatuses {
+		s.SyncFlowErrorMessages = s.SyncFlowErrorMessages[len(s.SyncFlowErrorMessages)-maxCarriedOverStatuses:]
+	}
+	if len(s.NormalizeFlowErrorMessages) > maxCarriedOverStatuses {
+		s.NormalizeFlowErrorMessages =
+			s.NormalizeFlowErrorMessages[len(s.NormalizeFlowErrorMessages)-maxCarriedOverStatuses:]
+	}
+	if len(s.ConfigUpdateErrorMessages) > maxCarriedOverStatuses {
+		s.ConfigUpdateErrorMessages =
+			s.ConfigUpdateErrorMessages[len(s.ConfigUpdateErrorMessages)-maxCarriedOverStatuses:]
+	}
+	if len(s.Progress) > maxCarriedOverStatuses {
+		s.Progress = s.Progress[len(s.Progress)-maxCarriedOverStatuses:]
+	}
 }
 
 // returns a new empty PeerFlowState
 func NewStartedPeerFlowState() *PeerFlowState {
 	return &PeerFlowState{
-		Progress:              []string{"started"},
-		SyncFlowStatuses:      nil,
-		NormalizeFlowStatuses: nil,
-		ActiveSignal:          shared.NoopSignal,
-		SetupComplete:         false,
-		SyncFlowErrors:        nil,
-		NormalizeFlowErrors:   nil,
+		Progress:                   []string{"started"},
+		SyncFlowStatuses:           nil,
+		NormalizeFlowStatuses:      nil,
+		ActiveSignal:               shared.NoopSignal,
+		SetupComplete:              false,
+		SyncFlowErrors:             nil,
+		NormalizeFlowErrors:        nil,
+		SyncFlowErrorMessages:      nil,
+		NormalizeFlowErrorMessages: nil,
+		NumSyncFlowErrors:          0,
+		NumNormalizeFlowErrors:     0,
+		ConfigUpdateErrorMessages:  nil,
+		RelationMessageMapping:     nil,
+		LastSyncedBatchID:          0,
+		LastNormalizedBatchID:      0,
 	}
 }
 
@@ -193,6 +282,239 @@ func PeerFlowWorkflow(ctx workflow.Context, input *PeerFlowWorkflowInput) (*Peer
 	return &res, nil
 }
 
+// startNormalizer runs normalize flows in the background, one at a time, whenever normalizeSignal
+// reports newly synced batches. Each normalize flow merges all the batches synced since the last
+// one. The returned future is ready once normalizeSignal is closed and drained.
+func (w *PeerFlowWorkflowExecution) startNormalizer(
+	ctx workflow.Context,
+	cfg *protos.FlowConnectionConfigs,
+	limits *PeerFlowLimits,
+	state *PeerFlowState,
+	normalizeSignal workflow.ReceiveChannel,
+) workflow.Future {
+	done, settable := workflow.NewFuture(ctx)
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		currentNormalizeFlowNum := 0
+		for normalizeSignal.Receive(ctx, nil) {
+			// normalize has caught up with sync, nothing to do.
+			if state.LastNormalizedBatchID >= state.LastSyncedBatchID {
+				continue
+			}
+
+			if limits.TotalNormalizeFlows != 0 && currentNormalizeFlowNum == limits.TotalNormalizeFlows {
+				w.logger.Info("All the normalizer flows have completed successfully, there was a"+
+					" limit on the number of normalizer to be executed: ", limits.TotalNormalizeFlows)
+				continue
+			}
+			currentNormalizeFlowNum++
+			// batches synced from here on are picked up by the next normalize flow.
+			lastSyncedBatchID := state.LastSyncedBatchID
+
+			normalizeFlowID, err := GetChildWorkflowID(ctx, "normalize-flow", cfg.FlowJobName)
+			if err != nil {
+				state.addNormalizeFlowError(err)
+				continue
+			}
+
+			// execute the normalize flow as a child workflow
+			childNormalizeFlowOpts := workflow.ChildWorkflowOptions{
+				WorkflowID:        normalizeFlowID,
+				ParentClosePolicy: enums.PARENT_CLOSE_POLICY_REQUEST_CANCEL,
+				RetryPolicy: &temporal.RetryPolicy{
+					MaximumAttempts: 2,
+				},
+			}
+			normalizeFlowCtx := workflow.WithChildOptions(ctx, childNormalizeFlowOpts)
+			childNormalizeFlowFuture := workflow.ExecuteChildWorkflow(
+				normalizeFlowCtx,
+				NormalizeFlowWorkflow,
+				cfg,
+			)
+
+			var childNormalizeFlowRes *model.NormalizeResponse
+			if err := childNormalizeFlowFuture.Get(normalizeFlowCtx, &childNormalizeFlowRes); err != nil {
+				w.logger.Error("failed to execute normalize flow: ", err)
+				state.addNormalizeFlowError(err)
+				continue
+			}
+			// destinations without normalized tables have nothing to normalize, the batches synced
+			// so far count as normalized so that the normalizer doesn't run after every sync.
+			if childNormalizeFlowRes == nil {
+				if lastSyncedBatchID > state.LastNormalizedBatchID {
+					state.LastNormalizedBatchID = lastSyncedBatchID
+				}
+				continue
+			}
+			state.addNormalizeFlowStatus(childNormalizeFlowRes)
+			if childNormalizeFlowRes.EndBatchID > state.LastNormalizedBatchID {
+				state.LastNormalizedBatchID = childNormalizeFlowRes.EndBatchID
+			}
+		}
+		settable.Set(nil, nil)
+	})
+	return done
+}
+
+// configUpdateAddedTables returns the source tables a config update adds to the peer flow, sorted.
+// Tables that are already replicated, and destination tables that another table is replicated to,
+// are rejected.
+func configUpdateAddedTables(cfg *protos.FlowConnectionConfigs, update *protos.FlowConfigUpdate) ([]string, error) {
+	dstTableNames := make(map[string]string, len(cfg.TableNameMapping)+len(update.AdditionalTables))
+	for srcTableName, dstTableName := range cfg.TableNameMapping {
+		dstTableNames[dstTableName] = srcTableName
+	}
+
+	addedTables := make([]string, 0, len(update.AdditionalTables))
+	for srcTableName := range update.AdditionalTables {
+		addedTables = append(addedTables, srcTableName)
+	}
+	sort.Strings(addedTables)
+	for _, srcTableName := range addedTables {
+		if _, ok := cfg.TableNameMapping[srcTableName]; ok {
+			return nil, fmt.Errorf("table %s is already replicated", srcTableName)
+		}
+		dstTableName := update.AdditionalTables[srcTableName]
+		if otherTableName, ok := dstTableNames[dstTableName]; ok {
+			return nil, fmt.Errorf("table %s is already replicated to %s", otherTableName, dstTableName)
+		}
+		dstTableNames[dstTableName] = srcTableName
+	}
+	return addedTables, nil
+}
+
+// applyConfigUpdate changes the config of the peer flow. The update is validated before any of it is
+// applied. Added tables are added to the publication and set up on the destination, their existing rows
+// are not copied. Removed tables are dropped from the publication, their destination tables are kept.
+// If the added tables can't be set up, the publication is changed back and the config is left as is.
+func (w *PeerFlowWorkflowExecution) applyConfigUpdate(
+	ctx workflow.Context,
+	cfg *protos.FlowConnectionConfigs,
+	limits *PeerFlowLimits,
+	update *protos.FlowConfigUpdate,
+) error {
+	addedTables, err := configUpdateAddedTables(cfg, update)
+	if err != nil {
+		return err
+	}
+	removedTables := make([]string, 0, len(update.RemovedTables))
+	for _, srcTableName := range update.RemovedTables {
+		if _, ok := cfg.TableNameMapping[srcTableName]; ok {
+			removedTables = append(removedTables, srcTableName)
+		}
+	}
+
+	if len(addedTables) > 0 || len(removedTables) > 0 {
+		alterPublicationCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout: 5 * time.Minute,
+		})
+		alterPublicationInput := &protos.AlterPublicationInput{
+			PeerConnectionConfig: cfg.Source,
+			FlowJobName:          cfg.FlowJobName,
+			AddedTables:          addedTables,
+			RemovedTables:        removedTables,
+			ColumnFilters:        cfg.ColumnFilters,
+			RowFilters:           cfg.RowFilters,
+		}
+		validateFuture := workflow.ExecuteActivity(alterPublicationCtx, flowable.ValidateAlterPublication,
+			alterPublicationInput)
+		if err := validateFuture.Get(alterPublicationCtx, nil); err != nil {
+			return fmt.Errorf("invalid config update: %w", err)
+		}
+
+		alterPublicationFuture := workflow.ExecuteActivity(alterPublicationCtx, flowable.AlterPublication,
+			alterPublicationInput)
+		if err := alterPublicationFuture.Get(alterPublicationCtx, nil); err != nil {
+			return fmt.Errorf("failed to alter publication: %w", err)
+		}
+
+		if len(addedTables) > 0 {
+			err := w.setupAddedTables(ctx, cfg, update, addedTables)
+			if err != nil {
+				// the tables are replicated as they were before the update.
+				rollbackInput := proto.Clone(alterPublicationInput).(*protos.AlterPublicationInput)
+				rollbackInput.AddedTables = removedTables
+				rollbackInput.RemovedTables = addedTables
+				rollbackFuture := workflow.ExecuteActivity(alterPublicationCtx, flowable.AlterPublication,
+					rollbackInput)
+				if rollbackErr := rollbackFuture.Get(alterPublicationCtx, nil); rollbackErr != nil {
+					err = multierror.Append(err, fmt.Errorf("failed to roll back publication: %w", rollbackErr))
+				}
+				return err
+			}
+			w.logger.Info("added tables to peer flow - ", addedTables)
+		}
+
+		for _, srcTableName := range removedTables {
+			delete(cfg.TableNameSchemaMapping, cfg.TableNameMapping[srcTableName])
+			delete(cfg.TableNameMapping, srcTableName)
+			for relID, tableName := range cfg.SrcTableIdNameMapping {
+				if tableName == srcTableName {
+					delete(cfg.SrcTableIdNameMapping, relID)
+				}
+			}
+		}
+	}
+
+	if update.BatchSize > 0 {
+		limits.MaxBatchSize = int(update.BatchSize)
+		cfg.MaxBatchSize = update.BatchSize
+	}
+	if update.IdleTimeoutSeconds > 0 {
+		cfg.IdleTimeoutSeconds = update.IdleTimeoutSeconds
+	}
+	return nil
+}
+
+// setupAddedTables runs the setup flow for the tables a config update adds and adds them to the config.
+func (w *PeerFlowWorkflowExecution) setupAddedTables(
+	ctx workflow.Context,
+	cfg *protos.FlowConnectionConfigs,
+	update *protos.FlowConfigUpdate,
+	addedTables []string,
+) error {
+	setupCfg := proto.Clone(cfg).(*protos.FlowConnectionConfigs)
+	setupCfg.TableNameMapping = make(map[string]string, len(addedTables))
+	for _, srcTableName := range addedTables {
+		setupCfg.TableNameMapping[srcTableName] = update.AdditionalTables[srcTableName]
+	}
+	setupCfg.SrcTableIdNameMapping = nil
+	setupCfg.TableNameSchemaMapping = nil
+	setupCfg.DoInitialCopy = false
+
+	setupFlowID, err := GetChildWorkflowID(ctx, "setup-flow", cfg.FlowJobName)
+	if err != nil {
+		return err
+	}
+	setupFlowCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:        setupFlowID,
+		ParentClosePolicy: enums.PARENT_CLOSE_POLICY_REQUEST_CANCEL,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 2,
+		},
+	})
+	setupFlowFuture := workflow.ExecuteChildWorkflow(setupFlowCtx, SetupFlowWorkflow, setupCfg)
+	if err := setupFlowFuture.Get(setupFlowCtx, &setupCfg); err != nil {
+		return fmt.Errorf("failed to setup added tables: %w", err)
+	}
+
+	if cfg.SrcTableIdNameMapping == nil {
+		cfg.SrcTableIdNameMapping = make(map[uint32]string)
+	}
+	if cfg.TableNameSchemaMapping == nil {
+		cfg.TableNameSchemaMapping = make(map[string]*protos.TableSchema)
+	}
+	for srcTableName, dstTableName := range setupCfg.TableNameMapping {
+		cfg.TableNameMapping[srcTableName] = dstTableName
+	}
+	for relID, srcTableName := range setupCfg.SrcTableIdNameMapping {
+		cfg.SrcTableIdNameMapping[relID] = srcTableName
+	}
+	for dstTableName, tableSchema := range setupCfg.TableNameSchemaMapping {
+		cfg.TableNameSchemaMapping[dstTableName] = tableSchema
+	}
+	return nil
+}
+
 func PeerFlowWorkflowWithConfig(
 	ctx workflow.Context,
 	cfg *protos.FlowConnectionConfigs,
@@ -228,7 +550,19 @@ func PeerFlowWorkflowWithConfig(
 	signalChan := workflow.GetSignalChannel(ctx, shared.PeerFlowSignalName)
 	signalHandler := func(_ workflow.Context, v shared.PeerFlowSignal) {
 		w.logger.Info("received signal - ", v)
-		state.ActiveSignal = v
+		switch v {
+		case shared.ResumeSignal:
+			// only a paused flow is resumed, a shutdown still goes through.
+			if state.ActiveSignal == shared.PauseSignal {
+				state.ActiveSignal = shared.NoopSignal
+			}
+		case shared.PauseSignal:
+			if state.ActiveSignal != shared.ShutdownSignal {
+				state.ActiveSignal = v
+			}
+		default:
+			state.ActiveSignal = v
+		}
 	}
 
 	// Support a signal to pause the peer flow.
@@ -239,6 +573,17 @@ func PeerFlowWorkflowWithConfig(
 		signalHandler(ctx, signalVal)
 	})
 
+	// Support a signal to update the config of the peer flow, updates are applied
+	// before the next sync flow and the peer flow continues as new with the new config.
+	var pendingConfigUpdates []*protos.FlowConfigUpdate
+	configUpdateChan := workflow.GetSignalChannel(ctx, shared.PeerFlowConfigUpdateSignalName)
+	selector.AddReceive(configUpdateChan, func(c workflow.ReceiveChannel, more bool) {
+		var update *protos.FlowConfigUpdate
+		c.Receive(ctx, &update)
+		w.logger.Info("received config update - ", update)
+		pendingConfigUpdates = append(pendingConfigUpdates, update)
+	})
+
 	if !state.SetupComplete {
 		// start the SetupFlow workflow as a child workflow, and wait for it to complete
 		// it should return the table schema for the source peer
@@ -259,24 +604,73 @@ func PeerFlowWorkflowWithConfig(
 			return state, fmt.Errorf("failed to execute child workflow: %w", err)
 		}
 
+		// copy the existing rows before any changes are synced.
+		if cfg.DoInitialCopy {
+			snapshotFlowID, err := GetChildWorkflowID(ctx, "snapshot-flow", cfg.FlowJobName)
+			if err != nil {
+				return state, err
+			}
+			childSnapshotFlowOpts := workflow.ChildWorkflowOptions{
+				WorkflowID:        snapshotFlowID,
+				ParentClosePolicy: enums.PARENT_CLOSE_POLICY_REQUEST_CANCEL,
+				RetryPolicy: &temporal.RetryPolicy{
+					MaximumAttempts: 20,
+				},
+			}
+			snapshotFlowCtx := workflow.WithChildOptions(ctx, childSnapshotFlowOpts)
+			snapshotFlowFuture := workflow.ExecuteChildWorkflow(snapshotFlowCtx, SnapshotFlowWorkflow, cfg)
+			if err := snapshotFlowFuture.Get(snapshotFlowCtx, nil); err != nil {
+				return state, fmt.Errorf("failed to execute child workflow: %w", err)
+			}
+			state.Progress = append(state.Progress, "executed snapshot flow")
+		}
+
 		state.SetupComplete = true
 		state.Progress = append(state.Progress, "executed setup flow")
 	}
 
 	syncFlowOptions := &protos.SyncFlowOptions{
-		BatchSize: int32(limits.MaxBatchSize),
+		BatchSize:          int32(limits.MaxBatchSize),
+		IdleTimeoutSeconds: cfg.IdleTimeoutSeconds,
+	}
+
+	// normalize runs on its own, so syncs keep loading the raw table while a MERGE is running.
+	// every sync wakes up the normalizer, syncs that complete while it is busy are picked up
+	// together by its next run.
+	normalizeSignal := workflow.NewBufferedChannel(ctx, 1)
+	normalizeDone := w.startNormalizer(ctx, cfg, limits, state, normalizeSignal)
+	// catch up on batches the previous run didn't get to normalize.
+	if state.LastNormalizedBatchID < state.LastSyncedBatchID {
+		normalizeSignal.SendAsync(true)
 	}
 
 	currentSyncFlowNum := 0
-	currentNormalizeFlowNum := 0
 
 	for {
+		// block until the peer flow is resumed.
+		if state.ActiveSignal == shared.PauseSignal {
+			w.logger.Info("peer flow has been paused")
+			state.Progress = append(state.Progress, "paused")
+			for state.ActiveSignal == shared.PauseSignal {
+				selector.Select(ctx)
+			}
+			if state.ActiveSignal != shared.ShutdownSignal {
+				w.logger.Info("peer flow has been resumed")
+				state.Progress = append(state.Progress, "resumed")
+			}
+		}
+
 		// check if the peer flow has been shutdown
 		if state.ActiveSignal == shared.ShutdownSignal {
 			w.logger.Info("peer flow has been shutdown")
 			break
 		}
 
+		if len(pendingConfigUpdates) > 0 {
+			w.logger.Info("applying config updates before the next sync flow")
+			break
+		}
+
 		// check if total sync flows have been completed
 		if limits.TotalSyncFlows != 0 && currentSyncFlowNum == limits.TotalSyncFlows {
 			w.logger.Info("All the syncflows have completed successfully, there was a"+
@@ -298,70 +692,83 @@ func PeerFlowWorkflowWithConfig(
 				MaximumAttempts: 2,
 			},
 		}
-		ctx = workflow.WithChildOptions(ctx, childSyncFlowOpts)
+		syncFlowCtx := workflow.WithChildOptions(ctx, childSyncFlowOpts)
+		syncFlowOptions.RelationMessageMapping = state.RelationMessageMapping
 		childSyncFlowFuture := workflow.ExecuteChildWorkflow(
-			ctx,
+			syncFlowCtx,
 			SyncFlowWorkflow,
 			cfg,
 			syncFlowOptions,
 		)
 
+		syncFlowPending := true
 		selector.AddFuture(childSyncFlowFuture, func(f workflow.Future) {
+			syncFlowPending = false
 			var childSyncFlowRes *model.SyncResponse
-			if err := f.Get(ctx, &childSyncFlowRes); err != nil {
+			if err := f.Get(syncFlowCtx, &childSyncFlowRes); err != nil {
 				w.logger.Error("failed to execute sync flow: ", err)
-				state.SyncFlowErrors = multierror.Append(state.SyncFlowErrors, err)
+				state.addSyncFlowError(err)
 			} else {
+				if childSyncFlowRes != nil && childSyncFlowRes.RelationMessageMapping != nil {
+					state.RelationMessageMapping = childSyncFlowRes.RelationMessageMapping
+					// the mapping is kept once in the state, not with every status.
+					childSyncFlowRes.RelationMessageMapping = nil
+				}
 				state.SyncFlowStatuses = append(state.SyncFlowStatuses, childSyncFlowRes)
+				// the destination tables were already altered, normalize needs the new columns.
+				if childSyncFlowRes != nil {
+					for _, schemaDelta := range childSyncFlowRes.TableSchemaDeltas {
+						if schema, ok := cfg.TableNameSchemaMapping[schemaDelta.DstTableName]; ok {
+							model.ApplyTableSchemaDelta(schema, schemaDelta)
+						}
+					}
+					if childSyncFlowRes.CurrentSyncBatchID > state.LastSyncedBatchID {
+						state.LastSyncedBatchID = childSyncFlowRes.CurrentSyncBatchID
+						// a pending wake up already covers this batch.
+						normalizeSignal.SendAsync(true)
+					}
+				}
 			}
 		})
-		selector.Select(ctx)
-
-		/*
-			NormalizeFlow - normalize raw changes on target to final table
-			SyncFlow and NormalizeFlow are independent.
-			TODO -
-			1. Currently NormalizeFlow runs right after SyncFlow. We need to make it asynchronous
-			NormalizeFlow will start only after Initial Load
-		*/
-		if limits.TotalNormalizeFlows != 0 && currentNormalizeFlowNum == limits.TotalNormalizeFlows {
-			w.logger.Info("All the normalizer flows have completed successfully, there was a"+
-				" limit on the number of normalizer to be executed: ", limits.TotalNormalizeFlows)
-			break
+		// signals received while the sync flow runs are only acted upon once it completes, so that
+		// its batch is recorded before continuing as new and no other sync flow is started meanwhile.
+		for syncFlowPending {
+			selector.Select(ctx)
 		}
-		currentNormalizeFlowNum++
+	}
 
-		normalizeFlowID, err := GetChildWorkflowID(ctx, "normalize-flow", cfg.FlowJobName)
-		if err != nil {
-			return state, err
-		}
+	// let the normalizer catch up with the last syncs before continuing as new.
+	normalizeSignal.Close()
+	if err := normalizeDone.Get(ctx, nil); err != nil {
+		return state, fmt.Errorf("failed to wait for normalize flows: %w", err)
+	}
 
-		// execute the normalize flow as a child workflow
-		childNormalizeFlowOpts := workflow.ChildWorkflowOptions{
-			WorkflowID:        normalizeFlowID,
-			ParentClosePolicy: enums.PARENT_CLOSE_POLICY_REQUEST_CANCEL,
-			RetryPolicy: &temporal.RetryPolicy{
-				MaximumAttempts: 2,
-			},
+	// signals not yet received would be lost when continuing as new, the signalled state is
+	// carried over to the next run.
+	for {
+		var signalVal shared.PeerFlowSignal
+		if !signalChan.ReceiveAsync(&signalVal) {
+			break
 		}
-		ctx = workflow.WithChildOptions(ctx, childNormalizeFlowOpts)
-		childNormalizeFlowFuture := workflow.ExecuteChildWorkflow(
-			ctx,
-			NormalizeFlowWorkflow,
-			cfg,
-		)
-
-		selector.AddFuture(childNormalizeFlowFuture, func(f workflow.Future) {
-			var childNormalizeFlowRes *model.NormalizeResponse
-			if err := f.Get(ctx, &childNormalizeFlowRes); err != nil {
-				w.logger.Error("failed to execute normalize flow: ", err)
-				state.NormalizeFlowErrors = multierror.Append(state.NormalizeFlowErrors, err)
-			} else {
-				state.NormalizeFlowStatuses = append(state.NormalizeFlowStatuses, childNormalizeFlowRes)
-			}
-		})
-		selector.Select(ctx)
+		signalHandler(ctx, signalVal)
+	}
+	for {
+		var update *protos.FlowConfigUpdate
+		if !configUpdateChan.ReceiveAsync(&update) {
+			break
+		}
+		pendingConfigUpdates = append(pendingConfigUpdates, update)
+	}
+	// an update that can't be applied is left out, the peer flow keeps running with its config.
+	for _, update := range pendingConfigUpdates {
+		if err := w.applyConfigUpdate(ctx, cfg, limits, update); err != nil {
+			w.logger.Error("failed to apply config update: ", err)
+			state.addConfigUpdateError(err)
+			continue
+		}
+		state.Progress = append(state.Progress, "applied config update")
 	}
 
+	state.trimForContinueAsNew()
 	return nil, workflow.NewContinueAsNewError(ctx, PeerFlowWorkflowWithConfig, cfg, limits, state)
 }
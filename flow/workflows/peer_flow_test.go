@@ -0,0 +1,362 @@
+package peerflow
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/activities"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model"
+	"github.com/PeerDB-io/peer-flow/shared"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+// newPeerFlowTestEnv returns a test environment for peer flows whose setup is complete,
+// sync flows are mocked by the tests and normalize flows have nothing to normalize.
+func newPeerFlowTestEnv(t *testing.T) *testsuite.TestWorkflowEnvironment {
+	return newPeerFlowTestEnvWithNormalize(t,
+		func(_ workflow.Context, _ *protos.FlowConnectionConfigs) (*model.NormalizeResponse, error) {
+			return nil, nil
+		})
+}
+
+// newPeerFlowTestEnvWithNormalize returns a test environment for peer flows whose setup is complete,
+// normalize flows return the results of normalize.
+func newPeerFlowTestEnvWithNormalize(
+	t *testing.T,
+	normalize func(workflow.Context, *protos.FlowConnectionConfigs) (*model.NormalizeResponse, error),
+) *testsuite.TestWorkflowEnvironment {
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(PeerFlowWorkflowWithConfig)
+	env.RegisterWorkflow(SyncFlowWorkflow)
+	env.RegisterWorkflow(NormalizeFlowWorkflow)
+	env.OnWorkflow(NormalizeFlowWorkflow, mock.Anything, mock.Anything).Return(normalize).Maybe()
+	t.Cleanup(func() { env.AssertExpectations(t) })
+	return env
+}
+
+func newTestPeerFlowConfig() *protos.FlowConnectionConfigs {
+	return &protos.FlowConnectionConfigs{
+		FlowJobName:            "test_flow",
+		TableNameMapping:       map[string]string{"public.users": "public.users_dst"},
+		TableNameSchemaMapping: map[string]*protos.TableSchema{},
+	}
+}
+
+func newSetupPeerFlowState() *PeerFlowState {
+	state := NewStartedPeerFlowState()
+	state.SetupComplete = true
+	return state
+}
+
+// continuedAsNewState returns the state a peer flow continued as new with.
+func continuedAsNewState(t *testing.T, env *testsuite.TestWorkflowEnvironment) *PeerFlowState {
+	_, _, state := continuedAsNewInput(t, env)
+	return state
+}
+
+// continuedAsNewInput returns the config, limits and state a peer flow continued as new with.
+func continuedAsNewInput(
+	t *testing.T,
+	env *testsuite.TestWorkflowEnvironment,
+) (*protos.FlowConnectionConfigs, *PeerFlowLimits, *PeerFlowState) {
+	require.True(t, env.IsWorkflowCompleted())
+	var continueAsNewErr *workflow.ContinueAsNewError
+	require.True(t, errors.As(env.GetWorkflowError(), &continueAsNewErr), env.GetWorkflowError())
+
+	var cfg *protos.FlowConnectionConfigs
+	var limits *PeerFlowLimits
+	var state *PeerFlowState
+	err := converter.GetDefaultDataConverter().FromPayloads(continueAsNewErr.Input, &cfg, &limits, &state)
+	require.NoError(t, err)
+	return cfg, limits, state
+}
+
+func TestPeerFlowPassesRelationsToNextSync(t *testing.T) {
+	env := newPeerFlowTestEnv(t)
+
+	relations := map[uint32]*protos.RelationMessage{
+		16384: {RelationId: 16384, RelationName: "users"},
+	}
+	numSyncFlows := 0
+	env.OnWorkflow(SyncFlowWorkflow, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ workflow.Context, _ *protos.FlowConnectionConfigs,
+			options *protos.SyncFlowOptions) (*model.SyncResponse, error) {
+			numSyncFlows++
+			if numSyncFlows == 1 {
+				require.Empty(t, options.RelationMessageMapping)
+				return &model.SyncResponse{NumRecordsSynced: 1, RelationMessageMapping: relations}, nil
+			}
+			// the relations of the first pull are known to the second one.
+			require.Contains(t, options.RelationMessageMapping, uint32(16384))
+			return nil, nil
+		}).Times(2)
+
+	env.ExecuteWorkflow(PeerFlowWorkflowWithConfig, newTestPeerFlowConfig(),
+		&PeerFlowLimits{TotalSyncFlows: 2}, newSetupPeerFlowState())
+
+	state := continuedAsNewState(t, env)
+	require.Contains(t, state.RelationMessageMapping, uint32(16384))
+	// the relations are kept once in the state, not with every status.
+	require.Nil(t, state.SyncFlowStatuses[0].RelationMessageMapping)
+}
+
+func TestPeerFlowTrimsStateForContinueAsNew(t *testing.T) {
+	env := newPeerFlowTestEnv(t)
+
+	state := newSetupPeerFlowState()
+	for i := 1; i <= 40; i++ {
+		state.SyncFlowStatuses = append(state.SyncFlowStatuses,
+			&model.SyncResponse{NumRecordsSynced: int64(i % 2), CurrentSyncBatchID: int64(i)}, nil)
+		state.SyncFlowErrorMessages = append(state.SyncFlowErrorMessages, fmt.Sprintf("error %d", i))
+		state.Progress = append(state.Progress, fmt.Sprintf("progress %d", i))
+	}
+	state.NumSyncFlowErrors = 40
+
+	numSyncFlows := 0
+	env.OnWorkflow(SyncFlowWorkflow, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ workflow.Context, _ *protos.FlowConnectionConfigs,
+			_ *protos.SyncFlowOptions) (*model.SyncResponse, error) {
+			numSyncFlows++
+			if numSyncFlows == 1 {
+				return nil, errors.New("sync failed")
+			}
+			return &model.SyncResponse{NumRecordsSynced: 1, CurrentSyncBatchID: 41}, nil
+		}).Times(2)
+
+	env.ExecuteWorkflow(PeerFlowWorkflowWithConfig, newTestPeerFlowConfig(),
+		&PeerFlowLimits{TotalSyncFlows: 2}, state)
+
+	state = continuedAsNewState(t, env)
+	require.Len(t, state.SyncFlowStatuses, maxCarriedOverStatuses)
+	for _, status := range state.SyncFlowStatuses {
+		// syncs that had nothing to sync aren't carried over.
+		require.NotNil(t, status)
+		require.EqualValues(t, 1, status.NumRecordsSynced)
+	}
+	require.EqualValues(t, 41, state.SyncFlowStatuses[maxCarriedOverStatuses-1].CurrentSyncBatchID)
+
+	require.Len(t, state.SyncFlowErrorMessages, maxCarriedOverStatuses)
+	require.Contains(t, state.SyncFlowErrorMessages[maxCarriedOverStatuses-1], "sync failed")
+	// the number of errors is kept across runs even though older messages are dropped.
+	require.EqualValues(t, 41, state.NumSyncFlowErrors)
+	require.Len(t, state.Progress, maxCarriedOverStatuses)
+	require.EqualValues(t, 41, state.LastSyncedBatchID)
+}
+
+func TestPeerFlowConfigUpdateWaitsForRunningSync(t *testing.T) {
+	env := newPeerFlowTestEnv(t)
+
+	// the update is signalled while the first sync flow is still running.
+	env.OnWorkflow(SyncFlowWorkflow, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ workflow.Context, _ *protos.FlowConnectionConfigs,
+			_ *protos.SyncFlowOptions) (*model.SyncResponse, error) {
+			return &model.SyncResponse{NumRecordsSynced: 1, CurrentSyncBatchID: 1}, nil
+		}).After(time.Minute).Once()
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(shared.PeerFlowConfigUpdateSignalName, &protos.FlowConfigUpdate{BatchSize: 500})
+	}, 10*time.Second)
+
+	env.ExecuteWorkflow(PeerFlowWorkflowWithConfig, newTestPeerFlowConfig(),
+		&PeerFlowLimits{TotalSyncFlows: 5}, newSetupPeerFlowState())
+
+	cfg, limits, state := continuedAsNewInput(t, env)
+	// the batch of the running sync flow is recorded before continuing as new.
+	require.EqualValues(t, 1, state.LastSyncedBatchID)
+	require.Len(t, state.SyncFlowStatuses, 1)
+	require.Equal(t, 500, limits.MaxBatchSize)
+	require.EqualValues(t, 500, cfg.MaxBatchSize)
+}
+
+// signalConfigUpdate signals a config update while the first sync flow of a peer flow is running.
+func signalConfigUpdate(env *testsuite.TestWorkflowEnvironment, update *protos.FlowConfigUpdate) {
+	env.OnWorkflow(SyncFlowWorkflow, mock.Anything, mock.Anything, mock.Anything).Return(
+		&model.SyncResponse{NumRecordsSynced: 1, CurrentSyncBatchID: 1}, nil).After(time.Minute).Once()
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(shared.PeerFlowConfigUpdateSignalName, update)
+	}, 10*time.Second)
+}
+
+func TestPeerFlowRejectsConfigUpdateOfReplicatedTable(t *testing.T) {
+	env := newPeerFlowTestEnv(t)
+	signalConfigUpdate(env, &protos.FlowConfigUpdate{
+		BatchSize:        500,
+		AdditionalTables: map[string]string{"public.users": "public.users_copy"},
+	})
+
+	env.ExecuteWorkflow(PeerFlowWorkflowWithConfig, newTestPeerFlowConfig(),
+		&PeerFlowLimits{TotalSyncFlows: 5, MaxBatchSize: 100}, newSetupPeerFlowState())
+
+	// the peer flow keeps running with its config, none of the update is applied.
+	cfg, limits, state := continuedAsNewInput(t, env)
+	require.Len(t, state.ConfigUpdateErrorMessages, 1)
+	require.Contains(t, state.ConfigUpdateErrorMessages[0], "table public.users is already replicated")
+	require.Equal(t, 100, limits.MaxBatchSize)
+	require.Equal(t, map[string]string{"public.users": "public.users_dst"}, cfg.TableNameMapping)
+}
+
+func TestConfigUpdateAddedTables(t *testing.T) {
+	cfg := newTestPeerFlowConfig()
+
+	addedTables, err := configUpdateAddedTables(cfg, &protos.FlowConfigUpdate{
+		AdditionalTables: map[string]string{"public.orders": "public.orders_dst", "public.items": "public.items_dst"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"public.items", "public.orders"}, addedTables)
+
+	_, err = configUpdateAddedTables(cfg, &protos.FlowConfigUpdate{
+		AdditionalTables: map[string]string{"public.orders": "public.users_dst"},
+	})
+	require.ErrorContains(t, err, "table public.users is already replicated to public.users_dst")
+
+	_, err = configUpdateAddedTables(cfg, &protos.FlowConfigUpdate{
+		AdditionalTables: map[string]string{"public.items": "public.dst", "public.orders": "public.dst"},
+	})
+	require.ErrorContains(t, err, "table public.items is already replicated to public.dst")
+}
+
+func TestPeerFlowRollsBackPublicationWhenSetupFails(t *testing.T) {
+	env := newPeerFlowTestEnv(t)
+	env.RegisterWorkflow(SetupFlowWorkflow)
+	env.RegisterActivity(&activities.FlowableActivity{})
+	signalConfigUpdate(env, &protos.FlowConfigUpdate{
+		AdditionalTables: map[string]string{"public.orders": "public.orders_dst"},
+		RemovedTables:    []string{"public.users"},
+	})
+
+	env.OnActivity(flowable.ValidateAlterPublication, mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnActivity(flowable.AlterPublication, mock.Anything, mock.MatchedBy(
+		func(input *protos.AlterPublicationInput) bool {
+			return len(input.AddedTables) == 1 && input.AddedTables[0] == "public.orders"
+		})).Return(nil).Once()
+	// the publication is changed back once the added table can't be set up.
+	env.OnActivity(flowable.AlterPublication, mock.Anything, mock.MatchedBy(
+		func(input *protos.AlterPublicationInput) bool {
+			return len(input.AddedTables) == 1 && input.AddedTables[0] == "public.users" &&
+				len(input.RemovedTables) == 1 && input.RemovedTables[0] == "public.orders"
+		})).Return(nil).Once()
+	env.OnWorkflow(SetupFlowWorkflow, mock.Anything, mock.Anything).Return(nil, errors.New("setup failed"))
+
+	env.ExecuteWorkflow(PeerFlowWorkflowWithConfig, newTestPeerFlowConfig(),
+		&PeerFlowLimits{TotalSyncFlows: 5}, newSetupPeerFlowState())
+
+	cfg, _, state := continuedAsNewInput(t, env)
+	require.Len(t, state.ConfigUpdateErrorMessages, 1)
+	require.Contains(t, state.ConfigUpdateErrorMessages[0], "setup failed")
+	require.Equal(t, map[string]string{"public.users": "public.users_dst"}, cfg.TableNameMapping)
+}
+
+func TestPeerFlowResumeDuringSyncDoesNotStartAnotherSync(t *testing.T) {
+	env := newPeerFlowTestEnv(t)
+
+	numRunning := 0
+	env.SetOnChildWorkflowStartedListener(func(info *workflow.Info, _ workflow.Context, _ converter.EncodedValues) {
+		if info.WorkflowType.Name == "SyncFlowWorkflow" {
+			require.Zero(t, numRunning, "sync flow started while another one is running")
+			numRunning++
+		}
+	})
+	env.OnWorkflow(SyncFlowWorkflow, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ workflow.Context, _ *protos.FlowConnectionConfigs,
+			_ *protos.SyncFlowOptions) (*model.SyncResponse, error) {
+			numRunning--
+			return nil, nil
+		}).After(time.Minute).Times(2)
+	// the flow is paused and resumed while the first sync flow is still running.
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(shared.PeerFlowSignalName, shared.PauseSignal)
+	}, 10*time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(shared.PeerFlowSignalName, shared.ResumeSignal)
+	}, 20*time.Second)
+
+	env.ExecuteWorkflow(PeerFlowWorkflowWithConfig, newTestPeerFlowConfig(),
+		&PeerFlowLimits{TotalSyncFlows: 2}, newSetupPeerFlowState())
+
+	state := continuedAsNewState(t, env)
+	require.Equal(t, shared.NoopSignal, state.ActiveSignal)
+}
+
+func TestPeerFlowCarriesSignalReceivedAfterLastSync(t *testing.T) {
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(PeerFlowWorkflowWithConfig)
+	env.RegisterWorkflow(SyncFlowWorkflow)
+	env.RegisterWorkflow(NormalizeFlowWorkflow)
+	syncBatches(env, 1)
+	env.OnWorkflow(NormalizeFlowWorkflow, mock.Anything, mock.Anything).Return(
+		&model.NormalizeResponse{Done: true, StartBatchID: 1, EndBatchID: 1}, nil).After(time.Minute).Once()
+	// the flow is paused while waiting for the normalizer to catch up before continuing as new.
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(shared.PeerFlowSignalName, shared.PauseSignal)
+	}, 10*time.Second)
+
+	env.ExecuteWorkflow(PeerFlowWorkflowWithConfig, newTestPeerFlowConfig(),
+		&PeerFlowLimits{TotalSyncFlows: 1}, newSetupPeerFlowState())
+
+	state := continuedAsNewState(t, env)
+	require.Equal(t, shared.PauseSignal, state.ActiveSignal)
+	env.AssertExpectations(t)
+}
+
+// syncBatches mocks sync flows that each sync a new batch.
+func syncBatches(env *testsuite.TestWorkflowEnvironment, numBatches int) {
+	batchID := int64(0)
+	env.OnWorkflow(SyncFlowWorkflow, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ workflow.Context, _ *protos.FlowConnectionConfigs,
+			_ *protos.SyncFlowOptions) (*model.SyncResponse, error) {
+			batchID++
+			return &model.SyncResponse{NumRecordsSynced: 1, CurrentSyncBatchID: batchID}, nil
+		}).Times(numBatches)
+}
+
+func TestPeerFlowNoopNormalizeAdvancesBatchID(t *testing.T) {
+	numNormalizeFlows := 0
+	env := newPeerFlowTestEnvWithNormalize(t,
+		func(_ workflow.Context, _ *protos.FlowConnectionConfigs) (*model.NormalizeResponse, error) {
+			numNormalizeFlows++
+			// like EventHub and Kafka, the destination has nothing to normalize.
+			return nil, nil
+		})
+	syncBatches(env, 3)
+
+	env.ExecuteWorkflow(PeerFlowWorkflowWithConfig, newTestPeerFlowConfig(),
+		&PeerFlowLimits{TotalSyncFlows: 3}, newSetupPeerFlowState())
+
+	state := continuedAsNewState(t, env)
+	require.EqualValues(t, 3, state.LastSyncedBatchID)
+	require.EqualValues(t, 3, state.LastNormalizedBatchID)
+	require.Empty(t, state.NormalizeFlowStatuses)
+	require.LessOrEqual(t, numNormalizeFlows, 3)
+}
+
+func TestPeerFlowKeepsLatestNormalizeStatuses(t *testing.T) {
+	normalizedBatchID := int64(0)
+	env := newPeerFlowTestEnvWithNormalize(t,
+		func(_ workflow.Context, _ *protos.FlowConnectionConfigs) (*model.NormalizeResponse, error) {
+			normalizedBatchID++
+			return &model.NormalizeResponse{
+				Done:         true,
+				StartBatchID: normalizedBatchID,
+				EndBatchID:   normalizedBatchID,
+			}, nil
+		})
+	syncBatches(env, 24)
+
+	env.ExecuteWorkflow(PeerFlowWorkflowWithConfig, newTestPeerFlowConfig(),
+		&PeerFlowLimits{TotalSyncFlows: 24}, newSetupPeerFlowState())
+
+	state := continuedAsNewState(t, env)
+	require.EqualValues(t, 24, state.LastSyncedBatchID)
+	require.EqualValues(t, normalizedBatchID, state.LastNormalizedBatchID)
+	require.Len(t, state.NormalizeFlowStatuses, maxCarriedOverStatuses)
+	require.EqualValues(t, normalizedBatchID,
+		state.NormalizeFlowStatuses[maxCarriedOverStatuses-1].EndBatchID)
+}
@@ -13,10 +13,25 @@ import (
 	"go.temporal.io/sdk/workflow"
 )
 
+const QRepFlowStatusQuery = "q-qrep-flow-status"
+
 type QRepFlowExecution struct {
 	config          *protos.QRepConfig
 	flowExecutionID string
 	logger          log.Logger
+	// partitions of the current batch, and how many of them are done.
+	numPartitions          int
+	numPartitionsProcessed int
+}
+
+// QRepFlowStatus is the progress of a QRep flow, returned by QRepFlowStatusQuery.
+type QRepFlowStatus struct {
+	// Partitions replicated by the flow so far, across all batches.
+	NumPartitionsProcessed int
+	// Partitions of the current batch that are not replicated yet.
+	NumPartitionsRemaining int
+	// Last partition of the previous batch, the current batch starts after it.
+	LastPartitionID string
 }
 
 // NewQRepFlowExecution creates a new instance of QRepFlowExecution.
@@ -126,6 +141,8 @@ func (q *QRepFlowExecution) processPartitions(
 ) error {
 	futures := make(map[workflow.Future]struct{})
 	sel := workflow.NewSelector(ctx)
+	q.numPartitions = len(partitions)
+	q.numPartitionsProcessed = 0
 
 	for _, partition := range partitions {
 		for len(futures) >= maxParallelWorkers {
@@ -141,6 +158,7 @@ func (q *QRepFlowExecution) processPartitions(
 		sel.AddFuture(future, func(f workflow.Future) {
 			// When the future is ready, remove it from the map
 			delete(futures, f)
+			q.numPartitionsProcessed++
 		})
 	}
 
@@ -232,6 +250,21 @@ func QRepFlowWorkflow(
 
 	q := NewQRepFlowExecution(ctx, config)
 
+	// register a query to get the progress of the flow
+	err = workflow.SetQueryHandler(ctx, QRepFlowStatusQuery, func() (QRepFlowStatus, error) {
+		status := QRepFlowStatus{
+			NumPartitionsProcessed: numPartitionsProcessed + q.numPartitionsProcessed,
+			NumPartitionsRemaining: q.numPartitions - q.numPartitionsProcessed,
+		}
+		if lastPartition != nil {
+			status.LastPartitionID = lastPartition.PartitionId
+		}
+		return status, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register query handler: %w", err)
+	}
+
 	err = q.SetupMetadataTables(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to setup metadata tables: %w", err)
@@ -261,6 +294,9 @@ func QRepFlowWorkflow(
 
 	q.logger.Info("partitions processed - ", len(partitions.Partitions))
 	numPartitionsProcessed += len(partitions.Partitions)
+	// the batch is now counted in numPartitionsProcessed.
+	q.numPartitions = 0
+	q.numPartitionsProcessed = 0
 
 	if len(partitions.Partitions) > 0 {
 		lastPartition = partitions.Partitions[len(partitions.Partitions)-1]
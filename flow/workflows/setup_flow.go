@@ -149,6 +149,8 @@ func (s *SetupFlowExecution) setupReplication(
 		PeerConnectionConfig: config.Source,
 		FlowJobName:          s.PeerFlowName,
 		TableNameMapping:     config.TableNameMapping,
+		ColumnFilters:        config.ColumnFilters,
+		RowFilters:           config.RowFilters,
 	}
 	setupReplicationFuture := workflow.ExecuteActivity(ctx, flowable.SetupReplication, setupReplicationInput)
 	if err := setupReplicationFuture.Get(ctx, nil); err != nil {
@@ -172,6 +174,7 @@ func (s *SetupFlowExecution) createRawTable(
 		PeerConnectionConfig: config.Destination,
 		FlowJobName:          s.PeerFlowName,
 		TableNameMapping:     config.TableNameMapping,
+		CdcSyncMode:          config.CdcSyncMode,
 	}
 
 	rawTblFuture := workflow.ExecuteActivity(ctx, flowable.CreateRawTable, createRawTblInput)
@@ -198,6 +201,7 @@ func (s *SetupFlowExecution) fetchTableSchemaAndSetupNormalizedTables(
 		tableSchemaInput := &protos.GetTableSchemaInput{
 			PeerConnectionConfig: flowConnectionConfigs.Source,
 			TableIdentifier:      srcTableName,
+			ColumnFilter:         flowConnectionConfigs.ColumnFilters[srcTableName],
 		}
 		fSrcTableSchema := workflow.ExecuteActivity(ctx, flowable.GetTableSchema, tableSchemaInput)
 		var srcTableSchema *protos.TableSchema
@@ -206,6 +210,12 @@ func (s *SetupFlowExecution) fetchTableSchemaAndSetupNormalizedTables(
 		}
 		s.logger.Info(fmt.Sprintf("fetched schema for table %s for peer flow %s ", srcTableSchema, s.PeerFlowName))
 
+		if flowConnectionConfigs.CdcSyncMode == protos.CDCSyncMode_CDC_SYNC_MODE_DIRECT_APPLY {
+			if err := checkDirectApplyTableSchema(srcTableName, srcTableSchema); err != nil {
+				return nil, err
+			}
+		}
+
 		tableNameSchemaMapping[flowConnectionConfigs.TableNameMapping[srcTableName]] = srcTableSchema
 
 		s.logger.Info(fmt.Sprintf("setting up normalized table for table %s for peer flow - %s",
@@ -216,6 +226,7 @@ func (s *SetupFlowExecution) fetchTableSchemaAndSetupNormalizedTables(
 			PeerConnectionConfig: flowConnectionConfigs.Destination,
 			TableIdentifier:      flowConnectionConfigs.TableNameMapping[srcTableName],
 			SourceTableSchema:    srcTableSchema,
+			Transforms:           flowConnectionConfigs.Transforms[srcTableName],
 		}
 		fSetupNormalizedTables := workflow.ExecuteActivity(ctx, flowable.CreateNormalizedTable, setupConfig)
 
@@ -232,6 +243,17 @@ func (s *SetupFlowExecution) fetchTableSchemaAndSetupNormalizedTables(
 	return tableNameSchemaMapping, nil
 }
 
+// checkDirectApplyTableSchema checks that the changes of a table can be applied directly. Rows of tables
+// without key columns can only be told apart by their old row, which needs REPLICA IDENTITY FULL.
+func checkDirectApplyTableSchema(srcTableName string, schema *protos.TableSchema) error {
+	if len(schema.PrimaryKeyColumns) == 0 &&
+		schema.ReplicaIdentity != protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL {
+		return fmt.Errorf("table %s has no primary key and no REPLICA IDENTITY FULL, "+
+			"its updates and deletes can't be applied directly", srcTableName)
+	}
+	return nil
+}
+
 // executeSetupFlow executes the setup flow.
 func (s *SetupFlowExecution) executeSetupFlow(
 	ctx workflow.Context,
@@ -249,9 +271,11 @@ func (s *SetupFlowExecution) executeSetupFlow(
 		return nil, fmt.Errorf("failed to ensure pullability: %w", err)
 	}
 
-	// then setup replication
-	if err := s.setupReplication(ctx, config); err != nil {
-		return nil, fmt.Errorf("failed to setup replication on source: %w", err)
+	// then setup replication, unless the initial copy sets it up along with the snapshot.
+	if !config.DoInitialCopy {
+		if err := s.setupReplication(ctx, config); err != nil {
+			return nil, fmt.Errorf("failed to setup replication on source: %w", err)
+		}
 	}
 
 	// then create the raw table
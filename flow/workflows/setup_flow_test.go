@@ -0,0 +1,20 @@
+package peerflow
+
+import (
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDirectApplyTableSchema(t *testing.T) {
+	require.NoError(t, checkDirectApplyTableSchema("public.users", &protos.TableSchema{
+		PrimaryKeyColumns: []string{"id"},
+	}))
+	require.NoError(t, checkDirectApplyTableSchema("public.events", &protos.TableSchema{
+		ReplicaIdentity: protos.ReplicaIdentityType_REPLICA_IDENTITY_FULL,
+	}))
+
+	err := checkDirectApplyTableSchema("public.events", &protos.TableSchema{})
+	require.ErrorContains(t, err, "table public.events has no primary key and no REPLICA IDENTITY FULL")
+}
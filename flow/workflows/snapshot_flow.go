@@ -0,0 +1,272 @@
+// This file corresponds to the initial snapshot of a CDC mirror.
+package peerflow
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	connpostgres "github.com/PeerDB-io/peer-flow/connectors/postgres"
+	"github.com/PeerDB-io/peer-flow/connectors/utils"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/model/qvalue"
+	"github.com/PeerDB-io/peer-flow/shared"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// default number of rows copied per partition during the initial snapshot.
+const defaultSnapshotNumRowsPerPartition = 500000
+
+type SnapshotFlowExecution struct {
+	config *protos.FlowConnectionConfigs
+	logger log.Logger
+}
+
+// setupReplication creates the publication and the replication slot, exporting the snapshot of the
+// slot's starting point. This has to run in the session that later releases the snapshot.
+func (s *SnapshotFlowExecution) setupReplication(
+	sessionCtx workflow.Context,
+) (*protos.SetupReplicationOutput, error) {
+	flowName := s.config.FlowJobName
+	s.logger.Info("setting up replication on source for peer flow - ", flowName)
+
+	ctx := workflow.WithActivityOptions(sessionCtx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+	})
+	setupReplicationInput := &protos.SetupReplicationInput{
+		PeerConnectionConfig: s.config.Source,
+		FlowJobName:          flowName,
+		TableNameMapping:     s.config.TableNameMapping,
+		ColumnFilters:        s.config.ColumnFilters,
+		RowFilters:           s.config.RowFilters,
+	}
+
+	res := &protos.SetupReplicationOutput{}
+	setupReplicationFuture := workflow.ExecuteActivity(ctx, snapshot.ExportSlotSnapshot, setupReplicationInput)
+	if err := setupReplicationFuture.Get(ctx, &res); err != nil {
+		return nil, fmt.Errorf("failed to setup replication on source peer: %w", err)
+	}
+
+	s.logger.Info("replication slot live on source for peer flow - ", flowName)
+	return res, nil
+}
+
+// closeSlotKeepAlive releases the exported snapshot once the initial copy is done.
+func (s *SnapshotFlowExecution) closeSlotKeepAlive(sessionCtx workflow.Context) error {
+	flowName := s.config.FlowJobName
+	s.logger.Info("closing slot keep alive for peer flow - ", flowName)
+
+	ctx := workflow.WithActivityOptions(sessionCtx, workflow.ActivityOptions{
+		StartToCloseTimeout: 15 * time.Minute,
+	})
+	if err := workflow.ExecuteActivity(ctx, snapshot.CloseSlotKeepAlive, flowName).Get(ctx, nil); err != nil {
+		return fmt.Errorf("failed to close slot keep alive for peer flow: %w", err)
+	}
+
+	s.logger.Info("closed slot keep alive for peer flow - ", flowName)
+	return nil
+}
+
+var invalidWorkflowNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// isPartitionColumnKind returns whether partitions can be ranges of a column of this kind.
+func isPartitionColumnKind(kind string) bool {
+	switch qvalue.QValueKind(kind) {
+	case qvalue.QValueKindInt32, qvalue.QValueKindInt64,
+		qvalue.QValueKindTimestamp, qvalue.QValueKindTimestampTZ:
+		return true
+	default:
+		return false
+	}
+}
+
+// getTableQRepConfig returns the config of a QRep flow reading a mapped table of a CDC mirror,
+// partitioned on the first column of the table's key. Tables without a key, or whose key starts
+// with a column that can't be ranged on, are partitioned on the blocks of their rows instead.
+func getTableQRepConfig(
+	config *protos.FlowConnectionConfigs,
+	srcTableName string,
+	dstTableName string,
+) (*protos.QRepConfig, error) {
+	tableSchema, ok := config.TableNameSchemaMapping[dstTableName]
+	if !ok {
+		return nil, fmt.Errorf("no schema for table %s", srcTableName)
+	}
+
+	// only the columns of the schema are read, it leaves out filtered out columns.
+	columnNames := make([]string, 0, len(tableSchema.Columns))
+	for columnName := range tableSchema.Columns {
+		columnNames = append(columnNames, utils.QuoteIdentifier(columnName))
+	}
+	sort.Strings(columnNames)
+
+	var partitionCol, query string
+	if len(tableSchema.PrimaryKeyColumns) > 0 &&
+		isPartitionColumnKind(tableSchema.Columns[tableSchema.PrimaryKeyColumns[0]]) {
+		partitionCol = tableSchema.PrimaryKeyColumns[0]
+		query = fmt.Sprintf("SELECT %s FROM %s WHERE %s BETWEEN {{.start}} AND {{.end}}",
+			strings.Join(columnNames, ","), srcTableName, utils.QuoteIdentifier(partitionCol))
+	} else {
+		partitionCol = shared.CTIDWatermarkColumn
+		query = fmt.Sprintf("SELECT %s FROM %s WHERE ctid BETWEEN format('(%%s,0)',{{.start}}::BIGINT)::TID"+
+			" AND format('(%%s,65535)',{{.end}}::BIGINT)::TID", strings.Join(columnNames, ","), srcTableName)
+	}
+	if rowFilter, ok := config.RowFilters[srcTableName]; ok {
+		// the filter is checked with the parser used on replicated changes, so that nothing but a single
+		// expression over the table's columns ends up in the query.
+		if err := connpostgres.ValidateRowFilter(rowFilter); err != nil {
+			return nil, fmt.Errorf("row filter of table %s: %w", srcTableName, err)
+		}
+		query = fmt.Sprintf("%s AND (%s)", query, rowFilter)
+	}
+
+	return &protos.QRepConfig{
+		SourcePeer:                 config.Source,
+		DestinationPeer:            config.Destination,
+		DestinationTableIdentifier: dstTableName,
+		Query:                      query,
+		WatermarkTable:             srcTableName,
+		WatermarkColumn:            partitionCol,
+		Transforms:                 config.Transforms[srcTableName],
+	}, nil
+}
+
+// cloneTable starts a QRep flow copying the source table as of the snapshot.
+func (s *SnapshotFlowExecution) cloneTable(
+	ctx workflow.Context,
+	snapshotName string,
+	srcTableName string,
+	dstTableName string,
+) (workflow.ChildWorkflowFuture, error) {
+	flowName := s.config.FlowJobName
+
+	config, err := getTableQRepConfig(s.config, srcTableName, dstTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up the initial copy: %w", err)
+	}
+
+	childWorkflowID, err := GetChildWorkflowID(ctx, "clone", flowName)
+	if err != nil {
+		return nil, err
+	}
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:        childWorkflowID,
+		ParentClosePolicy: enums.PARENT_CLOSE_POLICY_REQUEST_CANCEL,
+		// partitions are retried by the copy, a failed copy is started over with the snapshot flow.
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 1,
+		},
+	})
+
+	numRowsPerPartition := s.config.SnapshotNumRowsPerPartition
+	if numRowsPerPartition == 0 {
+		numRowsPerPartition = defaultSnapshotNumRowsPerPartition
+	}
+
+	config.FlowJobName = invalidWorkflowNameChars.ReplaceAllString(
+		fmt.Sprintf("clone_%s_%s", flowName, dstTableName), "_")
+	config.InitialCopyOnly = true
+	config.NumRowsPerPartition = numRowsPerPartition
+	config.MaxParallelWorkers = s.config.SnapshotMaxParallelWorkers
+	config.SnapshotName = snapshotName
+
+	s.logger.Info(fmt.Sprintf("starting initial copy of %s to %s", srcTableName, dstTableName))
+	return workflow.ExecuteChildWorkflow(childCtx, QRepFlowWorkflow, config, nil, 0), nil
+}
+
+// cloneTables copies every mapped table and waits for all of the copies to finish.
+func (s *SnapshotFlowExecution) cloneTables(ctx workflow.Context, snapshotName string) error {
+	// map iteration order isn't deterministic, start the copies in a fixed order.
+	srcTableNames := make([]string, 0, len(s.config.TableNameMapping))
+	for srcTableName := range s.config.TableNameMapping {
+		srcTableNames = append(srcTableNames, srcTableName)
+	}
+	sort.Strings(srcTableNames)
+
+	futures := make([]workflow.ChildWorkflowFuture, 0, len(srcTableNames))
+	for _, srcTableName := range srcTableNames {
+		future, err := s.cloneTable(ctx, snapshotName, srcTableName, s.config.TableNameMapping[srcTableName])
+		if err != nil {
+			return err
+		}
+		futures = append(futures, future)
+	}
+
+	for i, future := range futures {
+		if err := future.Get(ctx, nil); err != nil {
+			return fmt.Errorf("failed to copy table %s: %w", srcTableNames[i], err)
+		}
+	}
+
+	s.logger.Info("initial copy completed for peer flow - ", s.config.FlowJobName)
+	return nil
+}
+
+// truncateDestinationTables removes the rows an earlier attempt of the initial copy left in the
+// destination tables.
+func (s *SnapshotFlowExecution) truncateDestinationTables(ctx workflow.Context) error {
+	s.logger.Info("truncating destination tables before starting over the initial copy of peer flow - ",
+		s.config.FlowJobName)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 15 * time.Minute,
+	})
+	if err := workflow.ExecuteActivity(ctx, flowable.TruncateDestinationTables, s.config).Get(ctx, nil); err != nil {
+		return fmt.Errorf("failed to truncate destination tables: %w", err)
+	}
+	return nil
+}
+
+// SnapshotFlowWorkflow creates the replication slot of a CDC mirror and copies the existing rows
+// of its tables, reading them under the snapshot exported with the slot so that replication
+// picks up exactly where the copy ends. A failed copy is started over by retrying the workflow,
+// which first removes the rows copied by the failed attempt.
+func SnapshotFlowWorkflow(ctx workflow.Context, config *protos.FlowConnectionConfigs) (err error) {
+	s := &SnapshotFlowExecution{
+		config: config,
+		logger: workflow.GetLogger(ctx),
+	}
+
+	// the snapshot lives on the connection that created the slot, so the activities
+	// exporting and releasing it have to run on the same worker.
+	sessionCtx, err := workflow.CreateSession(ctx, &workflow.SessionOptions{
+		CreationTimeout:  5 * time.Minute,
+		ExecutionTimeout: time.Hour * 24 * 365 * 100,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	defer workflow.CompleteSession(sessionCtx)
+
+	// the snapshot is released however the copy ends, also when the workflow is canceled, otherwise its
+	// connection stays open and keeps the slot active, so a retry couldn't drop and create the slot again.
+	defer func() {
+		closeCtx, _ := workflow.NewDisconnectedContext(sessionCtx)
+		if closeErr := s.closeSlotKeepAlive(closeCtx); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	if workflow.GetInfo(ctx).Attempt > 1 {
+		if err := s.truncateDestinationTables(ctx); err != nil {
+			return err
+		}
+	}
+
+	slotInfo, err := s.setupReplication(sessionCtx)
+	if err != nil {
+		return err
+	}
+
+	// copying the latest rows instead would miss or duplicate changes made since the slot was created.
+	if slotInfo.SnapshotName == "" {
+		return fmt.Errorf("no snapshot exported with the replication slot of peer flow %s", config.FlowJobName)
+	}
+
+	return s.cloneTables(ctx, slotInfo.SnapshotName)
+}
@@ -0,0 +1,122 @@
+package peerflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PeerDB-io/peer-flow/activities"
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/shared"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func newTestSnapshotFlowConfig(primaryKeyColumns ...string) *protos.FlowConnectionConfigs {
+	return &protos.FlowConnectionConfigs{
+		FlowJobName:      "test_flow",
+		TableNameMapping: map[string]string{"public.users": "public.users_dst"},
+		TableNameSchemaMapping: map[string]*protos.TableSchema{
+			"public.users_dst": {
+				TableIdentifier:   "public.users",
+				Columns:           map[string]string{"id": "int64", "name": "string", "uid": "string"},
+				PrimaryKeyColumns: primaryKeyColumns,
+			},
+		},
+	}
+}
+
+func TestGetTableQRepConfigPartitionsOnKey(t *testing.T) {
+	config, err := getTableQRepConfig(newTestSnapshotFlowConfig("id", "uid"), "public.users", "public.users_dst")
+	require.NoError(t, err)
+	require.Equal(t, "id", config.WatermarkColumn)
+	require.Equal(t, `SELECT "id","name","uid" FROM public.users WHERE "id" BETWEEN {{.start}} AND {{.end}}`,
+		config.Query)
+}
+
+func TestGetTableQRepConfigFallsBackToCTID(t *testing.T) {
+	// tables without a key, and keys starting with a text or uuid column, are partitioned on blocks.
+	for _, primaryKeyColumns := range [][]string{nil, {"uid"}} {
+		flowConfig := newTestSnapshotFlowConfig(primaryKeyColumns...)
+		flowConfig.RowFilters = map[string]string{"public.users": "id > 10"}
+
+		config, err := getTableQRepConfig(flowConfig, "public.users", "public.users_dst")
+		require.NoError(t, err)
+		require.Equal(t, shared.CTIDWatermarkColumn, config.WatermarkColumn)
+		require.Equal(t, `SELECT "id","name","uid" FROM public.users WHERE ctid BETWEEN `+
+			`format('(%s,0)',{{.start}}::BIGINT)::TID AND format('(%s,65535)',{{.end}}::BIGINT)::TID AND (id > 10)`,
+			config.Query)
+	}
+}
+
+func newSnapshotFlowTestEnv(t *testing.T, snapshotName string) *testsuite.TestWorkflowEnvironment {
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(worker.Options{EnableSessionWorker: true})
+	env.RegisterWorkflow(SnapshotFlowWorkflow)
+	env.RegisterWorkflow(QRepFlowWorkflow)
+	env.RegisterActivity(&activities.SnapshotActivity{})
+	env.OnActivity(snapshot.ExportSlotSnapshot, mock.Anything, mock.Anything).Return(
+		&protos.SetupReplicationOutput{SlotName: "peerflow_slot_test_flow", SnapshotName: snapshotName}, nil)
+	t.Cleanup(func() { env.AssertExpectations(t) })
+	return env
+}
+
+func TestSnapshotFlowCopiesUnderExportedSnapshot(t *testing.T) {
+	env := newSnapshotFlowTestEnv(t, "00000003-00000002-1")
+	env.OnActivity(snapshot.CloseSlotKeepAlive, mock.Anything, "test_flow").Return(nil).Once()
+	env.OnWorkflow(QRepFlowWorkflow, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ workflow.Context, config *protos.QRepConfig, _ *protos.QRepPartition, _ int) error {
+			require.Equal(t, "00000003-00000002-1", config.SnapshotName)
+			require.Equal(t, shared.CTIDWatermarkColumn, config.WatermarkColumn)
+			require.True(t, config.InitialCopyOnly)
+			return nil
+		}).Once()
+
+	env.ExecuteWorkflow(SnapshotFlowWorkflow, newTestSnapshotFlowConfig())
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+}
+
+func TestSnapshotFlowRequiresExportedSnapshot(t *testing.T) {
+	env := newSnapshotFlowTestEnv(t, "")
+	env.OnActivity(snapshot.CloseSlotKeepAlive, mock.Anything, "test_flow").Return(nil).Once()
+
+	env.ExecuteWorkflow(SnapshotFlowWorkflow, newTestSnapshotFlowConfig("id"))
+	require.True(t, env.IsWorkflowCompleted())
+	require.ErrorContains(t, env.GetWorkflowError(), "no snapshot exported")
+}
+
+func TestSnapshotFlowReleasesSnapshotWhenCopyFails(t *testing.T) {
+	env := newSnapshotFlowTestEnv(t, "00000003-00000002-1")
+	env.OnActivity(snapshot.CloseSlotKeepAlive, mock.Anything, "test_flow").Return(nil).Once()
+	env.OnWorkflow(QRepFlowWorkflow, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		errors.New("partition failed")).Once()
+
+	env.ExecuteWorkflow(SnapshotFlowWorkflow, newTestSnapshotFlowConfig("id"))
+	require.True(t, env.IsWorkflowCompleted())
+	require.ErrorContains(t, env.GetWorkflowError(), "failed to copy table public.users")
+}
+
+func TestGetTableQRepConfigQuotesColumns(t *testing.T) {
+	flowConfig := newTestSnapshotFlowConfig(`User "ID"`)
+	flowConfig.TableNameSchemaMapping["public.users_dst"].Columns = map[string]string{
+		`User "ID"`: "int64",
+		"Name":      "string",
+	}
+
+	config, err := getTableQRepConfig(flowConfig, "public.users", "public.users_dst")
+	require.NoError(t, err)
+	require.Equal(t, `SELECT "Name","User ""ID""" FROM public.users WHERE "User ""ID""" BETWEEN {{.start}} AND {{.end}}`,
+		config.Query)
+}
+
+func TestGetTableQRepConfigRejectsInvalidRowFilter(t *testing.T) {
+	flowConfig := newTestSnapshotFlowConfig("id")
+	flowConfig.RowFilters = map[string]string{"public.users": "id > 10) OR (1 = 1"}
+
+	_, err := getTableQRepConfig(flowConfig, "public.users", "public.users_dst")
+	require.ErrorContains(t, err, "row filter of table public.users")
+}
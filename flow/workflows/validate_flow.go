@@ -0,0 +1,275 @@
+// This file corresponds to validating that the destination of a mirror matches its source.
+package peerflow
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/PeerDB-io/peer-flow/generated/protos"
+	"github.com/PeerDB-io/peer-flow/shared"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultValidateNumRowsPerPartition = 100000
+	defaultValidateMaxParallelWorkers  = 4
+)
+
+// bounds of the ranges checking for destination rows before the first and after the last partition,
+// timestamps stay within what every peer can store.
+var (
+	minValidateTimestamp = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxValidateTimestamp = time.Date(9999, 12, 31, 23, 59, 59, 999999000, time.UTC)
+)
+
+type ValidateFlowExecution struct {
+	req    *protos.ValidateFlowRequest
+	logger log.Logger
+}
+
+// tableConfigs returns the configs of the QRep flows reading each table of the mirror. Tables are
+// partitioned on the number of rows, so partitions are inclusive ranges of the watermark column.
+func (v *ValidateFlowExecution) tableConfigs() ([]*protos.QRepConfig, error) {
+	numRowsPerPartition := v.req.NumRowsPerPartition
+	if numRowsPerPartition == 0 {
+		numRowsPerPartition = defaultValidateNumRowsPerPartition
+	}
+
+	var configs []*protos.QRepConfig
+	switch {
+	case v.req.ConnectionConfigs != nil:
+		flowConfig := v.req.ConnectionConfigs
+		// map iteration order isn't deterministic, validate the tables in a fixed order.
+		srcTableNames := make([]string, 0, len(flowConfig.TableNameMapping))
+		for srcTableName := range flowConfig.TableNameMapping {
+			srcTableNames = append(srcTableNames, srcTableName)
+		}
+		sort.Strings(srcTableNames)
+
+		for _, srcTableName := range srcTableNames {
+			dstTableName := flowConfig.TableNameMapping[srcTableName]
+			config, err := getTableQRepConfig(flowConfig, srcTableName, dstTableName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set up validation of table %s: %w", srcTableName, err)
+			}
+			// blocks of the source rows have no counterpart on the destination.
+			if config.WatermarkColumn == shared.CTIDWatermarkColumn {
+				return nil, fmt.Errorf("table %s has no key of a type to partition on", srcTableName)
+			}
+			config.FlowJobName = invalidWorkflowNameChars.ReplaceAllString(
+				fmt.Sprintf("validate_%s_%s", flowConfig.FlowJobName, dstTableName), "_")
+			configs = append(configs, config)
+		}
+	case v.req.QrepConfig != nil:
+		// the query of the mirror reads the partitions from the source, so it has to select
+		// inclusive ranges of the watermark column like BETWEEN does.
+		config := proto.Clone(v.req.QrepConfig).(*protos.QRepConfig)
+		config.SnapshotName = ""
+		configs = append(configs, config)
+	default:
+		return nil, fmt.Errorf("no config to validate flow %s", v.req.FlowJobName)
+	}
+
+	for _, config := range configs {
+		config.NumRowsPerPartition = numRowsPerPartition
+	}
+	return configs, nil
+}
+
+// getPartitions returns the partitions of the source table, with a partition before the first
+// and after the last one so that destination rows outside of the source's range are counted.
+func (v *ValidateFlowExecution) getPartitions(
+	ctx workflow.Context,
+	config *protos.QRepConfig,
+) ([]*protos.QRepPartition, error) {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Minute,
+	})
+
+	partitions := &protos.QRepParitionResult{}
+	partitionsFuture := workflow.ExecuteActivity(ctx, flowable.GetQRepPartitions, config, nil)
+	if err := partitionsFuture.Get(ctx, &partitions); err != nil {
+		return nil, fmt.Errorf("failed to fetch partitions to validate: %w", err)
+	}
+
+	return addOuterPartitions(partitions.Partitions), nil
+}
+
+// addOuterPartitions adds partitions covering the values before the first and after the last
+// partition of a table.
+func addOuterPartitions(partitions []*protos.QRepPartition) []*protos.QRepPartition {
+	if len(partitions) == 0 {
+		return partitions
+	}
+
+	outerPartition := func(id string, partitionRange *protos.PartitionRange) *protos.QRepPartition {
+		return &protos.QRepPartition{PartitionId: id, Range: partitionRange}
+	}
+	first := partitions[0].Range
+	last := partitions[len(partitions)-1].Range
+	var before, after *protos.QRepPartition
+	switch {
+	case first.GetIntRange() != nil && last.GetIntRange() != nil:
+		if start := first.GetIntRange().Start; start > math.MinInt64 {
+			before = outerPartition("before-first", &protos.PartitionRange{
+				Range: &protos.PartitionRange_IntRange{
+					IntRange: &protos.IntPartitionRange{Start: math.MinInt64, End: start - 1},
+				},
+			})
+		}
+		if end := last.GetIntRange().End; end < math.MaxInt64 {
+			after = outerPartition("after-last", &protos.PartitionRange{
+				Range: &protos.PartitionRange_IntRange{
+					IntRange: &protos.IntPartitionRange{Start: end + 1, End: math.MaxInt64},
+				},
+			})
+		}
+	case first.GetTimestampRange() != nil && last.GetTimestampRange() != nil:
+		if start := first.GetTimestampRange().Start.AsTime(); start.After(minValidateTimestamp) {
+			before = outerPartition("before-first", &protos.PartitionRange{
+				Range: &protos.PartitionRange_TimestampRange{
+					TimestampRange: &protos.TimestampPartitionRange{
+						Start: timestamppb.New(minValidateTimestamp),
+						End:   timestamppb.New(start.Add(-time.Microsecond)),
+					},
+				},
+			})
+		}
+		if end := last.GetTimestampRange().End.AsTime(); end.Before(maxValidateTimestamp) {
+			after = outerPartition("after-last", &protos.PartitionRange{
+				Range: &protos.PartitionRange_TimestampRange{
+					TimestampRange: &protos.TimestampPartitionRange{
+						Start: timestamppb.New(end.Add(time.Microsecond)),
+						End:   timestamppb.New(maxValidateTimestamp),
+					},
+				},
+			})
+		}
+	}
+
+	withOuter := make([]*protos.QRepPartition, 0, len(partitions)+2)
+	if before != nil {
+		withOuter = append(withOuter, before)
+	}
+	withOuter = append(withOuter, partitions...)
+	if after != nil {
+		withOuter = append(withOuter, after)
+	}
+	return withOuter
+}
+
+// validateTable compares every partition of a table, the error of the table is set if it
+// couldn't be compared.
+func (v *ValidateFlowExecution) validateTable(
+	ctx workflow.Context,
+	config *protos.QRepConfig,
+	maxParallelWorkers int,
+) *protos.TableValidation {
+	table := &protos.TableValidation{
+		SourceTableIdentifier:      config.WatermarkTable,
+		DestinationTableIdentifier: config.DestinationTableIdentifier,
+		PartitionColumn:            config.WatermarkColumn,
+	}
+	v.logger.Info("validating table - ", config.WatermarkTable)
+
+	partitions, err := v.getPartitions(ctx, config)
+	if err != nil {
+		table.Error = err.Error()
+		return table
+	}
+	table.NumPartitions = uint32(len(partitions))
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 15 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 2,
+		},
+	})
+
+	// partitions are compared in parallel, results are kept in the order of the partitions.
+	results := make([]*protos.PartitionValidation, len(partitions))
+	var firstErr error
+	numRunning := 0
+	sel := workflow.NewSelector(ctx)
+	for i, partition := range partitions {
+		for numRunning >= maxParallelWorkers {
+			sel.Select(ctx)
+		}
+
+		i := i
+		future := workflow.ExecuteActivity(ctx, flowable.ValidateQRepPartition, config, partition,
+			v.req.CompareChecksums)
+		numRunning++
+		sel.AddFuture(future, func(f workflow.Future) {
+			numRunning--
+			if err := f.Get(ctx, &results[i]); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("failed to validate partition %s: %w", partitions[i].PartitionId, err)
+			}
+		})
+	}
+	for numRunning > 0 {
+		sel.Select(ctx)
+	}
+	if firstErr != nil {
+		table.Error = firstErr.Error()
+		return table
+	}
+
+	for _, result := range results {
+		table.SourceRowCount += result.SourceRowCount
+		table.DestinationRowCount += result.DestinationRowCount
+		if result.SourceRowCount != result.DestinationRowCount || result.SourceChecksum != result.DestinationChecksum {
+			table.MismatchedPartitions = append(table.MismatchedPartitions, result)
+		}
+	}
+
+	v.logger.Info(fmt.Sprintf("validated table %s, %d of %d partitions mismatched",
+		config.WatermarkTable, len(table.MismatchedPartitions), len(partitions)))
+	return table
+}
+
+// ValidateFlowWorkflow compares the row counts of each table of a CDC or QRep mirror between its
+// source and destination, partition by partition, and reports the partitions that differ. Counts are
+// computed by the peers. If the request asks to compare checksums, every row of both sides is also
+// read through the worker to checksum the partitions, which costs as much as resyncing the mirror.
+// Rows changed on the source since they were last synced show up as mismatches, so CDC mirrors are
+// best validated while they're paused or caught up.
+func ValidateFlowWorkflow(
+	ctx workflow.Context,
+	req *protos.ValidateFlowRequest,
+) (*protos.ValidateFlowResponse, error) {
+	v := &ValidateFlowExecution{
+		req:    req,
+		logger: workflow.GetLogger(ctx),
+	}
+
+	maxParallelWorkers := defaultValidateMaxParallelWorkers
+	if req.MaxParallelWorkers > 0 {
+		maxParallelWorkers = int(req.MaxParallelWorkers)
+	}
+
+	configs, err := v.tableConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &protos.ValidateFlowResponse{
+		Ok: true,
+	}
+	for _, config := range configs {
+		table := v.validateTable(ctx, config, maxParallelWorkers)
+		if table.Error != "" || len(table.MismatchedPartitions) > 0 {
+			res.Ok = false
+		}
+		res.Tables = append(res.Tables, table)
+	}
+
+	v.logger.Info("validated flow - ", req.FlowJobName)
+	return res, nil
+}
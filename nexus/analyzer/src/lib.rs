@@ -310,6 +310,10 @@ fn parse_db_options(
                 .get("s3_integration")
                 .map(|s| s.to_string())
                 .unwrap_or_default();
+            let storage_int = opts
+                .get("storage_integration")
+                .map(|s| s.to_string())
+                .unwrap_or_default();
 
             let snowflake_config = SnowflakeConfig {
                 account_id: opts
@@ -339,6 +343,7 @@ fn parse_db_options(
                     .parse::<u64>()
                     .context("unable to parse query_timeout")?,
                 s3_integration: s3_int,
+                storage_integration: storage_int,
             };
             let config = Config::SnowflakeConfig(snowflake_config);
             Some(config)
@@ -397,6 +402,8 @@ fn parse_db_options(
         DbType::Eventhub => None,
         DbType::S3 => None,
         DbType::Sqlserver => None,
+        DbType::Kafka => None,
+        DbType::Mysql => None,
     };
 
     Ok(config)
@@ -55,7 +55,7 @@ lazy_static::lazy_static! {
             name: "sync_data_format",
             default_val: Some("default"),
             required: false,
-            accepted_values: Some(vec!["default", "avro"]),
+            accepted_values: Some(vec!["default", "avro", "parquet"]),
         },
         QRepOptionType::String {
             name: "staging_path",
@@ -93,6 +93,18 @@ lazy_static::lazy_static! {
             default_value: 0,
             required: false,
         },
+        QRepOptionType::String {
+            name: "parquet_compression",
+            default_val: None,
+            required: false,
+            accepted_values: Some(vec!["uncompressed", "snappy", "gzip", "zstd", "brotli"]),
+        },
+        QRepOptionType::Int {
+            name: "parquet_row_group_size_mb",
+            min_value: Some(0),
+            default_value: 0,
+            required: false,
+        },
         ]
     };
 }
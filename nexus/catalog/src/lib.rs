@@ -169,6 +169,16 @@ impl Catalog {
                     buf.reserve(config_len);
                     sqlserver_config.encode(&mut buf)?;
                 }
+                Config::KafkaConfig(kafka_config) => {
+                    let config_len = kafka_config.encoded_len();
+                    buf.reserve(config_len);
+                    kafka_config.encode(&mut buf)?;
+                }
+                Config::MysqlConfig(mysql_config) => {
+                    let config_len = mysql_config.encoded_len();
+                    buf.reserve(config_len);
+                    mysql_config.encode(&mut buf)?;
+                }
             };
 
             buf
@@ -330,6 +340,18 @@ impl Catalog {
                     pt::peerdb_peers::SqlServerConfig::decode(options.as_slice()).context(err)?;
                 Ok(Some(Config::SqlserverConfig(sqlserver_config)))
             }
+            Some(DbType::Kafka) => {
+                let err = format!("unable to decode {} options for peer {}", "kafka", name);
+                let kafka_config =
+                    pt::peerdb_peers::KafkaConfig::decode(options.as_slice()).context(err)?;
+                Ok(Some(Config::KafkaConfig(kafka_config)))
+            }
+            Some(DbType::Mysql) => {
+                let err = format!("unable to decode {} options for peer {}", "mysql", name);
+                let mysql_config =
+                    pt::peerdb_peers::MySqlConfig::decode(options.as_slice()).context(err)?;
+                Ok(Some(Config::MysqlConfig(mysql_config)))
+            }
             None => Ok(None),
         }
     }
@@ -157,6 +157,9 @@ impl FlowGrpcClient {
                     "sync_data_format" => {
                         cfg.sync_mode = match s.as_str() {
                             "avro" => pt::peerdb_flow::QRepSyncMode::QrepSyncModeStorageAvro as i32,
+                            "parquet" => {
+                                pt::peerdb_flow::QRepSyncMode::QrepSyncModeStorageParquet as i32
+                            }
                             _ => pt::peerdb_flow::QRepSyncMode::QrepSyncModeMultiInsert as i32,
                         }
                     }
@@ -184,6 +187,7 @@ impl FlowGrpcClient {
                         }
                     }
                     "staging_path" => cfg.staging_path = s.clone(),
+                    "parquet_compression" => cfg.parquet_compression = s.clone(),
                     _ => return anyhow::Result::Err(anyhow::anyhow!("invalid str option {}", key)),
                 },
                 Value::Number(n) => match key.as_str() {
@@ -212,6 +216,11 @@ impl FlowGrpcClient {
                             cfg.num_rows_per_partition = n as u32;
                         }
                     }
+                    "parquet_row_group_size_mb" => {
+                        if let Some(n) = n.as_i64() {
+                            cfg.parquet_row_group_size_mb = n as u32;
+                        }
+                    }
                     _ => return anyhow::Result::Err(anyhow::anyhow!("invalid num option {}", key)),
                 },
                 _ => {
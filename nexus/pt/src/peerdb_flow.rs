@@ -6,6 +6,39 @@ pub struct TableNameMapping {
     #[prost(string, tag = "2")]
     pub destination_table_name: ::prost::alloc::string::String,
 }
+/// columns of a source table to replicate, columns not replicated never leave the source.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct ColumnFilter {
+    /// if not empty, only these columns are replicated.
+    #[prost(string, repeated, tag = "1")]
+    pub include_columns: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    /// these columns are never replicated.
+    #[prost(string, repeated, tag = "2")]
+    pub exclude_columns: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+}
+/// a change applied to every record of a table between the source and the destination.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct RecordTransform {
+    #[prost(enumeration = "RecordTransformType", tag = "1")]
+    pub r#type: i32,
+    #[prost(string, tag = "2")]
+    pub column: ::prost::alloc::string::String,
+    #[prost(string, tag = "3")]
+    pub new_column: ::prost::alloc::string::String,
+    #[prost(string, tag = "4")]
+    pub new_type: ::prost::alloc::string::String,
+    #[prost(string, tag = "5")]
+    pub value: ::prost::alloc::string::String,
+}
+/// transforms of a table, applied in order.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct TableTransforms {
+    #[prost(message, repeated, tag = "1")]
+    pub transforms: ::prost::alloc::vec::Vec<RecordTransform>,
+}
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct FlowConnectionConfigs {
@@ -38,12 +71,76 @@ pub struct FlowConnectionConfigs {
     pub metadata_peer: ::core::option::Option<super::peerdb_peers::Peer>,
     #[prost(uint32, tag = "9")]
     pub max_batch_size: u32,
+    /// copy the existing rows of the source tables before replicating changes.
+    #[prost(bool, tag = "10")]
+    pub do_initial_copy: bool,
+    /// rows per partition and number of partitions copied in parallel for the initial copy.
+    #[prost(uint32, tag = "11")]
+    pub snapshot_num_rows_per_partition: u32,
+    #[prost(uint32, tag = "12")]
+    pub snapshot_max_parallel_workers: u32,
+    /// seconds a sync flow waits for new records before syncing what it has, 10 if 0.
+    #[prost(uint64, tag = "13")]
+    pub idle_timeout_seconds: u64,
+    /// columns to replicate, keyed by source table name. tables without a filter replicate every column.
+    #[prost(map = "string, message", tag = "14")]
+    pub column_filters: ::std::collections::HashMap<
+        ::prost::alloc::string::String,
+        ColumnFilter,
+    >,
+    /// boolean expressions rows have to satisfy to be replicated, keyed by source table name.
+    /// rows updated out of the filter are deleted from the destination.
+    #[prost(map = "string, string", tag = "15")]
+    pub row_filters: ::std::collections::HashMap<
+        ::prost::alloc::string::String,
+        ::prost::alloc::string::String,
+    >,
+    /// transforms applied to the records and schema of each table, keyed by source table name.
+    #[prost(map = "string, message", tag = "16")]
+    pub transforms: ::std::collections::HashMap<
+        ::prost::alloc::string::String,
+        TableTransforms,
+    >,
+    #[prost(enumeration = "CdcSyncMode", tag = "17")]
+    pub cdc_sync_mode: i32,
+    /// format of the objects S3 destinations write batches as, avro or parquet. avro if empty.
+    #[prost(string, tag = "18")]
+    pub cdc_object_format: ::prost::alloc::string::String,
+    /// compression codec of parquet objects, snappy if empty.
+    #[prost(string, tag = "19")]
+    pub parquet_compression: ::prost::alloc::string::String,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct RelationMessageColumn {
+    #[prost(uint32, tag = "1")]
+    pub flags: u32,
+    #[prost(string, tag = "2")]
+    pub name: ::prost::alloc::string::String,
+    #[prost(uint32, tag = "3")]
+    pub data_type: u32,
+}
+/// columns of a source relation as sent in a RelationMessage of the replication stream.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct RelationMessage {
+    #[prost(uint32, tag = "1")]
+    pub relation_id: u32,
+    #[prost(string, tag = "2")]
+    pub relation_name: ::prost::alloc::string::String,
+    #[prost(message, repeated, tag = "3")]
+    pub columns: ::prost::alloc::vec::Vec<RelationMessageColumn>,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct SyncFlowOptions {
     #[prost(int32, tag = "1")]
     pub batch_size: i32,
+    /// relations seen by earlier sync flows, keyed by relation id.
+    #[prost(map = "uint32, message", tag = "2")]
+    pub relation_message_mapping: ::std::collections::HashMap<u32, RelationMessage>,
+    #[prost(uint64, tag = "3")]
+    pub idle_timeout_seconds: u64,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -132,6 +229,68 @@ pub struct SetupReplicationInput {
         ::prost::alloc::string::String,
         ::prost::alloc::string::String,
     >,
+    #[prost(map = "string, message", tag = "4")]
+    pub column_filters: ::std::collections::HashMap<
+        ::prost::alloc::string::String,
+        ColumnFilter,
+    >,
+    #[prost(map = "string, string", tag = "5")]
+    pub row_filters: ::std::collections::HashMap<
+        ::prost::alloc::string::String,
+        ::prost::alloc::string::String,
+    >,
+}
+/// changes to apply to a running peer flow, sent with the config update signal.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct FlowConfigUpdate {
+    /// new max batch size of sync flows, unchanged if 0.
+    #[prost(uint32, tag = "1")]
+    pub batch_size: u32,
+    /// new idle timeout of sync flows, unchanged if 0.
+    #[prost(uint64, tag = "2")]
+    pub idle_timeout_seconds: u64,
+    /// source tables to start replicating, mapped to their destination tables.
+    #[prost(map = "string, string", tag = "3")]
+    pub additional_tables: ::std::collections::HashMap<
+        ::prost::alloc::string::String,
+        ::prost::alloc::string::String,
+    >,
+    /// source tables to stop replicating.
+    #[prost(string, repeated, tag = "4")]
+    pub removed_tables: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct AlterPublicationInput {
+    #[prost(message, optional, tag = "1")]
+    pub peer_connection_config: ::core::option::Option<super::peerdb_peers::Peer>,
+    #[prost(string, tag = "2")]
+    pub flow_job_name: ::prost::alloc::string::String,
+    #[prost(string, repeated, tag = "3")]
+    pub added_tables: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    #[prost(string, repeated, tag = "4")]
+    pub removed_tables: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    #[prost(map = "string, message", tag = "5")]
+    pub column_filters: ::std::collections::HashMap<
+        ::prost::alloc::string::String,
+        ColumnFilter,
+    >,
+    #[prost(map = "string, string", tag = "6")]
+    pub row_filters: ::std::collections::HashMap<
+        ::prost::alloc::string::String,
+        ::prost::alloc::string::String,
+    >,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct SetupReplicationOutput {
+    #[prost(string, tag = "1")]
+    pub slot_name: ::prost::alloc::string::String,
+    /// name of the snapshot exported when the slot was created,
+    /// empty if the slot already existed.
+    #[prost(string, tag = "2")]
+    pub snapshot_name: ::prost::alloc::string::String,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -145,6 +304,9 @@ pub struct CreateRawTableInput {
         ::prost::alloc::string::String,
         ::prost::alloc::string::String,
     >,
+    /// no raw table is needed when changes are applied directly.
+    #[prost(enumeration = "CdcSyncMode", tag = "4")]
+    pub cdc_sync_mode: i32,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -159,6 +321,9 @@ pub struct GetTableSchemaInput {
     pub peer_connection_config: ::core::option::Option<super::peerdb_peers::Peer>,
     #[prost(string, tag = "2")]
     pub table_identifier: ::prost::alloc::string::String,
+    /// columns left out of the returned schema, key columns can't be filtered out.
+    #[prost(message, optional, tag = "3")]
+    pub column_filter: ::core::option::Option<ColumnFilter>,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -172,8 +337,38 @@ pub struct TableSchema {
         ::prost::alloc::string::String,
         ::prost::alloc::string::String,
     >,
-    #[prost(string, tag = "3")]
-    pub primary_key_column: ::prost::alloc::string::String,
+    /// columns identifying a row, either the primary key or the replica identity index.
+    /// empty if the table has neither, in which case normalize falls back to
+    /// full-row matching (REPLICA IDENTITY FULL) or append-only.
+    #[prost(string, repeated, tag = "3")]
+    pub primary_key_columns: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    #[prost(enumeration = "ReplicaIdentityType", tag = "4")]
+    pub replica_identity: i32,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct DeltaColumn {
+    #[prost(string, tag = "1")]
+    pub column_name: ::prost::alloc::string::String,
+    #[prost(string, tag = "2")]
+    pub column_type: ::prost::alloc::string::String,
+}
+/// difference between the columns of a source relation and the known schema of its
+/// destination table, detected from a RelationMessage in the replication stream.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct TableSchemaDelta {
+    #[prost(string, tag = "1")]
+    pub src_table_name: ::prost::alloc::string::String,
+    #[prost(string, tag = "2")]
+    pub dst_table_name: ::prost::alloc::string::String,
+    #[prost(message, repeated, tag = "3")]
+    pub added_columns: ::prost::alloc::vec::Vec<DeltaColumn>,
+    #[prost(string, repeated, tag = "4")]
+    pub dropped_columns: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    /// columns whose type changed, with the new type.
+    #[prost(message, repeated, tag = "5")]
+    pub retyped_columns: ::prost::alloc::vec::Vec<DeltaColumn>,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -184,6 +379,9 @@ pub struct SetupNormalizedTableInput {
     pub table_identifier: ::prost::alloc::string::String,
     #[prost(message, optional, tag = "3")]
     pub source_table_schema: ::core::option::Option<TableSchema>,
+    /// transforms deriving the destination table from the source table.
+    #[prost(message, optional, tag = "4")]
+    pub transforms: ::core::option::Option<TableTransforms>,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -282,6 +480,20 @@ pub struct QRepConfig {
     /// how many rows to process per batch.
     #[prost(uint32, tag = "16")]
     pub num_rows_per_partition: u32,
+    /// exported snapshot the source is read under, used for the initial copy of CDC mirrors.
+    #[prost(string, tag = "17")]
+    pub snapshot_name: ::prost::alloc::string::String,
+    /// transforms applied to the records of the query before they are written.
+    #[prost(message, optional, tag = "18")]
+    pub transforms: ::core::option::Option<TableTransforms>,
+    /// These are only used when sync_mode is PARQUET.
+    /// compression codec of the Parquet files, one of uncompressed, snappy, gzip,
+    /// zstd or brotli. snappy if not specified.
+    #[prost(string, tag = "19")]
+    pub parquet_compression: ::prost::alloc::string::String,
+    /// size in MiB of the values buffered for each row group of the Parquet files, 64 if 0.
+    #[prost(uint32, tag = "20")]
+    pub parquet_row_group_size_mb: u32,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -303,12 +515,168 @@ pub struct DropFlowInput {
     #[prost(string, tag = "1")]
     pub flow_name: ::prost::alloc::string::String,
 }
+/// row counts and checksums of a partition of a table on the source and the destination of a mirror.
+/// checksums don't depend on the order of the rows.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct PartitionValidation {
+    #[prost(message, optional, tag = "1")]
+    pub partition: ::core::option::Option<QRepPartition>,
+    #[prost(int64, tag = "2")]
+    pub source_row_count: i64,
+    #[prost(int64, tag = "3")]
+    pub destination_row_count: i64,
+    #[prost(string, tag = "4")]
+    pub source_checksum: ::prost::alloc::string::String,
+    #[prost(string, tag = "5")]
+    pub destination_checksum: ::prost::alloc::string::String,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct TableValidation {
+    #[prost(string, tag = "1")]
+    pub source_table_identifier: ::prost::alloc::string::String,
+    #[prost(string, tag = "2")]
+    pub destination_table_identifier: ::prost::alloc::string::String,
+    /// column the table is partitioned on, the key of CDC mirrors and the watermark of QRep mirrors.
+    #[prost(string, tag = "3")]
+    pub partition_column: ::prost::alloc::string::String,
+    #[prost(uint32, tag = "4")]
+    pub num_partitions: u32,
+    #[prost(int64, tag = "5")]
+    pub source_row_count: i64,
+    #[prost(int64, tag = "6")]
+    pub destination_row_count: i64,
+    /// partitions whose row counts or checksums differ.
+    #[prost(message, repeated, tag = "7")]
+    pub mismatched_partitions: ::prost::alloc::vec::Vec<PartitionValidation>,
+    /// set if the table couldn't be validated.
+    #[prost(string, tag = "8")]
+    pub error: ::prost::alloc::string::String,
+}
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
+#[repr(i32)]
+pub enum RecordTransformType {
+    /// not a transform, rejected so that a transform without a type isn't taken for a rename.
+    RecordTransformUnspecified = 0,
+    /// renames column to new_column.
+    RecordTransformRename = 1,
+    /// replaces column with the hex SHA-256 of its value, salted with value.
+    RecordTransformHash = 2,
+    /// replaces all but the last value characters of column with '*'.
+    RecordTransformMask = 3,
+    /// leaves column out of the destination.
+    RecordTransformDrop = 4,
+    /// converts column to new_type.
+    RecordTransformCast = 5,
+    /// adds new_column as the string rendered by the text/template in value, e.g. "{{.first}} {{.last}}".
+    RecordTransformComputed = 6,
+    /// adds new_column holding value, converted to new_type.
+    RecordTransformConstant = 7,
+}
+impl RecordTransformType {
+    /// String value of the enum field names used in the ProtoBuf definition.
+    ///
+    /// The values are not transformed in any way and thus are considered stable
+    /// (if the ProtoBuf definition does not change) and safe for programmatic use.
+    pub fn as_str_name(&self) -> &'static str {
+        match self {
+            RecordTransformType::RecordTransformUnspecified => "RECORD_TRANSFORM_UNSPECIFIED",
+            RecordTransformType::RecordTransformRename => "RECORD_TRANSFORM_RENAME",
+            RecordTransformType::RecordTransformHash => "RECORD_TRANSFORM_HASH",
+            RecordTransformType::RecordTransformMask => "RECORD_TRANSFORM_MASK",
+            RecordTransformType::RecordTransformDrop => "RECORD_TRANSFORM_DROP",
+            RecordTransformType::RecordTransformCast => "RECORD_TRANSFORM_CAST",
+            RecordTransformType::RecordTransformComputed => "RECORD_TRANSFORM_COMPUTED",
+            RecordTransformType::RecordTransformConstant => "RECORD_TRANSFORM_CONSTANT",
+        }
+    }
+    /// Creates an enum from field names used in the ProtoBuf definition.
+    pub fn from_str_name(value: &str) -> ::core::option::Option<Self> {
+        match value {
+            "RECORD_TRANSFORM_UNSPECIFIED" => Some(Self::RecordTransformUnspecified),
+            "RECORD_TRANSFORM_RENAME" => Some(Self::RecordTransformRename),
+            "RECORD_TRANSFORM_HASH" => Some(Self::RecordTransformHash),
+            "RECORD_TRANSFORM_MASK" => Some(Self::RecordTransformMask),
+            "RECORD_TRANSFORM_DROP" => Some(Self::RecordTransformDrop),
+            "RECORD_TRANSFORM_CAST" => Some(Self::RecordTransformCast),
+            "RECORD_TRANSFORM_COMPUTED" => Some(Self::RecordTransformComputed),
+            "RECORD_TRANSFORM_CONSTANT" => Some(Self::RecordTransformConstant),
+            _ => None,
+        }
+    }
+}
+/// how changes are written to the destination of a CDC mirror.
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
+#[repr(i32)]
+pub enum CdcSyncMode {
+    /// changes are staged in a raw table and merged into the destination tables by normalize.
+    RawTable = 0,
+    /// changes are applied to the destination tables directly, each batch in one transaction
+    /// with its checkpoint. Only supported by Postgres destinations.
+    DirectApply = 1,
+}
+impl CdcSyncMode {
+    /// String value of the enum field names used in the ProtoBuf definition.
+    ///
+    /// The values are not transformed in any way and thus are considered stable
+    /// (if the ProtoBuf definition does not change) and safe for programmatic use.
+    pub fn as_str_name(&self) -> &'static str {
+        match self {
+            CdcSyncMode::RawTable => "CDC_SYNC_MODE_RAW_TABLE",
+            CdcSyncMode::DirectApply => "CDC_SYNC_MODE_DIRECT_APPLY",
+        }
+    }
+    /// Creates an enum from field names used in the ProtoBuf definition.
+    pub fn from_str_name(value: &str) -> ::core::option::Option<Self> {
+        match value {
+            "CDC_SYNC_MODE_RAW_TABLE" => Some(Self::RawTable),
+            "CDC_SYNC_MODE_DIRECT_APPLY" => Some(Self::DirectApply),
+            _ => None,
+        }
+    }
+}
+/// replica identity of a source table, mirrors pg_class.relreplident
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
+#[repr(i32)]
+pub enum ReplicaIdentityType {
+    ReplicaIdentityDefault = 0,
+    ReplicaIdentityNothing = 1,
+    ReplicaIdentityFull = 2,
+    ReplicaIdentityIndex = 3,
+}
+impl ReplicaIdentityType {
+    /// String value of the enum field names used in the ProtoBuf definition.
+    ///
+    /// The values are not transformed in any way and thus are considered stable
+    /// (if the ProtoBuf definition does not change) and safe for programmatic use.
+    pub fn as_str_name(&self) -> &'static str {
+        match self {
+            ReplicaIdentityType::ReplicaIdentityDefault => "REPLICA_IDENTITY_DEFAULT",
+            ReplicaIdentityType::ReplicaIdentityNothing => "REPLICA_IDENTITY_NOTHING",
+            ReplicaIdentityType::ReplicaIdentityFull => "REPLICA_IDENTITY_FULL",
+            ReplicaIdentityType::ReplicaIdentityIndex => "REPLICA_IDENTITY_INDEX",
+        }
+    }
+    /// Creates an enum from field names used in the ProtoBuf definition.
+    pub fn from_str_name(value: &str) -> ::core::option::Option<Self> {
+        match value {
+            "REPLICA_IDENTITY_DEFAULT" => Some(Self::ReplicaIdentityDefault),
+            "REPLICA_IDENTITY_NOTHING" => Some(Self::ReplicaIdentityNothing),
+            "REPLICA_IDENTITY_FULL" => Some(Self::ReplicaIdentityFull),
+            "REPLICA_IDENTITY_INDEX" => Some(Self::ReplicaIdentityIndex),
+            _ => None,
+        }
+    }
+}
 /// protos for qrep
 #[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
 #[repr(i32)]
 pub enum QRepSyncMode {
     QrepSyncModeMultiInsert = 0,
     QrepSyncModeStorageAvro = 1,
+    /// records are written as Parquet files, only supported by S3 destinations.
+    QrepSyncModeStorageParquet = 2,
 }
 impl QRepSyncMode {
     /// String value of the enum field names used in the ProtoBuf definition.
@@ -319,6 +687,7 @@ impl QRepSyncMode {
         match self {
             QRepSyncMode::QrepSyncModeMultiInsert => "QREP_SYNC_MODE_MULTI_INSERT",
             QRepSyncMode::QrepSyncModeStorageAvro => "QREP_SYNC_MODE_STORAGE_AVRO",
+            QRepSyncMode::QrepSyncModeStorageParquet => "QREP_SYNC_MODE_STORAGE_PARQUET",
         }
     }
     /// Creates an enum from field names used in the ProtoBuf definition.
@@ -326,6 +695,7 @@ impl QRepSyncMode {
         match value {
             "QREP_SYNC_MODE_MULTI_INSERT" => Some(Self::QrepSyncModeMultiInsert),
             "QREP_SYNC_MODE_STORAGE_AVRO" => Some(Self::QrepSyncModeStorageAvro),
+            "QREP_SYNC_MODE_STORAGE_PARQUET" => Some(Self::QrepSyncModeStorageParquet),
             _ => None,
         }
     }
@@ -15,8 +15,12 @@ pub struct SnowflakeConfig {
     pub role: ::prost::alloc::string::String,
     #[prost(uint64, tag = "8")]
     pub query_timeout: u64,
+    /// storage integration of S3 external stages, storage_integration takes precedence.
     #[prost(string, tag = "9")]
     pub s3_integration: ::prost::alloc::string::String,
+    /// storage integration of external stages, whether they're on S3, GCS or Azure.
+    #[prost(string, tag = "10")]
+    pub storage_integration: ::prost::alloc::string::String,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -83,6 +87,8 @@ pub struct EventHubConfig {
     pub location: ::prost::alloc::string::String,
     #[prost(message, optional, tag = "4")]
     pub metadata_db: ::core::option::Option<PostgresConfig>,
+    #[prost(enumeration = "ChangeEventFormat", tag = "5")]
+    pub event_format: i32,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -103,6 +109,65 @@ pub struct SqlServerConfig {
     pub password: ::prost::alloc::string::String,
     #[prost(string, tag = "5")]
     pub database: ::prost::alloc::string::String,
+    /// holds the LSNs of the checkpoints of each mirror.
+    #[prost(message, optional, tag = "6")]
+    pub metadata_db: ::core::option::Option<PostgresConfig>,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct KafkaConfig {
+    #[prost(string, repeated, tag = "1")]
+    pub servers: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    #[prost(string, tag = "2")]
+    pub username: ::prost::alloc::string::String,
+    #[prost(string, tag = "3")]
+    pub password: ::prost::alloc::string::String,
+    /// PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512, no authentication if empty.
+    #[prost(string, tag = "4")]
+    pub sasl_mechanism: ::prost::alloc::string::String,
+    #[prost(bool, tag = "5")]
+    pub disable_tls: bool,
+    #[prost(enumeration = "KafkaValueEncoding", tag = "6")]
+    pub value_encoding: i32,
+    /// partitions and replication factor of the topics created for tables, broker defaults if 0.
+    #[prost(int32, tag = "7")]
+    pub partitions: i32,
+    #[prost(int32, tag = "8")]
+    pub replication_factor: i32,
+    /// holds the offsets synced by each mirror.
+    #[prost(message, optional, tag = "9")]
+    pub metadata_db: ::core::option::Option<PostgresConfig>,
+    /// the Debezium envelope is only supported with JSON values.
+    #[prost(enumeration = "ChangeEventFormat", tag = "10")]
+    pub event_format: i32,
+    /// Confluent compatible schema registry the Avro schemas of values are registered with,
+    /// needed for Avro values.
+    #[prost(string, tag = "11")]
+    pub schema_registry_url: ::prost::alloc::string::String,
+    #[prost(string, tag = "12")]
+    pub schema_registry_username: ::prost::alloc::string::String,
+    #[prost(string, tag = "13")]
+    pub schema_registry_password: ::prost::alloc::string::String,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct MySqlConfig {
+    #[prost(string, tag = "1")]
+    pub host: ::prost::alloc::string::String,
+    #[prost(uint32, tag = "2")]
+    pub port: u32,
+    #[prost(string, tag = "3")]
+    pub user: ::prost::alloc::string::String,
+    #[prost(string, tag = "4")]
+    pub password: ::prost::alloc::string::String,
+    #[prost(string, tag = "5")]
+    pub database: ::prost::alloc::string::String,
+    /// server id the connector registers with when reading the binlog, unique among the replicas of the server.
+    #[prost(uint32, tag = "6")]
+    pub server_id: u32,
+    /// holds the GTID sets of the checkpoints of each mirror.
+    #[prost(message, optional, tag = "7")]
+    pub metadata_db: ::core::option::Option<PostgresConfig>,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -111,7 +176,7 @@ pub struct Peer {
     pub name: ::prost::alloc::string::String,
     #[prost(enumeration = "DbType", tag = "2")]
     pub r#type: i32,
-    #[prost(oneof = "peer::Config", tags = "3, 4, 5, 6, 7, 8, 9")]
+    #[prost(oneof = "peer::Config", tags = "3, 4, 5, 6, 7, 8, 9, 10, 11")]
     pub config: ::core::option::Option<peer::Config>,
 }
 /// Nested message and enum types in `Peer`.
@@ -133,6 +198,69 @@ pub mod peer {
         S3Config(super::S3Config),
         #[prost(message, tag = "9")]
         SqlserverConfig(super::SqlServerConfig),
+        #[prost(message, tag = "10")]
+        KafkaConfig(super::KafkaConfig),
+        #[prost(message, tag = "11")]
+        MysqlConfig(super::MySqlConfig),
+    }
+}
+/// layout of the change events written to streaming destinations.
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
+#[repr(i32)]
+pub enum ChangeEventFormat {
+    /// the columns of the changed row.
+    Row = 0,
+    /// an envelope compatible with Debezium's JSON converter, carrying the
+    /// operation, the row before and after the change, its source and a schema.
+    Debezium = 1,
+}
+impl ChangeEventFormat {
+    /// String value of the enum field names used in the ProtoBuf definition.
+    ///
+    /// The values are not transformed in any way and thus are considered stable
+    /// (if the ProtoBuf definition does not change) and safe for programmatic use.
+    pub fn as_str_name(&self) -> &'static str {
+        match self {
+            ChangeEventFormat::Row => "CHANGE_EVENT_FORMAT_ROW",
+            ChangeEventFormat::Debezium => "CHANGE_EVENT_FORMAT_DEBEZIUM",
+        }
+    }
+    /// Creates an enum from field names used in the ProtoBuf definition.
+    pub fn from_str_name(value: &str) -> ::core::option::Option<Self> {
+        match value {
+            "CHANGE_EVENT_FORMAT_ROW" => Some(Self::Row),
+            "CHANGE_EVENT_FORMAT_DEBEZIUM" => Some(Self::Debezium),
+            _ => None,
+        }
+    }
+}
+/// encoding of the values of records written to Kafka.
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
+#[repr(i32)]
+pub enum KafkaValueEncoding {
+    Json = 0,
+    /// Avro in the Confluent wire format, with the schema derived from the destination table
+    /// registered in the schema registry under the subject <topic>-value.
+    Avro = 1,
+}
+impl KafkaValueEncoding {
+    /// String value of the enum field names used in the ProtoBuf definition.
+    ///
+    /// The values are not transformed in any way and thus are considered stable
+    /// (if the ProtoBuf definition does not change) and safe for programmatic use.
+    pub fn as_str_name(&self) -> &'static str {
+        match self {
+            KafkaValueEncoding::Json => "KAFKA_VALUE_ENCODING_JSON",
+            KafkaValueEncoding::Avro => "KAFKA_VALUE_ENCODING_AVRO",
+        }
+    }
+    /// Creates an enum from field names used in the ProtoBuf definition.
+    pub fn from_str_name(value: &str) -> ::core::option::Option<Self> {
+        match value {
+            "KAFKA_VALUE_ENCODING_JSON" => Some(Self::Json),
+            "KAFKA_VALUE_ENCODING_AVRO" => Some(Self::Avro),
+            _ => None,
+        }
     }
 }
 #[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
@@ -145,6 +273,8 @@ pub enum DbType {
     Eventhub = 4,
     S3 = 5,
     Sqlserver = 6,
+    Kafka = 7,
+    Mysql = 8,
 }
 impl DbType {
     /// String value of the enum field names used in the ProtoBuf definition.
@@ -160,6 +290,8 @@ impl DbType {
             DbType::Eventhub => "EVENTHUB",
             DbType::S3 => "S3",
             DbType::Sqlserver => "SQLSERVER",
+            DbType::Kafka => "KAFKA",
+            DbType::Mysql => "MYSQL",
         }
     }
     /// Creates an enum from field names used in the ProtoBuf definition.
@@ -172,6 +304,8 @@ impl DbType {
             "EVENTHUB" => Some(Self::Eventhub),
             "S3" => Some(Self::S3),
             "SQLSERVER" => Some(Self::Sqlserver),
+            "KAFKA" => Some(Self::Kafka),
+            "MYSQL" => Some(Self::Mysql),
             _ => None,
         }
     }
@@ -56,6 +56,139 @@ pub struct ShutdownResponse {
     #[prost(string, tag = "2")]
     pub error_message: ::prost::alloc::string::String,
 }
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct PauseRequest {
+    #[prost(string, tag = "1")]
+    pub workflow_id: ::prost::alloc::string::String,
+    #[prost(string, tag = "2")]
+    pub flow_job_name: ::prost::alloc::string::String,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct PauseResponse {
+    #[prost(bool, tag = "1")]
+    pub ok: bool,
+    #[prost(string, tag = "2")]
+    pub error_message: ::prost::alloc::string::String,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct ResumeRequest {
+    #[prost(string, tag = "1")]
+    pub workflow_id: ::prost::alloc::string::String,
+    #[prost(string, tag = "2")]
+    pub flow_job_name: ::prost::alloc::string::String,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct ResumeResponse {
+    #[prost(bool, tag = "1")]
+    pub ok: bool,
+    #[prost(string, tag = "2")]
+    pub error_message: ::prost::alloc::string::String,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct FlowStatusRequest {
+    #[prost(string, tag = "1")]
+    pub workflow_id: ::prost::alloc::string::String,
+    #[prost(string, tag = "2")]
+    pub flow_job_name: ::prost::alloc::string::String,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct SyncFlowStatus {
+    #[prost(int64, tag = "1")]
+    pub first_synced_checkpoint_id: i64,
+    #[prost(int64, tag = "2")]
+    pub last_synced_checkpoint_id: i64,
+    #[prost(int64, tag = "3")]
+    pub num_records_synced: i64,
+    #[prost(int64, tag = "4")]
+    pub sync_batch_id: i64,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct FlowStatusResponse {
+    #[prost(bool, tag = "1")]
+    pub setup_complete: bool,
+    #[prost(string, tag = "2")]
+    pub active_signal: ::prost::alloc::string::String,
+    #[prost(int64, tag = "3")]
+    pub last_synced_checkpoint_id: i64,
+    #[prost(int64, tag = "4")]
+    pub last_synced_batch_id: i64,
+    #[prost(int64, tag = "5")]
+    pub last_normalized_batch_id: i64,
+    /// most recent sync flows that synced records, oldest first.
+    #[prost(message, repeated, tag = "6")]
+    pub recent_sync_flow_statuses: ::prost::alloc::vec::Vec<SyncFlowStatus>,
+    /// most recent errors, oldest first.
+    #[prost(string, repeated, tag = "7")]
+    pub sync_flow_errors: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    #[prost(string, repeated, tag = "8")]
+    pub normalize_flow_errors: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    #[prost(uint64, tag = "9")]
+    pub num_sync_flow_errors: u64,
+    #[prost(uint64, tag = "10")]
+    pub num_normalize_flow_errors: u64,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QRepFlowStatusRequest {
+    #[prost(string, tag = "1")]
+    pub workflow_id: ::prost::alloc::string::String,
+    #[prost(string, tag = "2")]
+    pub flow_job_name: ::prost::alloc::string::String,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QRepFlowStatusResponse {
+    #[prost(uint64, tag = "1")]
+    pub num_partitions_processed: u64,
+    #[prost(uint64, tag = "2")]
+    pub num_partitions_remaining: u64,
+    #[prost(string, tag = "3")]
+    pub last_partition_id: ::prost::alloc::string::String,
+}
+/// exactly one of connection_configs and qrep_config is set, depending on the type of the mirror.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct ValidateFlowRequest {
+    #[prost(string, tag = "1")]
+    pub flow_job_name: ::prost::alloc::string::String,
+    #[prost(message, optional, tag = "2")]
+    pub connection_configs: ::core::option::Option<
+        super::peerdb_flow::FlowConnectionConfigs,
+    >,
+    #[prost(message, optional, tag = "3")]
+    pub qrep_config: ::core::option::Option<super::peerdb_flow::QRepConfig>,
+    /// rows per partition compared, 100000 if 0.
+    #[prost(uint32, tag = "4")]
+    pub num_rows_per_partition: u32,
+    /// partitions compared in parallel, 4 if 0.
+    #[prost(uint32, tag = "5")]
+    pub max_parallel_workers: u32,
+    /// also compare a checksum of the rows of each partition. Row counts are computed by the peers,
+    /// checksums read every row of both sides through the worker, as the peers don't hash values
+    /// alike and transforms are applied by the worker.
+    #[prost(bool, tag = "6")]
+    pub compare_checksums: bool,
+}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct ValidateFlowResponse {
+    #[prost(string, tag = "1")]
+    pub workflow_id: ::prost::alloc::string::String,
+    /// true if every table was validated and matches.
+    #[prost(bool, tag = "2")]
+    pub ok: bool,
+    #[prost(message, repeated, tag = "3")]
+    pub tables: ::prost::alloc::vec::Vec<super::peerdb_flow::TableValidation>,
+    #[prost(string, tag = "4")]
+    pub error_message: ::prost::alloc::string::String,
+}
 /// Generated client implementations.
 pub mod flow_service_client {
     #![allow(unused_variables, dead_code, missing_docs, clippy::let_unit_value)]
@@ -241,6 +374,127 @@ pub mod flow_service_client {
                 .insert(GrpcMethod::new("peerdb_route.FlowService", "ShutdownFlow"));
             self.inner.unary(req, path, codec).await
         }
+        pub async fn pause_flow(
+            &mut self,
+            request: impl tonic::IntoRequest<super::PauseRequest>,
+        ) -> std::result::Result<tonic::Response<super::PauseResponse>, tonic::Status> {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static(
+                "/peerdb_route.FlowService/PauseFlow",
+            );
+            let mut req = request.into_request();
+            req.extensions_mut()
+                .insert(GrpcMethod::new("peerdb_route.FlowService", "PauseFlow"));
+            self.inner.unary(req, path, codec).await
+        }
+        pub async fn resume_flow(
+            &mut self,
+            request: impl tonic::IntoRequest<super::ResumeRequest>,
+        ) -> std::result::Result<tonic::Response<super::ResumeResponse>, tonic::Status> {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static(
+                "/peerdb_route.FlowService/ResumeFlow",
+            );
+            let mut req = request.into_request();
+            req.extensions_mut()
+                .insert(GrpcMethod::new("peerdb_route.FlowService", "ResumeFlow"));
+            self.inner.unary(req, path, codec).await
+        }
+        pub async fn get_flow_status(
+            &mut self,
+            request: impl tonic::IntoRequest<super::FlowStatusRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::FlowStatusResponse>,
+            tonic::Status,
+        > {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static(
+                "/peerdb_route.FlowService/GetFlowStatus",
+            );
+            let mut req = request.into_request();
+            req.extensions_mut()
+                .insert(GrpcMethod::new("peerdb_route.FlowService", "GetFlowStatus"));
+            self.inner.unary(req, path, codec).await
+        }
+        pub async fn get_q_rep_flow_status(
+            &mut self,
+            request: impl tonic::IntoRequest<super::QRepFlowStatusRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QRepFlowStatusResponse>,
+            tonic::Status,
+        > {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static(
+                "/peerdb_route.FlowService/GetQRepFlowStatus",
+            );
+            let mut req = request.into_request();
+            req.extensions_mut()
+                .insert(
+                    GrpcMethod::new("peerdb_route.FlowService", "GetQRepFlowStatus"),
+                );
+            self.inner.unary(req, path, codec).await
+        }
+        pub async fn validate_flow(
+            &mut self,
+            request: impl tonic::IntoRequest<super::ValidateFlowRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::ValidateFlowResponse>,
+            tonic::Status,
+        > {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static(
+                "/peerdb_route.FlowService/ValidateFlow",
+            );
+            let mut req = request.into_request();
+            req.extensions_mut()
+                .insert(GrpcMethod::new("peerdb_route.FlowService", "ValidateFlow"));
+            self.inner.unary(req, path, codec).await
+        }
     }
 }
 /// Generated server implementations.
@@ -278,6 +532,35 @@ pub mod flow_service_server {
             tonic::Response<super::ShutdownResponse>,
             tonic::Status,
         >;
+        async fn pause_flow(
+            &self,
+            request: tonic::Request<super::PauseRequest>,
+        ) -> std::result::Result<tonic::Response<super::PauseResponse>, tonic::Status>;
+        async fn resume_flow(
+            &self,
+            request: tonic::Request<super::ResumeRequest>,
+        ) -> std::result::Result<tonic::Response<super::ResumeResponse>, tonic::Status>;
+        async fn get_flow_status(
+            &self,
+            request: tonic::Request<super::FlowStatusRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::FlowStatusResponse>,
+            tonic::Status,
+        >;
+        async fn get_q_rep_flow_status(
+            &self,
+            request: tonic::Request<super::QRepFlowStatusRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QRepFlowStatusResponse>,
+            tonic::Status,
+        >;
+        async fn validate_flow(
+            &self,
+            request: tonic::Request<super::ValidateFlowRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::ValidateFlowResponse>,
+            tonic::Status,
+        >;
     }
     #[derive(Debug)]
     pub struct FlowServiceServer<T: FlowService> {
@@ -542,6 +825,236 @@ pub mod flow_service_server {
                     };
                     Box::pin(fut)
                 }
+                "/peerdb_route.FlowService/PauseFlow" => {
+                    #[allow(non_camel_case_types)]
+                    struct PauseFlowSvc<T: FlowService>(pub Arc<T>);
+                    impl<
+                        T: FlowService,
+                    > tonic::server::UnaryService<super::PauseRequest>
+                    for PauseFlowSvc<T> {
+                        type Response = super::PauseResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::PauseRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                (*inner).pause_flow(request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = PauseFlowSvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
+                "/peerdb_route.FlowService/ResumeFlow" => {
+                    #[allow(non_camel_case_types)]
+                    struct ResumeFlowSvc<T: FlowService>(pub Arc<T>);
+                    impl<
+                        T: FlowService,
+                    > tonic::server::UnaryService<super::ResumeRequest>
+                    for ResumeFlowSvc<T> {
+                        type Response = super::ResumeResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::ResumeRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                (*inner).resume_flow(request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = ResumeFlowSvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
+                "/peerdb_route.FlowService/GetFlowStatus" => {
+                    #[allow(non_camel_case_types)]
+                    struct GetFlowStatusSvc<T: FlowService>(pub Arc<T>);
+                    impl<
+                        T: FlowService,
+                    > tonic::server::UnaryService<super::FlowStatusRequest>
+                    for GetFlowStatusSvc<T> {
+                        type Response = super::FlowStatusResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::FlowStatusRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                (*inner).get_flow_status(request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = GetFlowStatusSvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
+                "/peerdb_route.FlowService/GetQRepFlowStatus" => {
+                    #[allow(non_camel_case_types)]
+                    struct GetQRepFlowStatusSvc<T: FlowService>(pub Arc<T>);
+                    impl<
+                        T: FlowService,
+                    > tonic::server::UnaryService<super::QRepFlowStatusRequest>
+                    for GetQRepFlowStatusSvc<T> {
+                        type Response = super::QRepFlowStatusResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::QRepFlowStatusRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                (*inner).get_q_rep_flow_status(request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = GetQRepFlowStatusSvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
+                "/peerdb_route.FlowService/ValidateFlow" => {
+                    #[allow(non_camel_case_types)]
+                    struct ValidateFlowSvc<T: FlowService>(pub Arc<T>);
+                    impl<
+                        T: FlowService,
+                    > tonic::server::UnaryService<super::ValidateFlowRequest>
+                    for ValidateFlowSvc<T> {
+                        type Response = super::ValidateFlowResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::ValidateFlowRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                (*inner).validate_flow(request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = ValidateFlowSvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
                 _ => {
                     Box::pin(async move {
                         Ok(
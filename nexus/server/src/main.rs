@@ -625,6 +625,12 @@ impl ExtendedQueryHandler for NexusBackend {
                             Some(Config::SqlserverConfig(_)) => {
                                 panic!("peer type not supported: {:?}", peer)
                             }
+                            Some(Config::KafkaConfig(_)) => {
+                                panic!("peer type not supported: {:?}", peer)
+                            }
+                            Some(Config::MysqlConfig(_)) => {
+                                panic!("peer type not supported: {:?}", peer)
+                            }
                             None => {
                                 panic!("peer type not supported: {:?}", peer)
                             }